@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Controller struct {
+	authorizer      authz.Authorizer
+	spaceStore      store.SpaceStore
+	repoStore       store.RepoStore
+	auditEventStore store.AuditEventStore
+}
+
+func NewController(
+	authorizer authz.Authorizer,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	auditEventStore store.AuditEventStore,
+) *Controller {
+	return &Controller{
+		authorizer:      authorizer,
+		spaceStore:      spaceStore,
+		repoStore:       repoStore,
+		auditEventStore: auditEventStore,
+	}
+}
+
+func (c *Controller) getSpaceCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	permission enum.Permission,
+) (*types.Space, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, permission); err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	return space, nil
+}
+
+func (c *Controller) getRepoCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	permission enum.Permission,
+) (*types.Repository, error) {
+	if repoRef == "" {
+		return nil, usererror.BadRequest("A valid repository reference must be provided.")
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, permission); err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	return repo, nil
+}
+
+func sanitizeFilter(filter *types.AuditEventFilter) error {
+	for _, resourceType := range filter.ResourceTypes {
+		if err := audit.ResourceType(resourceType).Validate(); err != nil {
+			return usererror.BadRequestf("Invalid resource type: %s", resourceType)
+		}
+	}
+
+	for _, action := range filter.Actions {
+		if err := audit.Action(action).Validate(); err != nil {
+			return usererror.BadRequestf("Invalid action: %s", action)
+		}
+	}
+
+	if filter.CreatedGt > 0 && filter.CreatedLt > 0 && filter.CreatedGt >= filter.CreatedLt {
+		return usererror.BadRequest("The created_gt value must be less than created_lt.")
+	}
+
+	return nil
+}
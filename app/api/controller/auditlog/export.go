@@ -0,0 +1,151 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// exportBatchSize is the number of audit events read from the database at once during export.
+const exportBatchSize = 1000
+
+var exportCSVHeader = []string{
+	"id",
+	"created",
+	"action",
+	"resource_type",
+	"resource_identifier",
+	"resource_data",
+	"space_path",
+	"repo_path",
+	"principal_id",
+	"principal_uid",
+	"principal_email",
+	"client_ip",
+	"request_method",
+	"request_id",
+	"old_object",
+	"new_object",
+	"data",
+}
+
+// Export writes all audit events matching the filter to the writer. Only available to admins.
+func (c *Controller) Export(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.AuditEventFilter,
+	format enum.AuditExportFormat,
+	w io.Writer,
+) error {
+	if !session.Principal.Admin {
+		return usererror.ErrForbidden
+	}
+
+	if err := sanitizeFilter(filter); err != nil {
+		return err
+	}
+
+	// Events created while the export is running are excluded to keep the pages stable.
+	if filter.CreatedLt == 0 {
+		filter.CreatedLt = time.Now().UnixMilli() + 1
+	}
+
+	var write func(event *types.AuditEvent) error
+	var flush func() error
+
+	switch format {
+	case enum.AuditExportFormatCSV:
+		csvWriter := csv.NewWriter(w)
+		if err := csvWriter.Write(exportCSVHeader); err != nil {
+			return fmt.Errorf("failed to write csv header: %w", err)
+		}
+		write = func(event *types.AuditEvent) error { return csvWriter.Write(auditEventToCSV(event)) }
+		flush = func() error {
+			csvWriter.Flush()
+			return csvWriter.Error()
+		}
+	case enum.AuditExportFormatJSON:
+		encoder := json.NewEncoder(w)
+		write = func(event *types.AuditEvent) error { return encoder.Encode(event) }
+		flush = func() error { return nil }
+	default:
+		return usererror.BadRequestf("Unsupported export format: %s", format)
+	}
+
+	filter.Size = exportBatchSize
+	for filter.Page = 1; ; filter.Page++ {
+		events, err := c.auditEventStore.List(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list audit events: %w", err)
+		}
+
+		for _, event := range events {
+			if err := write(event); err != nil {
+				return fmt.Errorf("failed to write audit event: %w", err)
+			}
+		}
+
+		if err := flush(); err != nil {
+			return fmt.Errorf("failed to flush audit events: %w", err)
+		}
+
+		if len(events) < exportBatchSize {
+			return nil
+		}
+	}
+}
+
+func auditEventToCSV(event *types.AuditEvent) []string {
+	return []string{
+		strconv.FormatInt(event.ID, 10),
+		time.UnixMilli(event.Created).UTC().Format(time.RFC3339),
+		event.Action,
+		event.ResourceType,
+		event.ResourceIdentifier,
+		mapToJSONString(event.ResourceData),
+		event.SpacePath,
+		event.RepoPath,
+		strconv.FormatInt(event.Actor.ID, 10),
+		event.Actor.UID,
+		event.Actor.Email,
+		event.ClientIP,
+		event.RequestMethod,
+		event.RequestID,
+		string(event.OldObject),
+		string(event.NewObject),
+		mapToJSONString(event.Data),
+	}
+}
+
+func mapToJSONString(m map[string]string) string {
+	if len(m) == 0 {
+		return ""
+	}
+
+	// marshaling of a string map can't fail
+	raw, _ := json.Marshal(m)
+	return string(raw)
+}
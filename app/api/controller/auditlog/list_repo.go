@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListRepo lists audit events of a repository.
+func (c *Controller) ListRepo(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.AuditEventFilter,
+) ([]*types.AuditEvent, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to acquire access to repository: %w", err)
+	}
+
+	if err = sanitizeFilter(filter); err != nil {
+		return nil, 0, err
+	}
+
+	filter.RepoPath = repo.Path
+
+	return c.list(ctx, filter)
+}
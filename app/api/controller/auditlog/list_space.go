@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListSpace lists audit events of a space and all its subspaces and repositories.
+func (c *Controller) ListSpace(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	filter *types.AuditEventFilter,
+) ([]*types.AuditEvent, int64, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	if err = sanitizeFilter(filter); err != nil {
+		return nil, 0, err
+	}
+
+	filter.SpacePath = space.Path
+
+	return c.list(ctx, filter)
+}
+
+func (c *Controller) list(
+	ctx context.Context,
+	filter *types.AuditEventFilter,
+) ([]*types.AuditEvent, int64, error) {
+	count, err := c.auditEventStore.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit events: %w", err)
+	}
+
+	events, err := c.auditEventStore.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	return events, count, nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListAnnotations returns the annotations of all status checks reported for a commit in a repository.
+func (c *Controller) ListAnnotations(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+) ([]*types.CheckAnnotation, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	annotations, err := c.checkAnnotationStore.List(ctx, repo.ID, commitSHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list status check annotations for repo=%s: %w", repo.Identifier, err)
+	}
+
+	return annotations, nil
+}
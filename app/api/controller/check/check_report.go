@@ -21,10 +21,12 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	checkevents "github.com/harness/gitness/app/events/check"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
@@ -42,8 +44,28 @@ type ReportInput struct {
 
 	Started int64 `json:"started,omitempty"`
 	Ended   int64 `json:"ended,omitempty"`
+
+	// Annotations replace all previously reported annotations of the status check.
+	// If omitted, the existing annotations are kept.
+	Annotations []AnnotationInput `json:"annotations"`
+}
+
+// AnnotationInput is a finding of the status check about a range of lines of a file.
+type AnnotationInput struct {
+	Path      string                       `json:"path"`
+	LineStart int                          `json:"line_start"`
+	LineEnd   int                          `json:"line_end"`
+	Severity  enum.CheckAnnotationSeverity `json:"severity"`
+	Title     string                       `json:"title"`
+	Message   string                       `json:"message"`
 }
 
+const (
+	maxAnnotationCount         = 1000
+	maxAnnotationTitleLength   = 255
+	maxAnnotationMessageLength = 4096
+)
+
 // TODO: Can we drop the '$' - depends on whether harness allows it.
 var regexpCheckIdentifier = "^[0-9a-zA-Z-_.$]{1,127}$"
 var matcherCheckIdentifier = regexp.MustCompile(regexpCheckIdentifier)
@@ -84,6 +106,58 @@ func (in *ReportInput) Sanitize(
 		return usererror.BadRequest("started time reported after ended time")
 	}
 
+	if len(in.Annotations) > maxAnnotationCount {
+		return usererror.BadRequestf("A status check can have at most %d annotations", maxAnnotationCount)
+	}
+
+	for i := range in.Annotations {
+		if err := in.Annotations[i].Sanitize(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Sanitize validates and sanitizes the AnnotationInput data.
+func (in *AnnotationInput) Sanitize() error {
+	in.Path = strings.TrimLeft(strings.TrimSpace(in.Path), "/")
+	if in.Path == "" {
+		return usererror.BadRequest("Annotation file path is missing")
+	}
+
+	if in.LineStart < 1 {
+		return usererror.BadRequest("Annotation start line must be a positive number")
+	}
+
+	if in.LineEnd == 0 {
+		in.LineEnd = in.LineStart
+	}
+
+	if in.LineEnd < in.LineStart {
+		return usererror.BadRequest("Annotation end line must not be before the start line")
+	}
+
+	var ok bool
+	in.Severity, ok = in.Severity.Sanitize()
+	if !ok {
+		return usererror.BadRequest("Invalid value provided for annotation severity")
+	}
+
+	in.Title = strings.TrimSpace(in.Title)
+	if len(in.Title) > maxAnnotationTitleLength {
+		return usererror.BadRequestf("Annotation title can have at most %d characters", maxAnnotationTitleLength)
+	}
+
+	in.Message = strings.TrimSpace(in.Message)
+	if in.Message == "" {
+		return usererror.BadRequest("Annotation message is missing")
+	}
+
+	if len(in.Message) > maxAnnotationMessageLength {
+		return usererror.BadRequestf("Annotation message can have at most %d characters", maxAnnotationMessageLength)
+	}
+
 	return nil
 }
 
@@ -177,14 +251,54 @@ func (c *Controller) Report(
 		Ended:      ended,
 	}
 
-	err = c.checkStore.Upsert(ctx, statusCheckReport)
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.checkStore.Upsert(ctx, statusCheckReport); err != nil {
+			return fmt.Errorf("failed to upsert status check result for repo=%s: %w", repo.Identifier, err)
+		}
+
+		if in.Annotations == nil {
+			return nil
+		}
+
+		err := c.checkAnnotationStore.ReplaceForCheck(ctx, statusCheckReport.ID, in.annotations())
+		if err != nil {
+			return fmt.Errorf("failed to store status check annotations for repo=%s: %w", repo.Identifier, err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to upsert status check result for repo=%s: %w", repo.Identifier, err)
+		return nil, err
 	}
 
+	c.reporter.Reported(ctx, &checkevents.ReportedPayload{
+		RepoID:      repo.ID,
+		PrincipalID: session.Principal.ID,
+		CommitSHA:   commitSHA,
+		Identifier:  in.Identifier,
+		Status:      in.Status,
+	})
+
 	return statusCheckReport, nil
 }
 
+func (in *ReportInput) annotations() []*types.CheckAnnotation {
+	annotations := make([]*types.CheckAnnotation, len(in.Annotations))
+	for i, a := range in.Annotations {
+		annotations[i] = &types.CheckAnnotation{
+			CheckIdentifier: in.Identifier,
+			Path:            a.Path,
+			LineStart:       a.LineStart,
+			LineEnd:         a.LineEnd,
+			Severity:        a.Severity,
+			Title:           a.Title,
+			Message:         a.Message,
+		}
+	}
+
+	return annotations
+}
+
 func getStartTime(in *ReportInput, check types.Check, now int64) int64 {
 	// start value came in api
 	if in.Started != 0 {
@@ -22,6 +22,7 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
+	checkevents "github.com/harness/gitness/app/events/check"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -37,6 +38,9 @@ type Controller struct {
 	checkStore store.CheckStore
 	git        git.Interface
 	sanitizers map[enum.CheckPayloadKind]func(in *ReportInput, s *auth.Session) error
+	reporter   *checkevents.Reporter
+
+	checkAnnotationStore store.CheckAnnotationStore
 }
 
 func NewController(
@@ -47,6 +51,8 @@ func NewController(
 	checkStore store.CheckStore,
 	git git.Interface,
 	sanitizers map[enum.CheckPayloadKind]func(in *ReportInput, s *auth.Session) error,
+	reporter *checkevents.Reporter,
+	checkAnnotationStore store.CheckAnnotationStore,
 ) *Controller {
 	return &Controller{
 		tx:         tx,
@@ -56,6 +62,9 @@ func NewController(
 		checkStore: checkStore,
 		git:        git,
 		sanitizers: sanitizers,
+		reporter:   reporter,
+
+		checkAnnotationStore: checkAnnotationStore,
 	}
 }
 
@@ -0,0 +1,123 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package environment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Controller struct {
+	authorizer        authz.Authorizer
+	spaceStore        store.SpaceStore
+	environmentStore  store.EnvironmentStore
+	principalStore    store.PrincipalStore
+	userGroupResolver usergroup.Resolver
+}
+
+func NewController(
+	authorizer authz.Authorizer,
+	spaceStore store.SpaceStore,
+	environmentStore store.EnvironmentStore,
+	principalStore store.PrincipalStore,
+	userGroupResolver usergroup.Resolver,
+) *Controller {
+	return &Controller{
+		authorizer:        authorizer,
+		spaceStore:        spaceStore,
+		environmentStore:  environmentStore,
+		principalStore:    principalStore,
+		userGroupResolver: userGroupResolver,
+	}
+}
+
+func (c *Controller) getSpaceCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	permission enum.Permission,
+) (*types.Space, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, permission); err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	return space, nil
+}
+
+// checkApprovers verifies that the approver principals and user groups exist.
+func (c *Controller) checkApprovers(ctx context.Context, approverIDs []int64, approverUserGroups []string) error {
+	for _, id := range approverIDs {
+		_, err := c.principalStore.Find(ctx, id)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return usererror.BadRequestf("Approver with ID %d doesn't exist.", id)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find approver principal: %w", err)
+		}
+	}
+
+	for _, scopedID := range approverUserGroups {
+		_, err := c.userGroupResolver.Resolve(ctx, scopedID)
+		if errors.Is(err, usergroup.ErrNotFound) {
+			return usererror.BadRequestf("Approver user group %q doesn't exist.", scopedID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to resolve approver user group: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// dedupApprovers removes duplicate approvers and replaces nil lists with empty ones.
+func dedupApprovers(approverIDs []int64, approverUserGroups []string) ([]int64, []string) {
+	ids := make([]int64, 0, len(approverIDs))
+	seenIDs := make(map[int64]struct{}, len(approverIDs))
+	for _, id := range approverIDs {
+		if _, ok := seenIDs[id]; ok {
+			continue
+		}
+		seenIDs[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	groups := make([]string, 0, len(approverUserGroups))
+	seenGroups := make(map[string]struct{}, len(approverUserGroups))
+	for _, group := range approverUserGroups {
+		if _, ok := seenGroups[group]; ok {
+			continue
+		}
+		seenGroups[group] = struct{}{}
+		groups = append(groups, group)
+	}
+
+	return ids, groups
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package environment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateInput struct {
+	Identifier         string   `json:"identifier"`
+	Description        string   `json:"description"`
+	ApproverIDs        []int64  `json:"approver_ids"`
+	ApproverUserGroups []string `json:"approver_user_groups"`
+}
+
+func (in *CreateInput) sanitize() error {
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	in.ApproverIDs, in.ApproverUserGroups = dedupApprovers(in.ApproverIDs, in.ApproverUserGroups)
+
+	return nil
+}
+
+// Create creates a new deployment environment in the space.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *CreateInput,
+) (*types.Environment, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	if err = c.checkApprovers(ctx, in.ApproverIDs, in.ApproverUserGroups); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	env := &types.Environment{
+		SpaceID:            space.ID,
+		Identifier:         in.Identifier,
+		Description:        in.Description,
+		ApproverIDs:        in.ApproverIDs,
+		ApproverUserGroups: in.ApproverUserGroups,
+		CreatedBy:          session.Principal.ID,
+		Created:            now,
+		Updated:            now,
+	}
+
+	if err = c.environmentStore.Create(ctx, env); err != nil {
+		return nil, fmt.Errorf("failed to create environment: %w", err)
+	}
+
+	return env, nil
+}
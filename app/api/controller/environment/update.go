@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package environment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UpdateInput is used for updating an environment.
+// The identifier can't be changed because pipelines and past stages refer to the environment by it.
+type UpdateInput struct {
+	Description        *string   `json:"description"`
+	ApproverIDs        *[]int64  `json:"approver_ids"`
+	ApproverUserGroups *[]string `json:"approver_user_groups"`
+}
+
+func (in *UpdateInput) sanitize() error {
+	if in.Description != nil {
+		*in.Description = strings.TrimSpace(*in.Description)
+		if err := check.Description(*in.Description); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Update updates a deployment environment of the space.
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	in *UpdateInput,
+) (*types.Environment, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	env, err := c.environmentStore.FindByIdentifier(ctx, space.ID, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find environment: %w", err)
+	}
+
+	if in.Description != nil {
+		env.Description = *in.Description
+	}
+	if in.ApproverIDs != nil {
+		env.ApproverIDs = *in.ApproverIDs
+	}
+	if in.ApproverUserGroups != nil {
+		env.ApproverUserGroups = *in.ApproverUserGroups
+	}
+
+	env.ApproverIDs, env.ApproverUserGroups = dedupApprovers(env.ApproverIDs, env.ApproverUserGroups)
+
+	if err = c.checkApprovers(ctx, env.ApproverIDs, env.ApproverUserGroups); err != nil {
+		return nil, err
+	}
+
+	env.Updated = time.Now().UnixMilli()
+
+	if err = c.environmentStore.Update(ctx, env); err != nil {
+		return nil, fmt.Errorf("failed to update environment: %w", err)
+	}
+
+	return env, nil
+}
@@ -0,0 +1,184 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/usergroup"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const maxApprovalCommentLength = 1024
+
+// StageDecisionInput is used to approve or reject a stage waiting for approval.
+type StageDecisionInput struct {
+	Comment string `json:"comment"`
+}
+
+func (in *StageDecisionInput) sanitize() error {
+	in.Comment = strings.TrimSpace(in.Comment)
+	if len(in.Comment) > maxApprovalCommentLength {
+		return usererror.BadRequestf("Comment can't be longer than %d characters.", maxApprovalCommentLength)
+	}
+
+	return nil
+}
+
+// stageDecision holds the resources involved in a decision on a stage waiting for approval.
+type stageDecision struct {
+	repo      *types.Repository
+	pipeline  *types.Pipeline
+	execution *types.Execution
+	stage     *types.Stage
+}
+
+// prepareStageDecision finds the stage waiting for approval and verifies
+// that the principal is allowed to approve the deployment.
+func (c *Controller) prepareStageDecision(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	stageNum int64,
+) (*stageDecision, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, pipelineIdentifier, enum.PermissionPipelineExecute)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	stage, err := c.stageStore.FindByNumber(ctx, execution.ID, int(stageNum))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stage %d: %w", stageNum, err)
+	}
+
+	if stage.Status != enum.CIStatusWaitingOnApproval {
+		return nil, usererror.BadRequest("The stage isn't waiting for approval.")
+	}
+
+	env, err := c.environmentStore.Find(ctx, stage.EnvironmentID)
+	switch {
+	case errors.Is(err, gitness_store.ErrResourceNotFound):
+		// the environment was deleted in the meantime, so only repo owners can decide on the deployment.
+		isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, repo)
+		if err != nil {
+			return nil, err
+		}
+		if !isRepoOwner {
+			return nil, usererror.Forbidden(
+				fmt.Sprintf("The environment %q no longer exists, only repository owners can decide on the deployment.",
+					stage.Environment))
+		}
+	case err != nil:
+		return nil, fmt.Errorf("failed to find environment: %w", err)
+	default:
+		isApprover, err := c.isApprover(ctx, session.Principal, env)
+		if err != nil {
+			return nil, err
+		}
+		if !isApprover {
+			return nil, usererror.Forbidden(
+				fmt.Sprintf("Only approvers of the environment %q can decide on the deployment.", env.Identifier))
+		}
+	}
+
+	return &stageDecision{
+		repo:      repo,
+		pipeline:  pipeline,
+		execution: execution,
+		stage:     stage,
+	}, nil
+}
+
+// isApprover returns true if the principal is one of the approvers of the environment,
+// either directly or as a member of one of the approver user groups.
+func (c *Controller) isApprover(
+	ctx context.Context,
+	principal types.Principal,
+	env *types.Environment,
+) (bool, error) {
+	if slices.Contains(env.ApproverIDs, principal.ID) {
+		return true, nil
+	}
+
+	for _, scopedID := range env.ApproverUserGroups {
+		userGroup, err := c.userGroupResolver.Resolve(ctx, scopedID)
+		if errors.Is(err, usergroup.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to resolve approver user group %q: %w", scopedID, err)
+		}
+
+		if slices.Contains(userGroup.Users, principal.UID) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// recordStageDecision stores the decision of the principal on the stage.
+func (c *Controller) recordStageDecision(
+	ctx context.Context,
+	session *auth.Session,
+	stage *types.Stage,
+	decision enum.StageApprovalDecision,
+	comment string,
+) (*types.StageApproval, error) {
+	approval := &types.StageApproval{
+		ExecutionID:   stage.ExecutionID,
+		StageID:       stage.ID,
+		StageNumber:   stage.Number,
+		EnvironmentID: stage.EnvironmentID,
+		Environment:   stage.Environment,
+		PrincipalID:   session.Principal.ID,
+		Approver:      session.Principal.ToPrincipalInfo(),
+		Decision:      decision,
+		Comment:       comment,
+		Created:       time.Now().UnixMilli(),
+	}
+
+	if err := c.stageApprovalStore.Create(ctx, approval); err != nil {
+		return nil, fmt.Errorf("failed to record stage approval: %w", err)
+	}
+
+	return approval, nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// errStageDecided is returned if another approver decided on the stage concurrently.
+var errStageDecided = usererror.Conflict("The stage has already been approved or rejected.")
+
+// ApproveStage approves the deployment of a stage waiting for approval and schedules the stage.
+func (c *Controller) ApproveStage(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	stageNum int64,
+	in *StageDecisionInput,
+) (*types.StageApproval, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	d, err := c.prepareStageDecision(ctx, session, repoRef, pipelineIdentifier, executionNum, stageNum)
+	if err != nil {
+		return nil, err
+	}
+
+	var approval *types.StageApproval
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		d.stage.Status = enum.CIStatusPending
+		err := c.stageStore.Update(ctx, d.stage)
+		if errors.Is(err, gitness_store.ErrVersionConflict) {
+			return errStageDecided
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update stage: %w", err)
+		}
+
+		approval, err = c.recordStageDecision(ctx, session, d.stage, enum.StageApprovalDecisionApproved, in.Comment)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.scheduler.Schedule(ctx, d.stage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule the approved stage: %w", err)
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("execution.id", d.execution.ID).
+		Int64("stage.number", d.stage.Number).
+		Str("environment", d.stage.Environment).
+		Msg("stage deployment approved")
+
+	return approval, nil
+}
@@ -18,9 +18,13 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
+	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
 )
 
 type Controller struct {
@@ -33,7 +37,17 @@ type Controller struct {
 	triggerer      triggerer.Triggerer
 	repoStore      store.RepoStore
 	stageStore     store.StageStore
+	stepStore      store.StepStore
 	pipelineStore  store.PipelineStore
+	artifactStore  store.PipelineArtifactStore
+	blobStore      blob.Store
+	config         *types.Config
+
+	scheduler          scheduler.Scheduler
+	environmentStore   store.EnvironmentStore
+	stageApprovalStore store.StageApprovalStore
+	userGroupResolver  usergroup.Resolver
+	testCaseStore      store.TestCaseStore
 }
 
 func NewController(
@@ -46,7 +60,16 @@ func NewController(
 	triggerer triggerer.Triggerer,
 	repoStore store.RepoStore,
 	stageStore store.StageStore,
+	stepStore store.StepStore,
 	pipelineStore store.PipelineStore,
+	artifactStore store.PipelineArtifactStore,
+	blobStore blob.Store,
+	config *types.Config,
+	scheduler scheduler.Scheduler,
+	environmentStore store.EnvironmentStore,
+	stageApprovalStore store.StageApprovalStore,
+	userGroupResolver usergroup.Resolver,
+	testCaseStore store.TestCaseStore,
 ) *Controller {
 	return &Controller{
 		tx:             tx,
@@ -58,6 +81,16 @@ func NewController(
 		triggerer:      triggerer,
 		repoStore:      repoStore,
 		stageStore:     stageStore,
+		stepStore:      stepStore,
 		pipelineStore:  pipelineStore,
+		artifactStore:  artifactStore,
+		blobStore:      blobStore,
+		config:         config,
+
+		scheduler:          scheduler,
+		environmentStore:   environmentStore,
+		stageApprovalStore: stageApprovalStore,
+		userGroupResolver:  userGroupResolver,
+		testCaseStore:      testCaseStore,
 	}
 }
@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/storage"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/types/enum"
+)
+
+// DownloadArtifact returns either a signed url or the content of an artifact of an execution.
+func (c *Controller) DownloadArtifact(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	name string,
+) (string, io.ReadCloser, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, pipelineIdentifier, enum.PermissionPipelineView)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	artifact, err := c.artifactStore.Find(ctx, execution.ID, name)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find artifact: %w", err)
+	}
+
+	blobPath := storage.ArtifactBlobPath(repo.ID, execution.ID, artifact.Name)
+
+	signedURL, err := c.blobStore.GetSignedURL(ctx, blobPath)
+	if err != nil && !errors.Is(err, blob.ErrNotSupported) {
+		return "", nil, fmt.Errorf("failed to get signed URL: %w", err)
+	}
+
+	if signedURL != "" {
+		return signedURL, nil, nil
+	}
+
+	file, err := c.blobStore.Download(ctx, blobPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to download artifact from blobstore: %w", err)
+	}
+
+	return "", file, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/checks"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RejectStage rejects the deployment of a stage waiting for approval.
+// The stage is declined and the rest of the execution is stopped.
+func (c *Controller) RejectStage(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	stageNum int64,
+	in *StageDecisionInput,
+) (*types.StageApproval, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	d, err := c.prepareStageDecision(ctx, session, repoRef, pipelineIdentifier, executionNum, stageNum)
+	if err != nil {
+		return nil, err
+	}
+
+	var approval *types.StageApproval
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		now := time.Now().UnixMilli()
+		d.stage.Status = enum.CIStatusDeclined
+		d.stage.Started = now
+		d.stage.Stopped = now
+		err := c.stageStore.Update(ctx, d.stage)
+		if errors.Is(err, gitness_store.ErrVersionConflict) {
+			return errStageDecided
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update stage: %w", err)
+		}
+
+		approval, err = c.recordStageDecision(ctx, session, d.stage, enum.StageApprovalDecisionRejected, in.Comment)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.canceler.Decline(ctx, d.repo, d.execution)
+	if err != nil {
+		return nil, fmt.Errorf("unable to stop execution: %w", err)
+	}
+
+	// Write to the checks store, log and ignore on errors
+	err = checks.Write(ctx, c.checkStore, d.execution, d.pipeline)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("could not update status check")
+	}
+
+	return approval, nil
+}
@@ -0,0 +1,295 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/checks"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Retry creates a new execution of the pipeline that re-runs the stages of a completed execution
+// that failed or were declined, along with the stages depending on them. It runs on the same commit
+// with the same params, and reuses the results of the other stages.
+func (c *Controller) Retry(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+) (*types.Execution, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path,
+		pipelineIdentifier, enum.PermissionPipelineExecute)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	if !execution.Status.IsDone() {
+		return nil, usererror.BadRequest("Only completed executions can be retried.")
+	}
+
+	stages, err := c.stageStore.ListWithSteps(ctx, execution.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stages of execution %d: %w", executionNum, err)
+	}
+
+	retried := stagesToRetry(stages)
+	if len(retried) == 0 {
+		return nil, usererror.BadRequest("The execution has no failed stages to retry.")
+	}
+
+	now := time.Now().UnixMilli()
+	retryStages := make([]*types.Stage, len(stages))
+	for i, stage := range stages {
+		if _, ok := retried[stage.Name]; !ok {
+			retryStages[i] = reusedStage(stage, execution.Number, now)
+			continue
+		}
+
+		retryStages[i], err = c.retriedStage(ctx, stage, retried, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pipeline, err = c.pipelineStore.IncrementSeqNum(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to increment execution sequence number: %w", err)
+	}
+
+	retry := &types.Execution{
+		RepoID:       execution.RepoID,
+		PipelineID:   execution.PipelineID,
+		Trigger:      session.Principal.UID,
+		CreatedBy:    session.Principal.ID,
+		Number:       pipeline.Seq,
+		Parent:       execution.Parent,
+		RetryOf:      execution.Number,
+		Status:       enum.CIStatusPending,
+		Event:        execution.Event,
+		Action:       execution.Action,
+		Link:         execution.Link,
+		Timestamp:    execution.Timestamp,
+		Title:        execution.Title,
+		Message:      execution.Message,
+		Before:       execution.Before,
+		After:        execution.After,
+		Ref:          execution.Ref,
+		Fork:         execution.Fork,
+		Source:       execution.Source,
+		Target:       execution.Target,
+		Author:       execution.Author,
+		AuthorName:   execution.AuthorName,
+		AuthorEmail:  execution.AuthorEmail,
+		AuthorAvatar: execution.AuthorAvatar,
+		Sender:       session.Principal.UID,
+		Params:       execution.Params,
+		Cron:         execution.Cron,
+		Deploy:       execution.Deploy,
+		DeployID:     execution.DeployID,
+		Debug:        execution.Debug,
+		Created:      now,
+		Updated:      now,
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		err := c.executionStore.Create(ctx, retry)
+		if err != nil {
+			return fmt.Errorf("failed to create execution: %w", err)
+		}
+
+		for _, stage := range retryStages {
+			stage.ExecutionID = retry.ID
+			err = c.stageStore.Create(ctx, stage)
+			if err != nil {
+				return fmt.Errorf("failed to create stage: %w", err)
+			}
+
+			for _, step := range stage.Steps {
+				step.StageID = stage.ID
+				err = c.stepStore.Create(ctx, step)
+				if err != nil {
+					return fmt.Errorf("failed to create step: %w", err)
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// try to write to check store. log on failure but don't error out the execution
+	err = checks.Write(ctx, c.checkStore, retry, pipeline)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("could not write to check store")
+	}
+
+	for _, stage := range retryStages {
+		if stage.Status != enum.CIStatusPending {
+			continue
+		}
+		err = c.scheduler.Schedule(ctx, stage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to schedule stage: %w", err)
+		}
+	}
+
+	return retry, nil
+}
+
+// stagesToRetry returns the names of the stages that failed or were declined
+// and of all the stages that depend on them, directly or indirectly.
+// The results of other stages, including the skipped ones, are reused.
+func stagesToRetry(stages []*types.Stage) map[string]struct{} {
+	retried := map[string]struct{}{}
+	for _, stage := range stages {
+		if stage.Status.IsFailed() || stage.Status == enum.CIStatusDeclined {
+			retried[stage.Name] = struct{}{}
+		}
+	}
+
+	for added := true; added; {
+		added = false
+		for _, stage := range stages {
+			if _, ok := retried[stage.Name]; ok {
+				continue
+			}
+			if dependsOnAny(stage, retried) {
+				retried[stage.Name] = struct{}{}
+				added = true
+			}
+		}
+	}
+
+	return retried
+}
+
+func dependsOnAny(stage *types.Stage, names map[string]struct{}) bool {
+	for _, dep := range stage.DependsOn {
+		if _, ok := names[dep]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// reusedStage returns a copy of a stage, and its steps, whose result is reused by the retry of its execution.
+func reusedStage(stage *types.Stage, executionNum int64, now int64) *types.Stage {
+	reused := *stage
+	reused.ID = 0
+	reused.ExecutionID = 0
+	reused.Version = 0
+	reused.Created = now
+	reused.Updated = now
+
+	// link to the execution that actually ran the stage, which keeps its logs.
+	if reused.ReusedFrom == 0 {
+		reused.ReusedFrom = executionNum
+	}
+
+	reused.Steps = make([]*types.Step, len(stage.Steps))
+	for i, step := range stage.Steps {
+		s := *step
+		s.ID = 0
+		s.StageID = 0
+		s.Version = 0
+		reused.Steps[i] = &s
+	}
+
+	return &reused
+}
+
+// retriedStage returns a new stage to re-run the stage in the retry of its execution.
+func (c *Controller) retriedStage(
+	ctx context.Context,
+	stage *types.Stage,
+	retried map[string]struct{},
+	now int64,
+) (*types.Stage, error) {
+	s := &types.Stage{
+		RepoID:        stage.RepoID,
+		Number:        stage.Number,
+		Name:          stage.Name,
+		Kind:          stage.Kind,
+		Type:          stage.Type,
+		Status:        enum.CIStatusPending,
+		OS:            stage.OS,
+		Arch:          stage.Arch,
+		Variant:       stage.Variant,
+		Kernel:        stage.Kernel,
+		Limit:         stage.Limit,
+		LimitRepo:     stage.LimitRepo,
+		OnSuccess:     stage.OnSuccess,
+		OnFailure:     stage.OnFailure,
+		DependsOn:     stage.DependsOn,
+		Labels:        stage.Labels,
+		Environment:   stage.Environment,
+		EnvironmentID: stage.EnvironmentID,
+		Matrix:        stage.Matrix,
+		Created:       now,
+		Updated:       now,
+	}
+
+	// the reused dependencies are complete, the stage only waits for the retried ones.
+	if dependsOnAny(s, retried) {
+		s.Status = enum.CIStatusWaitingOnDeps
+		return s, nil
+	}
+
+	if s.EnvironmentID == 0 {
+		return s, nil
+	}
+
+	env, err := c.environmentStore.Find(ctx, s.EnvironmentID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find environment: %w", err)
+	}
+
+	if env.IsProtected() {
+		s.Status = enum.CIStatusWaitingOnApproval
+	}
+
+	return s, nil
+}
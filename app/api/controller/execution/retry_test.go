@@ -0,0 +1,180 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestStagesToRetry(t *testing.T) {
+	stage := func(name string, status enum.CIStatus, dependsOn ...string) *types.Stage {
+		return &types.Stage{Name: name, Status: status, DependsOn: dependsOn}
+	}
+
+	tests := []struct {
+		name   string
+		stages []*types.Stage
+		want   []string
+	}{
+		{
+			name: "all succeeded",
+			stages: []*types.Stage{
+				stage("a", enum.CIStatusSuccess),
+				stage("b", enum.CIStatusSuccess, "a"),
+			},
+			want: []string{},
+		},
+		{
+			name: "failed statuses",
+			stages: []*types.Stage{
+				stage("failure", enum.CIStatusFailure),
+				stage("error", enum.CIStatusError),
+				stage("killed", enum.CIStatusKilled),
+				stage("declined", enum.CIStatusDeclined),
+				stage("success", enum.CIStatusSuccess),
+			},
+			want: []string{"declined", "error", "failure", "killed"},
+		},
+		{
+			name: "skipped stages are reused",
+			stages: []*types.Stage{
+				stage("a", enum.CIStatusSuccess),
+				stage("b", enum.CIStatusSkipped),
+				stage("c", enum.CIStatusSkipped, "b"),
+			},
+			want: []string{},
+		},
+		{
+			name: "dependents of failed stages",
+			stages: []*types.Stage{
+				stage("build", enum.CIStatusFailure),
+				stage("test", enum.CIStatusSkipped, "build"),
+				stage("deploy", enum.CIStatusSkipped, "test"),
+				stage("lint", enum.CIStatusSuccess),
+				stage("notify", enum.CIStatusSkipped, "lint"),
+			},
+			want: []string{"build", "deploy", "test"},
+		},
+		{
+			name: "dependents listed before their dependencies",
+			stages: []*types.Stage{
+				stage("deploy", enum.CIStatusSkipped, "test"),
+				stage("test", enum.CIStatusSuccess, "build", "lint"),
+				stage("lint", enum.CIStatusSuccess),
+				stage("build", enum.CIStatusKilled),
+			},
+			want: []string{"build", "deploy", "test"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			retried := stagesToRetry(test.stages)
+
+			got := make([]string, 0, len(retried))
+			for name := range retried {
+				got = append(got, name)
+			}
+			slices.Sort(got)
+
+			if !slices.Equal(got, test.want) {
+				t.Errorf("expected retried stages %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestReusedStage(t *testing.T) {
+	const now = 1000
+
+	tests := []struct {
+		name           string
+		reusedFrom     int64
+		executionNum   int64
+		wantReusedFrom int64
+	}{
+		{
+			name:           "stage ran in the execution",
+			reusedFrom:     0,
+			executionNum:   5,
+			wantReusedFrom: 5,
+		},
+		{
+			name:           "stage reused by the execution",
+			reusedFrom:     3,
+			executionNum:   5,
+			wantReusedFrom: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stage := &types.Stage{
+				ID:          10,
+				ExecutionID: 20,
+				Version:     2,
+				Number:      1,
+				Name:        "build",
+				Status:      enum.CIStatusSuccess,
+				ReusedFrom:  test.reusedFrom,
+				Created:     1,
+				Updated:     2,
+				Steps: []*types.Step{
+					{ID: 30, StageID: 10, Version: 3, Number: 1, Name: "step", Status: enum.CIStatusSuccess},
+				},
+			}
+
+			reused := reusedStage(stage, test.executionNum, now)
+
+			if reused.ID != 0 || reused.ExecutionID != 0 || reused.Version != 0 {
+				t.Errorf("expected stage identity to be reset, got id=%d execution_id=%d version=%d",
+					reused.ID, reused.ExecutionID, reused.Version)
+			}
+			if reused.Created != now || reused.Updated != now {
+				t.Errorf("expected stage timestamps %d, got created=%d updated=%d", now, reused.Created, reused.Updated)
+			}
+			if reused.ReusedFrom != test.wantReusedFrom {
+				t.Errorf("expected reused from %d, got %d", test.wantReusedFrom, reused.ReusedFrom)
+			}
+			if reused.Name != stage.Name || reused.Status != stage.Status {
+				t.Errorf("expected stage result to be kept, got name=%q status=%q", reused.Name, reused.Status)
+			}
+
+			if len(reused.Steps) != 1 {
+				t.Fatalf("expected 1 step, got %d", len(reused.Steps))
+			}
+			step := reused.Steps[0]
+			if step == stage.Steps[0] {
+				t.Fatal("expected step to be copied")
+			}
+			if step.ID != 0 || step.StageID != 0 || step.Version != 0 {
+				t.Errorf("expected step identity to be reset, got id=%d stage_id=%d version=%d",
+					step.ID, step.StageID, step.Version)
+			}
+			if step.Name != "step" || step.Status != enum.CIStatusSuccess {
+				t.Errorf("expected step result to be kept, got name=%q status=%q", step.Name, step.Status)
+			}
+
+			// the original stage must be left untouched.
+			if stage.ID != 10 || stage.Steps[0].ID != 30 || stage.ReusedFrom != test.reusedFrom {
+				t.Error("expected original stage to be unchanged")
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/storage"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UploadArtifact stores a file produced by a step of a running execution.
+// It's called from the pipeline steps, which authenticate as the pipeline service principal.
+func (c *Controller) UploadArtifact(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	name string,
+	file io.Reader,
+) (*types.PipelineArtifact, error) {
+	if err := storage.CheckArtifactName(name); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+	err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	if execution.Status.IsDone() {
+		return nil, usererror.BadRequest("Artifacts can only be uploaded while the execution is running.")
+	}
+
+	size, err := storage.Upload(ctx, c.blobStore, storage.ArtifactBlobPath(repo.ID, execution.ID, name),
+		file, c.config.CI.StorageMaxFileSize)
+	if err != nil {
+		return nil, err
+	}
+
+	artifact := &types.PipelineArtifact{
+		RepoID:      repo.ID,
+		ExecutionID: execution.ID,
+		Name:        name,
+		Size:        size,
+		Created:     time.Now().UnixMilli(),
+	}
+
+	if err = c.artifactStore.Upsert(ctx, artifact); err != nil {
+		return nil, fmt.Errorf("failed to store artifact: %w", err)
+	}
+
+	return artifact, nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/testreport"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// maxFlakyChecks limits the number of failed test cases of a test report checked for flakiness,
+// as the check requires a history lookup per test case.
+const maxFlakyChecks = 100
+
+// UploadTestReport stores the test cases of a test report produced by a step of a running execution
+// and returns the test summary of the execution.
+// It's called from the pipeline steps, which authenticate as the pipeline service principal.
+func (c *Controller) UploadTestReport(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	format enum.TestReportFormat,
+	report io.Reader,
+) (types.TestSummary, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return types.TestSummary{}, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+	err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoPush)
+	if err != nil {
+		return types.TestSummary{}, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return types.TestSummary{}, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return types.TestSummary{}, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	if execution.Status.IsDone() {
+		return types.TestSummary{}, usererror.BadRequest(
+			"Test reports can only be uploaded while the execution is running.")
+	}
+
+	testCases, err := testreport.Parse(format, report)
+	if err != nil {
+		return types.TestSummary{}, err
+	}
+
+	now := time.Now().UnixMilli()
+	flakyChecks := 0
+
+	for _, testCase := range testCases {
+		testCase.PipelineID = pipeline.ID
+		testCase.ExecutionID = execution.ID
+		testCase.Created = now
+
+		if testCase.Status != enum.TestStatusFailed || flakyChecks >= maxFlakyChecks {
+			continue
+		}
+
+		flakyChecks++
+
+		history, errHistory := c.testCaseStore.ListHistory(ctx, pipeline.ID, testCase.Suite, testCase.Name,
+			testreport.HistorySize)
+		if errHistory != nil {
+			return types.TestSummary{}, fmt.Errorf("failed to list history of test case: %w", errHistory)
+		}
+
+		testCase.Flaky = testreport.IsFlaky(execution, history)
+	}
+
+	var summary types.TestSummary
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		if err = c.testCaseStore.CreateMany(ctx, testCases); err != nil {
+			return fmt.Errorf("failed to store test cases: %w", err)
+		}
+
+		summary, err = c.testCaseStore.Summary(ctx, execution.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get test summary: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return types.TestSummary{}, err
+	}
+
+	return summary, nil
+}
@@ -18,9 +18,13 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
+	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
 )
@@ -40,8 +44,18 @@ func ProvideController(
 	triggerer triggerer.Triggerer,
 	repoStore store.RepoStore,
 	stageStore store.StageStore,
+	stepStore store.StepStore,
 	pipelineStore store.PipelineStore,
+	artifactStore store.PipelineArtifactStore,
+	blobStore blob.Store,
+	config *types.Config,
+	scheduler scheduler.Scheduler,
+	environmentStore store.EnvironmentStore,
+	stageApprovalStore store.StageApprovalStore,
+	userGroupResolver usergroup.Resolver,
+	testCaseStore store.TestCaseStore,
 ) *Controller {
 	return NewController(tx, authorizer, executionStore, checkStore,
-		canceler, commitService, triggerer, repoStore, stageStore, pipelineStore)
+		canceler, commitService, triggerer, repoStore, stageStore, stepStore, pipelineStore, artifactStore, blobStore,
+		config, scheduler, environmentStore, stageApprovalStore, userGroupResolver, testCaseStore)
 }
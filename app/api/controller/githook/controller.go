@@ -25,6 +25,7 @@ import (
 	eventsgit "github.com/harness/gitness/app/events/git"
 	eventsrepo "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -47,6 +48,7 @@ type Controller struct {
 	pullreqStore        store.PullReqStore
 	urlProvider         url.Provider
 	protectionManager   *protection.Manager
+	signatureVerifier   *publickey.SignatureVerifier
 	limiter             limiter.ResourceLimiter
 	settings            *settings.Service
 	preReceiveExtender  PreReceiveExtender
@@ -64,6 +66,7 @@ func NewController(
 	pullreqStore store.PullReqStore,
 	urlProvider url.Provider,
 	protectionManager *protection.Manager,
+	signatureVerifier *publickey.SignatureVerifier,
 	limiter limiter.ResourceLimiter,
 	settings *settings.Service,
 	preReceiveExtender PreReceiveExtender,
@@ -80,6 +83,7 @@ func NewController(
 		pullreqStore:        pullreqStore,
 		urlProvider:         urlProvider,
 		protectionManager:   protectionManager,
+		signatureVerifier:   signatureVerifier,
 		limiter:             limiter,
 		settings:            settings,
 		preReceiveExtender:  preReceiveExtender,
@@ -32,6 +32,9 @@ type RestrictedGIT interface {
 	GetBranch(ctx context.Context, params *git.GetBranchParams) (*git.GetBranchOutput, error)
 	Diff(ctx context.Context, in *git.DiffParams, files ...api.FileDiffRequest) (<-chan *git.FileDiff, <-chan error)
 	GetBlob(ctx context.Context, params *git.GetBlobParams) (*git.GetBlobOutput, error)
+	ListCommitSHAs(ctx context.Context, params *git.ListCommitSHAsParams) (*git.ListCommitSHAsOutput, error)
+	GetCommitSignatures(ctx context.Context, params *git.GetCommitSignaturesParams) (*git.GetCommitSignaturesOutput, error)
+	GetRefChanges(ctx context.Context, params *git.GetRefChangesParams) (*git.GetRefChangesOutput, error)
 	FindOversizeFiles(
 		ctx context.Context,
 		params *git.FindOversizeFilesParams,
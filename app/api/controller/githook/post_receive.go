@@ -217,7 +217,7 @@ func (c *Controller) suggestPullRequest(
 	// do we have a PR related to it?
 	prs, err := c.pullreqStore.List(ctx, &types.PullReqFilter{
 		Page: 1,
-		// without forks we expect at most one PR (keep 2 to not break when forks are introduced)
+		// a branch can have an open PR into its own repo and one into the upstream repo (for forks)
 		Size:         2,
 		SourceRepoID: repo.ID,
 		SourceBranch: branchName,
@@ -239,11 +239,23 @@ func (c *Controller) suggestPullRequest(
 
 	// for already existing PRs, print them to users terminal for easier access.
 	if len(prs) > 0 {
-		msgs := make([]string, 2*len(prs)+1)
+		msgs := make([]string, 1, 2*len(prs)+1)
 		msgs[0] = fmt.Sprintf("Branch %q has open PRs:", branchName)
-		for i, pr := range prs {
-			msgs[2*i+1] = fmt.Sprintf("  (#%d) %s", pr.Number, pr.Title)
-			msgs[2*i+2] = "    " + c.urlProvider.GenerateUIPRURL(ctx, repo.Path, pr.Number)
+		for _, pr := range prs {
+			// PRs from forks are part of the upstream repo
+			prRepoPath := repo.Path
+			if pr.TargetRepoID != repo.ID {
+				targetRepo, err := c.repoStore.Find(ctx, pr.TargetRepoID)
+				if err != nil {
+					log.Ctx(ctx).Warn().Err(err).Msgf("failed to find target repo of PR #%d", pr.Number)
+					continue
+				}
+				prRepoPath = targetRepo.Path
+			}
+
+			msgs = append(msgs,
+				fmt.Sprintf("  (#%d) %s", pr.Number, pr.Title),
+				"    "+c.urlProvider.GenerateUIPRURL(ctx, prRepoPath, pr.Number))
 		}
 		out.Messages = append(out.Messages, msgs...)
 		return
@@ -24,6 +24,7 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/hook"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -92,7 +93,7 @@ func (c *Controller) PreReceive(
 
 		dummySession := &auth.Session{Principal: *principal, Metadata: nil}
 
-		err = c.checkProtectionRules(ctx, dummySession, repo, refUpdates, &output)
+		err = c.checkProtectionRules(ctx, rgit, dummySession, repo, in, refUpdates, &output)
 		if output.Error != nil {
 			return output, nil
 		}
@@ -145,8 +146,10 @@ func (c *Controller) blockPullReqRefUpdate(refUpdates changedRefs, state enum.Re
 
 func (c *Controller) checkProtectionRules(
 	ctx context.Context,
+	rgit RestrictedGIT,
 	session *auth.Session,
 	repo *types.Repository,
+	in types.GithookPreReceiveInput,
 	refUpdates changedRefs,
 	output *hook.Output,
 ) error {
@@ -160,23 +163,50 @@ func (c *Controller) checkProtectionRules(
 		return fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
 	}
 
+	// new SHAs of the pushed branches, used to find the new commits that don't have a verified signature.
+	branchNewSHAs := make(map[string]string, len(in.RefUpdates))
+	for _, refUpdate := range in.RefUpdates {
+		if strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch) && !refUpdate.New.IsNil() {
+			branchNewSHAs[refUpdate.Ref[len(gitReferenceNamePrefixBranch):]] = refUpdate.New.String()
+		}
+	}
+
+	findUnverifiedCommits := func(ctx context.Context, branchName string) ([]string, error) {
+		newSHA, ok := branchNewSHAs[branchName]
+		if !ok {
+			return nil, nil
+		}
+
+		return c.signatureVerifier.FindUnverifiedCommits(ctx, rgit, &git.ListCommitSHAsParams{
+			ReadParams: git.ReadParams{
+				RepoUID:             repo.GitUID,
+				AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+			},
+			GitREF:              newSHA,
+			ExcludeExistingRefs: true,
+		})
+	}
+
+	findPushChanges := pushChangesFinder(rgit, repo, in)
+
 	var ruleViolations []types.RuleViolations
 	var errCheckAction error
 
-	//nolint:unparam
 	checkAction := func(refAction protection.RefAction, refType protection.RefType, names []string) {
 		if errCheckAction != nil || len(names) == 0 {
 			return
 		}
 
 		violations, err := protectionRules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
-			Actor:       &session.Principal,
-			AllowBypass: true,
-			IsRepoOwner: isRepoOwner,
-			Repo:        repo,
-			RefAction:   refAction,
-			RefType:     refType,
-			RefNames:    names,
+			Actor:                 &session.Principal,
+			AllowBypass:           true,
+			IsRepoOwner:           isRepoOwner,
+			Repo:                  repo,
+			RefAction:             refAction,
+			RefType:               refType,
+			RefNames:              names,
+			FindUnverifiedCommits: findUnverifiedCommits,
+			FindPushChanges:       findPushChanges,
 		})
 		if err != nil {
 			errCheckAction = fmt.Errorf("failed to verify protection rules for git push: %w", err)
@@ -190,6 +220,9 @@ func (c *Controller) checkProtectionRules(
 	checkAction(protection.RefActionDelete, protection.RefTypeBranch, refUpdates.branches.deleted)
 	checkAction(protection.RefActionUpdate, protection.RefTypeBranch, refUpdates.branches.updated)
 	checkAction(protection.RefActionUpdateForce, protection.RefTypeBranch, refUpdates.branches.forced)
+	checkAction(protection.RefActionCreate, protection.RefTypeTag, refUpdates.tags.created)
+	checkAction(protection.RefActionDelete, protection.RefTypeTag, refUpdates.tags.deleted)
+	checkAction(protection.RefActionUpdate, protection.RefTypeTag, refUpdates.tags.updated)
 
 	if errCheckAction != nil {
 		return errCheckAction
@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/types"
+)
+
+// pushChangesFinder returns a function that lists the new commits and the changed paths introduced by the push
+// to a branch or a tag. It's used to verify push protection rules. The changes of each reference are read only once.
+// The paths of new references are compared to their merge base with the default branch.
+func pushChangesFinder(
+	rgit RestrictedGIT,
+	repo *types.Repository,
+	in types.GithookPreReceiveInput,
+) func(ctx context.Context, refType protection.RefType, refName string) (protection.PushChanges, error) {
+	refUpdates := make(map[string]hook.ReferenceUpdate, len(in.RefUpdates))
+	for _, refUpdate := range in.RefUpdates {
+		if !refUpdate.New.IsNil() {
+			refUpdates[refUpdate.Ref] = refUpdate
+		}
+	}
+
+	readParams := git.ReadParams{
+		RepoUID:             repo.GitUID,
+		AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+	}
+
+	found := make(map[string]protection.PushChanges)
+
+	return func(ctx context.Context, refType protection.RefType, refName string) (protection.PushChanges, error) {
+		var ref string
+		switch refType {
+		case protection.RefTypeBranch:
+			ref = gitReferenceNamePrefixBranch + refName
+		case protection.RefTypeTag:
+			ref = gitReferenceNamePrefixTag + refName
+		case protection.RefTypeRaw:
+			ref = refName
+		}
+
+		if changes, ok := found[ref]; ok {
+			return changes, nil
+		}
+
+		refUpdate, ok := refUpdates[ref]
+		if !ok {
+			return protection.PushChanges{}, nil
+		}
+
+		var oldSHA string
+		if !refUpdate.Old.IsNil() {
+			oldSHA = refUpdate.Old.String()
+		}
+
+		out, err := rgit.GetRefChanges(ctx, &git.GetRefChangesParams{
+			ReadParams: readParams,
+			OldSHA:     oldSHA,
+			NewSHA:     refUpdate.New.String(),
+			BaseRef:    gitReferenceNamePrefixBranch + repo.DefaultBranch,
+		})
+		if err != nil {
+			return protection.PushChanges{}, fmt.Errorf("failed to get changes pushed to %q: %w", ref, err)
+		}
+
+		changes := protection.PushChanges{
+			Commits:      make([]protection.PushCommit, len(out.Commits)),
+			ChangedPaths: out.ChangedPaths,
+		}
+		for i, commit := range out.Commits {
+			changes.Commits[i] = protection.PushCommit{
+				SHA:            commit.SHA.String(),
+				Message:        commit.Message,
+				AuthorEmail:    commit.Author.Identity.Email,
+				CommitterEmail: commit.Committer.Identity.Email,
+			}
+		}
+
+		found[ref] = changes
+
+		return changes, nil
+	}
+}
@@ -20,6 +20,7 @@ import (
 	eventsgit "github.com/harness/gitness/app/events/git"
 	eventsrepo "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -50,6 +51,7 @@ func ProvideController(
 	pullreqStore store.PullReqStore,
 	urlProvider url.Provider,
 	protectionManager *protection.Manager,
+	signatureVerifier *publickey.SignatureVerifier,
 	githookFactory hook.ClientFactory,
 	limiter limiter.ResourceLimiter,
 	settings *settings.Service,
@@ -67,6 +69,7 @@ func ProvideController(
 		pullreqStore,
 		urlProvider,
 		protectionManager,
+		signatureVerifier,
 		limiter,
 		settings,
 		preReceiveExtender,
@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/types/enum"
+)
+
+// authTokenLifetime is the lifetime of the tokens issued for LFS operations via ssh.
+const authTokenLifetime = time.Hour
+
+type AuthenticateOutput struct {
+	Href      string            `json:"href"`
+	Header    map[string]string `json:"header,omitempty"`
+	ExpiresIn int64             `json:"expires_in,omitempty"`
+}
+
+// Authenticate implements the git-lfs-authenticate ssh command.
+// It returns the LFS API url of the repo together with a short-lived token
+// that grants the permissions required for the requested operation.
+func (c *Controller) Authenticate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	operation BatchOperation,
+) (*AuthenticateOutput, error) {
+	var permissions []enum.Permission
+	switch operation {
+	case BatchOperationDownload:
+		permissions = []enum.Permission{enum.PermissionRepoView}
+	case BatchOperationUpload:
+		permissions = []enum.Permission{enum.PermissionRepoView, enum.PermissionRepoPush}
+	default:
+		return nil, usererror.BadRequestf("Invalid operation %q.", operation)
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, permissions[len(permissions)-1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	// session of ssh connections doesn't contain the salt of the principal.
+	principal, err := c.principalStore.Find(ctx, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	lifetime := authTokenLifetime
+	token, err := jwt.GenerateForTokenWithAccessPermissions(
+		principal.ID,
+		&lifetime,
+		principal.Salt,
+		&jwt.SubClaimsAccessPermissions{
+			Source: jwt.LFSSource,
+			Permissions: []jwt.AccessPermissions{
+				{
+					SpaceID:        repo.ParentID,
+					RepoIdentifier: repo.Identifier,
+					Permissions:    permissions,
+				},
+			},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate lfs token: %w", err)
+	}
+
+	return &AuthenticateOutput{
+		Href: c.lfsURL(ctx, repo),
+		Header: map[string]string{
+			"Authorization": "Bearer " + token,
+		},
+		ExpiresIn: int64(authTokenLifetime.Seconds()),
+	}, nil
+}
@@ -0,0 +1,208 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// maxBatchObjects is the max number of objects accepted in a single batch request.
+	maxBatchObjects = 1000
+
+	transferBasic  = "basic"
+	hashAlgoSHA256 = "sha256"
+)
+
+type BatchOperation string
+
+const (
+	BatchOperationDownload BatchOperation = "download"
+	BatchOperationUpload   BatchOperation = "upload"
+)
+
+// Reference identifies the git ref an LFS request is related to.
+type Reference struct {
+	Name string `json:"name"`
+}
+
+// Pointer identifies an LFS object.
+type Pointer struct {
+	OID  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+type BatchInput struct {
+	Operation BatchOperation `json:"operation"`
+	Transfers []string       `json:"transfers,omitempty"`
+	Ref       *Reference     `json:"ref,omitempty"`
+	Objects   []Pointer      `json:"objects"`
+	HashAlgo  string         `json:"hash_algo,omitempty"`
+}
+
+type BatchOutput struct {
+	Transfer string          `json:"transfer,omitempty"`
+	Objects  []*ObjectOutput `json:"objects"`
+	HashAlgo string          `json:"hash_algo,omitempty"`
+}
+
+type ObjectOutput struct {
+	Pointer
+	Authenticated bool               `json:"authenticated,omitempty"`
+	Actions       map[string]*Action `json:"actions,omitempty"`
+	Error         *ObjectError       `json:"error,omitempty"`
+}
+
+// Action describes how the client can transfer an object.
+type Action struct {
+	Href   string            `json:"href"`
+	Header map[string]string `json:"header,omitempty"`
+}
+
+type ObjectError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (in *BatchInput) sanitize() error {
+	if in.Operation != BatchOperationDownload && in.Operation != BatchOperationUpload {
+		return usererror.BadRequestf("Invalid operation %q.", in.Operation)
+	}
+
+	if len(in.Transfers) > 0 && !slices.Contains(in.Transfers, transferBasic) {
+		return usererror.BadRequestf("Only the %q transfer adapter is supported.", transferBasic)
+	}
+
+	if in.HashAlgo != "" && in.HashAlgo != hashAlgoSHA256 {
+		return usererror.Conflict(fmt.Sprintf("Only the %q hash algorithm is supported.", hashAlgoSHA256))
+	}
+
+	if len(in.Objects) == 0 {
+		return usererror.BadRequest("At least one object is required.")
+	}
+
+	if len(in.Objects) > maxBatchObjects {
+		return usererror.RequestTooLargef("At most %d objects can be requested at once.", maxBatchObjects)
+	}
+
+	return nil
+}
+
+// Batch handles the LFS batch API request.
+// It returns the actions the client has to take to transfer each of the requested objects.
+// The authorization (optional) is the value of the Authorization header of the batch request,
+// the transfers are authenticated with it the same way as the batch request.
+func (c *Controller) Batch(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *BatchInput,
+	authorization string,
+) (*BatchOutput, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	permission := enum.PermissionRepoView
+	if in.Operation == BatchOperationUpload {
+		permission = enum.PermissionRepoPush
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, permission)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	oids := make([]string, 0, len(in.Objects))
+	for _, obj := range in.Objects {
+		if validateOID(obj.OID) == nil {
+			oids = append(oids, obj.OID)
+		}
+	}
+
+	existing, err := c.findObjects(ctx, repo, oids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find lfs objects: %w", err)
+	}
+
+	objectsURL := c.lfsURL(ctx, repo) + "/objects/"
+
+	out := &BatchOutput{
+		Transfer: transferBasic,
+		Objects:  make([]*ObjectOutput, len(in.Objects)),
+		HashAlgo: hashAlgoSHA256,
+	}
+
+	for i, obj := range in.Objects {
+		objOut := &ObjectOutput{Pointer: obj}
+		out.Objects[i] = objOut
+
+		if err := validateOID(obj.OID); err != nil || obj.Size < 0 {
+			objOut.Error = &ObjectError{
+				Code:    http.StatusUnprocessableEntity,
+				Message: "Invalid object id or size.",
+			}
+			continue
+		}
+
+		stored, ok := existing[obj.OID]
+
+		switch in.Operation {
+		case BatchOperationDownload:
+			if !ok {
+				objOut.Error = &ObjectError{
+					Code:    http.StatusNotFound,
+					Message: "Object does not exist.",
+				}
+				continue
+			}
+
+			objOut.Size = stored.Size
+			objOut.Actions = map[string]*Action{
+				string(BatchOperationDownload): newAction(objectsURL+obj.OID, authorization),
+			}
+
+		case BatchOperationUpload:
+			// object is already stored for the repo - the client doesn't have to upload it again.
+			if ok && stored.RepoID == repo.ID {
+				continue
+			}
+
+			objOut.Actions = map[string]*Action{
+				string(BatchOperationUpload): newAction(objectsURL+obj.OID, authorization),
+			}
+		}
+
+		objOut.Authenticated = authorization != "" && len(objOut.Actions) > 0
+	}
+
+	return out, nil
+}
+
+func newAction(href string, authorization string) *Action {
+	action := &Action{Href: href}
+	if authorization != "" {
+		action.Header = map[string]string{request.HeaderAuthorization: authorization}
+	}
+	return action
+}
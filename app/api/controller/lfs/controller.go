@@ -0,0 +1,155 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// lfsObjectBucketPathFmt is the path of an LFS object in the blob store.
+	// Objects are content addressed, the first two pairs of characters of the oid are used as subdirectories.
+	lfsObjectBucketPathFmt = "lfs/%s/%s/%s"
+)
+
+// oidRegex matches valid LFS object ids (sha256 hashes in lower-case hex encoding).
+var oidRegex = regexp.MustCompile("^[a-f0-9]{64}$")
+
+type Controller struct {
+	authorizer         authz.Authorizer
+	repoStore          store.RepoStore
+	principalStore     store.PrincipalStore
+	principalInfoCache store.PrincipalInfoCache
+	lfsObjectStore     store.LFSObjectStore
+	lfsLockStore       store.LFSLockStore
+	blobStore          blob.Store
+	urlProvider        url.Provider
+}
+
+func NewController(
+	authorizer authz.Authorizer,
+	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
+	principalInfoCache store.PrincipalInfoCache,
+	lfsObjectStore store.LFSObjectStore,
+	lfsLockStore store.LFSLockStore,
+	blobStore blob.Store,
+	urlProvider url.Provider,
+) *Controller {
+	return &Controller{
+		authorizer:         authorizer,
+		repoStore:          repoStore,
+		principalStore:     principalStore,
+		principalInfoCache: principalInfoCache,
+		lfsObjectStore:     lfsObjectStore,
+		lfsLockStore:       lfsLockStore,
+		blobStore:          blobStore,
+		urlProvider:        urlProvider,
+	}
+}
+
+// getRepoCheckAccess fetches a repo and checks if the current user has permission to access it.
+// Same as for git operations any repo state is allowed.
+func (c *Controller) getRepoCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	reqPermission enum.Permission,
+) (*types.Repository, error) {
+	return repo.GetRepoCheckAccess(
+		ctx,
+		c.repoStore,
+		c.authorizer,
+		session,
+		repoRef,
+		reqPermission,
+		nil,
+	)
+}
+
+// findObjects returns the LFS objects of the repo with the provided oids, mapped by oid.
+// Forks can reference objects that were pushed to their upstream repository,
+// hence objects that aren't stored for the repo are looked up along its upstream chain.
+func (c *Controller) findObjects(
+	ctx context.Context,
+	repo *types.Repository,
+	oids []string,
+) (map[string]*types.LFSObject, error) {
+	objects := make(map[string]*types.LFSObject, len(oids))
+	visited := map[int64]struct{}{}
+
+	for repoID, upstreamID := repo.ID, repo.ForkID; ; {
+		visited[repoID] = struct{}{}
+
+		found, err := c.lfsObjectStore.FindMany(ctx, repoID, oids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find lfs objects of repo %d: %w", repoID, err)
+		}
+
+		for _, obj := range found {
+			objects[obj.OID] = obj
+		}
+
+		oids = slices.DeleteFunc(oids, func(oid string) bool {
+			_, ok := objects[oid]
+			return ok
+		})
+
+		if _, ok := visited[upstreamID]; len(oids) == 0 || upstreamID == 0 || ok {
+			return objects, nil
+		}
+
+		upstream, err := c.repoStore.Find(ctx, upstreamID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find upstream repo %d: %w", upstreamID, err)
+		}
+
+		repoID, upstreamID = upstream.ID, upstream.ForkID
+	}
+}
+
+// lfsURL returns the base url of the LFS API of the repo.
+func (c *Controller) lfsURL(ctx context.Context, repo *types.Repository) string {
+	return c.urlProvider.GenerateGITCloneURL(ctx, repo.Path) + "/info/lfs"
+}
+
+func validateOID(oid string) error {
+	if !oidRegex.MatchString(oid) {
+		return usererror.BadRequestf("Invalid LFS object id %q.", oid)
+	}
+	return nil
+}
+
+func getLFSObjectBucketPath(oid string) string {
+	return fmt.Sprintf(lfsObjectBucketPathFmt, oid[0:2], oid[2:4], oid)
+}
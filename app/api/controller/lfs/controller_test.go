@@ -0,0 +1,410 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	gojwt "github.com/golang-jwt/jwt"
+)
+
+const testSalt = "test-salt"
+
+type fakeAuthorizer struct{}
+
+func (fakeAuthorizer) Check(context.Context, *auth.Session, *types.Scope, *types.Resource, enum.Permission,
+) (bool, error) {
+	return true, nil
+}
+
+func (fakeAuthorizer) CheckAll(context.Context, *auth.Session, ...types.PermissionCheck) (bool, error) {
+	return true, nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+	repo     *types.Repository
+	upstream *types.Repository
+}
+
+func (s *fakeRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	if s.upstream == nil || id != s.upstream.ID {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return s.upstream, nil
+}
+
+func (s *fakeRepoStore) FindByRef(_ context.Context, repoRef string) (*types.Repository, error) {
+	if repoRef != s.repo.Path {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return s.repo, nil
+}
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+}
+
+func (fakePrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	return &types.Principal{ID: id, Salt: testSalt}, nil
+}
+
+type fakeLFSObjectStore struct {
+	store.LFSObjectStore
+	objects map[string]*types.LFSObject
+}
+
+func (s *fakeLFSObjectStore) Find(_ context.Context, repoID int64, oid string) (*types.LFSObject, error) {
+	obj, ok := s.objects[oid]
+	if !ok || obj.RepoID != repoID {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return obj, nil
+}
+
+func (s *fakeLFSObjectStore) FindMany(_ context.Context, repoID int64, oids []string) ([]*types.LFSObject, error) {
+	var objects []*types.LFSObject
+	for _, oid := range oids {
+		if obj, ok := s.objects[oid]; ok && obj.RepoID == repoID {
+			objects = append(objects, obj)
+		}
+	}
+	return objects, nil
+}
+
+func (s *fakeLFSObjectStore) Create(_ context.Context, obj *types.LFSObject) error {
+	s.objects[obj.OID] = obj
+	return nil
+}
+
+type fakeBlobStore struct {
+	files map[string][]byte
+}
+
+func (s *fakeBlobStore) Upload(_ context.Context, file io.Reader, filePath string) error {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+	s.files[filePath] = data
+	return nil
+}
+
+func (s *fakeBlobStore) GetSignedURL(context.Context, string) (string, error) {
+	return "", nil
+}
+
+func (s *fakeBlobStore) Download(_ context.Context, filePath string) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(s.files[filePath])), nil
+}
+
+func (s *fakeBlobStore) Delete(_ context.Context, filePath string) error {
+	delete(s.files, filePath)
+	return nil
+}
+
+type fakeURLProvider struct {
+	url.Provider
+}
+
+func (fakeURLProvider) GenerateGITCloneURL(_ context.Context, repoPath string) string {
+	return "https://git.example.com/" + repoPath + ".git"
+}
+
+func oidOf(content string) string {
+	h := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(h[:])
+}
+
+func setupController() (*Controller, *fakeLFSObjectStore, *fakeBlobStore) {
+	repo := &types.Repository{
+		ID:         1,
+		ParentID:   10,
+		Identifier: "repo",
+		Path:       "space/repo",
+	}
+
+	objectStore := &fakeLFSObjectStore{objects: map[string]*types.LFSObject{}}
+	blobStore := &fakeBlobStore{files: map[string][]byte{}}
+
+	c := NewController(
+		fakeAuthorizer{},
+		&fakeRepoStore{repo: repo},
+		fakePrincipalStore{},
+		nil,
+		objectStore,
+		nil,
+		blobStore,
+		fakeURLProvider{},
+	)
+
+	return c, objectStore, blobStore
+}
+
+func TestBatch(t *testing.T) {
+	ctx := context.Background()
+	session := &auth.Session{Principal: types.Principal{ID: 1}}
+
+	existingOID := oidOf("existing")
+	missingOID := oidOf("missing")
+
+	tests := []struct {
+		name              string
+		operation         BatchOperation
+		authorization     string
+		wantActions       []string
+		wantErrorCodes    []int
+		wantAuthenticated bool
+	}{
+		{
+			name:           "download",
+			operation:      BatchOperationDownload,
+			wantActions:    []string{"download", "", ""},
+			wantErrorCodes: []int{0, 404, 422},
+		},
+		{
+			name:              "download-with-authorization",
+			operation:         BatchOperationDownload,
+			authorization:     "Bearer token",
+			wantActions:       []string{"download", "", ""},
+			wantErrorCodes:    []int{0, 404, 422},
+			wantAuthenticated: true,
+		},
+		{
+			name:           "upload",
+			operation:      BatchOperationUpload,
+			wantActions:    []string{"", "upload", ""},
+			wantErrorCodes: []int{0, 0, 422},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, objectStore, _ := setupController()
+			objectStore.objects[existingOID] = &types.LFSObject{OID: existingOID, Size: 8, RepoID: 1}
+
+			out, err := c.Batch(ctx, session, "space/repo", &BatchInput{
+				Operation: test.operation,
+				Objects: []Pointer{
+					{OID: existingOID, Size: 8},
+					{OID: missingOID, Size: 7},
+					{OID: "invalid", Size: 1},
+				},
+			}, test.authorization)
+			if err != nil {
+				t.Fatalf("batch failed: %s", err)
+			}
+
+			for i, obj := range out.Objects {
+				var gotAction string
+				for name, action := range obj.Actions {
+					gotAction = name
+					if want := "https://git.example.com/space/repo.git/info/lfs/objects/" + obj.OID; action.Href != want {
+						t.Errorf("object %d: want href %q got %q", i, want, action.Href)
+					}
+					if got := action.Header["Authorization"]; got != test.authorization {
+						t.Errorf("object %d: want authorization header %q got %q", i, test.authorization, got)
+					}
+				}
+				if gotAction != test.wantActions[i] {
+					t.Errorf("object %d: want action %q got %q", i, test.wantActions[i], gotAction)
+				}
+
+				var gotCode int
+				if obj.Error != nil {
+					gotCode = obj.Error.Code
+				}
+				if gotCode != test.wantErrorCodes[i] {
+					t.Errorf("object %d: want error code %d got %d", i, test.wantErrorCodes[i], gotCode)
+				}
+
+				wantAuthenticated := test.wantAuthenticated && gotAction != ""
+				if obj.Authenticated != wantAuthenticated {
+					t.Errorf("object %d: want authenticated=%t got %t", i, wantAuthenticated, obj.Authenticated)
+				}
+			}
+		})
+	}
+}
+
+func TestBatch_Fork(t *testing.T) {
+	ctx := context.Background()
+	session := &auth.Session{Principal: types.Principal{ID: 1}}
+
+	upstreamOID := oidOf("upstream")
+
+	c, objectStore, _ := setupController()
+	repoStore := c.repoStore.(*fakeRepoStore)
+	repoStore.repo.ForkID = 2
+	repoStore.upstream = &types.Repository{ID: 2, Path: "space/upstream"}
+	objectStore.objects[upstreamOID] = &types.LFSObject{OID: upstreamOID, Size: 8, RepoID: 2}
+
+	tests := []struct {
+		operation  BatchOperation
+		wantAction string
+	}{
+		{operation: BatchOperationDownload, wantAction: "download"},
+		{operation: BatchOperationUpload, wantAction: "upload"},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.operation), func(t *testing.T) {
+			out, err := c.Batch(ctx, session, "space/repo", &BatchInput{
+				Operation: test.operation,
+				Objects:   []Pointer{{OID: upstreamOID, Size: 8}},
+			}, "")
+			if err != nil {
+				t.Fatalf("batch failed: %s", err)
+			}
+
+			obj := out.Objects[0]
+			if obj.Error != nil {
+				t.Fatalf("unexpected object error: %+v", obj.Error)
+			}
+			if _, ok := obj.Actions[test.wantAction]; !ok || len(obj.Actions) != 1 {
+				t.Errorf("want action %q got %v", test.wantAction, obj.Actions)
+			}
+		})
+	}
+}
+
+func TestUpload(t *testing.T) {
+	ctx := context.Background()
+	session := &auth.Session{Principal: types.Principal{ID: 1}}
+
+	const content = "hello lfs"
+	oid := oidOf(content)
+
+	tests := []struct {
+		name    string
+		content string
+		size    int64
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			content: content,
+			size:    int64(len(content)),
+		},
+		{
+			name:    "content-larger-than-size",
+			content: content,
+			size:    int64(len(content)) - 1,
+			wantErr: true,
+		},
+		{
+			name:    "content-smaller-than-size",
+			content: content,
+			size:    int64(len(content)) + 1,
+			wantErr: true,
+		},
+		{
+			name:    "unknown-size",
+			content: content,
+			size:    -1,
+			wantErr: true,
+		},
+		{
+			name:    "content-mismatch",
+			content: "HELLO LFS",
+			size:    int64(len(content)),
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, objectStore, blobStore := setupController()
+
+			err := c.Upload(ctx, session, "space/repo", oid, test.size, strings.NewReader(test.content))
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				if len(objectStore.objects) != 0 || len(blobStore.files) != 0 {
+					t.Errorf("expected nothing to be stored")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("upload failed: %s", err)
+			}
+
+			obj, ok := objectStore.objects[oid]
+			if !ok || obj.Size != int64(len(content)) {
+				t.Errorf("object not stored correctly: %+v", obj)
+			}
+			if got := string(blobStore.files[getLFSObjectBucketPath(oid)]); got != content {
+				t.Errorf("want content %q got %q", content, got)
+			}
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	session := &auth.Session{Principal: types.Principal{ID: 1}}
+
+	c, _, _ := setupController()
+
+	if _, err := c.Authenticate(ctx, session, "space/repo", "delete"); err == nil {
+		t.Errorf("expected an error for an invalid operation")
+	}
+
+	out, err := c.Authenticate(ctx, session, "space/repo", BatchOperationUpload)
+	if err != nil {
+		t.Fatalf("authenticate failed: %s", err)
+	}
+
+	if want := "https://git.example.com/space/repo.git/info/lfs"; out.Href != want {
+		t.Errorf("want href %q got %q", want, out.Href)
+	}
+
+	token, ok := strings.CutPrefix(out.Header["Authorization"], "Bearer ")
+	if !ok {
+		t.Fatalf("expected a bearer token, got %q", out.Header["Authorization"])
+	}
+
+	claims := &jwt.Claims{}
+	_, err = gojwt.ParseWithClaims(token, claims, func(*gojwt.Token) (interface{}, error) {
+		return []byte(testSalt), nil
+	})
+	if err != nil {
+		t.Fatalf("failed to parse token: %s", err)
+	}
+
+	if claims.AccessPermissions == nil || claims.AccessPermissions.Source != jwt.LFSSource {
+		t.Fatalf("expected lfs access permissions, got %+v", claims.AccessPermissions)
+	}
+
+	permissions := claims.AccessPermissions.Permissions
+	if len(permissions) != 1 || permissions[0].SpaceID != 10 || permissions[0].RepoIdentifier != "repo" {
+		t.Errorf("expected permissions scoped to the repo, got %+v", permissions)
+	}
+}
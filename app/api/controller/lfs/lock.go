@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/types"
+)
+
+// maxLockListLimit is the max number of locks returned by a single request.
+const maxLockListLimit = 100
+
+// Lock is the LFS locks API representation of a lock.
+type Lock struct {
+	ID       string     `json:"id"`
+	Path     string     `json:"path"`
+	LockedAt time.Time  `json:"locked_at"`
+	Owner    *LockOwner `json:"owner,omitempty"`
+}
+
+type LockOwner struct {
+	Name string `json:"name"`
+}
+
+// mapLocks converts the locks to their API representation.
+func (c *Controller) mapLocks(ctx context.Context, locks []*types.LFSLock) ([]Lock, error) {
+	principalIDs := make([]int64, 0, len(locks))
+	for _, lock := range locks {
+		principalIDs = append(principalIDs, lock.CreatedBy)
+	}
+
+	principals, err := c.principalInfoCache.Map(ctx, principalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch lock owners: %w", err)
+	}
+
+	res := make([]Lock, len(locks))
+	for i, lock := range locks {
+		res[i] = Lock{
+			ID:       strconv.FormatInt(lock.ID, 10),
+			Path:     lock.Path,
+			LockedAt: time.UnixMilli(lock.Created).UTC(),
+		}
+		if principal, ok := principals[lock.CreatedBy]; ok {
+			res[i].Owner = &LockOwner{Name: principal.DisplayName}
+		}
+	}
+
+	return res, nil
+}
+
+func (c *Controller) mapLock(ctx context.Context, lock *types.LFSLock) (*Lock, error) {
+	locks, err := c.mapLocks(ctx, []*types.LFSLock{lock})
+	if err != nil {
+		return nil, err
+	}
+
+	return &locks[0], nil
+}
+
+// nextCursor returns the cursor of the page following the provided locks (empty if it's the last page).
+func nextCursor(locks []*types.LFSLock, limit int) string {
+	if len(locks) == 0 || len(locks) < limit {
+		return ""
+	}
+
+	return strconv.FormatInt(locks[len(locks)-1].ID, 10)
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type LockCreateInput struct {
+	Path string     `json:"path"`
+	Ref  *Reference `json:"ref,omitempty"`
+}
+
+type LockOutput struct {
+	Lock *Lock `json:"lock"`
+}
+
+func (in *LockCreateInput) sanitize() error {
+	in.Path = strings.TrimSpace(in.Path)
+	if in.Path == "" {
+		return usererror.BadRequest("Path is required.")
+	}
+
+	return nil
+}
+
+// LockCreate locks a file in the repository.
+func (c *Controller) LockCreate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *LockCreateInput,
+) (*LockOutput, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	lock := &types.LFSLock{
+		RepoID:    repo.ID,
+		Path:      in.Path,
+		Created:   time.Now().UnixMilli(),
+		CreatedBy: session.Principal.ID,
+	}
+	if in.Ref != nil {
+		lock.Ref = in.Ref.Name
+	}
+
+	err = c.lfsLockStore.Create(ctx, lock)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		existing, err := c.lfsLockStore.FindByPath(ctx, repo.ID, in.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find existing lfs lock: %w", err)
+		}
+
+		existingOut, err := c.mapLock(ctx, existing)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, usererror.ConflictWithPayload("Lock already exists.", map[string]any{"lock": existingOut})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lfs lock: %w", err)
+	}
+
+	out, err := c.mapLock(ctx, lock)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LockOutput{Lock: out}, nil
+}
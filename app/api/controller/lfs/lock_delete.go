@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types/enum"
+)
+
+type LockDeleteInput struct {
+	Force bool       `json:"force,omitempty"`
+	Ref   *Reference `json:"ref,omitempty"`
+}
+
+// LockDelete unlocks a file in the repository.
+// Locks owned by other users can only be removed by force and by users that are allowed to edit the repository.
+func (c *Controller) LockDelete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	lockID int64,
+	in *LockDeleteInput,
+) (*LockOutput, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	lock, err := c.lfsLockStore.Find(ctx, lockID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) || (err == nil && lock.RepoID != repo.ID) {
+		return nil, usererror.NotFound("Lock not found.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find lfs lock: %w", err)
+	}
+
+	if lock.CreatedBy != session.Principal.ID {
+		if !in.Force {
+			return nil, usererror.Forbidden("The lock is owned by another user, use force to remove it.")
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoEdit); err != nil {
+			return nil, fmt.Errorf("failed to verify authorization to force unlock: %w", err)
+		}
+	}
+
+	if err = c.lfsLockStore.Delete(ctx, lock.ID); err != nil {
+		return nil, fmt.Errorf("failed to delete lfs lock: %w", err)
+	}
+
+	out, err := c.mapLock(ctx, lock)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LockOutput{Lock: out}, nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type LockListOutput struct {
+	Locks      []Lock `json:"locks"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// LockList lists the locks of the repository.
+func (c *Controller) LockList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.LFSLockFilter,
+) (*LockListOutput, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	locks, err := c.lfsLockStore.List(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list lfs locks: %w", err)
+	}
+
+	out, err := c.mapLocks(ctx, locks)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LockListOutput{
+		Locks:      out,
+		NextCursor: nextCursor(locks, filter.Limit),
+	}, nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type LockVerifyInput struct {
+	Ref    *Reference `json:"ref,omitempty"`
+	Cursor string     `json:"cursor,omitempty"`
+	Limit  int        `json:"limit,omitempty"`
+}
+
+type LockVerifyOutput struct {
+	Ours       []Lock `json:"ours"`
+	Theirs     []Lock `json:"theirs"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// LockVerify lists the locks of the repository split by whether they are owned by the current user.
+// The client calls it before a push to find out if any of the pushed files are locked by somebody else.
+func (c *Controller) LockVerify(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *LockVerifyInput,
+) (*LockVerifyOutput, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	limit := in.Limit
+	if limit <= 0 || limit > maxLockListLimit {
+		limit = maxLockListLimit
+	}
+
+	filter := &types.LFSLockFilter{
+		Limit: limit,
+	}
+	if in.Cursor != "" {
+		filter.Cursor, err = strconv.ParseInt(in.Cursor, 10, 64)
+		if err != nil {
+			return nil, usererror.BadRequest("Invalid cursor.")
+		}
+	}
+
+	locks, err := c.lfsLockStore.List(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list lfs locks: %w", err)
+	}
+
+	var ours, theirs []*types.LFSLock
+	for _, lock := range locks {
+		if lock.CreatedBy == session.Principal.ID {
+			ours = append(ours, lock)
+		} else {
+			theirs = append(theirs, lock)
+		}
+	}
+
+	out := &LockVerifyOutput{
+		NextCursor: nextCursor(locks, limit),
+	}
+
+	if out.Ours, err = c.mapLocks(ctx, ours); err != nil {
+		return nil, err
+	}
+	if out.Theirs, err = c.mapLocks(ctx, theirs); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Upload stores the content of an LFS object.
+// The content is verified against the object id and the size before it's uploaded to the blob store.
+func (c *Controller) Upload(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	oid string,
+	size int64,
+	file io.Reader,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if err = validateOID(oid); err != nil {
+		return err
+	}
+
+	if file == nil {
+		return usererror.BadRequest("No file provided.")
+	}
+
+	if size < 0 {
+		return usererror.New(http.StatusLengthRequired, "The size of the object is required.")
+	}
+
+	_, err = c.lfsObjectStore.Find(ctx, repo.ID, oid)
+	if err == nil {
+		// object is already stored, nothing to do.
+		return nil
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find lfs object: %w", err)
+	}
+
+	// the content is buffered in a temporary file to verify it before it's uploaded to the blob store.
+	tmpFile, err := os.CreateTemp("", "lfs-object-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer func() {
+		_ = tmpFile.Close()
+		if err := os.Remove(tmpFile.Name()); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to remove temporary lfs object file %q", tmpFile.Name())
+		}
+	}()
+
+	// read at most one byte more than expected to detect content that is larger than the declared size.
+	hasher := sha256.New()
+	written, err := io.Copy(io.MultiWriter(tmpFile, hasher), io.LimitReader(file, size+1))
+	if err != nil {
+		return fmt.Errorf("failed to read lfs object content: %w", err)
+	}
+
+	if written != size {
+		return usererror.UnprocessableEntityf("The content size doesn't match the object size %d.", size)
+	}
+
+	if hex.EncodeToString(hasher.Sum(nil)) != oid {
+		return usererror.UnprocessableEntityf("The content doesn't match the object id %q.", oid)
+	}
+
+	if _, err = tmpFile.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to seek temporary file: %w", err)
+	}
+
+	if err = c.blobStore.Upload(ctx, tmpFile, getLFSObjectBucketPath(oid)); err != nil {
+		return fmt.Errorf("failed to upload lfs object: %w", err)
+	}
+
+	err = c.lfsObjectStore.Create(ctx, &types.LFSObject{
+		OID:       oid,
+		Size:      size,
+		Created:   time.Now().UnixMilli(),
+		CreatedBy: session.Principal.ID,
+		RepoID:    repo.ID,
+	})
+	if err != nil && !errors.Is(err, gitness_store.ErrDuplicate) {
+		return fmt.Errorf("failed to create lfs object: %w", err)
+	}
+
+	return nil
+}
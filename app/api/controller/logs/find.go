@@ -59,6 +59,19 @@ func (c *Controller) Find(
 		return nil, fmt.Errorf("failed to find stage: %w", err)
 	}
 
+	// the logs of a stage reused by a retry are kept by the execution that ran it.
+	if stage.ReusedFrom != 0 {
+		execution, err = c.executionStore.FindByNumber(ctx, pipeline.ID, stage.ReusedFrom)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find execution of reused stage: %w", err)
+		}
+
+		stage, err = c.stageStore.FindByNumber(ctx, execution.ID, stageNum)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find reused stage: %w", err)
+		}
+	}
+
 	step, err := c.stepStore.FindByNumber(ctx, stage.ID, stepNum)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find step: %w", err)
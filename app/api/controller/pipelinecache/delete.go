@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelinecache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/storage"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Delete deletes a pipeline cache, the next execution using its key starts with an empty cache.
+func (c *Controller) Delete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	key string,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if err = c.cacheStore.Delete(ctx, repo.ID, key); err != nil {
+		return fmt.Errorf("failed to delete pipeline cache: %w", err)
+	}
+
+	if err = c.blobStore.Delete(ctx, storage.CacheBlobPath(repo.ID, key)); err != nil {
+		return fmt.Errorf("failed to delete pipeline cache archive: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelinecache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/storage"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Restore returns either a signed url or the content of the cache archive with the provided key.
+func (c *Controller) Restore(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	key string,
+) (string, io.ReadCloser, error) {
+	if err := storage.CheckCacheKey(key); err != nil {
+		return "", nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if _, err = c.cacheStore.Find(ctx, repo.ID, key); err != nil {
+		return "", nil, fmt.Errorf("failed to find pipeline cache: %w", err)
+	}
+
+	if err = c.cacheStore.UpdateLastUsed(ctx, repo.ID, key, time.Now().UnixMilli()); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to update last used time of pipeline cache %s", key)
+	}
+
+	blobPath := storage.CacheBlobPath(repo.ID, key)
+
+	signedURL, err := c.blobStore.GetSignedURL(ctx, blobPath)
+	if err != nil && !errors.Is(err, blob.ErrNotSupported) {
+		return "", nil, fmt.Errorf("failed to get signed URL: %w", err)
+	}
+
+	if signedURL != "" {
+		return signedURL, nil, nil
+	}
+
+	file, err := c.blobStore.Download(ctx, blobPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to download pipeline cache from blobstore: %w", err)
+	}
+
+	return "", file, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelinecache
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/storage"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Save stores the cache archive uploaded by a pipeline step, replacing any previous archive with the same key.
+func (c *Controller) Save(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	key string,
+	file io.Reader,
+) (*types.PipelineCache, error) {
+	if err := storage.CheckCacheKey(key); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	size, err := storage.Upload(ctx, c.blobStore, storage.CacheBlobPath(repo.ID, key),
+		file, c.config.CI.StorageMaxFileSize)
+	if err != nil {
+		// the previous archive might have been partially overwritten.
+		_ = c.cacheStore.Delete(ctx, repo.ID, key)
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	cache := &types.PipelineCache{
+		RepoID:   repo.ID,
+		Key:      key,
+		Size:     size,
+		Created:  now,
+		Updated:  now,
+		LastUsed: now,
+	}
+
+	if err = c.cacheStore.Upsert(ctx, cache); err != nil {
+		return nil, fmt.Errorf("failed to store pipeline cache: %w", err)
+	}
+
+	return cache, nil
+}
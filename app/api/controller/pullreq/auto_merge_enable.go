@@ -0,0 +1,174 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type AutoMergeEnableInput struct {
+	Method    enum.MergeMethod `json:"method"`
+	SourceSHA string           `json:"source_sha"`
+	Title     string           `json:"title"`
+	Message   string           `json:"message"`
+}
+
+func (in *AutoMergeEnableInput) sanitize() error {
+	if in.SourceSHA == "" {
+		return usererror.BadRequest("source SHA must be provided")
+	}
+
+	method, ok := in.Method.Sanitize()
+	if !ok || method == "" {
+		return usererror.BadRequestf("unsupported merge method: %s", in.Method)
+	}
+
+	in.Method = method
+
+	// cleanup title / message (NOTE: git doesn't support white space only)
+	in.Title = strings.TrimSpace(in.Title)
+	in.Message = strings.TrimSpace(in.Message)
+
+	if (in.Method == enum.MergeMethodRebase || in.Method == enum.MergeMethodFastForward) &&
+		(in.Title != "" || in.Message != "") {
+		return usererror.BadRequestf(
+			"merge method %q doesn't support customizing commit title and message", in.Method)
+	}
+
+	return nil
+}
+
+// AutoMergeEnable marks a pull request to be merged automatically with the provided merge method
+// as soon as it satisfies all protection rules. Rules are never bypassed when merging automatically.
+//
+// Auto merge is enabled for the current source branch commit of the pull request,
+// it's disabled if new commits are pushed to the source branch or if the pull request gets closed.
+func (c *Controller) AutoMergeEnable(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *AutoMergeEnableInput,
+) (*types.PullReqAutoMerge, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Pull request must be open")
+	}
+
+	if pr.SourceSHA != in.SourceSHA {
+		return nil, usererror.BadRequest("A newer commit is available. Only the latest commit can be merged.")
+	}
+
+	if pr.IsDraft {
+		return nil, usererror.BadRequest("Auto merge can't be enabled for draft pull requests.")
+	}
+
+	sourceRepo := targetRepo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get source repository: %w", err)
+		}
+	}
+
+	protectionRules, isRepoOwner, err := c.fetchRules(ctx, session, targetRepo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rules: %w", err)
+	}
+
+	// Only the rules that can't ever be satisfied by the pull request are checked here.
+	// Other requirements (approvals, status checks...) are awaited by the auto merge.
+	ruleOut, _, err := protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
+		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              &session.Principal,
+		IsRepoOwner:        isRepoOwner,
+		TargetRepo:         targetRepo,
+		SourceRepo:         sourceRepo,
+		PullReq:            pr,
+		Method:             in.Method,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if !slices.Contains(ruleOut.AllowedMethods, in.Method) {
+		return nil, usererror.BadRequestf("Merge method %q is not allowed by the protection rules.", in.Method)
+	}
+
+	if ruleOut.RequiresMergeQueue {
+		return nil, usererror.BadRequest(
+			"Protection rules require merging through the merge queue. Add the pull request to the merge queue.")
+	}
+
+	// backfill commit title if none provided
+	if in.Title == "" {
+		switch in.Method {
+		case enum.MergeMethodMerge:
+			in.Title = fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
+		case enum.MergeMethodSquash:
+			in.Title = fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
+		case enum.MergeMethodRebase, enum.MergeMethodFastForward:
+			// Not used.
+		}
+	}
+
+	autoMerge := &types.PullReqAutoMerge{
+		PullReqID: pr.ID,
+		RepoID:    targetRepo.ID,
+		SourceSHA: pr.SourceSHA,
+		Method:    in.Method,
+		Title:     in.Title,
+		Message:   in.Message,
+		CreatedBy: session.Principal.ID,
+		Created:   time.Now().UnixMilli(),
+		EnabledBy: session.Principal.ToPrincipalInfo(),
+	}
+
+	if err = c.autoMergeStore.Upsert(ctx, autoMerge); err != nil {
+		return nil, fmt.Errorf("failed to enable auto merge: %w", err)
+	}
+
+	// the pull request could already be ready for merging
+	c.eventReporter.AutoMergeEnabled(ctx, &pullreqevents.AutoMergeEnabledPayload{
+		Base:        eventBase(pr, &session.Principal),
+		MergeMethod: in.Method,
+	})
+
+	return autoMerge, nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// AutoMergeFind returns the auto merge settings of a pull request.
+func (c *Controller) AutoMergeFind(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.PullReqAutoMerge, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	autoMerge, err := c.autoMergeStore.Find(ctx, pr.ID)
+	if errors.IsNotFound(err) {
+		return nil, usererror.NotFound("Auto merge is not enabled for the pull request.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find auto merge settings: %w", err)
+	}
+
+	autoMerge.EnabledBy, err = c.principalInfoCache.Get(ctx, autoMerge.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch principal info of the user who enabled auto merge: %w", err)
+	}
+
+	return autoMerge, nil
+}
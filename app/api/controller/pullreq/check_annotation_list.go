@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListCheckAnnotations returns the status check annotations of the latest commit of a pull request.
+// Annotations of lines changed by the pull request are marked so that they can be shown inline in the diff.
+func (c *Controller) ListCheckAnnotations(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+) ([]types.PullReqCheckAnnotation, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	annotations, err := c.checkAnnotationStore.List(ctx, repo.ID, pr.SourceSHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list status check annotations: %w", err)
+	}
+
+	if len(annotations) == 0 {
+		return []types.PullReqCheckAnnotation{}, nil
+	}
+
+	diff, err := c.git.GetDiffHunkHeaders(ctx, git.GetDiffHunkHeadersParams{
+		ReadParams:      git.ReadParams{RepoUID: repo.GitUID},
+		SourceCommitSHA: pr.MergeBaseSHA,
+		TargetCommitSHA: pr.SourceSHA,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hunk headers of the pull request diff: %w", err)
+	}
+
+	return mapPullReqCheckAnnotations(annotations, diff.Files), nil
+}
+
+// mapPullReqCheckAnnotations marks annotations whose line range intersects
+// with the lines added or modified by any of the diff hunks of the annotated file.
+func mapPullReqCheckAnnotations(
+	annotations []*types.CheckAnnotation,
+	files []git.DiffFileHunkHeaders,
+) []types.PullReqCheckAnnotation {
+	hunksByPath := make(map[string][]git.HunkHeader, len(files))
+	for _, file := range files {
+		hunksByPath[file.FileHeader.NewName] = file.HunkHeaders
+	}
+
+	result := make([]types.PullReqCheckAnnotation, len(annotations))
+	for i, annotation := range annotations {
+		result[i].CheckAnnotation = *annotation
+
+		for _, hunk := range hunksByPath[annotation.Path] {
+			if hunk.NewSpan == 0 {
+				continue // the hunk only removes lines
+			}
+
+			if annotation.LineStart <= hunk.NewLine+hunk.NewSpan-1 && annotation.LineEnd >= hunk.NewLine {
+				result[i].Changed = true
+				break
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+)
+
+func Test_mapPullReqCheckAnnotations(t *testing.T) {
+	files := []git.DiffFileHunkHeaders{
+		{
+			FileHeader: git.DiffFileHeader{OldName: "a.go", NewName: "a.go"},
+			HunkHeaders: []git.HunkHeader{
+				{OldLine: 10, OldSpan: 0, NewLine: 11, NewSpan: 3}, // added lines 11-13
+				{OldLine: 30, OldSpan: 2, NewLine: 32, NewSpan: 0}, // removed lines
+			},
+		},
+		{
+			FileHeader:  git.DiffFileHeader{OldName: "old.go", NewName: "new.go"},
+			HunkHeaders: []git.HunkHeader{{OldLine: 1, OldSpan: 1, NewLine: 1, NewSpan: 1}},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		annotation types.CheckAnnotation
+		want       bool
+	}{
+		{
+			name:       "inside hunk",
+			annotation: types.CheckAnnotation{Path: "a.go", LineStart: 12, LineEnd: 12},
+			want:       true,
+		},
+		{
+			name:       "overlaps hunk start",
+			annotation: types.CheckAnnotation{Path: "a.go", LineStart: 5, LineEnd: 11},
+			want:       true,
+		},
+		{
+			name:       "overlaps hunk end",
+			annotation: types.CheckAnnotation{Path: "a.go", LineStart: 13, LineEnd: 20},
+			want:       true,
+		},
+		{
+			name:       "before hunk",
+			annotation: types.CheckAnnotation{Path: "a.go", LineStart: 1, LineEnd: 10},
+			want:       false,
+		},
+		{
+			name:       "after hunk",
+			annotation: types.CheckAnnotation{Path: "a.go", LineStart: 14, LineEnd: 14},
+			want:       false,
+		},
+		{
+			name:       "at removed lines",
+			annotation: types.CheckAnnotation{Path: "a.go", LineStart: 32, LineEnd: 32},
+			want:       false,
+		},
+		{
+			name:       "renamed file",
+			annotation: types.CheckAnnotation{Path: "new.go", LineStart: 1, LineEnd: 1},
+			want:       true,
+		},
+		{
+			name:       "file not in diff",
+			annotation: types.CheckAnnotation{Path: "b.go", LineStart: 12, LineEnd: 12},
+			want:       false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			annotation := test.annotation
+			result := mapPullReqCheckAnnotations([]*types.CheckAnnotation{&annotation}, files)
+			if len(result) != 1 {
+				t.Fatalf("expected one annotation, got %d", len(result))
+			}
+
+			if result[0].Changed != test.want {
+				t.Errorf("expected changed=%t, got %t", test.want, result[0].Changed)
+			}
+		})
+	}
+}
@@ -17,15 +17,20 @@ package pullreq
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sort"
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
+// maxPullReqFailedTests is the maximum number of failed tests returned per pipeline status check.
+const maxPullReqFailedTests = 20
+
 // ListChecks return an array of status check results for a commit in a repository.
 func (c *Controller) ListChecks(
 	ctx context.Context,
@@ -82,10 +87,16 @@ func (c *Controller) ListChecks(
 			delete(reqChecks.BypassableIdentifiers, check.Identifier)
 		}
 
+		tests, err := c.checkTests(ctx, repo, check)
+		if err != nil {
+			return types.PullReqChecks{}, fmt.Errorf("failed to get test results of status check: %w", err)
+		}
+
 		result.Checks = append(result.Checks, types.PullReqCheck{
 			Required:   required || bypassable,
 			Bypassable: bypassable,
 			Check:      check,
+			Tests:      tests,
 		})
 	}
 
@@ -125,3 +136,56 @@ func (c *Controller) ListChecks(
 
 	return result, nil
 }
+
+// checkTests returns the test results of the pipeline execution that reported the status check.
+// It returns nil for status checks not reported by a pipeline and for executions that reported no tests.
+func (c *Controller) checkTests(
+	ctx context.Context,
+	repo *types.Repository,
+	check types.Check,
+) (*types.PullReqCheckTests, error) {
+	if check.Payload.Kind != enum.CheckPayloadKindPipeline {
+		return nil, nil //nolint:nilnil
+	}
+
+	payload := types.CheckPayloadInternal{}
+	if err := json.Unmarshal(check.Payload.Data, &payload); err != nil || payload.RepoID != repo.ID {
+		return nil, nil //nolint:nilnil
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, payload.PipelineID, payload.Number)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, nil //nolint:nilnil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution: %w", err)
+	}
+
+	summary, err := c.testCaseStore.Summary(ctx, execution.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test summary: %w", err)
+	}
+
+	if summary.Total == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	tests := &types.PullReqCheckTests{
+		Summary:     summary,
+		FailedTests: []*types.TestCase{},
+	}
+
+	if summary.Failed == 0 {
+		return tests, nil
+	}
+
+	tests.FailedTests, err = c.testCaseStore.List(ctx, execution.ID, types.TestCaseFilter{
+		Pagination: types.Pagination{Page: 1, Size: maxPullReqFailedTests},
+		Status:     enum.TestStatusFailed,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list failed tests: %w", err)
+	}
+
+	return tests, nil
+}
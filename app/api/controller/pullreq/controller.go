@@ -31,6 +31,7 @@ import (
 	locker "github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
@@ -64,11 +65,18 @@ type Controller struct {
 	fileViewStore          store.PullReqFileViewStore
 	membershipStore        store.MembershipStore
 	checkStore             store.CheckStore
+	checkAnnotationStore   store.CheckAnnotationStore
+	executionStore         store.ExecutionStore
+	testCaseStore          store.TestCaseStore
+	mergeQueueStore        store.MergeQueueStore
+	autoMergeStore         store.PullReqAutoMergeStore
+	dependencyStore        store.PullReqDependencyStore
 	git                    git.Interface
 	eventReporter          *pullreqevents.Reporter
 	codeCommentMigrator    *codecomments.Migrator
 	pullreqService         *pullreq.Service
 	pullreqListService     *pullreq.ListService
+	merger                 *pullreq.Merger
 	protectionManager      *protection.Manager
 	sseStreamer            sse.Streamer
 	codeOwners             *codeowners.Service
@@ -77,6 +85,8 @@ type Controller struct {
 	labelSvc               *label.Service
 	instrumentation        instrument.Service
 	userGroupService       usergroup.SearchService
+	signatureVerifier      *publickey.SignatureVerifier
+	dependencyService      *pullreq.DependencyService
 }
 
 func NewController(
@@ -97,11 +107,18 @@ func NewController(
 	fileViewStore store.PullReqFileViewStore,
 	membershipStore store.MembershipStore,
 	checkStore store.CheckStore,
+	checkAnnotationStore store.CheckAnnotationStore,
+	executionStore store.ExecutionStore,
+	testCaseStore store.TestCaseStore,
+	mergeQueueStore store.MergeQueueStore,
+	autoMergeStore store.PullReqAutoMergeStore,
+	dependencyStore store.PullReqDependencyStore,
 	git git.Interface,
 	eventReporter *pullreqevents.Reporter,
 	codeCommentMigrator *codecomments.Migrator,
 	pullreqService *pullreq.Service,
 	pullreqListService *pullreq.ListService,
+	merger *pullreq.Merger,
 	protectionManager *protection.Manager,
 	sseStreamer sse.Streamer,
 	codeowners *codeowners.Service,
@@ -110,6 +127,8 @@ func NewController(
 	labelSvc *label.Service,
 	instrumentation instrument.Service,
 	userGroupService usergroup.SearchService,
+	signatureVerifier *publickey.SignatureVerifier,
+	dependencyService *pullreq.DependencyService,
 ) *Controller {
 	return &Controller{
 		tx:                     tx,
@@ -129,11 +148,18 @@ func NewController(
 		fileViewStore:          fileViewStore,
 		membershipStore:        membershipStore,
 		checkStore:             checkStore,
+		checkAnnotationStore:   checkAnnotationStore,
+		executionStore:         executionStore,
+		testCaseStore:          testCaseStore,
+		mergeQueueStore:        mergeQueueStore,
+		autoMergeStore:         autoMergeStore,
+		dependencyStore:        dependencyStore,
 		git:                    git,
 		codeCommentMigrator:    codeCommentMigrator,
 		eventReporter:          eventReporter,
 		pullreqService:         pullreqService,
 		pullreqListService:     pullreqListService,
+		merger:                 merger,
 		protectionManager:      protectionManager,
 		sseStreamer:            sseStreamer,
 		codeOwners:             codeowners,
@@ -142,6 +168,8 @@ func NewController(
 		labelSvc:               labelSvc,
 		instrumentation:        instrumentation,
 		userGroupService:       userGroupService,
+		signatureVerifier:      signatureVerifier,
+		dependencyService:      dependencyService,
 	}
 }
 
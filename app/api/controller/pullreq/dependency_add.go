@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type DependencyAddInput struct {
+	// DependsOn is the number of the pull request the pull request depends on.
+	DependsOn int64 `json:"depends_on"`
+}
+
+// DependencyAdd declares that a pull request depends on another pull request of the same repository.
+// The pull request can't be merged until the pull request it depends on is merged.
+func (c *Controller) DependencyAdd(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *DependencyAddInput,
+) (*types.PullReqDependencies, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Dependencies can be added only to open pull requests")
+	}
+
+	if in.DependsOn == pr.Number {
+		return nil, usererror.BadRequest("A pull request can't depend on itself")
+	}
+
+	dependsOn, err := c.pullreqStore.FindByNumber(ctx, repo.ID, in.DependsOn)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.BadRequestf("Pull request #%d not found", in.DependsOn)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request dependency by number: %w", err)
+	}
+
+	if dependsOn.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequestf("Pull request #%d is not open", in.DependsOn)
+	}
+
+	isCyclic, err := c.dependencyService.IsDependency(ctx, dependsOn.ID, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for cyclic pull request dependencies: %w", err)
+	}
+
+	if isCyclic {
+		return nil, usererror.BadRequestf("Pull request #%d already depends on pull request #%d",
+			dependsOn.Number, pr.Number)
+	}
+
+	err = c.dependencyStore.Create(ctx, &types.PullReqDependency{
+		PullReqID:   pr.ID,
+		DependsOnID: dependsOn.ID,
+		CreatedBy:   session.Principal.ID,
+		Created:     time.Now().UnixMilli(),
+	})
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.Conflict(
+			fmt.Sprintf("Pull request already depends on pull request #%d", dependsOn.Number))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull request dependency: %w", err)
+	}
+
+	c.publishDependenciesUpdated(ctx, repo, pr)
+
+	dependencies, err := c.dependencyService.List(ctx, pr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request dependencies: %w", err)
+	}
+
+	return dependencies, nil
+}
+
+func (c *Controller) publishDependenciesUpdated(ctx context.Context, repo *types.Repository, pr *types.PullReq) {
+	if err := c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// DependencyDelete removes the declared dependency of a pull request on another pull request.
+func (c *Controller) DependencyDelete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	dependsOnNum int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	dependsOn, err := c.pullreqStore.FindByNumber(ctx, repo.ID, dependsOnNum)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request dependency by number: %w", err)
+	}
+
+	if err = c.dependencyStore.Delete(ctx, pr.ID, dependsOn.ID); err != nil {
+		return fmt.Errorf("failed to delete pull request dependency: %w", err)
+	}
+
+	c.publishDependenciesUpdated(ctx, repo, pr)
+
+	return nil
+}
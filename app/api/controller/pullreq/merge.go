@@ -24,11 +24,11 @@ import (
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
-	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/contextutil"
 	"github.com/harness/gitness/errors"
@@ -179,14 +179,14 @@ func (c *Controller) Merge(
 	sourceRepo := targetRepo
 	sourceWriteParams := targetWriteParams
 	if pr.SourceRepoID != pr.TargetRepoID {
-		sourceWriteParams, err = controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
+		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
 		}
 
-		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
+		sourceWriteParams, err = controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
+			return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
 		}
 	}
 
@@ -200,7 +200,7 @@ func (c *Controller) Merge(
 		return nil, nil, fmt.Errorf("failed to list status checks: %w", err)
 	}
 
-	codeOwnerWithApproval, err := c.codeOwners.Evaluate(ctx, sourceRepo, pr, reviewers)
+	codeOwnerWithApproval, err := c.codeOwners.Evaluate(ctx, targetRepo, pr, reviewers)
 	// check for error and ignore if it is codeowners file not found else throw error
 	if err != nil && !errors.Is(err, codeowners.ErrNotFound) {
 		return nil, nil, fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
@@ -218,6 +218,9 @@ func (c *Controller) Merge(
 		Method:             in.Method, // the method can be empty for dry run or dry run rules
 		CheckResults:       checkResults,
 		CodeOwners:         codeOwnerWithApproval,
+		FindUnverifiedCommits: func(ctx context.Context) ([]string, error) {
+			return c.signatureVerifier.FindUnverifiedPullReqCommits(ctx, c.git, targetRepo, pr)
+		},
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
@@ -234,6 +237,8 @@ func (c *Controller) Merge(
 			RequiresCodeOwnersApprovalLatest:    ruleOut.RequiresCodeOwnersApprovalLatest,
 			RequiresCommentResolution:           ruleOut.RequiresCommentResolution,
 			RequiresNoChangeRequests:            ruleOut.RequiresNoChangeRequests,
+			RequiresMergeQueue:                  ruleOut.RequiresMergeQueue,
+			RequiresChecklistCompletion:         ruleOut.RequiresChecklistCompletion,
 			MinimumRequiredApprovalsCount:       ruleOut.MinimumRequiredApprovalsCount,
 			MinimumRequiredApprovalsCountLatest: ruleOut.MinimumRequiredApprovalsCountLatest,
 		}, nil, nil
@@ -355,6 +360,8 @@ func (c *Controller) Merge(
 			RequiresCodeOwnersApprovalLatest:    ruleOut.RequiresCodeOwnersApprovalLatest,
 			RequiresCommentResolution:           ruleOut.RequiresCommentResolution,
 			RequiresNoChangeRequests:            ruleOut.RequiresNoChangeRequests,
+			RequiresMergeQueue:                  ruleOut.RequiresMergeQueue,
+			RequiresChecklistCompletion:         ruleOut.RequiresChecklistCompletion,
 			MinimumRequiredApprovalsCount:       ruleOut.MinimumRequiredApprovalsCount,
 			MinimumRequiredApprovalsCountLatest: ruleOut.MinimumRequiredApprovalsCountLatest,
 		}
@@ -362,6 +369,20 @@ func (c *Controller) Merge(
 		return out, nil, nil
 	}
 
+	blockedBy, err := c.dependencyService.CheckDependenciesMerged(ctx, pr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if blockedBy != "" {
+		log.Ctx(ctx).Info().Msg("aborting pull request merge because of open dependencies")
+
+		return nil, &types.MergeViolations{
+			RuleViolations: violations,
+			Message:        blockedBy,
+		}, nil
+	}
+
 	if protection.IsCritical(violations) {
 		sb := strings.Builder{}
 		for i, ruleViolation := range violations {
@@ -387,195 +408,42 @@ func (c *Controller) Merge(
 		}, nil
 	}
 
-	// commit details: author, committer and message
-
-	var author *git.Identity
-
-	switch in.Method {
-	case enum.MergeMethodMerge:
-		author = controller.IdentityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
-	case enum.MergeMethodSquash:
-		author = controller.IdentityFromPrincipalInfo(pr.Author)
-	case enum.MergeMethodRebase, enum.MergeMethodFastForward:
-		author = nil // Not important for these merge methods: the author info in the commits will be preserved.
-	}
-
-	var committer *git.Identity
-
-	switch in.Method {
-	case enum.MergeMethodMerge, enum.MergeMethodSquash:
-		committer = controller.SystemServicePrincipalInfo()
-	case enum.MergeMethodRebase:
-		committer = controller.IdentityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
-	case enum.MergeMethodFastForward:
-		committer = nil // Not important for fast-forward merge
-	}
-
-	// backfill commit title if none provided
-	if in.Title == "" {
-		switch in.Method {
-		case enum.MergeMethodMerge:
-			in.Title = fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
-		case enum.MergeMethodSquash:
-			in.Title = fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
-		case enum.MergeMethodRebase, enum.MergeMethodFastForward:
-			// Not used.
-		}
-	}
-
 	// create merge commit(s)
 
 	log.Ctx(ctx).Debug().Msgf("all pre-check passed, merge PR")
 
-	now := time.Now()
-	mergeOutput, err := c.git.Merge(ctx, &git.MergeParams{
-		WriteParams:     targetWriteParams,
-		BaseBranch:      pr.TargetBranch,
-		HeadRepoUID:     sourceRepo.GitUID,
-		HeadBranch:      pr.SourceBranch,
-		Message:         git.CommitMessage(in.Title, in.Message),
-		Committer:       committer,
-		CommitterDate:   &now,
-		Author:          author,
-		AuthorDate:      &now,
-		RefType:         gitenum.RefTypeBranch,
-		RefName:         pr.TargetBranch,
-		HeadExpectedSHA: sha.Must(in.SourceSHA),
-		Method:          gitenum.MergeMethod(in.Method),
+	mergeResult, err := c.merger.Merge(ctx, &pullreq.MergeParams{
+		TargetRepo:         targetRepo,
+		SourceRepo:         sourceRepo,
+		TargetWriteParams:  targetWriteParams,
+		SourceWriteParams:  sourceWriteParams,
+		PullReq:            pr,
+		MergedBy:           &session.Principal,
+		Method:             in.Method,
+		SourceSHA:          in.SourceSHA,
+		Title:              in.Title,
+		Message:            in.Message,
+		DeleteSourceBranch: ruleOut.DeleteSourceBranch,
+		RulesBypassed:      protection.IsBypassed(violations),
 	})
 	if err != nil {
-		return nil, nil, fmt.Errorf("merge execution failed: %w", err)
+		return nil, nil, err
 	}
-	//nolint:nestif
-	if mergeOutput.MergeSHA.String() == "" || len(mergeOutput.ConflictFiles) > 0 {
-		_, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
-			if pr.SourceSHA != mergeOutput.HeadSHA.String() {
-				return errors.New("source SHA has changed")
-			}
-
-			// update all Merge specific information
-			pr.MergeBaseSHA = mergeOutput.MergeBaseSHA.String()
-			pr.MergeTargetSHA = ptr.String(mergeOutput.BaseSHA.String())
-			pr.MergeSHA = nil
-			pr.UpdateMergeOutcome(in.Method, mergeOutput.ConflictFiles)
-			pr.Stats.DiffStats = types.NewDiffStats(
-				mergeOutput.CommitCount,
-				mergeOutput.ChangedFileCount,
-				mergeOutput.Additions,
-				mergeOutput.Deletions,
-			)
-			return nil
-		})
-		if err != nil {
-			// non-critical error
-			log.Ctx(ctx).Warn().Err(err).Msg("failed to update pull request with conflict files")
-		} else {
-			if err = c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
-				log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
-			}
-		}
 
+	if len(mergeResult.ConflictFiles) > 0 {
 		log.Ctx(ctx).Info().Msg("aborting pull request merge because of conflicts")
 
 		return nil, &types.MergeViolations{
-			ConflictFiles:  mergeOutput.ConflictFiles,
+			ConflictFiles:  mergeResult.ConflictFiles,
 			RuleViolations: violations,
 			// In case of conflicting files we prioritize those for the error message.
-			Message: fmt.Sprintf("Merge blocked by conflicting files: %v", mergeOutput.ConflictFiles),
+			Message: fmt.Sprintf("Merge blocked by conflicting files: %v", mergeResult.ConflictFiles),
 		}, nil
 	}
 
 	log.Ctx(ctx).Debug().Msgf("successfully merged PR")
 
-	mergedBy := session.Principal.ID
-
-	var activitySeqMerge, activitySeqBranchDeleted int64
-	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
-		pr.State = enum.PullReqStateMerged
-
-		nowMilli := now.UnixMilli()
-
-		pr.Merged = &nowMilli
-		pr.MergedBy = &mergedBy
-		pr.MergeMethod = &in.Method
-
-		// update all Merge specific information (might be empty if previous merge check failed)
-		// since this is the final operation on the PR, we update any sha that might've changed by now.
-		pr.SourceSHA = mergeOutput.HeadSHA.String()
-		pr.MergeTargetSHA = ptr.String(mergeOutput.BaseSHA.String())
-		pr.MergeBaseSHA = mergeOutput.MergeBaseSHA.String()
-		pr.MergeSHA = ptr.String(mergeOutput.MergeSHA.String())
-		pr.MarkAsMerged()
-		pr.Stats.DiffStats = types.NewDiffStats(
-			mergeOutput.CommitCount,
-			mergeOutput.ChangedFileCount,
-			mergeOutput.Additions,
-			mergeOutput.Deletions,
-		)
-
-		// update sequence for PR activities
-		pr.ActivitySeq++
-		activitySeqMerge = pr.ActivitySeq
-
-		if ruleOut.DeleteSourceBranch {
-			pr.ActivitySeq++
-			activitySeqBranchDeleted = pr.ActivitySeq
-		}
-
-		return nil
-	})
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to update pull request: %w", err)
-	}
-
-	pr.ActivitySeq = activitySeqMerge
-	activityPayload := &types.PullRequestActivityPayloadMerge{
-		MergeMethod:   in.Method,
-		MergeSHA:      mergeOutput.MergeSHA.String(),
-		TargetSHA:     mergeOutput.BaseSHA.String(),
-		SourceSHA:     mergeOutput.HeadSHA.String(),
-		RulesBypassed: protection.IsBypassed(violations),
-	}
-	if _, errAct := c.activityStore.CreateWithPayload(ctx, pr, mergedBy, activityPayload, nil); errAct != nil {
-		// non-critical error
-		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull req merge activity")
-	}
-
-	c.eventReporter.Merged(ctx, &pullreqevents.MergedPayload{
-		Base:        eventBase(pr, &session.Principal),
-		MergeMethod: in.Method,
-		MergeSHA:    mergeOutput.MergeSHA.String(),
-		TargetSHA:   mergeOutput.BaseSHA.String(),
-		SourceSHA:   mergeOutput.HeadSHA.String(),
-	})
-
-	var branchDeleted bool
-	if ruleOut.DeleteSourceBranch {
-		errDelete := c.git.DeleteBranch(ctx, &git.DeleteBranchParams{
-			WriteParams: sourceWriteParams,
-			BranchName:  pr.SourceBranch,
-		})
-		if errDelete != nil {
-			// non-critical error
-			log.Ctx(ctx).Err(errDelete).Msgf("failed to delete source branch after merging")
-		} else {
-			branchDeleted = true
-
-			// NOTE: there is a chance someone pushed on the branch between merge and delete.
-			// Either way, we'll use the SHA that was merged with for the activity to be consistent from PR perspective.
-			pr.ActivitySeq = activitySeqBranchDeleted
-			if _, errAct := c.activityStore.CreateWithPayload(ctx, pr, mergedBy,
-				&types.PullRequestActivityPayloadBranchDelete{SHA: in.SourceSHA}, nil); errAct != nil {
-				// non-critical error
-				log.Ctx(ctx).Err(errAct).
-					Msgf("failed to write pull request activity for successful automatic branch delete")
-			}
-		}
-	}
-
-	if err = c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
-		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
-	}
+	pr = mergeResult.PullReq
 
 	if protection.IsBypassed(violations) {
 		err = c.auditService.Log(ctx,
@@ -626,8 +494,8 @@ func (c *Controller) Merge(
 		log.Ctx(ctx).Warn().Msgf("failed to insert instrumentation record for merge pr operation: %s", err)
 	}
 	return &types.MergeResponse{
-		SHA:            mergeOutput.MergeSHA.String(),
-		BranchDeleted:  branchDeleted,
+		SHA:            mergeResult.MergeSHA,
+		BranchDeleted:  mergeResult.BranchDeleted,
 		RuleViolations: violations,
 	}, nil, nil
 }
@@ -0,0 +1,229 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type MergeQueueAddInput struct {
+	Method    enum.MergeMethod `json:"method"`
+	SourceSHA string           `json:"source_sha"`
+	Title     string           `json:"title"`
+	Message   string           `json:"message"`
+
+	BypassRules bool `json:"bypass_rules"`
+}
+
+func (in *MergeQueueAddInput) sanitize() error {
+	if in.SourceSHA == "" {
+		return usererror.BadRequest("source SHA must be provided")
+	}
+
+	method, ok := in.Method.Sanitize()
+	if !ok || method == "" {
+		return usererror.BadRequestf("unsupported merge method: %s", in.Method)
+	}
+
+	in.Method = method
+
+	// The branch is fast-forwarded to the speculative merge commit, fast-forwarding the pull request is meaningless.
+	if in.Method == enum.MergeMethodFastForward {
+		return usererror.BadRequest("merge queue doesn't support the fast-forward merge method")
+	}
+
+	// cleanup title / message (NOTE: git doesn't support white space only)
+	in.Title = strings.TrimSpace(in.Title)
+	in.Message = strings.TrimSpace(in.Message)
+
+	if in.Method == enum.MergeMethodRebase && (in.Title != "" || in.Message != "") {
+		return usererror.BadRequestf(
+			"merge method %q doesn't support customizing commit title and message", in.Method)
+	}
+
+	return nil
+}
+
+// MergeQueueAdd adds a pull request to the merge queue of its target branch.
+//
+// The pull request must satisfy all protection rules, except the status checks which are
+// evaluated on the speculative merge commit created by the merge queue.
+func (c *Controller) MergeQueueAdd(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *MergeQueueAddInput,
+) (*types.MergeQueueEntry, *types.MergeViolations, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, nil, err
+	}
+
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, nil, usererror.BadRequest("Pull request must be open")
+	}
+
+	if pr.SourceSHA != in.SourceSHA {
+		return nil, nil,
+			usererror.BadRequest("A newer commit is available. Only the latest commit can be merged.")
+	}
+
+	if pr.IsDraft {
+		return nil, nil, usererror.BadRequest(
+			"Draft pull requests can't be merged. Clear the draft flag first.",
+		)
+	}
+
+	existing, err := c.mergeQueueStore.FindByPullReqID(ctx, pr.ID)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, nil, fmt.Errorf("failed to find merge queue entry of the pull request: %w", err)
+	}
+	if existing != nil && existing.State != enum.MergeQueueEntryStateFailed {
+		return nil, nil, usererror.Conflict("Pull request is already in the merge queue.")
+	}
+
+	sourceRepo := targetRepo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
+		}
+	}
+
+	reviewers, err := c.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load list of reviwers: %w", err)
+	}
+
+	protectionRules, isRepoOwner, err := c.fetchRules(ctx, session, targetRepo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch rules: %w", err)
+	}
+
+	codeOwnerWithApproval, err := c.codeOwners.Evaluate(ctx, targetRepo, pr, reviewers)
+	// check for error and ignore if it is codeowners file not found else throw error
+	if err != nil && !errors.Is(err, codeowners.ErrNotFound) {
+		return nil, nil, fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
+	}
+
+	_, violations, err := protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
+		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              &session.Principal,
+		AllowBypass:        in.BypassRules,
+		IsRepoOwner:        isRepoOwner,
+		TargetRepo:         targetRepo,
+		SourceRepo:         sourceRepo,
+		PullReq:            pr,
+		Reviewers:          reviewers,
+		Method:             in.Method,
+		CodeOwners:         codeOwnerWithApproval,
+		MergeQueue:         true,
+		FindUnverifiedCommits: func(ctx context.Context) ([]string, error) {
+			return c.signatureVerifier.FindUnverifiedPullReqCommits(ctx, c.git, targetRepo, pr)
+		},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if protection.IsCritical(violations) {
+		return nil, &types.MergeViolations{
+			RuleViolations: violations,
+			Message:        protection.GenerateErrorMessageForBlockingViolations(violations),
+		}, nil
+	}
+
+	blockedBy, err := c.dependencyService.CheckDependenciesMerged(ctx, pr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if blockedBy != "" {
+		return nil, &types.MergeViolations{
+			RuleViolations: violations,
+			Message:        blockedBy,
+		}, nil
+	}
+
+	// backfill commit title if none provided
+	if in.Title == "" {
+		switch in.Method {
+		case enum.MergeMethodMerge:
+			in.Title = fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
+		case enum.MergeMethodSquash:
+			in.Title = fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
+		case enum.MergeMethodRebase, enum.MergeMethodFastForward:
+			// Not used.
+		}
+	}
+
+	now := time.Now().UnixMilli()
+	entry := &types.MergeQueueEntry{
+		RepoID:        targetRepo.ID,
+		Branch:        pr.TargetBranch,
+		PullReqID:     pr.ID,
+		PullReqNumber: pr.Number,
+		State:         enum.MergeQueueEntryStateQueued,
+		Method:        in.Method,
+		Title:         in.Title,
+		Message:       in.Message,
+		BypassRules:   in.BypassRules,
+		HeadSHA:       pr.SourceSHA,
+		CreatedBy:     session.Principal.ID,
+		Created:       now,
+		Updated:       now,
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		// a failed entry is replaced with a new one, which puts the pull request at the end of the queue.
+		if existing != nil {
+			if err := c.mergeQueueStore.Delete(ctx, existing.ID); err != nil {
+				return fmt.Errorf("failed to delete failed merge queue entry: %w", err)
+			}
+		}
+
+		if err := c.mergeQueueStore.Create(ctx, entry); err != nil {
+			return fmt.Errorf("failed to create merge queue entry: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return entry, nil, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types/enum"
+)
+
+// MergeQueueRemove removes a pull request from the merge queue.
+// The speculative merge commits of the pull requests queued after it are recreated by the merge queue.
+func (c *Controller) MergeQueueRemove(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	entry, err := c.mergeQueueStore.FindByPullReqID(ctx, pr.ID)
+	if errors.IsNotFound(err) {
+		return usererror.NotFound("Pull request is not in the merge queue.")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find merge queue entry of the pull request: %w", err)
+	}
+
+	if err = c.mergeQueueStore.Delete(ctx, entry.ID); err != nil {
+		return fmt.Errorf("failed to delete merge queue entry: %w", err)
+	}
+
+	return nil
+}
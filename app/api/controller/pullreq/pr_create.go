@@ -20,6 +20,8 @@ import (
 	"strings"
 	"time"
 
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
@@ -41,11 +43,16 @@ type CreateInput struct {
 	SourceRepoRef string `json:"source_repo_ref"`
 	SourceBranch  string `json:"source_branch"`
 	TargetBranch  string `json:"target_branch"`
+
+	// Template is the name of the pull request template used to prefill the description if it's empty.
+	// If not provided, the default template of the target branch is used, if any.
+	Template string `json:"template"`
 }
 
 func (in *CreateInput) Sanitize() error {
 	in.Title = strings.TrimSpace(in.Title)
 	in.Description = strings.TrimSpace(in.Description)
+	in.Template = strings.TrimSpace(in.Template)
 
 	if err := validateTitle(in.Title); err != nil {
 		return err
@@ -69,7 +76,7 @@ func (c *Controller) Create(
 		return nil, err
 	}
 
-	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
 	if err != nil {
 		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
 	}
@@ -82,6 +89,15 @@ func (c *Controller) Create(
 		}
 	}
 
+	if sourceRepo.ID == targetRepo.ID {
+		// pull requests within a repository require push access, cross-repo ones are opened from forks.
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, targetRepo, enum.PermissionRepoPush); err != nil {
+			return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+		}
+	} else if sourceRepo.ForkID != targetRepo.ID {
+		return nil, usererror.BadRequest("The source repository has to be a fork of the target repository")
+	}
+
 	if sourceRepo.ID == targetRepo.ID && in.TargetBranch == in.SourceBranch {
 		return nil, usererror.BadRequest("target and source branch can't be the same")
 	}
@@ -100,9 +116,20 @@ func (c *Controller) Create(
 		return nil, err
 	}
 
+	if err = c.applyTemplate(ctx, targetRepo, in); err != nil {
+		return nil, err
+	}
+
+	if sourceRepo.ID != targetRepo.ID {
+		// the source commit has to be available in the target repository, as that's where the PR lives.
+		if err = c.fetchSourceCommit(ctx, session, sourceRepo, targetRepo, sourceSHA); err != nil {
+			return nil, err
+		}
+	}
+
 	mergeBaseResult, err := c.git.MergeBase(ctx, git.MergeBaseParams{
-		ReadParams: git.ReadParams{RepoUID: sourceRepo.GitUID},
-		Ref1:       in.SourceBranch,
+		ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
+		Ref1:       sourceSHA.String(),
 		Ref2:       in.TargetBranch,
 	})
 	if err != nil {
@@ -172,6 +199,59 @@ func (c *Controller) Create(
 	return pr, nil
 }
 
+// fetchSourceCommit makes the commit of the source repository (fork) available in the target repository.
+func (c *Controller) fetchSourceCommit(
+	ctx context.Context,
+	session *auth.Session,
+	sourceRepo *types.Repository,
+	targetRepo *types.Repository,
+	commitSHA sha.SHA,
+) error {
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, targetRepo)
+	if err != nil {
+		return fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	err = c.git.FetchObjects(ctx, &git.FetchObjectsParams{
+		WriteParams:   writeParams,
+		SourceRepoUID: sourceRepo.GitUID,
+		ObjectSHAs:    []sha.SHA{commitSHA},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch source commit from the source repository: %w", err)
+	}
+
+	return nil
+}
+
+// applyTemplate prefills an empty pull request description from a template file in the target branch.
+func (c *Controller) applyTemplate(ctx context.Context, targetRepo *types.Repository, in *CreateInput) error {
+	if in.Description != "" {
+		return nil
+	}
+
+	template, err := c.findTemplate(ctx, targetRepo, in.TargetBranch, in.Template)
+	if err != nil && in.Template != "" {
+		return fmt.Errorf("failed to find pull request template: %w", err)
+	}
+	if err != nil {
+		// the default template is optional, failure to read it shouldn't block pull request creation.
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find default pull request template")
+		return nil
+	}
+
+	if template == nil && in.Template != "" {
+		return usererror.BadRequestf("Pull request template %q not found", in.Template)
+	}
+	if template == nil {
+		return nil
+	}
+
+	in.Description = strings.TrimSpace(template.Content)
+
+	return validateDescription(in.Description)
+}
+
 // newPullReq creates new pull request object.
 func newPullReq(
 	session *auth.Session,
@@ -121,9 +121,15 @@ func (c *Controller) State(ctx context.Context,
 			return nil, err
 		}
 
+		if sourceRepo.ID != targetRepo.ID {
+			if err = c.fetchSourceCommit(ctx, session, sourceRepo, targetRepo, sourceSHA); err != nil {
+				return nil, err
+			}
+		}
+
 		mergeBaseResult, err := c.git.MergeBase(ctx, git.MergeBaseParams{
-			ReadParams: git.ReadParams{RepoUID: sourceRepo.GitUID},
-			Ref1:       pr.SourceBranch,
+			ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
+			Ref1:       sourceSHA.String(),
 			Ref2:       pr.TargetBranch,
 		})
 		if err != nil {
@@ -189,6 +195,12 @@ func (c *Controller) State(ctx context.Context,
 		})
 	}
 
+	if oldDraft && !pr.IsDraft && pr.State == enum.PullReqStateOpen {
+		c.eventReporter.ReadyForReview(ctx, &pullreqevents.ReadyForReviewPayload{
+			Base: eventBase(pr, &session.Principal),
+		})
+	}
+
 	if err = c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
 		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
 	}
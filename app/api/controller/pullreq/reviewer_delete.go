@@ -21,6 +21,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -74,6 +75,11 @@ func (c *Controller) ReviewerDelete(
 		return fmt.Errorf("failed to delete reviewer: %w", err)
 	}
 
+	c.eventReporter.ReviewerDeleted(ctx, &pullreqevents.ReviewerDeletedPayload{
+		Base:       eventBase(pr, &session.Principal),
+		ReviewerID: reviewerID,
+	})
+
 	err = func() error {
 		payload := &types.PullRequestActivityPayloadReviewerDelete{
 			CommitSHA:   reviewer.SHA,
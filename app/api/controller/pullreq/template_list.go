@@ -0,0 +1,219 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gittypes "github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// pullReqTemplateName is the name of the pull request template file (with the .md extension)
+	// or of the directory that contains multiple named templates.
+	pullReqTemplateName = "PULL_REQUEST_TEMPLATE"
+
+	// pullReqTemplateDefault is the name of the template stored in the PULL_REQUEST_TEMPLATE.md file.
+	pullReqTemplateDefault = "default"
+
+	pullReqTemplateExt = ".md"
+
+	// maxPullReqTemplateSize is the max size of a template, same as the max length of a pull request description.
+	maxPullReqTemplateSize = 64 << 10 // 64K
+)
+
+// pullReqTemplateDirs are the directories searched for pull request templates, in the order of priority.
+// Only the templates of the first directory that contains any are used.
+var pullReqTemplateDirs = []string{".harness", ""}
+
+// TemplateList lists the pull request templates available in the target branch.
+func (c *Controller) TemplateList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	branch string,
+) ([]types.PullReqTemplate, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if branch == "" {
+		branch = repo.DefaultBranch
+	}
+
+	templates, err := c.listTemplates(ctx, repo, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// findTemplate returns the pull request template with the provided name from the target branch.
+// If the name is empty it returns the default template, or the only template if there's just one.
+// It returns nil if there's no such template.
+func (c *Controller) findTemplate(
+	ctx context.Context,
+	repo *types.Repository,
+	branch string,
+	name string,
+) (*types.PullReqTemplate, error) {
+	templates, err := c.listTemplates(ctx, repo, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" && len(templates) == 1 {
+		return &templates[0], nil
+	}
+
+	if name == "" {
+		name = pullReqTemplateDefault
+	}
+
+	for i := range templates {
+		if strings.EqualFold(templates[i].Name, name) {
+			return &templates[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (c *Controller) listTemplates(
+	ctx context.Context,
+	repo *types.Repository,
+	branch string,
+) ([]types.PullReqTemplate, error) {
+	readParams := git.CreateReadParams(repo)
+	ref := gittypes.BranchPrefix + branch
+
+	for _, dir := range pullReqTemplateDirs {
+		var templates []types.PullReqTemplate
+
+		filePath := path.Join(dir, pullReqTemplateName+pullReqTemplateExt)
+		template, err := c.readTemplate(ctx, readParams, ref, pullReqTemplateDefault, filePath)
+		if err != nil {
+			return nil, err
+		}
+		if template != nil {
+			templates = append(templates, *template)
+		}
+
+		dirPath := path.Join(dir, pullReqTemplateName)
+		nodes, err := c.git.ListTreeNodes(ctx, &git.ListTreeNodeParams{
+			ReadParams: readParams,
+			GitREF:     ref,
+			Path:       dirPath,
+		})
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to list pull request templates in %q: %w", dirPath, err)
+		}
+
+		var dirNodes []git.TreeNode
+		if nodes != nil {
+			dirNodes = nodes.Nodes
+		}
+
+		for _, node := range dirNodes {
+			if node.Type != git.TreeNodeTypeBlob || !strings.EqualFold(path.Ext(node.Name), pullReqTemplateExt) {
+				continue
+			}
+
+			name := strings.TrimSuffix(node.Name, path.Ext(node.Name))
+			template, err = c.readTemplate(ctx, readParams, ref, name, node.Path)
+			if err != nil {
+				return nil, err
+			}
+			if template != nil {
+				templates = append(templates, *template)
+			}
+		}
+
+		if len(templates) > 0 {
+			return templates, nil
+		}
+	}
+
+	return []types.PullReqTemplate{}, nil
+}
+
+// readTemplate reads the template file. It returns nil if the file doesn't exist or if it's too large.
+func (c *Controller) readTemplate(
+	ctx context.Context,
+	readParams git.ReadParams,
+	ref string,
+	name string,
+	filePath string,
+) (*types.PullReqTemplate, error) {
+	node, err := c.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     ref,
+		Path:       filePath,
+	})
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request template %q: %w", filePath, err)
+	}
+
+	if node.Node.Type != git.TreeNodeTypeBlob {
+		return nil, nil
+	}
+
+	blob, err := c.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        node.Node.SHA,
+		SizeLimit:  maxPullReqTemplateSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request template %q content: %w", filePath, err)
+	}
+
+	defer func() {
+		if err := blob.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to close blob content reader")
+		}
+	}()
+
+	if blob.Size > maxPullReqTemplateSize {
+		log.Ctx(ctx).Warn().Msgf("pull request template %q is too large, skipping it", filePath)
+		return nil, nil
+	}
+
+	content, err := io.ReadAll(blob.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pull request template %q content: %w", filePath, err)
+	}
+
+	return &types.PullReqTemplate{
+		Name:    name,
+		Path:    filePath,
+		Content: string(content),
+	}, nil
+}
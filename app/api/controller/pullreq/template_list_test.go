@@ -0,0 +1,189 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"io"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+)
+
+// fakeTemplateGit serves the files of a branch. The path of a file is used as the SHA of its blob.
+type fakeTemplateGit struct {
+	git.Interface
+	files map[string]string
+}
+
+func (g *fakeTemplateGit) GetTreeNode(
+	_ context.Context,
+	params *git.GetTreeNodeParams,
+) (*git.GetTreeNodeOutput, error) {
+	if _, ok := g.files[params.Path]; ok {
+		return &git.GetTreeNodeOutput{Node: git.TreeNode{
+			Type: git.TreeNodeTypeBlob,
+			SHA:  params.Path,
+			Name: path.Base(params.Path),
+			Path: params.Path,
+		}}, nil
+	}
+
+	for filePath := range g.files {
+		if strings.HasPrefix(filePath, params.Path+"/") {
+			return &git.GetTreeNodeOutput{Node: git.TreeNode{
+				Type: git.TreeNodeTypeTree,
+				Name: path.Base(params.Path),
+				Path: params.Path,
+			}}, nil
+		}
+	}
+
+	return nil, errors.NotFound("path %q not found", params.Path)
+}
+
+func (g *fakeTemplateGit) ListTreeNodes(
+	_ context.Context,
+	params *git.ListTreeNodeParams,
+) (*git.ListTreeNodeOutput, error) {
+	var filePaths []string
+	for filePath := range g.files {
+		if path.Dir(filePath) == params.Path {
+			filePaths = append(filePaths, filePath)
+		}
+	}
+
+	if len(filePaths) == 0 {
+		return nil, errors.NotFound("path %q not found", params.Path)
+	}
+
+	sort.Strings(filePaths)
+
+	nodes := make([]git.TreeNode, len(filePaths))
+	for i, filePath := range filePaths {
+		nodes[i] = git.TreeNode{
+			Type: git.TreeNodeTypeBlob,
+			SHA:  filePath,
+			Name: path.Base(filePath),
+			Path: filePath,
+		}
+	}
+
+	return &git.ListTreeNodeOutput{Nodes: nodes}, nil
+}
+
+func (g *fakeTemplateGit) GetBlob(_ context.Context, params *git.GetBlobParams) (*git.GetBlobOutput, error) {
+	content := g.files[params.SHA]
+	return &git.GetBlobOutput{
+		Size:        int64(len(content)),
+		ContentSize: int64(len(content)),
+		Content:     io.NopCloser(strings.NewReader(content)),
+	}, nil
+}
+
+func TestController_ListTemplates(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []types.PullReqTemplate
+	}{
+		{
+			name:  "no templates",
+			files: map[string]string{"README.md": "readme"},
+			want:  []types.PullReqTemplate{},
+		},
+		{
+			name:  "root file",
+			files: map[string]string{"PULL_REQUEST_TEMPLATE.md": "root"},
+			want: []types.PullReqTemplate{
+				{Name: "default", Path: "PULL_REQUEST_TEMPLATE.md", Content: "root"},
+			},
+		},
+		{
+			name: "harness file over root file",
+			files: map[string]string{
+				"PULL_REQUEST_TEMPLATE.md":          "root",
+				".harness/PULL_REQUEST_TEMPLATE.md": "harness",
+			},
+			want: []types.PullReqTemplate{
+				{Name: "default", Path: ".harness/PULL_REQUEST_TEMPLATE.md", Content: "harness"},
+			},
+		},
+		{
+			name: "harness directory over root file",
+			files: map[string]string{
+				"PULL_REQUEST_TEMPLATE.md":              "root",
+				".harness/PULL_REQUEST_TEMPLATE/bug.md": "bug",
+			},
+			want: []types.PullReqTemplate{
+				{Name: "bug", Path: ".harness/PULL_REQUEST_TEMPLATE/bug.md", Content: "bug"},
+			},
+		},
+		{
+			name: "root directory",
+			files: map[string]string{
+				"PULL_REQUEST_TEMPLATE/feature.md": "feature",
+				"PULL_REQUEST_TEMPLATE/bug.MD":     "bug",
+				"PULL_REQUEST_TEMPLATE/notes.txt":  "notes",
+			},
+			want: []types.PullReqTemplate{
+				{Name: "bug", Path: "PULL_REQUEST_TEMPLATE/bug.MD", Content: "bug"},
+				{Name: "feature", Path: "PULL_REQUEST_TEMPLATE/feature.md", Content: "feature"},
+			},
+		},
+		{
+			name: "single file along with directory",
+			files: map[string]string{
+				"PULL_REQUEST_TEMPLATE.md":     "default",
+				"PULL_REQUEST_TEMPLATE/bug.md": "bug",
+			},
+			want: []types.PullReqTemplate{
+				{Name: "default", Path: "PULL_REQUEST_TEMPLATE.md", Content: "default"},
+				{Name: "bug", Path: "PULL_REQUEST_TEMPLATE/bug.md", Content: "bug"},
+			},
+		},
+		{
+			name: "too large template",
+			files: map[string]string{
+				"PULL_REQUEST_TEMPLATE.md":     strings.Repeat("a", maxPullReqTemplateSize+1),
+				"PULL_REQUEST_TEMPLATE/bug.md": "bug",
+			},
+			want: []types.PullReqTemplate{
+				{Name: "bug", Path: "PULL_REQUEST_TEMPLATE/bug.md", Content: "bug"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &Controller{git: &fakeTemplateGit{files: test.files}}
+
+			got, err := c.listTemplates(context.Background(), &types.Repository{GitUID: "repo"}, "main")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected templates %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
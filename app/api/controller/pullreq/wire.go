@@ -24,6 +24,7 @@ import (
 	"github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
@@ -57,13 +58,21 @@ func ProvideController(
 	fileViewStore store.PullReqFileViewStore,
 	membershipStore store.MembershipStore,
 	checkStore store.CheckStore,
+	checkAnnotationStore store.CheckAnnotationStore,
+	executionStore store.ExecutionStore,
+	testCaseStore store.TestCaseStore,
+	mergeQueueStore store.MergeQueueStore,
+	autoMergeStore store.PullReqAutoMergeStore,
+	dependencyStore store.PullReqDependencyStore,
 	rpcClient git.Interface, eventReporter *pullreqevents.Reporter, codeCommentMigrator *codecomments.Migrator,
-	pullreqService *pullreq.Service, pullreqListService *pullreq.ListService,
+	pullreqService *pullreq.Service, pullreqListService *pullreq.ListService, merger *pullreq.Merger,
 	ruleManager *protection.Manager, sseStreamer sse.Streamer,
 	codeOwners *codeowners.Service, locker *locker.Locker, importer *migrate.PullReq,
 	labelSvc *label.Service,
 	instrumentation instrument.Service,
 	userGroupService usergroup.SearchService,
+	signatureVerifier *publickey.SignatureVerifier,
+	dependencyService *pullreq.DependencyService,
 ) *Controller {
 	return NewController(tx,
 		urlProvider,
@@ -82,11 +91,18 @@ func ProvideController(
 		fileViewStore,
 		membershipStore,
 		checkStore,
+		checkAnnotationStore,
+		executionStore,
+		testCaseStore,
+		mergeQueueStore,
+		autoMergeStore,
+		dependencyStore,
 		rpcClient,
 		eventReporter,
 		codeCommentMigrator,
 		pullreqService,
 		pullreqListService,
+		merger,
 		ruleManager,
 		sseStreamer,
 		codeOwners,
@@ -95,5 +111,7 @@ func ProvideController(
 		labelSvc,
 		instrumentation,
 		userGroupService,
+		signatureVerifier,
+		dependencyService,
 	)
 }
@@ -15,10 +15,12 @@
 package repo
 
 import (
+	"bytes"
 	"cmp"
 	"context"
 	"encoding/base64"
 	"fmt"
+	"path"
 	"strings"
 	"time"
 
@@ -102,6 +104,33 @@ func (c *Controller) CommitFiles(ctx context.Context,
 		branchName = in.Branch
 	}
 
+	actions := make([]git.CommitFileAction, len(in.Actions))
+	for i, action := range in.Actions {
+		var rawPayload []byte
+		switch action.Encoding {
+		case enum.ContentEncodingTypeBase64:
+			rawPayload, err = base64.StdEncoding.DecodeString(action.Payload)
+			if err != nil {
+				return types.CommitFilesResponse{}, nil, errors.Internal(err, "failed to decode base64 payload")
+			}
+		case enum.ContentEncodingTypeUTF8:
+			fallthrough
+		default:
+			// by default we treat content as is
+			rawPayload = []byte(action.Payload)
+		}
+
+		actions[i] = git.CommitFileAction{
+			Action:  action.Action,
+			Path:    action.Path,
+			Payload: rawPayload,
+			SHA:     action.SHA,
+		}
+	}
+
+	message := git.CommitMessage(in.Title, in.Message)
+	author := cmp.Or(in.Author, identityFromPrincipal(session.Principal))
+
 	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
 		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
 		Actor:              &session.Principal,
@@ -111,6 +140,10 @@ func (c *Controller) CommitFiles(ctx context.Context,
 		RefAction:          refAction,
 		RefType:            protection.RefTypeBranch,
 		RefNames:           []string{branchName},
+		FindPushChanges: func(context.Context, protection.RefType, string) (protection.PushChanges, error) {
+			// the commit is committed by the system on behalf of the actor.
+			return commitChanges(message, author.Email, session.Principal.Email, actions), nil
+		},
 	})
 	if err != nil {
 		return types.CommitFilesResponse{}, nil, fmt.Errorf("failed to verify protection rules: %w", err)
@@ -129,30 +162,6 @@ func (c *Controller) CommitFiles(ctx context.Context,
 		return types.CommitFilesResponse{}, violations, nil
 	}
 
-	actions := make([]git.CommitFileAction, len(in.Actions))
-	for i, action := range in.Actions {
-		var rawPayload []byte
-		switch action.Encoding {
-		case enum.ContentEncodingTypeBase64:
-			rawPayload, err = base64.StdEncoding.DecodeString(action.Payload)
-			if err != nil {
-				return types.CommitFilesResponse{}, nil, errors.Internal(err, "failed to decode base64 payload")
-			}
-		case enum.ContentEncodingTypeUTF8:
-			fallthrough
-		default:
-			// by default we treat content as is
-			rawPayload = []byte(action.Payload)
-		}
-
-		actions[i] = git.CommitFileAction{
-			Action:  action.Action,
-			Path:    action.Path,
-			Payload: rawPayload,
-			SHA:     action.SHA,
-		}
-	}
-
 	// Create internal write params. Note: This will skip the pre-commit protection rules check.
 	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
 	if err != nil {
@@ -162,13 +171,13 @@ func (c *Controller) CommitFiles(ctx context.Context,
 	now := time.Now()
 	commit, err := c.git.CommitFiles(ctx, &git.CommitFilesParams{
 		WriteParams:   writeParams,
-		Message:       git.CommitMessage(in.Title, in.Message),
+		Message:       message,
 		Branch:        in.Branch,
 		NewBranch:     in.NewBranch,
 		Actions:       actions,
 		Committer:     identityFromPrincipal(bootstrap.NewSystemServiceSession().Principal),
 		CommitterDate: &now,
-		Author:        cmp.Or(in.Author, identityFromPrincipal(session.Principal)),
+		Author:        author,
 		AuthorDate:    &now,
 	})
 	if err != nil {
@@ -216,3 +225,37 @@ func (c *Controller) CommitFiles(ctx context.Context,
 		},
 	}, nil, nil
 }
+
+// commitChanges returns the changes the commit of the file actions introduces to the branch.
+// It's used to verify push protection rules before the commit is created.
+func commitChanges(
+	message string,
+	authorEmail string,
+	committerEmail string,
+	actions []git.CommitFileAction,
+) protection.PushChanges {
+	changedPaths := make([]string, 0, len(actions))
+	for _, action := range actions {
+		changedPaths = append(changedPaths, cleanPath(action.Path))
+
+		// the payload of a move action starts with the new path, optionally followed by the new content.
+		if action.Action == git.MoveAction {
+			newPath, _, _ := bytes.Cut(action.Payload, []byte{0})
+			changedPaths = append(changedPaths, cleanPath(string(newPath)))
+		}
+	}
+
+	return protection.PushChanges{
+		Commits: []protection.PushCommit{{
+			Message:        message,
+			AuthorEmail:    authorEmail,
+			CommitterEmail: committerEmail,
+		}},
+		ChangedPaths: changedPaths,
+	}
+}
+
+// cleanPath returns the path of the file relative to the repository root, the way it's committed.
+func cleanPath(filePath string) string {
+	return strings.Trim(path.Clean("/"+filePath), "/")
+}
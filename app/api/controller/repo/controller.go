@@ -35,6 +35,7 @@ import (
 	"github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/rules"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/services/usergroup"
@@ -84,6 +85,7 @@ type Controller struct {
 	ruleStore          store.RuleStore
 	checkStore         store.CheckStore
 	pullReqStore       store.PullReqStore
+	lfsObjectStore     store.LFSObjectStore
 	settings           *settings.Service
 	principalInfoCache store.PrincipalInfoCache
 	userGroupStore     store.UserGroupStore
@@ -104,6 +106,7 @@ type Controller struct {
 	labelSvc           *label.Service
 	instrumentation    instrument.Service
 	rulesSvc           *rules.Service
+	signatureVerifier  *publickey.SignatureVerifier
 }
 
 func NewController(
@@ -119,6 +122,7 @@ func NewController(
 	ruleStore store.RuleStore,
 	checkStore store.CheckStore,
 	pullReqStore store.PullReqStore,
+	lfsObjectStore store.LFSObjectStore,
 	settings *settings.Service,
 	principalInfoCache store.PrincipalInfoCache,
 	protectionManager *protection.Manager,
@@ -139,6 +143,7 @@ func NewController(
 	userGroupStore store.UserGroupStore,
 	userGroupService usergroup.SearchService,
 	rulesSvc *rules.Service,
+	signatureVerifier *publickey.SignatureVerifier,
 ) *Controller {
 	return &Controller{
 		defaultBranch:      config.Git.DefaultBranch,
@@ -153,6 +158,7 @@ func NewController(
 		ruleStore:          ruleStore,
 		checkStore:         checkStore,
 		pullReqStore:       pullReqStore,
+		lfsObjectStore:     lfsObjectStore,
 		settings:           settings,
 		principalInfoCache: principalInfoCache,
 		protectionManager:  protectionManager,
@@ -173,6 +179,7 @@ func NewController(
 		userGroupStore:     userGroupStore,
 		userGroupService:   userGroupService,
 		rulesSvc:           rulesSvc,
+		signatureVerifier:  signatureVerifier,
 	}
 }
 
@@ -26,13 +26,14 @@ import (
 )
 
 type CommitTag struct {
-	Name        string           `json:"name"`
-	SHA         string           `json:"sha"`
-	IsAnnotated bool             `json:"is_annotated"`
-	Title       string           `json:"title,omitempty"`
-	Message     string           `json:"message,omitempty"`
-	Tagger      *types.Signature `json:"tagger,omitempty"`
-	Commit      *types.Commit    `json:"commit,omitempty"`
+	Name         string                       `json:"name"`
+	SHA          string                       `json:"sha"`
+	IsAnnotated  bool                         `json:"is_annotated"`
+	Title        string                       `json:"title,omitempty"`
+	Message      string                       `json:"message,omitempty"`
+	Tagger       *types.Signature             `json:"tagger,omitempty"`
+	Commit       *types.Commit                `json:"commit,omitempty"`
+	Verification *types.SignatureVerification `json:"verification,omitempty"`
 }
 
 // ListCommitTags lists the commit tags of a repo.
@@ -68,6 +69,10 @@ func (c *Controller) ListCommitTags(ctx context.Context,
 		}
 	}
 
+	if err = c.verifyTagSignatures(ctx, rpcOut.Tags, tags); err != nil {
+		return nil, err
+	}
+
 	return tags, nil
 }
 
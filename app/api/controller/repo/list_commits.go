@@ -84,6 +84,10 @@ func (c *Controller) ListCommits(ctx context.Context,
 		commits[i] = *commit
 	}
 
+	if err = c.verifyCommitSignatures(ctx, repo, commits); err != nil {
+		return types.ListCommitResponse{}, err
+	}
+
 	renameDetailList := make([]types.RenameDetails, len(rpcOut.RenameDetails))
 	for i := range rpcOut.RenameDetails {
 		renameDetails := controller.MapRenameDetails(rpcOut.RenameDetails[i])
@@ -16,14 +16,17 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/githook"
 	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -71,10 +74,21 @@ func (c *Controller) PurgeNoAuth(
 		}
 	}
 
+	// forks are using the git objects of the repository, they have to be detached before it's removed.
+	if err := c.DetachForks(ctx, session, repo); err != nil {
+		return fmt.Errorf("failed to detach forks of the repository: %w", err)
+	}
+
 	if err := c.repoStore.Purge(ctx, repo.ID, repo.Deleted); err != nil {
 		return fmt.Errorf("failed to delete repo from db: %w", err)
 	}
 
+	if repo.ForkID != 0 {
+		if err := c.DecrementNumForks(ctx, repo.ForkID); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to update number of forks of the upstream repository")
+		}
+	}
+
 	if err := c.DeleteGitRepository(ctx, session, repo.GitUID); err != nil {
 		log.Ctx(ctx).Err(err).Msg("failed to remove git repository")
 	}
@@ -125,3 +139,116 @@ func (c *Controller) DeleteGitRepository(
 
 	return nil
 }
+
+// DetachForks copies the git objects the forks borrow from the repository into the forks
+// and removes the link between the forks and the repository.
+func (c *Controller) DetachForks(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+) error {
+	forks, err := c.repoStore.ListForks(ctx, repo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list forks: %w", err)
+	}
+
+	for _, fork := range forks {
+		if err := c.DetachFork(ctx, session, fork); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DetachFork copies the git objects the fork borrows from its upstream repository into the fork
+// and removes the link between the fork and the upstream repository.
+// NOTE: The number of forks of the upstream repository isn't updated.
+func (c *Controller) DetachFork(
+	ctx context.Context,
+	session *auth.Session,
+	fork *types.Repository,
+) error {
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, fork)
+	if err != nil {
+		return fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	err = c.git.DetachFork(ctx, &git.DetachForkParams{
+		WriteParams: writeParams,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to detach git repository of fork %d: %w", fork.ID, err)
+	}
+
+	// forks find LFS objects through their upstream repositories, so they need their own copy of them.
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.copyUpstreamLFSObjects(ctx, fork); err != nil {
+			return err
+		}
+
+		if err := c.repoStore.ResetForkID(ctx, fork.ID); err != nil {
+			return fmt.Errorf("failed to reset upstream of fork %d: %w", fork.ID, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("repo.id", fork.ID).
+		Int64("repo.upstream_id", fork.ForkID).
+		Msg("detached fork from its upstream repository")
+
+	return nil
+}
+
+// copyUpstreamLFSObjects copies the LFS objects of all upstream repositories of the fork into the fork.
+func (c *Controller) copyUpstreamLFSObjects(ctx context.Context, fork *types.Repository) error {
+	visited := map[int64]struct{}{fork.ID: {}}
+
+	for upstreamID := fork.ForkID; upstreamID != 0; {
+		if _, ok := visited[upstreamID]; ok {
+			return nil
+		}
+		visited[upstreamID] = struct{}{}
+
+		if err := c.lfsObjectStore.CopyToRepo(ctx, upstreamID, fork.ID); err != nil {
+			return fmt.Errorf("failed to copy LFS objects of repo %d to fork %d: %w", upstreamID, fork.ID, err)
+		}
+
+		upstream, err := c.repoStore.Find(ctx, upstreamID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find upstream repo %d: %w", upstreamID, err)
+		}
+
+		upstreamID = upstream.ForkID
+	}
+
+	return nil
+}
+
+// DecrementNumForks decrements the number of forks of the upstream repository.
+func (c *Controller) DecrementNumForks(ctx context.Context, upstreamID int64) error {
+	upstream, err := c.repoStore.Find(ctx, upstreamID)
+	if err != nil {
+		return fmt.Errorf("failed to find upstream repository: %w", err)
+	}
+
+	_, err = c.repoStore.UpdateOptLock(ctx, upstream, func(r *types.Repository) error {
+		if r.NumForks > 0 {
+			r.NumForks--
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update upstream repository: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+)
+
+// verifyCommitSignatures fills in the signature verification status of the provided commits.
+func (c *Controller) verifyCommitSignatures(
+	ctx context.Context,
+	repo *types.Repository,
+	commits []types.Commit,
+) error {
+	if len(commits) == 0 {
+		return nil
+	}
+
+	commitSHAs := make([]string, len(commits))
+	for i := range commits {
+		commitSHAs[i] = commits[i].SHA
+	}
+
+	out, err := c.git.GetCommitSignatures(ctx, &git.GetCommitSignaturesParams{
+		ReadParams: git.CreateReadParams(repo),
+		CommitSHAs: commitSHAs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get commit signatures: %w", err)
+	}
+
+	for i, signature := range out.Signatures {
+		if signature == nil {
+			continue
+		}
+
+		commits[i].Verification, err = c.signatureVerifier.Verify(ctx, signature, commits[i].Committer.Identity.Email)
+		if err != nil {
+			return fmt.Errorf("failed to verify signature of commit %s: %w", commits[i].SHA, err)
+		}
+	}
+
+	return nil
+}
+
+// verifyTagSignatures fills in the signature verification status of the provided annotated tags.
+func (c *Controller) verifyTagSignatures(
+	ctx context.Context,
+	gitTags []git.CommitTag,
+	tags []CommitTag,
+) error {
+	for i := range gitTags {
+		if gitTags[i].Signature == nil {
+			continue
+		}
+
+		var taggerEmail string
+		if gitTags[i].Tagger != nil {
+			taggerEmail = gitTags[i].Tagger.Identity.Email
+		}
+
+		verification, err := c.signatureVerifier.Verify(ctx, gitTags[i].Signature, taggerEmail)
+		if err != nil {
+			return fmt.Errorf("failed to verify signature of tag %s: %w", gitTags[i].Name, err)
+		}
+
+		tags[i].Verification = verification
+	}
+
+	return nil
+}
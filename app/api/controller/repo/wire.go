@@ -26,6 +26,7 @@ import (
 	"github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/rules"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/services/usergroup"
@@ -59,6 +60,7 @@ func ProvideController(
 	ruleStore store.RuleStore,
 	checkStore store.CheckStore,
 	pullReqStore store.PullReqStore,
+	lfsObjectStore store.LFSObjectStore,
 	settings *settings.Service,
 	principalInfoCache store.PrincipalInfoCache,
 	protectionManager *protection.Manager,
@@ -79,15 +81,16 @@ func ProvideController(
 	userGroupStore store.UserGroupStore,
 	userGroupService usergroup.SearchService,
 	rulesSvc *rules.Service,
+	signatureVerifier *publickey.SignatureVerifier,
 ) *Controller {
 	return NewController(config, tx, urlProvider,
 		authorizer,
 		repoStore, spaceStore, pipelineStore, executionStore,
-		principalStore, ruleStore, checkStore, pullReqStore, settings,
+		principalStore, ruleStore, checkStore, pullReqStore, lfsObjectStore, settings,
 		principalInfoCache, protectionManager, rpcClient, importer,
 		codeOwners, reporeporter, indexer, limiter, locker, auditService, mtxManager, identifierCheck,
 		repoChecks, publicAccess, labelSvc, instrumentation, userGroupStore, userGroupService,
-		rulesSvc,
+		rulesSvc, signatureVerifier,
 	)
 }
 
@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// lastSeenUpdateInterval limits how often the last seen time of a runner is written to the database.
+const lastSeenUpdateInterval = 15 * time.Second
+
+// Authenticate returns the runner the token was generated for and records that the runner is alive.
+func (c *Controller) Authenticate(ctx context.Context, token string) (*types.Runner, error) {
+	if token == "" {
+		return nil, usererror.ErrUnauthorized
+	}
+
+	runner, err := c.runnerStore.FindByTokenHash(ctx, hashToken(token))
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find runner by token: %w", err)
+	}
+
+	now := time.Now()
+	if now.Sub(time.UnixMilli(runner.LastSeen)) < lastSeenUpdateInterval {
+		return runner, nil
+	}
+
+	// failing to record the heartbeat shouldn't fail the runner's request.
+	if err = c.runnerStore.UpdateLastSeen(ctx, runner.ID, now.UnixMilli()); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("runner", runner.Identifier).Msg("failed to update runner last seen time")
+	} else {
+		runner.LastSeen = now.UnixMilli()
+	}
+
+	return runner, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/runner-go/client"
+)
+
+// machinePrefix is prepended to the runner identifier to form the machine name stored on accepted stages.
+// Identifiers can't contain slashes, so the name can't collide with the instance ID of the embedded runner.
+const machinePrefix = "runner/"
+
+type Controller struct {
+	config      *types.Config
+	runnerStore store.RunnerStore
+	stageStore  store.StageStore
+	stepStore   store.StepStore
+	urlProvider url.Provider
+	client      client.Client
+}
+
+func NewController(
+	config *types.Config,
+	runnerStore store.RunnerStore,
+	stageStore store.StageStore,
+	stepStore store.StepStore,
+	urlProvider url.Provider,
+	client client.Client,
+) *Controller {
+	return &Controller{
+		config:      config,
+		runnerStore: runnerStore,
+		stageStore:  stageStore,
+		stepStore:   stepStore,
+		urlProvider: urlProvider,
+		client:      client,
+	}
+}
+
+// withStatus sets the health status of the runner based on the last time it contacted the server.
+func (c *Controller) withStatus(runner *types.Runner) *types.Runner {
+	runner.Status = enum.RunnerStatusOffline
+	if time.Since(time.UnixMilli(runner.LastSeen)) < c.config.CI.RunnerHeartbeatTimeout {
+		runner.Status = enum.RunnerStatusOnline
+	}
+
+	return runner
+}
+
+// checkStage verifies that the stage has been accepted by the runner.
+// Stages hold the secrets of the pipeline, so runners are never given access to stages of other runners.
+func (c *Controller) checkStage(ctx context.Context, runner *types.Runner, stageID int64) (*types.Stage, error) {
+	stage, err := c.stageStore.Find(ctx, stageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stage: %w", err)
+	}
+
+	if stage.Machine != machineName(runner) {
+		return nil, usererror.ErrForbidden
+	}
+
+	return stage, nil
+}
+
+// checkStep verifies that the step belongs to a stage accepted by the runner.
+func (c *Controller) checkStep(ctx context.Context, runner *types.Runner, stepID int64) (*types.Step, error) {
+	step, err := c.stepStore.Find(ctx, stepID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find step: %w", err)
+	}
+
+	if _, err = c.checkStage(ctx, runner, step.StageID); err != nil {
+		return nil, err
+	}
+
+	return step, nil
+}
+
+func machineName(runner *types.Runner) string {
+	return machinePrefix + runner.Identifier
+}
@@ -0,0 +1,179 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeRunnerStore struct {
+	store.RunnerStore
+	runners   map[string]*types.Runner
+	updateErr error
+	updates   int
+}
+
+func (s *fakeRunnerStore) FindByTokenHash(_ context.Context, tokenHash string) (*types.Runner, error) {
+	runner, ok := s.runners[tokenHash]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	r := *runner
+	return &r, nil
+}
+
+func (s *fakeRunnerStore) UpdateLastSeen(context.Context, int64, int64) error {
+	s.updates++
+	return s.updateErr
+}
+
+func TestController_Authenticate(t *testing.T) {
+	const token = "secret-token"
+
+	now := time.Now()
+	tests := []struct {
+		name        string
+		token       string
+		lastSeen    time.Time
+		updateErr   error
+		wantErr     error
+		wantUpdates int
+		wantUpdated bool
+	}{
+		{
+			name:    "empty token",
+			token:   "",
+			wantErr: usererror.ErrUnauthorized,
+		},
+		{
+			name:    "unknown token",
+			token:   "other-token",
+			wantErr: usererror.ErrUnauthorized,
+		},
+		{
+			name:        "valid token",
+			token:       token,
+			lastSeen:    now.Add(-time.Hour),
+			wantUpdates: 1,
+			wantUpdated: true,
+		},
+		{
+			name:     "recently seen runner",
+			token:    token,
+			lastSeen: now.Add(-time.Second),
+		},
+		{
+			name:        "failed last seen update",
+			token:       token,
+			lastSeen:    now.Add(-time.Hour),
+			updateErr:   errors.New("db error"),
+			wantUpdates: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runnerStore := &fakeRunnerStore{
+				runners: map[string]*types.Runner{
+					hashToken(token): {ID: 1, Identifier: "runner", LastSeen: test.lastSeen.UnixMilli()},
+				},
+				updateErr: test.updateErr,
+			}
+			c := &Controller{runnerStore: runnerStore}
+
+			runner, err := c.Authenticate(context.Background(), test.token)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("expected error %v, got: %v", test.wantErr, err)
+			}
+			if runnerStore.updates != test.wantUpdates {
+				t.Errorf("expected %d last seen updates, got %d", test.wantUpdates, runnerStore.updates)
+			}
+			if err != nil {
+				return
+			}
+
+			if updated := runner.LastSeen != test.lastSeen.UnixMilli(); updated != test.wantUpdated {
+				t.Errorf("expected last seen updated=%t, got %t", test.wantUpdated, updated)
+			}
+		})
+	}
+}
+
+func TestController_WithStatus(t *testing.T) {
+	config := &types.Config{}
+	config.CI.RunnerHeartbeatTimeout = time.Minute
+	c := &Controller{config: config}
+
+	tests := []struct {
+		name     string
+		lastSeen int64
+		want     enum.RunnerStatus
+	}{
+		{
+			name:     "never seen",
+			lastSeen: 0,
+			want:     enum.RunnerStatusOffline,
+		},
+		{
+			name:     "seen recently",
+			lastSeen: time.Now().Add(-10 * time.Second).UnixMilli(),
+			want:     enum.RunnerStatusOnline,
+		},
+		{
+			name:     "heartbeat timed out",
+			lastSeen: time.Now().Add(-2 * time.Minute).UnixMilli(),
+			want:     enum.RunnerStatusOffline,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runner := c.withStatus(&types.Runner{LastSeen: test.lastSeen, Status: enum.RunnerStatusOnline})
+			if runner.Status != test.want {
+				t.Errorf("expected status %q, got %q", test.want, runner.Status)
+			}
+		})
+	}
+}
+
+func TestGenerateToken(t *testing.T) {
+	token1, err := generateToken()
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	token2, err := generateToken()
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	if len(token1) != 2*runnerTokenLength {
+		t.Errorf("expected token of length %d, got %d", 2*runnerTokenLength, len(token1))
+	}
+	if token1 == token2 {
+		t.Error("expected generated tokens to be unique")
+	}
+	if hashToken(token1) == token1 || hashToken(token1) != hashToken(token1) {
+		t.Error("expected token hash to be deterministic and differ from the token")
+	}
+}
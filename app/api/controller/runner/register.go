@@ -0,0 +1,127 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+)
+
+// runnerTokenLength is the number of random bytes in a runner registration token.
+const runnerTokenLength = 32
+
+type RegisterInput struct {
+	Identifier  string            `json:"identifier"`
+	Description string            `json:"description"`
+	OS          string            `json:"os"`
+	Arch        string            `json:"arch"`
+	Kernel      string            `json:"kernel"`
+	Variant     string            `json:"variant"`
+	Labels      map[string]string `json:"labels"`
+}
+
+func (in *RegisterInput) sanitize() error {
+	in.Identifier = strings.TrimSpace(in.Identifier)
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	in.OS = strings.ToLower(strings.TrimSpace(in.OS))
+	in.Arch = strings.ToLower(strings.TrimSpace(in.Arch))
+	in.Kernel = strings.TrimSpace(in.Kernel)
+	in.Variant = strings.TrimSpace(in.Variant)
+
+	return nil
+}
+
+type RegisterOutput struct {
+	types.Runner
+
+	// Token is used by the runner to authenticate. It's only returned once, on registration.
+	Token string `json:"token"`
+}
+
+// Register registers a new remote runner and generates its token. Only available to admins.
+func (c *Controller) Register(
+	ctx context.Context,
+	session *auth.Session,
+	in *RegisterInput,
+) (*RegisterOutput, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	runner := &types.Runner{
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		OS:          in.OS,
+		Arch:        in.Arch,
+		Kernel:      in.Kernel,
+		Variant:     in.Variant,
+		Labels:      in.Labels,
+		TokenHash:   hashToken(token),
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+	}
+
+	if err = c.runnerStore.Create(ctx, runner); err != nil {
+		return nil, fmt.Errorf("failed to create runner: %w", err)
+	}
+
+	return &RegisterOutput{
+		Runner: *c.withStatus(runner),
+		Token:  token,
+	}, nil
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, runnerTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate runner token: %w", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/drone/drone-go/drone"
+	"github.com/drone/runner-go/client"
+)
+
+// pollTimeout is the longest time a runner request waits for a stage or a cancellation before
+// returning empty-handed. Runners are expected to poll again right away.
+const pollTimeout = 30 * time.Second
+
+// Request waits for the next stage matching the runner's platform and labels.
+// It returns nil if no stage became available within the poll timeout.
+func (c *Controller) Request(
+	ctx context.Context,
+	runner *types.Runner,
+	filter *client.Filter,
+) (*drone.Stage, error) {
+	// the platform and labels configured on registration take precedence over what the runner reports.
+	if runner.OS != "" {
+		filter.OS = runner.OS
+	}
+	if runner.Arch != "" {
+		filter.Arch = runner.Arch
+	}
+	if runner.Kernel != "" {
+		filter.Kernel = runner.Kernel
+	}
+	if runner.Variant != "" {
+		filter.Variant = runner.Variant
+	}
+	if len(runner.Labels) > 0 {
+		filter.Labels = runner.Labels
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pollTimeout)
+	defer cancel()
+
+	stage, err := c.client.Request(ctx, filter)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to request stage: %w", err)
+	}
+
+	return stage, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/pipeline/storage"
+	"github.com/harness/gitness/types"
+
+	"github.com/drone/drone-go/drone"
+	"github.com/drone/runner-go/client"
+)
+
+// Accept assigns the stage to the runner. Only one runner can accept a stage.
+func (c *Controller) Accept(
+	ctx context.Context,
+	runner *types.Runner,
+	stageID int64,
+) (*drone.Stage, error) {
+	stage, err := c.stageStore.Find(ctx, stageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stage: %w", err)
+	}
+
+	if stage.Machine != "" {
+		return nil, usererror.Conflict("The stage has already been accepted.")
+	}
+
+	out := &drone.Stage{
+		ID:      stageID,
+		Machine: machineName(runner),
+	}
+	if err = c.client.Accept(ctx, out); err != nil {
+		return nil, fmt.Errorf("failed to accept stage: %w", err)
+	}
+
+	return out, nil
+}
+
+// Details returns everything the runner needs to execute an accepted stage.
+func (c *Controller) Details(
+	ctx context.Context,
+	runner *types.Runner,
+	stageID int64,
+) (*client.Context, error) {
+	if _, err := c.checkStage(ctx, runner, stageID); err != nil {
+		return nil, err
+	}
+
+	details, err := c.client.Detail(ctx, &drone.Stage{ID: stageID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stage details: %w", err)
+	}
+
+	// remote runners can't reach the clone and api URLs used by the containers of the embedded runner.
+	cloneURL := c.urlProvider.GenerateGITCloneURL(ctx, details.Repo.Namespace)
+	details.Repo.HTTPURL = cloneURL
+	details.Repo.Link = cloneURL
+	if details.Netrc != nil {
+		details.Netrc.Machine = c.urlProvider.GetGITHostname(ctx)
+	}
+	details.Build.Params[storage.EnvAPIURL] = c.urlProvider.GetAPIURL(ctx)
+
+	return details, nil
+}
+
+// UpdateStage reports the start or the completion of an accepted stage.
+func (c *Controller) UpdateStage(
+	ctx context.Context,
+	runner *types.Runner,
+	in *drone.Stage,
+) (*drone.Stage, error) {
+	if _, err := c.checkStage(ctx, runner, in.ID); err != nil {
+		return nil, err
+	}
+
+	in.Machine = machineName(runner)
+	if err := c.client.Update(ctx, in); err != nil {
+		return nil, fmt.Errorf("failed to update stage: %w", err)
+	}
+
+	return in, nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/drone/drone-go/drone"
+)
+
+// UpdateStep reports the start or the completion of a step of an accepted stage.
+func (c *Controller) UpdateStep(
+	ctx context.Context,
+	runner *types.Runner,
+	in *drone.Step,
+) (*drone.Step, error) {
+	step, err := c.checkStep(ctx, runner, in.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	in.StageID = step.StageID
+	if err = c.client.UpdateStep(ctx, in); err != nil {
+		return nil, fmt.Errorf("failed to update step: %w", err)
+	}
+
+	return in, nil
+}
+
+type LogsInput struct {
+	Lines []*drone.Line `json:"lines"`
+}
+
+// WriteLogs appends lines to the live log stream of a running step.
+func (c *Controller) WriteLogs(
+	ctx context.Context,
+	runner *types.Runner,
+	stepID int64,
+	in *LogsInput,
+) error {
+	if _, err := c.checkStep(ctx, runner, stepID); err != nil {
+		return err
+	}
+
+	if err := c.client.Batch(ctx, stepID, in.Lines); err != nil {
+		return fmt.Errorf("failed to write step logs: %w", err)
+	}
+
+	return nil
+}
+
+// UploadLogs stores the complete logs of a finished step.
+func (c *Controller) UploadLogs(
+	ctx context.Context,
+	runner *types.Runner,
+	stepID int64,
+	in *LogsInput,
+) error {
+	if _, err := c.checkStep(ctx, runner, stepID); err != nil {
+		return err
+	}
+
+	if err := c.client.Upload(ctx, stepID, in.Lines); err != nil {
+		return fmt.Errorf("failed to upload step logs: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+)
+
+// Watch waits for the execution to be canceled. It returns false if the execution
+// is still running after the poll timeout, in which case the runner should watch again.
+func (c *Controller) Watch(
+	ctx context.Context,
+	runner *types.Runner,
+	executionID int64,
+) (bool, error) {
+	stages, err := c.stageStore.List(ctx, executionID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list stages: %w", err)
+	}
+
+	accepted := false
+	for _, stage := range stages {
+		if stage.Machine == machineName(runner) {
+			accepted = true
+			break
+		}
+	}
+	if !accepted {
+		return false, usererror.ErrForbidden
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pollTimeout)
+	defer cancel()
+
+	canceled, err := c.client.Watch(ctx, executionID)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to watch execution: %w", err)
+	}
+
+	return canceled, nil
+}
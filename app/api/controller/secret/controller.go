@@ -16,15 +16,17 @@ package secret
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/secret/external"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/encrypt"
 )
 
 type Controller struct {
-	encrypter   encrypt.Encrypter
-	secretStore store.SecretStore
-	authorizer  authz.Authorizer
-	spaceStore  store.SpaceStore
+	encrypter         encrypt.Encrypter
+	secretStore       store.SecretStore
+	authorizer        authz.Authorizer
+	spaceStore        store.SpaceStore
+	externalProviders *external.Providers
 }
 
 func NewController(
@@ -32,11 +34,13 @@ func NewController(
 	encrypter encrypt.Encrypter,
 	secretStore store.SecretStore,
 	spaceStore store.SpaceStore,
+	externalProviders *external.Providers,
 ) *Controller {
 	return &Controller{
-		encrypter:   encrypter,
-		secretStore: secretStore,
-		authorizer:  authorizer,
-		spaceStore:  spaceStore,
+		encrypter:         encrypter,
+		secretStore:       secretStore,
+		authorizer:        authorizer,
+		spaceStore:        spaceStore,
+		externalProviders: externalProviders,
 	}
 }
@@ -16,6 +16,7 @@ package secret
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -24,6 +25,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/secret/external"
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -43,6 +45,11 @@ type CreateInput struct {
 	UID        string `json:"uid" deprecated:"true"`
 	Identifier string `json:"identifier"`
 	Data       string `json:"data"`
+
+	// Provider is where the value of the secret is stored, local by default.
+	Provider enum.SecretProvider `json:"provider"`
+	// ExternalPath references the value in the external provider, it's required for external providers.
+	ExternalPath string `json:"external_path"`
 }
 
 func (c *Controller) Create(ctx context.Context, session *auth.Session, in *CreateInput) (*types.Secret, error) {
@@ -67,6 +74,12 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 		return nil, err
 	}
 
+	if in.Provider.IsExternal() {
+		if err = c.checkExternalAccess(ctx, session, parentSpace); err != nil {
+			return nil, err
+		}
+	}
+
 	var secret *types.Secret
 	now := time.Now().UnixMilli()
 	secret = &types.Secret{
@@ -78,10 +91,15 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 		Created:     now,
 		Updated:     now,
 		Version:     0,
+
+		Provider:     in.Provider,
+		ExternalPath: in.ExternalPath,
 	}
-	secret, err = enc(c.encrypter, secret)
-	if err != nil {
-		return nil, fmt.Errorf("could not encrypt secret: %w", err)
+	if !secret.Provider.IsExternal() {
+		secret, err = enc(c.encrypter, secret)
+		if err != nil {
+			return nil, fmt.Errorf("could not encrypt secret: %w", err)
+		}
 	}
 	err = c.secretStore.Create(ctx, secret)
 	if err != nil {
@@ -108,7 +126,62 @@ func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 	}
 
 	in.Description = strings.TrimSpace(in.Description)
-	return check.Description(in.Description)
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	provider, ok := in.Provider.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Invalid secret provider %q.", in.Provider)
+	}
+	in.Provider = provider
+	in.ExternalPath = strings.TrimSpace(in.ExternalPath)
+
+	return c.checkProvider(in.Provider, in.ExternalPath, in.Data != "")
+}
+
+// checkProvider verifies that only secrets of external providers have an external path,
+// and that they reference a valid path of a configured provider instead of holding a value.
+func (c *Controller) checkProvider(provider enum.SecretProvider, externalPath string, hasData bool) error {
+	if !provider.IsExternal() {
+		if externalPath != "" {
+			return usererror.BadRequest("External path is only supported for secrets of external providers.")
+		}
+		return nil
+	}
+
+	if hasData {
+		return usererror.BadRequest("Secrets of external providers can't hold a value.")
+	}
+
+	if externalPath == "" {
+		return usererror.BadRequest("External path is required for secrets of external providers.")
+	}
+
+	err := c.externalProviders.Validate(provider, externalPath)
+	if errors.Is(err, external.ErrProviderNotConfigured) {
+		return usererror.BadRequestf("Secret provider %q is not configured.", provider)
+	}
+	if err != nil {
+		return usererror.BadRequest(err.Error())
+	}
+
+	return nil
+}
+
+// checkExternalAccess verifies that the principal is allowed to reference values of external providers.
+// External paths aren't scoped to spaces and can reference any value the server has access to,
+// so only space admins can create secrets of external providers.
+func (c *Controller) checkExternalAccess(ctx context.Context, session *auth.Session, space *types.Space) error {
+	err := apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit)
+	if errors.Is(err, apiauth.ErrNotAuthorized) {
+		return usererror.Forbidden("Only space admins can create secrets of external providers.")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to authorize external secret: %w", err)
+	}
+
+	return nil
 }
 
 // helper function returns the same secret with encrypted data.
@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -33,6 +34,9 @@ type UpdateInput struct {
 	Identifier  *string `json:"identifier"`
 	Description *string `json:"description"`
 	Data        *string `json:"data"`
+
+	Provider     *enum.SecretProvider `json:"provider"`
+	ExternalPath *string              `json:"external_path"`
 }
 
 func (c *Controller) Update(
@@ -56,6 +60,12 @@ func (c *Controller) Update(
 		return nil, fmt.Errorf("failed to authorize: %w", err)
 	}
 
+	if (in.Provider != nil && in.Provider.IsExternal()) || (in.ExternalPath != nil && *in.ExternalPath != "") {
+		if err = c.checkExternalAccess(ctx, session, space); err != nil {
+			return nil, err
+		}
+	}
+
 	secret, err := c.secretStore.FindByIdentifier(ctx, space.ID, identifier)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find secret: %w", err)
@@ -68,6 +78,29 @@ func (c *Controller) Update(
 		if in.Description != nil {
 			original.Description = *in.Description
 		}
+		wasExternal := original.Provider.IsExternal()
+		if in.Provider != nil {
+			original.Provider = *in.Provider
+		}
+		if in.ExternalPath != nil {
+			original.ExternalPath = *in.ExternalPath
+		}
+
+		if original.Provider.IsExternal() {
+			// the value of an external secret lives in the provider, drop any previously stored value.
+			original.Data = ""
+			return c.checkProvider(original.Provider, original.ExternalPath, in.Data != nil && *in.Data != "")
+		}
+
+		if wasExternal {
+			if in.Data == nil {
+				return usererror.BadRequest("A value is required when converting an external secret to a local one.")
+			}
+			original.ExternalPath = ""
+		}
+		if err := c.checkProvider(original.Provider, original.ExternalPath, in.Data != nil); err != nil {
+			return err
+		}
 		if in.Data != nil {
 			data, err := c.encrypter.Encrypt(*in.Data)
 			if err != nil {
@@ -99,5 +132,17 @@ func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
 		}
 	}
 
+	if in.Provider != nil {
+		provider, ok := in.Provider.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Invalid secret provider %q.", *in.Provider)
+		}
+		in.Provider = &provider
+	}
+
+	if in.ExternalPath != nil {
+		*in.ExternalPath = strings.TrimSpace(*in.ExternalPath)
+	}
+
 	return nil
 }
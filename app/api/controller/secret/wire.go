@@ -16,6 +16,7 @@ package secret
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/secret/external"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/encrypt"
 
@@ -32,6 +33,7 @@ func ProvideController(
 	secretStore store.SecretStore,
 	authorizer authz.Authorizer,
 	spaceStore store.SpaceStore,
+	externalProviders *external.Providers,
 ) *Controller {
-	return NewController(authorizer, encrypter, secretStore, spaceStore)
+	return NewController(authorizer, encrypter, secretStore, spaceStore, externalProviders)
 }
@@ -35,6 +35,7 @@ import (
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/rules"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -99,6 +100,7 @@ type Controller struct {
 	instrumentation instrument.Service
 	executionStore  store.ExecutionStore
 	rulesSvc        *rules.Service
+	settings        *settings.Service
 }
 
 func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Provider,
@@ -111,7 +113,7 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 	limiter limiter.ResourceLimiter, publicAccess publicaccess.Service, auditService audit.Service,
 	gitspaceSvc *gitspace.Service, labelSvc *label.Service,
 	instrumentation instrument.Service, executionStore store.ExecutionStore,
-	rulesSvc *rules.Service,
+	rulesSvc *rules.Service, settings *settings.Service,
 ) *Controller {
 	return &Controller{
 		nestedSpacesEnabled: config.NestedSpacesEnabled,
@@ -141,6 +143,7 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 		instrumentation:     instrumentation,
 		executionStore:      executionStore,
 		rulesSvc:            rulesSvc,
+		settings:            settings,
 	}
 }
 
@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"net/url"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/errors"
+
+	"github.com/gotidy/ptr"
+)
+
+// NotificationChannels holds the chat channels to which the space delivers
+// notifications about pull requests in its repositories.
+// Repositories use the channels of the closest space that has them configured.
+type NotificationChannels struct {
+	SlackWebhookURL *string `json:"slack_webhook_url" yaml:"slack_webhook_url"`
+	TeamsWebhookURL *string `json:"teams_webhook_url" yaml:"teams_webhook_url"`
+}
+
+func getDefaultNotificationChannels() *NotificationChannels {
+	return &NotificationChannels{
+		SlackWebhookURL: ptr.String(""),
+		TeamsWebhookURL: ptr.String(""),
+	}
+}
+
+func getNotificationChannelsMappings(s *NotificationChannels) []settings.SettingHandler {
+	return []settings.SettingHandler{
+		settings.Mapping(settings.KeyNotificationSlackWebhookURL, s.SlackWebhookURL),
+		settings.Mapping(settings.KeyNotificationTeamsWebhookURL, s.TeamsWebhookURL),
+	}
+}
+
+func getNotificationChannelsAsKeyValues(s *NotificationChannels) []settings.KeyValue {
+	kvs := make([]settings.KeyValue, 0, 2)
+
+	if s.SlackWebhookURL != nil {
+		kvs = append(kvs, settings.KeyValue{
+			Key:   settings.KeyNotificationSlackWebhookURL,
+			Value: s.SlackWebhookURL,
+		})
+	}
+
+	if s.TeamsWebhookURL != nil {
+		kvs = append(kvs, settings.KeyValue{
+			Key:   settings.KeyNotificationTeamsWebhookURL,
+			Value: s.TeamsWebhookURL,
+		})
+	}
+
+	return kvs
+}
+
+func (in *NotificationChannels) sanitize() error {
+	if err := sanitizeWebhookURL("slack", in.SlackWebhookURL); err != nil {
+		return err
+	}
+
+	if err := sanitizeWebhookURL("teams", in.TeamsWebhookURL); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// sanitizeWebhookURL verifies the webhook URL. An empty value removes the channel.
+func sanitizeWebhookURL(name string, webhookURL *string) error {
+	if webhookURL == nil || *webhookURL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(*webhookURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return errors.InvalidArgument("%s webhook URL must be a valid https URL", name)
+	}
+
+	return nil
+}
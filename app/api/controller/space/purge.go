@@ -67,8 +67,17 @@ func (c *Controller) PurgeNoAuth(
 	)
 	defer cancel()
 
+	// forks outside of the space are using the git objects of the repositories, detach them before the purge.
+	repos, err := c.listReposToPurge(ctx, space.ID, *space.Deleted)
+	if err != nil {
+		return err
+	}
+
+	if err = c.detachExternalForks(ctx, session, repos); err != nil {
+		return fmt.Errorf("failed to detach forks of space %d repositories: %w", space.ID, err)
+	}
+
 	var toBeDeletedRepos []*types.Repository
-	var err error
 	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
 		toBeDeletedRepos, err = c.purgeSpaceInnerInTx(ctx, space.ID, *space.Deleted)
 		return err
@@ -77,9 +86,23 @@ func (c *Controller) PurgeNoAuth(
 		return fmt.Errorf("failed to purge space %d in a tnx: %w", space.ID, err)
 	}
 
+	toBeDeletedRepoIDs := make(map[int64]struct{}, len(toBeDeletedRepos))
+	for _, repo := range toBeDeletedRepos {
+		toBeDeletedRepoIDs[repo.ID] = struct{}{}
+	}
+
 	// permanently purge all repositories in the space and its subspaces after successful space purge tnx.
 	// cleanup will handle failed repository deletions.
 	for _, repo := range toBeDeletedRepos {
+		if _, ok := toBeDeletedRepoIDs[repo.ForkID]; repo.ForkID != 0 && !ok {
+			if err := c.repoCtrl.DecrementNumForks(ctx, repo.ForkID); err != nil {
+				log.Ctx(ctx).Warn().Err(err).
+					Int64("repo_id", repo.ID).
+					Int64("repo_fork_id", repo.ForkID).
+					Msg("failed to update number of forks of the upstream repository")
+			}
+		}
+
 		err := c.repoCtrl.DeleteGitRepository(ctx, session, repo.GitUID)
 		if err != nil {
 			log.Ctx(ctx).Warn().Err(err).
@@ -93,7 +116,38 @@ func (c *Controller) PurgeNoAuth(
 	return nil
 }
 
-func (c *Controller) purgeSpaceInnerInTx(
+// detachExternalForks detaches all forks of the provided repositories that aren't in the provided list themselves.
+func (c *Controller) detachExternalForks(
+	ctx context.Context,
+	session *auth.Session,
+	repos []*types.Repository,
+) error {
+	repoIDs := make(map[int64]struct{}, len(repos))
+	for _, repo := range repos {
+		repoIDs[repo.ID] = struct{}{}
+	}
+
+	for _, repo := range repos {
+		forks, err := c.repoStore.ListForks(ctx, repo.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list forks of repo %d: %w", repo.ID, err)
+		}
+
+		for _, fork := range forks {
+			if _, ok := repoIDs[fork.ID]; ok {
+				continue
+			}
+
+			if err := c.repoCtrl.DetachFork(ctx, session, fork); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) listReposToPurge(
 	ctx context.Context,
 	spaceID int64,
 	deletedAt int64,
@@ -112,6 +166,19 @@ func (c *Controller) purgeSpaceInnerInTx(
 		return nil, fmt.Errorf("failed to list space repositories: %w", err)
 	}
 
+	return repos, nil
+}
+
+func (c *Controller) purgeSpaceInnerInTx(
+	ctx context.Context,
+	spaceID int64,
+	deletedAt int64,
+) ([]*types.Repository, error) {
+	repos, err := c.listReposToPurge(ctx, spaceID, deletedAt)
+	if err != nil {
+		return nil, err
+	}
+
 	// purge cascade deletes all the child spaces from DB.
 	err = c.spaceStore.Purge(ctx, spaceID, &deletedAt)
 	if err != nil {
@@ -26,6 +26,7 @@ import (
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/rules"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -52,7 +53,7 @@ func ProvideController(config *types.Config, tx dbtx.Transactor, urlProvider url
 	exporter *exporter.Repository, limiter limiter.ResourceLimiter, publicAccess publicaccess.Service,
 	auditService audit.Service, gitspaceService *gitspace.Service,
 	labelSvc *label.Service, instrumentation instrument.Service, executionStore store.ExecutionStore,
-	rulesSvc *rules.Service,
+	rulesSvc *rules.Service, settings *settings.Service,
 ) *Controller {
 	return NewController(config, tx, urlProvider,
 		sseStreamer, identifierCheck, authorizer,
@@ -63,6 +64,6 @@ func ProvideController(config *types.Config, tx dbtx.Transactor, urlProvider url
 		exporter, limiter, publicAccess,
 		auditService, gitspaceService,
 		labelSvc, instrumentation, executionStore,
-		rulesSvc,
+		rulesSvc, settings,
 	)
 }
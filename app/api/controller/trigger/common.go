@@ -15,8 +15,14 @@
 package trigger
 
 import (
+	"strings"
+	"time"
+
+	gitcheck "github.com/harness/gitness/git/check"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/gorhill/cronexpr"
 )
 
 const (
@@ -66,3 +72,76 @@ func deduplicateActions(in []enum.TriggerAction) []enum.TriggerAction {
 
 	return out
 }
+
+func checkCron(cron string) error {
+	if cron == "" {
+		return check.NewValidationError("The cron expression of a cron trigger is required.")
+	}
+
+	if _, err := cronexpr.Parse(cron); err != nil {
+		return check.NewValidationErrorf("The provided cron expression is invalid: %s.", err)
+	}
+
+	return nil
+}
+
+func checkCronBranch(branch string) error {
+	if branch == "" {
+		return nil // the default branch is used
+	}
+
+	if err := gitcheck.BranchName(branch); err != nil {
+		return check.NewValidationErrorf("The provided cron branch is invalid: %s.", err)
+	}
+
+	return nil
+}
+
+func checkCronTimezone(timezone string) error {
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return check.NewValidationErrorf("The provided time zone '%s' is invalid.", timezone)
+	}
+
+	return nil
+}
+
+// checkUpstreamStatuses validates the upstream execution statuses on which a pipeline trigger fires.
+func checkUpstreamStatuses(statuses []enum.CIStatus) error {
+	for _, status := range statuses {
+		if enum.ParseCIStatus(string(status)) != status || !status.IsDone() {
+			return check.NewValidationErrorf("The provided upstream status '%s' is invalid.", status)
+		}
+	}
+
+	return nil
+}
+
+// deduplicateStatuses de-duplicates the upstream statuses provided in the trigger.
+func deduplicateStatuses(in []enum.CIStatus) []enum.CIStatus {
+	if len(in) == 0 {
+		return nil
+	}
+
+	statusSet := make(map[enum.CIStatus]struct{})
+	out := make([]enum.CIStatus, 0, len(in))
+	for _, status := range in {
+		if _, ok := statusSet[status]; ok {
+			continue
+		}
+		statusSet[status] = struct{}{}
+		out = append(out, status)
+	}
+
+	return out
+}
+
+// checkParams validates the params a pipeline trigger passes to the executions it fires.
+func checkParams(params map[string]string) error {
+	for key := range params {
+		if strings.TrimSpace(key) == "" {
+			return check.NewValidationError("The names of the trigger params can't be empty.")
+		}
+	}
+
+	return nil
+}
@@ -16,26 +16,34 @@ package trigger
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
 )
 
 type Controller struct {
+	tx            dbtx.Transactor
 	authorizer    authz.Authorizer
 	triggerStore  store.TriggerStore
 	pipelineStore store.PipelineStore
 	repoStore     store.RepoStore
+	cronScheduler *trigger.CronScheduler
 }
 
 func NewController(
+	tx dbtx.Transactor,
 	authorizer authz.Authorizer,
 	triggerStore store.TriggerStore,
 	pipelineStore store.PipelineStore,
 	repoStore store.RepoStore,
+	cronScheduler *trigger.CronScheduler,
 ) *Controller {
 	return &Controller{
+		tx:            tx,
 		authorizer:    authorizer,
 		triggerStore:  triggerStore,
 		pipelineStore: pipelineStore,
 		repoStore:     repoStore,
+		cronScheduler: cronScheduler,
 	}
 }
@@ -17,6 +17,7 @@ package trigger
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
@@ -35,6 +36,18 @@ type CreateInput struct {
 	Secret     string               `json:"secret"`
 	Disabled   bool                 `json:"disabled"`
 	Actions    []enum.TriggerAction `json:"actions"`
+
+	// Cron makes the trigger fire on a schedule instead of on repository events.
+	Cron         string `json:"cron"`
+	CronBranch   string `json:"cron_branch"`
+	CronTimezone string `json:"cron_timezone"`
+
+	// UpstreamPipeline makes the trigger fire on completed executions of another pipeline,
+	// in the repo referenced by UpstreamRepoRef or in the same repo if it's empty.
+	UpstreamRepoRef  string            `json:"upstream_repo_ref"`
+	UpstreamPipeline string            `json:"upstream_pipeline"`
+	UpstreamStatuses []enum.CIStatus   `json:"upstream_statuses"`
+	Params           map[string]string `json:"params"`
 }
 
 func (c *Controller) Create(
@@ -64,8 +77,28 @@ func (c *Controller) Create(
 		return nil, fmt.Errorf("failed to find pipeline: %w", err)
 	}
 
+	var upstreamPipelineID int64
+	if in.UpstreamPipeline != "" {
+		upstreamPipelineID, err = c.findUpstreamPipeline(ctx, session, repo, in.UpstreamRepoRef, in.UpstreamPipeline)
+		if err != nil {
+			return nil, err
+		}
+		if upstreamPipelineID == pipeline.ID {
+			return nil, check.NewValidationError("A pipeline can't be triggered by itself.")
+		}
+	}
+
+	triggerType := enum.TriggerHook
+	switch {
+	case in.Cron != "":
+		triggerType = enum.TriggerCron
+	case upstreamPipelineID != 0:
+		triggerType = enum.TriggerPipeline
+	}
+
 	now := time.Now().UnixMilli()
 	trigger := &types.Trigger{
+		Type:        triggerType,
 		Description: in.Description,
 		Disabled:    in.Disabled,
 		Secret:      in.Secret,
@@ -77,10 +110,26 @@ func (c *Controller) Create(
 		Created:     now,
 		Updated:     now,
 		Version:     0,
+
+		Cron:         in.Cron,
+		CronBranch:   in.CronBranch,
+		CronTimezone: in.CronTimezone,
+
+		UpstreamPipelineID: upstreamPipelineID,
+		UpstreamStatuses:   deduplicateStatuses(in.UpstreamStatuses),
+		Params:             in.Params,
 	}
-	err = c.triggerStore.Create(ctx, trigger)
+	// the recurring job is stored in the same database, so the trigger is never left without its schedule.
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		err := c.triggerStore.Create(ctx, trigger)
+		if err != nil {
+			return fmt.Errorf("trigger creation failed: %w", err)
+		}
+
+		return c.cronScheduler.Schedule(ctx, trigger)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("trigger creation failed: %w", err)
+		return nil, err
 	}
 
 	return trigger, nil
@@ -101,9 +150,92 @@ func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 	if err := checkActions(in.Actions); err != nil {
 		return err
 	}
-	if err := check.Identifier(in.Identifier); err != nil { //nolint:revive
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	in.Cron = strings.TrimSpace(in.Cron)
+	in.CronBranch = strings.TrimSpace(in.CronBranch)
+	in.CronTimezone = strings.TrimSpace(in.CronTimezone)
+	in.UpstreamRepoRef = strings.TrimSpace(in.UpstreamRepoRef)
+	in.UpstreamPipeline = strings.TrimSpace(in.UpstreamPipeline)
+
+	if in.UpstreamPipeline != "" {
+		return sanitizeCreatePipelineTriggerInput(in)
+	}
+	if in.UpstreamRepoRef != "" || len(in.UpstreamStatuses) > 0 || len(in.Params) > 0 {
+		return check.NewValidationError(
+			"The upstream repo, upstream statuses and params can only be set on pipeline triggers.")
+	}
+
+	if in.Cron == "" {
+		if in.CronBranch != "" || in.CronTimezone != "" {
+			return check.NewValidationError("The cron branch and time zone can only be set on cron triggers.")
+		}
+		return nil
+	}
+
+	if len(in.Actions) > 0 {
+		return check.NewValidationError("A cron trigger can't fire on repository events.")
+	}
+	if err := checkCron(in.Cron); err != nil {
+		return err
+	}
+	if err := checkCronBranch(in.CronBranch); err != nil {
+		return err
+	}
+	if err := checkCronTimezone(in.CronTimezone); err != nil { //nolint:revive
 		return err
 	}
 
 	return nil
 }
+
+func sanitizeCreatePipelineTriggerInput(in *CreateInput) error {
+	if len(in.Actions) > 0 {
+		return check.NewValidationError("A pipeline trigger can't fire on repository events.")
+	}
+	if in.Cron != "" || in.CronBranch != "" || in.CronTimezone != "" {
+		return check.NewValidationError("A pipeline trigger can't fire on a cron schedule.")
+	}
+	if err := checkUpstreamStatuses(in.UpstreamStatuses); err != nil {
+		return err
+	}
+	if err := checkParams(in.Params); err != nil { //nolint:revive
+		return err
+	}
+
+	return nil
+}
+
+// findUpstreamPipeline returns the ID of the pipeline whose executions fire a pipeline trigger.
+// Users can only trigger their pipelines from pipelines they're allowed to see.
+func (c *Controller) findUpstreamPipeline(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	upstreamRepoRef string,
+	upstreamPipelineIdentifier string,
+) (int64, error) {
+	upstreamRepo := repo
+	if upstreamRepoRef != "" {
+		var err error
+		upstreamRepo, err = c.repoStore.FindByRef(ctx, upstreamRepoRef)
+		if err != nil {
+			return 0, fmt.Errorf("failed to find upstream repo by ref: %w", err)
+		}
+	}
+
+	err := apiauth.CheckPipeline(ctx, c.authorizer, session, upstreamRepo.Path, upstreamPipelineIdentifier,
+		enum.PermissionPipelineView)
+	if err != nil {
+		return 0, fmt.Errorf("failed to authorize upstream pipeline: %w", err)
+	}
+
+	upstreamPipeline, err := c.pipelineStore.FindByIdentifier(ctx, upstreamRepo.ID, upstreamPipelineIdentifier)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find upstream pipeline: %w", err)
+	}
+
+	return upstreamPipeline.ID, nil
+}
@@ -46,9 +46,17 @@ func (c *Controller) Delete(
 		return fmt.Errorf("failed to find pipeline: %w", err)
 	}
 
-	err = c.triggerStore.DeleteByIdentifier(ctx, pipeline.ID, triggerIdentifier)
+	trigger, err := c.triggerStore.FindByIdentifier(ctx, pipeline.ID, triggerIdentifier)
 	if err != nil {
-		return fmt.Errorf("could not delete trigger: %w", err)
+		return fmt.Errorf("failed to find trigger: %w", err)
 	}
-	return nil
+
+	return c.tx.WithTx(ctx, func(ctx context.Context) error {
+		err := c.triggerStore.DeleteByIdentifier(ctx, pipeline.ID, triggerIdentifier)
+		if err != nil {
+			return fmt.Errorf("could not delete trigger: %w", err)
+		}
+
+		return c.cronScheduler.Unschedule(ctx, trigger.ID)
+	})
 }
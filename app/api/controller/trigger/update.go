@@ -35,6 +35,13 @@ type UpdateInput struct {
 	Actions    []enum.TriggerAction `json:"actions"`
 	Secret     *string              `json:"secret"`
 	Disabled   *bool                `json:"disabled"` // can be nil, so keeping it a pointer
+
+	Cron         *string `json:"cron"`
+	CronBranch   *string `json:"cron_branch"`
+	CronTimezone *string `json:"cron_timezone"`
+
+	UpstreamStatuses []enum.CIStatus   `json:"upstream_statuses"`
+	Params           map[string]string `json:"params"`
 }
 
 func (c *Controller) Update(
@@ -70,26 +77,69 @@ func (c *Controller) Update(
 		return nil, fmt.Errorf("failed to find trigger: %w", err)
 	}
 
-	return c.triggerStore.UpdateOptLock(ctx,
-		trigger, func(original *types.Trigger) error {
-			if in.Identifier != nil {
-				original.Identifier = *in.Identifier
-			}
-			if in.Description != nil {
-				original.Description = *in.Description
-			}
-			if in.Actions != nil {
-				original.Actions = deduplicateActions(in.Actions)
-			}
-			if in.Secret != nil {
-				original.Secret = *in.Secret
-			}
-			if in.Disabled != nil {
-				original.Disabled = *in.Disabled
-			}
-
-			return nil
-		})
+	isCron := trigger.Type == enum.TriggerCron
+	if !isCron && (in.Cron != nil || in.CronBranch != nil || in.CronTimezone != nil) {
+		return nil, check.NewValidationError("The cron schedule can only be set on cron triggers.")
+	}
+	if isCron && len(in.Actions) > 0 {
+		return nil, check.NewValidationError("A cron trigger can't fire on repository events.")
+	}
+
+	isPipeline := trigger.Type == enum.TriggerPipeline
+	if !isPipeline && (in.UpstreamStatuses != nil || in.Params != nil) {
+		return nil, check.NewValidationError("The upstream statuses and params can only be set on pipeline triggers.")
+	}
+	if isPipeline && len(in.Actions) > 0 {
+		return nil, check.NewValidationError("A pipeline trigger can't fire on repository events.")
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		trigger, err = c.triggerStore.UpdateOptLock(ctx,
+			trigger, func(original *types.Trigger) error {
+				if in.Identifier != nil {
+					original.Identifier = *in.Identifier
+				}
+				if in.Description != nil {
+					original.Description = *in.Description
+				}
+				if in.Actions != nil {
+					original.Actions = deduplicateActions(in.Actions)
+				}
+				if in.Secret != nil {
+					original.Secret = *in.Secret
+				}
+				if in.Disabled != nil {
+					original.Disabled = *in.Disabled
+				}
+				if in.Cron != nil {
+					original.Cron = *in.Cron
+				}
+				if in.CronBranch != nil {
+					original.CronBranch = *in.CronBranch
+				}
+				if in.CronTimezone != nil {
+					original.CronTimezone = *in.CronTimezone
+				}
+				if in.UpstreamStatuses != nil {
+					original.UpstreamStatuses = deduplicateStatuses(in.UpstreamStatuses)
+				}
+				if in.Params != nil {
+					original.Params = in.Params
+				}
+
+				return nil
+			})
+		if err != nil {
+			return err
+		}
+
+		return c.cronScheduler.Schedule(ctx, trigger)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return trigger, nil
 }
 
 func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
@@ -123,5 +173,38 @@ func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
 		}
 	}
 
+	if in.Cron != nil {
+		*in.Cron = strings.TrimSpace(*in.Cron)
+		if err := checkCron(*in.Cron); err != nil {
+			return err
+		}
+	}
+
+	if in.CronBranch != nil {
+		*in.CronBranch = strings.TrimSpace(*in.CronBranch)
+		if err := checkCronBranch(*in.CronBranch); err != nil {
+			return err
+		}
+	}
+
+	if in.CronTimezone != nil {
+		*in.CronTimezone = strings.TrimSpace(*in.CronTimezone)
+		if err := checkCronTimezone(*in.CronTimezone); err != nil {
+			return err
+		}
+	}
+
+	if in.UpstreamStatuses != nil {
+		if err := checkUpstreamStatuses(in.UpstreamStatuses); err != nil {
+			return err
+		}
+	}
+
+	if in.Params != nil {
+		if err := checkParams(in.Params); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -16,7 +16,9 @@ package trigger
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
 
 	"github.com/google/wire"
 )
@@ -27,10 +29,12 @@ var WireSet = wire.NewSet(
 )
 
 func ProvideController(
+	tx dbtx.Transactor,
 	authorizer authz.Authorizer,
 	triggerStore store.TriggerStore,
 	pipelineStore store.PipelineStore,
 	repoStore store.RepoStore,
+	cronScheduler *trigger.CronScheduler,
 ) *Controller {
-	return NewController(authorizer, triggerStore, pipelineStore, repoStore)
+	return NewController(tx, authorizer, triggerStore, pipelineStore, repoStore, cronScheduler)
 }
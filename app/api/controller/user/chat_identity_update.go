@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const maxChatIdentifierLength = 256
+
+type UpdateChatIdentityInput struct {
+	Identifier string `json:"identifier"`
+}
+
+// UpdateChatIdentity links the user to its account in the chat application,
+// replacing the account that was linked before.
+func (c *Controller) UpdateChatIdentity(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	provider enum.ChatProvider,
+	in *UpdateChatIdentityInput,
+) (*types.ChatIdentity, error) {
+	user, err := c.principalStore.FindUserByUID(ctx, userUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user by uid: %w", err)
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	provider, err = sanitizeChatProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sanitizeUpdateChatIdentityInput(in); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	identity := &types.ChatIdentity{
+		PrincipalID: user.ID,
+		Provider:    provider,
+		Identifier:  in.Identifier,
+		Created:     now,
+		Updated:     now,
+	}
+
+	err = c.chatIdentityStore.Upsert(ctx, identity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert chat identity: %w", err)
+	}
+
+	return identity, nil
+}
+
+func sanitizeChatProvider(provider enum.ChatProvider) (enum.ChatProvider, error) {
+	provider, ok := provider.Sanitize()
+	if !ok || provider == "" {
+		return "", errors.InvalidArgument("invalid chat provider")
+	}
+
+	return provider, nil
+}
+
+func sanitizeUpdateChatIdentityInput(in *UpdateChatIdentityInput) error {
+	in.Identifier = strings.TrimSpace(in.Identifier)
+	if in.Identifier == "" {
+		return errors.InvalidArgument("chat identifier not provided")
+	}
+
+	if len(in.Identifier) > maxChatIdentifierLength {
+		return errors.InvalidArgument("chat identifier can't be longer than %d characters", maxChatIdentifierLength)
+	}
+
+	return nil
+}
@@ -54,6 +54,10 @@ func (c *Controller) CreatePublicKey(
 		return nil, err
 	}
 
+	if publickey.IsPGP(in.Content) {
+		return c.createPGPPublicKey(ctx, user, in)
+	}
+
 	key, comment, err := publickey.ParseString(in.Content)
 	if err != nil {
 		return nil, errors.InvalidArgument("could not parse public key")
@@ -71,6 +75,7 @@ func (c *Controller) CreatePublicKey(
 		Content:     in.Content,
 		Comment:     comment,
 		Type:        key.Type(),
+		Scheme:      enum.PublicKeySchemeSSH,
 	}
 
 	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
@@ -99,6 +104,59 @@ func (c *Controller) CreatePublicKey(
 	return k, nil
 }
 
+// createPGPPublicKey stores an OpenPGP public key. PGP keys can only be used for signature verification.
+func (c *Controller) createPGPPublicKey(
+	ctx context.Context,
+	user *types.User,
+	in *CreatePublicKeyInput,
+) (*types.PublicKey, error) {
+	if in.Usage != enum.PublicKeyUsageSign {
+		return nil, errors.InvalidArgument("PGP public keys can only be used for signing")
+	}
+
+	key, err := publickey.ParsePGP(in.Content)
+	if err != nil {
+		return nil, errors.InvalidArgument("could not parse PGP public key")
+	}
+
+	k := &types.PublicKey{
+		PrincipalID: user.ID,
+		Created:     time.Now().UnixMilli(),
+		Verified:    nil, // the key is created as unverified
+		Identifier:  in.Identifier,
+		Usage:       in.Usage,
+		Fingerprint: key.Fingerprint(),
+		Content:     in.Content,
+		Comment:     key.Comment(),
+		Type:        key.Type(),
+		Scheme:      enum.PublicKeySchemePGP,
+		SubKeyIDs:   key.KeyIDs(),
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		existingKeys, err := c.publicKeyStore.ListByFingerprint(ctx, k.Fingerprint)
+		if err != nil {
+			return fmt.Errorf("failed to read keys by fingerprint: %w", err)
+		}
+
+		if len(existingKeys) > 0 {
+			return errors.InvalidArgument("Key is already in use")
+		}
+
+		err = c.publicKeyStore.Create(ctx, k)
+		if err != nil {
+			return fmt.Errorf("failed to insert public key: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
 func sanitizeCreatePublicKeyInput(in *CreatePublicKeyInput) error {
 	if err := check.Identifier(in.Identifier); err != nil {
 		return err
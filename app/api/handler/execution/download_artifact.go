@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/execution"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+
+	"github.com/rs/zerolog/log"
+)
+
+// HandleDownloadArtifact writes the content of an artifact of the execution to the response body.
+func HandleDownloadArtifact(executionCtrl *execution.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+		pipelineIdentifier, err := request.GetPipelineIdentifierFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+		n, err := request.GetExecutionNumberFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+		name, err := request.GetArtifactNameFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		signedURL, file, err := executionCtrl.DownloadArtifact(ctx, session, repoRef, pipelineIdentifier, n, name)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+		if file != nil {
+			w.Header().Set("Content-Disposition", "attachment; filename="+name)
+			render.Reader(ctx, w, http.StatusOK, file)
+			err = file.Close()
+			if err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("failed to close file after rendering")
+			}
+			return
+		}
+		http.Redirect(
+			w,
+			r,
+			signedURL,
+			http.StatusTemporaryRedirect,
+		)
+	}
+}
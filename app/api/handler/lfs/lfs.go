@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/url"
+)
+
+// renderError renders the error of an LFS operation.
+// Same as for git operations, anonymous users are asked for credentials if they lack permissions.
+func renderError(
+	ctx context.Context,
+	w http.ResponseWriter,
+	urlProvider url.Provider,
+	session *auth.Session,
+	err error,
+) {
+	if errors.Is(err, apiauth.ErrNotAuthorized) && auth.IsAnonymousSession(session) {
+		w.Header().Add("WWW-Authenticate", fmt.Sprintf(`Basic realm="%s"`, urlProvider.GetAPIHostname(ctx)))
+		render.Unauthorized(ctx, w)
+		return
+	}
+
+	render.TranslatedUserError(ctx, w, err)
+}
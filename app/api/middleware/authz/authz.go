@@ -20,11 +20,34 @@ import (
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/jwt"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/log"
 )
 
+// BlockLFSToken blocks any request that uses a token issued for git lfs (via git-lfs-authenticate).
+// Such tokens are meant to be used exclusively with the git lfs API.
+func BlockLFSToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			if session, oks := request.AuthSessionFrom(ctx); oks {
+				if metadata, ok := session.Metadata.(*auth.AccessPermissionMetadata); ok &&
+					metadata.AccessPermissions.Source == jwt.LFSSource {
+					log.Ctx(ctx).Warn().Msg("blocking request - lfs tokens are only allowed for usage with the lfs API")
+
+					render.Unauthorized(ctx, w)
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		},
+	)
+}
+
 // BlockSessionToken blocks any request that uses a session token for authentication.
 // NOTE: Major use case as of now is blocking usage of session tokens with git.
 func BlockSessionToken(next http.Handler) http.Handler {
@@ -62,6 +62,7 @@ func processGitRequest(r *http.Request) (bool, error) {
 	const receivePack = "git-receive-pack"
 	const receivePackPath = "/" + receivePack
 	const serviceParam = "service"
+	const lfsPath = "/info/lfs"
 
 	allowedServices := []string{
 		uploadPack,
@@ -94,6 +95,11 @@ func processGitRequest(r *http.Request) (bool, error) {
 		}
 	}
 
+	// git lfs API (in case the lfs url was configured without the .git suffix)
+	if strings.Contains(urlPath, lfsPath+"/") {
+		return pathTerminatedWithMarkerAndURL(r, "", lfsPath, lfsPath, urlPath)
+	}
+
 	// no other APIs are called by git - just treat it as a full repo path.
 	return pathTerminatedWithMarkerAndURL(r, "", "", "", urlPath)
 }
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/runner"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+)
+
+/*
+ * Authenticate returns an http.HandlerFunc middleware that authenticates the remote runner
+ * using the token from the authorization header. The runner is stored in the request context,
+ * in case the token is missing or invalid, an error is rendered.
+ */
+func Authenticate(runnerCtrl *runner.Controller) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			rnr, err := runnerCtrl.Authenticate(ctx, request.GetRunnerToken(r))
+			if err != nil {
+				render.TranslatedUserError(ctx, w, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(request.WithRunner(ctx, rnr)))
+		})
+	}
+}
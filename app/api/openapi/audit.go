@@ -0,0 +1,153 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+var queryParameterAuditResourceType = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamResourceType,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("List of resource types of the audit events to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
+var queryParameterAuditAction = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAction,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("List of actions of the audit events to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
+var queryParameterAuditPrincipalID = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamPrincipalID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("List of IDs of the principals who performed the audited actions."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
+var queryParameterAuditExportFormat = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamFormat,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The file format of the export."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeString),
+				Default: ptrptr(string(enum.AuditExportFormatJSON)),
+				Enum:    enum.AuditExportFormat("").Enum(),
+			},
+		},
+	},
+}
+
+func auditOperations(reflector *openapi3.Reflector) {
+	const tag = "audit"
+
+	listAuditEventsSpace := openapi3.Operation{}
+	listAuditEventsSpace.WithTags(tag)
+	listAuditEventsSpace.WithParameters(
+		QueryParameterPage, QueryParameterLimit, queryParameterAuditResourceType, queryParameterAuditAction,
+		queryParameterAuditPrincipalID, queryParameterCreatedLt, queryParameterCreatedGt)
+	listAuditEventsSpace.WithMapOfAnything(map[string]interface{}{"operationId": "listAuditEventsSpace"})
+	_ = reflector.SetRequest(&listAuditEventsSpace, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listAuditEventsSpace, new([]types.AuditEvent), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listAuditEventsSpace, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listAuditEventsSpace, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listAuditEventsSpace, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listAuditEventsSpace, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/audit-logs", listAuditEventsSpace)
+
+	listAuditEventsRepo := openapi3.Operation{}
+	listAuditEventsRepo.WithTags(tag)
+	listAuditEventsRepo.WithParameters(
+		QueryParameterPage, QueryParameterLimit, queryParameterAuditResourceType, queryParameterAuditAction,
+		queryParameterAuditPrincipalID, queryParameterCreatedLt, queryParameterCreatedGt)
+	listAuditEventsRepo.WithMapOfAnything(map[string]interface{}{"operationId": "listAuditEventsRepo"})
+	_ = reflector.SetRequest(&listAuditEventsRepo, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&listAuditEventsRepo, new([]types.AuditEvent), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listAuditEventsRepo, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listAuditEventsRepo, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listAuditEventsRepo, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listAuditEventsRepo, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/audit-logs", listAuditEventsRepo)
+
+	exportAuditEvents := openapi3.Operation{}
+	exportAuditEvents.WithTags("admin")
+	exportAuditEvents.WithParameters(
+		queryParameterAuditExportFormat, queryParameterAuditResourceType, queryParameterAuditAction,
+		queryParameterAuditPrincipalID, queryParameterCreatedLt, queryParameterCreatedGt)
+	exportAuditEvents.WithMapOfAnything(map[string]interface{}{"operationId": "adminExportAuditEvents"})
+	_ = reflector.SetRequest(&exportAuditEvents, nil, http.MethodGet)
+	_ = reflector.SetStringResponse(&exportAuditEvents, http.StatusOK, "application/x-ndjson")
+	_ = reflector.SetStringResponse(&exportAuditEvents, http.StatusOK, "text/csv")
+	_ = reflector.SetJSONResponse(&exportAuditEvents, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&exportAuditEvents, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&exportAuditEvents, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&exportAuditEvents, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/audit-logs/export", exportAuditEvents)
+}
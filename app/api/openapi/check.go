@@ -105,6 +105,21 @@ func checkOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/checks/commits/{commit_sha}",
 		listStatusCheckResults)
 
+	listStatusCheckAnnotations := openapi3.Operation{}
+	listStatusCheckAnnotations.WithTags(tag)
+	listStatusCheckAnnotations.WithMapOfAnything(map[string]interface{}{"operationId": "listStatusCheckAnnotations"})
+	_ = reflector.SetRequest(&listStatusCheckAnnotations, struct {
+		repoRequest
+		CommitSHA string `path:"commit_sha"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&listStatusCheckAnnotations, new([]types.CheckAnnotation), http.StatusOK)
+	_ = reflector.SetJSONResponse(&listStatusCheckAnnotations, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&listStatusCheckAnnotations, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&listStatusCheckAnnotations, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&listStatusCheckAnnotations, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/checks/commits/{commit_sha}/annotations",
+		listStatusCheckAnnotations)
+
 	listStatusCheckRecent := openapi3.Operation{}
 	listStatusCheckRecent.WithTags(tag)
 	listStatusCheckRecent.WithParameters(
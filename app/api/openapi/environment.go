@@ -0,0 +1,172 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/environment"
+	"github.com/harness/gitness/app/api/controller/execution"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type environmentRequest struct {
+	spaceRequest
+	Identifier string `path:"environment_identifier"`
+}
+
+type createEnvironmentRequest struct {
+	spaceRequest
+	environment.CreateInput
+}
+
+type updateEnvironmentRequest struct {
+	environmentRequest
+	environment.UpdateInput
+}
+
+type stageDecisionRequest struct {
+	executionRequest
+	StageNumber string `path:"stage_number"`
+	execution.StageDecisionInput
+}
+
+var queryParameterQueryEnvironment = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring which is used to filter the environments by their identifier."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+// environmentOperations constructs the openapi specification for the deployment environment
+// and stage approval operations.
+//
+//nolint:funlen
+func environmentOperations(reflector *openapi3.Reflector) {
+	opList := openapi3.Operation{}
+	opList.WithTags("environment")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "listEnvironments"})
+	opList.WithParameters(queryParameterQueryEnvironment, QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opList, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, []types.Environment{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/environments", opList)
+
+	opCreate := openapi3.Operation{}
+	opCreate.WithTags("environment")
+	opCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createEnvironment"})
+	_ = reflector.SetRequest(&opCreate, new(createEnvironmentRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreate, new(types.Environment), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/environments", opCreate)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("environment")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "findEnvironment"})
+	_ = reflector.SetRequest(&opFind, new(environmentRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.Environment), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/spaces/{space_ref}/environments/{environment_identifier}", opFind)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("environment")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateEnvironment"})
+	_ = reflector.SetRequest(&opUpdate, new(updateEnvironmentRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.Environment), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/spaces/{space_ref}/environments/{environment_identifier}", opUpdate)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("environment")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteEnvironment"})
+	_ = reflector.SetRequest(&opDelete, new(environmentRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/spaces/{space_ref}/environments/{environment_identifier}", opDelete)
+
+	opListApprovals := openapi3.Operation{}
+	opListApprovals.WithTags("pipeline")
+	opListApprovals.WithMapOfAnything(map[string]interface{}{"operationId": "listExecutionApprovals"})
+	_ = reflector.SetRequest(&opListApprovals, new(executionRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListApprovals, []types.StageApproval{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListApprovals, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListApprovals, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListApprovals, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListApprovals, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/approvals", opListApprovals)
+
+	opApprove := openapi3.Operation{}
+	opApprove.WithTags("pipeline")
+	opApprove.WithMapOfAnything(map[string]interface{}{"operationId": "approveExecutionStage"})
+	_ = reflector.SetRequest(&opApprove, new(stageDecisionRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opApprove, new(types.StageApproval), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opApprove, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opApprove, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opApprove, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opApprove, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opApprove, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opApprove, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/stages/{stage_number}/approve",
+		opApprove)
+
+	opReject := openapi3.Operation{}
+	opReject.WithTags("pipeline")
+	opReject.WithMapOfAnything(map[string]interface{}{"operationId": "rejectExecutionStage"})
+	_ = reflector.SetRequest(&opReject, new(stageDecisionRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opReject, new(types.StageApproval), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opReject, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opReject, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opReject, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opReject, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opReject, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opReject, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/stages/{stage_number}/reject",
+		opReject)
+}
@@ -63,6 +63,9 @@ func (*OpenAPI) Generate() *openapi3.Spec {
 	repoOperations(&reflector)
 	rulesOperations(&reflector)
 	pipelineOperations(&reflector)
+	pipelineStorageOperations(&reflector)
+	testReportOperations(&reflector)
+	environmentOperations(&reflector)
 	connectorOperations(&reflector)
 	templateOperations(&reflector)
 	secretOperations(&reflector)
@@ -70,6 +73,8 @@ func (*OpenAPI) Generate() *openapi3.Spec {
 	pullReqOperations(&reflector)
 	webhookOperations(&reflector)
 	checkOperations(&reflector)
+	auditOperations(&reflector)
+	runnerOperations(&reflector)
 	uploadOperations(&reflector)
 	gitspaceOperations(&reflector)
 	infraProviderOperations(&reflector)
@@ -241,6 +241,19 @@ func pipelineOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/cancel", executionCancel)
 
+	executionRetry := openapi3.Operation{}
+	executionRetry.WithTags("pipeline")
+	executionRetry.WithMapOfAnything(map[string]interface{}{"operationId": "retryExecution"})
+	_ = reflector.SetRequest(&executionRetry, new(getExecutionRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&executionRetry, new(types.Execution), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/retry", executionRetry)
+
 	executionDelete := openapi3.Operation{}
 	executionDelete.WithTags("pipeline")
 	executionDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteExecution"})
@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type pipelineCacheRequest struct {
+	repoRequest
+	Key string `path:"cache_key"`
+}
+
+type pipelineCacheSaveRequest struct {
+	pipelineCacheRequest
+	Content string `json:"-" format:"binary" description:"Cache archive to store"`
+}
+
+type artifactRequest struct {
+	executionRequest
+	Name string `path:"artifact_name"`
+}
+
+type artifactUploadRequest struct {
+	artifactRequest
+	Content string `json:"-" format:"binary" description:"Artifact file to upload"`
+}
+
+// pipelineStorageOperations constructs the openapi specification for the pipeline cache
+// and pipeline artifact operations.
+//
+//nolint:funlen
+func pipelineStorageOperations(reflector *openapi3.Reflector) {
+	cacheList := openapi3.Operation{}
+	cacheList.WithTags("pipeline")
+	cacheList.WithMapOfAnything(map[string]interface{}{"operationId": "listPipelineCaches"})
+	cacheList.WithParameters(queryParameterQueryRepo, QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&cacheList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&cacheList, []types.PipelineCache{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&cacheList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&cacheList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&cacheList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&cacheList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pipeline-caches", cacheList)
+
+	cacheSave := openapi3.Operation{}
+	cacheSave.WithTags("pipeline")
+	cacheSave.WithMapOfAnything(map[string]interface{}{"operationId": "savePipelineCache"})
+	_ = reflector.SetRequest(&cacheSave, new(pipelineCacheSaveRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&cacheSave, new(types.PipelineCache), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&cacheSave, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&cacheSave, new(usererror.Error), http.StatusRequestEntityTooLarge)
+	_ = reflector.SetJSONResponse(&cacheSave, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&cacheSave, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&cacheSave, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&cacheSave, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/pipeline-caches/{cache_key}", cacheSave)
+
+	cacheRestore := openapi3.Operation{}
+	cacheRestore.WithTags("pipeline")
+	cacheRestore.WithMapOfAnything(map[string]interface{}{"operationId": "restorePipelineCache"})
+	_ = reflector.SetRequest(&cacheRestore, new(pipelineCacheRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&cacheRestore, nil, http.StatusTemporaryRedirect)
+	_ = reflector.SetJSONResponse(&cacheRestore, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&cacheRestore, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&cacheRestore, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&cacheRestore, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&cacheRestore, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pipeline-caches/{cache_key}", cacheRestore)
+
+	cacheDelete := openapi3.Operation{}
+	cacheDelete.WithTags("pipeline")
+	cacheDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deletePipelineCache"})
+	_ = reflector.SetRequest(&cacheDelete, new(pipelineCacheRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&cacheDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&cacheDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&cacheDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&cacheDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&cacheDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/pipeline-caches/{cache_key}", cacheDelete)
+
+	artifactList := openapi3.Operation{}
+	artifactList.WithTags("pipeline")
+	artifactList.WithMapOfAnything(map[string]interface{}{"operationId": "listExecutionArtifacts"})
+	_ = reflector.SetRequest(&artifactList, new(executionRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&artifactList, []types.PipelineArtifact{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/artifacts", artifactList)
+
+	artifactUpload := openapi3.Operation{}
+	artifactUpload.WithTags("pipeline")
+	artifactUpload.WithMapOfAnything(map[string]interface{}{"operationId": "uploadExecutionArtifact"})
+	_ = reflector.SetRequest(&artifactUpload, new(artifactUploadRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(types.PipelineArtifact), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusRequestEntityTooLarge)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/artifacts/{artifact_name}",
+		artifactUpload)
+
+	artifactDownload := openapi3.Operation{}
+	artifactDownload.WithTags("pipeline")
+	artifactDownload.WithMapOfAnything(map[string]interface{}{"operationId": "downloadExecutionArtifact"})
+	_ = reflector.SetRequest(&artifactDownload, new(artifactRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&artifactDownload, nil, http.StatusTemporaryRedirect)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/artifacts/{artifact_name}",
+		artifactDownload)
+}
@@ -72,6 +72,26 @@ type mergePullReq struct {
 	pullreq.MergeInput
 }
 
+type mergeQueueAddPullReq struct {
+	pullReqRequest
+	pullreq.MergeQueueAddInput
+}
+
+type autoMergeEnablePullReq struct {
+	pullReqRequest
+	pullreq.AutoMergeEnableInput
+}
+
+type dependencyAddPullReqRequest struct {
+	pullReqRequest
+	pullreq.DependencyAddInput
+}
+
+type dependencyDeletePullReqRequest struct {
+	pullReqRequest
+	DependsOnNumber int64 `path:"pullreq_depends_on_number"`
+}
+
 type commentCreatePullReqRequest struct {
 	pullReqRequest
 	pullreq.CommentCreateInput
@@ -702,6 +722,131 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge", mergePullReqOp)
 
+	opMergeQueueAdd := openapi3.Operation{}
+	opMergeQueueAdd.WithTags("pullreq")
+	opMergeQueueAdd.WithMapOfAnything(map[string]interface{}{"operationId": "mergeQueueAddPullReq"})
+	_ = reflector.SetRequest(&opMergeQueueAdd, new(mergeQueueAddPullReq), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMergeQueueAdd, new(types.MergeQueueEntry), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opMergeQueueAdd, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMergeQueueAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMergeQueueAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMergeQueueAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opMergeQueueAdd, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opMergeQueueAdd, new(types.MergeViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-queue", opMergeQueueAdd)
+
+	opMergeQueueRemove := openapi3.Operation{}
+	opMergeQueueRemove.WithTags("pullreq")
+	opMergeQueueRemove.WithMapOfAnything(map[string]interface{}{"operationId": "mergeQueueRemovePullReq"})
+	_ = reflector.SetRequest(&opMergeQueueRemove, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opMergeQueueRemove, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opMergeQueueRemove, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMergeQueueRemove, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMergeQueueRemove, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMergeQueueRemove, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-queue", opMergeQueueRemove)
+
+	opMergeQueueList := openapi3.Operation{}
+	opMergeQueueList.WithTags("pullreq")
+	opMergeQueueList.WithMapOfAnything(map[string]interface{}{"operationId": "mergeQueueList"})
+	opMergeQueueList.WithParameters(queryParameterTargetBranchPullRequest)
+	_ = reflector.SetRequest(&opMergeQueueList, new(listPullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opMergeQueueList, []types.MergeQueueEntry{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMergeQueueList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMergeQueueList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMergeQueueList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMergeQueueList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq/merge-queue", opMergeQueueList)
+
+	opTemplateList := openapi3.Operation{}
+	opTemplateList.WithTags("pullreq")
+	opTemplateList.WithMapOfAnything(map[string]interface{}{"operationId": "pullReqTemplateList"})
+	opTemplateList.WithParameters(queryParameterTargetBranchPullRequest)
+	_ = reflector.SetRequest(&opTemplateList, new(listPullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opTemplateList, []types.PullReqTemplate{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opTemplateList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opTemplateList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opTemplateList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTemplateList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq/templates", opTemplateList)
+
+	opAutoMergeFind := openapi3.Operation{}
+	opAutoMergeFind.WithTags("pullreq")
+	opAutoMergeFind.WithMapOfAnything(map[string]interface{}{"operationId": "autoMergeFindPullReq"})
+	_ = reflector.SetRequest(&opAutoMergeFind, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opAutoMergeFind, new(types.PullReqAutoMerge), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAutoMergeFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAutoMergeFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAutoMergeFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAutoMergeFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/auto-merge", opAutoMergeFind)
+
+	opAutoMergeEnable := openapi3.Operation{}
+	opAutoMergeEnable.WithTags("pullreq")
+	opAutoMergeEnable.WithMapOfAnything(map[string]interface{}{"operationId": "autoMergeEnablePullReq"})
+	_ = reflector.SetRequest(&opAutoMergeEnable, new(autoMergeEnablePullReq), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(types.PullReqAutoMerge), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAutoMergeEnable, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/auto-merge", opAutoMergeEnable)
+
+	opAutoMergeDisable := openapi3.Operation{}
+	opAutoMergeDisable.WithTags("pullreq")
+	opAutoMergeDisable.WithMapOfAnything(map[string]interface{}{"operationId": "autoMergeDisablePullReq"})
+	_ = reflector.SetRequest(&opAutoMergeDisable, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opAutoMergeDisable, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opAutoMergeDisable, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAutoMergeDisable, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAutoMergeDisable, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAutoMergeDisable, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/auto-merge", opAutoMergeDisable)
+
+	opDependencyList := openapi3.Operation{}
+	opDependencyList.WithTags("pullreq")
+	opDependencyList.WithMapOfAnything(map[string]interface{}{"operationId": "dependencyListPullReq"})
+	_ = reflector.SetRequest(&opDependencyList, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opDependencyList, new(types.PullReqDependencies), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opDependencyList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDependencyList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDependencyList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDependencyList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/dependencies", opDependencyList)
+
+	opDependencyAdd := openapi3.Operation{}
+	opDependencyAdd.WithTags("pullreq")
+	opDependencyAdd.WithMapOfAnything(map[string]interface{}{"operationId": "dependencyAddPullReq"})
+	_ = reflector.SetRequest(&opDependencyAdd, new(dependencyAddPullReqRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opDependencyAdd, new(types.PullReqDependencies), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opDependencyAdd, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDependencyAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDependencyAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDependencyAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDependencyAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDependencyAdd, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/dependencies", opDependencyAdd)
+
+	opDependencyDelete := openapi3.Operation{}
+	opDependencyDelete.WithTags("pullreq")
+	opDependencyDelete.WithMapOfAnything(map[string]interface{}{"operationId": "dependencyDeletePullReq"})
+	_ = reflector.SetRequest(&opDependencyDelete, new(dependencyDeletePullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDependencyDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDependencyDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDependencyDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDependencyDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDependencyDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/dependencies/{pullreq_depends_on_number}", opDependencyDelete)
+
 	opListCommits := openapi3.Operation{}
 	opListCommits.WithTags("pullreq")
 	opListCommits.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReqCommits"})
@@ -840,6 +985,18 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	panicOnErr(reflector.SetJSONResponse(&opChecks, new(usererror.Error), http.StatusNotFound))
 	panicOnErr(reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq/{pullreq_number}/checks", opChecks))
 
+	opCheckAnnotations := openapi3.Operation{}
+	opCheckAnnotations.WithTags("pullreq")
+	opCheckAnnotations.WithMapOfAnything(map[string]interface{}{"operationId": "checkAnnotationsPullReq"})
+	_ = reflector.SetRequest(&opCheckAnnotations, new(getPullReqChecksRequest), http.MethodGet)
+	panicOnErr(reflector.SetJSONResponse(&opCheckAnnotations, new([]types.PullReqCheckAnnotation), http.StatusOK))
+	panicOnErr(reflector.SetJSONResponse(&opCheckAnnotations, new(usererror.Error), http.StatusInternalServerError))
+	panicOnErr(reflector.SetJSONResponse(&opCheckAnnotations, new(usererror.Error), http.StatusUnauthorized))
+	panicOnErr(reflector.SetJSONResponse(&opCheckAnnotations, new(usererror.Error), http.StatusForbidden))
+	panicOnErr(reflector.SetJSONResponse(&opCheckAnnotations, new(usererror.Error), http.StatusNotFound))
+	panicOnErr(reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/checks/annotations", opCheckAnnotations))
+
 	opAssignLabel := openapi3.Operation{}
 	opAssignLabel.WithTags("pullreq")
 	opAssignLabel.WithMapOfAnything(map[string]interface{}{"operationId": "assignLabel"})
@@ -0,0 +1,210 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/runner"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/drone/drone-go/drone"
+	"github.com/drone/runner-go/client"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type (
+	// adminRunnerRegisterRequest is the request for registering a remote runner.
+	adminRunnerRegisterRequest struct {
+		runner.RegisterInput
+	}
+
+	// adminRunnerRequest is the request for runner specific admin operations.
+	adminRunnerRequest struct {
+		Identifier string `path:"runner_identifier"`
+	}
+
+	// adminRunnerListRequest is the request for listing remote runners.
+	adminRunnerListRequest struct {
+		Query string `query:"query"`
+
+		// include pagination request
+		paginationRequest
+	}
+
+	runnerStageRequest struct {
+		StageID int64 `path:"stage_id"`
+	}
+
+	runnerStageUpdateRequest struct {
+		runnerStageRequest
+		drone.Stage
+	}
+
+	runnerStepRequest struct {
+		StepID int64 `path:"step_id"`
+	}
+
+	runnerStepUpdateRequest struct {
+		runnerStepRequest
+		drone.Step
+	}
+
+	runnerStepLogsRequest struct {
+		runnerStepRequest
+		runner.LogsInput
+	}
+
+	runnerWatchRequest struct {
+		ExecutionID int64 `path:"execution_id"`
+	}
+
+	runnerWatchResponse struct {
+		Canceled bool `json:"canceled"`
+	}
+)
+
+// runnerOperations constructs the openapi specification for the remote runner
+// administration and the protocol used by the remote runners.
+func runnerOperations(reflector *openapi3.Reflector) {
+	opRegister := openapi3.Operation{}
+	opRegister.WithTags("admin")
+	opRegister.WithMapOfAnything(map[string]interface{}{"operationId": "adminRegisterRunner"})
+	_ = reflector.SetRequest(&opRegister, new(adminRunnerRegisterRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRegister, new(runner.RegisterOutput), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opRegister, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRegister, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRegister, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRegister, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/runners", opRegister)
+
+	opList := openapi3.Operation{}
+	opList.WithTags("admin")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "adminListRunners"})
+	_ = reflector.SetRequest(&opList, new(adminRunnerListRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]*types.Runner), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/runners", opList)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("admin")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "adminGetRunner"})
+	_ = reflector.SetRequest(&opFind, new(adminRunnerRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.Runner), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/runners/{runner_identifier}", opFind)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("admin")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "adminDeleteRunner"})
+	_ = reflector.SetRequest(&opDelete, new(adminRunnerRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/runners/{runner_identifier}", opDelete)
+
+	opRequest := openapi3.Operation{}
+	opRequest.WithTags("runner")
+	opRequest.WithMapOfAnything(map[string]interface{}{"operationId": "runnerRequestStage"})
+	_ = reflector.SetRequest(&opRequest, new(client.Filter), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRequest, new(drone.Stage), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRequest, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opRequest, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRequest, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/runner/request", opRequest)
+
+	opAccept := openapi3.Operation{}
+	opAccept.WithTags("runner")
+	opAccept.WithMapOfAnything(map[string]interface{}{"operationId": "runnerAcceptStage"})
+	_ = reflector.SetRequest(&opAccept, new(runnerStageRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opAccept, new(drone.Stage), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAccept, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAccept, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAccept, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opAccept, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/runner/stages/{stage_id}/accept", opAccept)
+
+	opDetails := openapi3.Operation{}
+	opDetails.WithTags("runner")
+	opDetails.WithMapOfAnything(map[string]interface{}{"operationId": "runnerGetStageDetails"})
+	_ = reflector.SetRequest(&opDetails, new(runnerStageRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opDetails, new(client.Context), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opDetails, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDetails, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDetails, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDetails, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/runner/stages/{stage_id}/details", opDetails)
+
+	opUpdateStage := openapi3.Operation{}
+	opUpdateStage.WithTags("runner")
+	opUpdateStage.WithMapOfAnything(map[string]interface{}{"operationId": "runnerUpdateStage"})
+	_ = reflector.SetRequest(&opUpdateStage, new(runnerStageUpdateRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpdateStage, new(drone.Stage), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateStage, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateStage, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateStage, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateStage, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdateStage, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/runner/stages/{stage_id}", opUpdateStage)
+
+	opUpdateStep := openapi3.Operation{}
+	opUpdateStep.WithTags("runner")
+	opUpdateStep.WithMapOfAnything(map[string]interface{}{"operationId": "runnerUpdateStep"})
+	_ = reflector.SetRequest(&opUpdateStep, new(runnerStepUpdateRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpdateStep, new(drone.Step), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateStep, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateStep, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateStep, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateStep, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdateStep, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/runner/steps/{step_id}", opUpdateStep)
+
+	opWriteLogs := openapi3.Operation{}
+	opWriteLogs.WithTags("runner")
+	opWriteLogs.WithMapOfAnything(map[string]interface{}{"operationId": "runnerWriteStepLogs"})
+	_ = reflector.SetRequest(&opWriteLogs, new(runnerStepLogsRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opWriteLogs, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opWriteLogs, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opWriteLogs, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opWriteLogs, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opWriteLogs, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/runner/steps/{step_id}/logs", opWriteLogs)
+
+	opUploadLogs := openapi3.Operation{}
+	opUploadLogs.WithTags("runner")
+	opUploadLogs.WithMapOfAnything(map[string]interface{}{"operationId": "runnerUploadStepLogs"})
+	_ = reflector.SetRequest(&opUploadLogs, new(runnerStepLogsRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opUploadLogs, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opUploadLogs, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUploadLogs, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUploadLogs, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUploadLogs, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/runner/steps/{step_id}/logs/upload", opUploadLogs)
+
+	opWatch := openapi3.Operation{}
+	opWatch.WithTags("runner")
+	opWatch.WithMapOfAnything(map[string]interface{}{"operationId": "runnerWatchExecution"})
+	_ = reflector.SetRequest(&opWatch, new(runnerWatchRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opWatch, new(runnerWatchResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opWatch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opWatch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opWatch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/runner/executions/{execution_id}/watch", opWatch)
+}
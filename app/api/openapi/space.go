@@ -45,6 +45,11 @@ type updateSpacePublicAccessRequest struct {
 	spaceRequest
 	space.UpdatePublicAccessInput
 }
+type notificationChannelsSpaceRequest struct {
+	spaceRequest
+	space.NotificationChannels
+}
+
 type moveSpaceRequest struct {
 	spaceRequest
 	space.MoveInput
@@ -297,6 +302,34 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(
 		http.MethodPost, "/spaces/{space_ref}/public-access", opUpdatePublicAccess)
 
+	opNotificationChannelsFind := openapi3.Operation{}
+	opNotificationChannelsFind.WithTags("space")
+	opNotificationChannelsFind.WithMapOfAnything(
+		map[string]interface{}{"operationId": "findSpaceNotificationChannels"})
+	_ = reflector.SetRequest(&opNotificationChannelsFind, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opNotificationChannelsFind, new(space.NotificationChannels), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opNotificationChannelsFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opNotificationChannelsFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opNotificationChannelsFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opNotificationChannelsFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/spaces/{space_ref}/notification-channels", opNotificationChannelsFind)
+
+	opNotificationChannelsUpdate := openapi3.Operation{}
+	opNotificationChannelsUpdate.WithTags("space")
+	opNotificationChannelsUpdate.WithMapOfAnything(
+		map[string]interface{}{"operationId": "updateSpaceNotificationChannels"})
+	_ = reflector.SetRequest(
+		&opNotificationChannelsUpdate, new(notificationChannelsSpaceRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opNotificationChannelsUpdate, new(space.NotificationChannels), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opNotificationChannelsUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opNotificationChannelsUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opNotificationChannelsUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opNotificationChannelsUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opNotificationChannelsUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/spaces/{space_ref}/notification-channels", opNotificationChannelsUpdate)
+
 	opDelete := openapi3.Operation{}
 	opDelete.WithTags("space")
 	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSpace"})
@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type testReportUploadRequest struct {
+	executionRequest
+	Format  enum.TestReportFormat `query:"format"`
+	Content string                `json:"-" format:"binary" description:"Test report to upload"`
+}
+
+type testCaseListRequest struct {
+	executionRequest
+	Status enum.TestStatus `query:"status"`
+}
+
+type testCaseHistoryRequest struct {
+	pipelineRequest
+	Suite string `query:"suite"`
+	Name  string `query:"name" required:"true"`
+}
+
+// testReportOperations constructs the openapi specification for the test report operations.
+func testReportOperations(reflector *openapi3.Reflector) {
+	testReportUpload := openapi3.Operation{}
+	testReportUpload.WithTags("pipeline")
+	testReportUpload.WithMapOfAnything(map[string]interface{}{"operationId": "uploadExecutionTestReport"})
+	_ = reflector.SetRequest(&testReportUpload, new(testReportUploadRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&testReportUpload, new(types.TestSummary), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&testReportUpload, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&testReportUpload, new(usererror.Error), http.StatusRequestEntityTooLarge)
+	_ = reflector.SetJSONResponse(&testReportUpload, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&testReportUpload, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&testReportUpload, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&testReportUpload, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/test-reports",
+		testReportUpload)
+
+	testSummary := openapi3.Operation{}
+	testSummary.WithTags("pipeline")
+	testSummary.WithMapOfAnything(map[string]interface{}{"operationId": "findExecutionTestSummary"})
+	_ = reflector.SetRequest(&testSummary, new(executionRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&testSummary, new(types.TestSummary), http.StatusOK)
+	_ = reflector.SetJSONResponse(&testSummary, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&testSummary, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&testSummary, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&testSummary, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/tests/summary",
+		testSummary)
+
+	testList := openapi3.Operation{}
+	testList.WithTags("pipeline")
+	testList.WithMapOfAnything(map[string]interface{}{"operationId": "listExecutionTests"})
+	testList.WithParameters(QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&testList, new(testCaseListRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&testList, []types.TestCase{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&testList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&testList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&testList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&testList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&testList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/tests",
+		testList)
+
+	testHistory := openapi3.Operation{}
+	testHistory.WithTags("pipeline")
+	testHistory.WithMapOfAnything(map[string]interface{}{"operationId": "listPipelineTestHistory"})
+	testHistory.WithParameters(QueryParameterLimit)
+	_ = reflector.SetRequest(&testHistory, new(testCaseHistoryRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&testHistory, []types.TestCaseRun{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&testHistory, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&testHistory, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&testHistory, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&testHistory, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&testHistory, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/tests/history", testHistory)
+}
@@ -153,6 +153,37 @@ func buildUser(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opKeyList, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/keys", opKeyList)
 
+	opChatIdentityList := openapi3.Operation{}
+	opChatIdentityList.WithTags("user")
+	opChatIdentityList.WithMapOfAnything(map[string]interface{}{"operationId": "listChatIdentities"})
+	_ = reflector.SetRequest(&opChatIdentityList, struct{}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opChatIdentityList, new([]types.ChatIdentity), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opChatIdentityList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/chat-identities", opChatIdentityList)
+
+	opChatIdentityUpdate := openapi3.Operation{}
+	opChatIdentityUpdate.WithTags("user")
+	opChatIdentityUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateChatIdentity"})
+	_ = reflector.SetRequest(&opChatIdentityUpdate, struct {
+		user.UpdateChatIdentityInput
+		Provider enum.ChatProvider `path:"chat_provider"`
+	}{}, http.MethodPut)
+	_ = reflector.SetJSONResponse(&opChatIdentityUpdate, new(types.ChatIdentity), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opChatIdentityUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opChatIdentityUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/user/chat-identities/{chat_provider}", opChatIdentityUpdate)
+
+	opChatIdentityDelete := openapi3.Operation{}
+	opChatIdentityDelete.WithTags("user")
+	opChatIdentityDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteChatIdentity"})
+	_ = reflector.SetRequest(&opChatIdentityDelete, struct {
+		Provider enum.ChatProvider `path:"chat_provider"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opChatIdentityDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opChatIdentityDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opChatIdentityDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/chat-identities/{chat_provider}", opChatIdentityDelete)
+
 	opListTokens := openapi3.Operation{}
 	opListTokens.WithTags("user")
 	opListTokens.WithMapOfAnything(map[string]interface{}{"operationId": "listTokens"})
@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	QueryParamResourceType = "resource_type"
+	QueryParamAction       = "action"
+	QueryParamPrincipalID  = "principal_id"
+	QueryParamFormat       = "format"
+)
+
+// ParseAuditEventFilter extracts the audit event query parameters for listing from the url.
+func ParseAuditEventFilter(r *http.Request) (*types.AuditEventFilter, error) {
+	principalIDs, err := QueryParamListAsPositiveInt64(r, QueryParamPrincipalID)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error parsing principal id filter: %w", err)
+	}
+
+	createdFilter, err := ParseCreated(r)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error parsing audit event created filter: %w", err)
+	}
+
+	resourceTypes, _ := QueryParamList(r, QueryParamResourceType)
+	actions, _ := QueryParamList(r, QueryParamAction)
+
+	return &types.AuditEventFilter{
+		Pagination: types.Pagination{
+			Page: ParsePage(r),
+			Size: ParseLimit(r),
+		},
+		CreatedFilter: createdFilter,
+		ResourceTypes: resourceTypes,
+		Actions:       actions,
+		PrincipalIDs:  principalIDs,
+	}, nil
+}
+
+// ParseAuditExportFormat extracts the audit event export format from the url.
+func ParseAuditExportFormat(r *http.Request) (enum.AuditExportFormat, error) {
+	format, ok := enum.AuditExportFormat(r.URL.Query().Get(QueryParamFormat)).Sanitize()
+	if !ok {
+		return "", usererror.BadRequest("Invalid audit export format.")
+	}
+
+	return format, nil
+}
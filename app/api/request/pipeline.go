@@ -17,7 +17,9 @@ package request
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 const (
@@ -26,9 +28,14 @@ const (
 	PathParamStageNumber        = "stage_number"
 	PathParamStepNumber         = "step_number"
 	PathParamTriggerIdentifier  = "trigger_identifier"
+	PathParamArtifactName       = "artifact_name"
+	PathParamCacheKey           = "cache_key"
 	QueryParamLatest            = "latest"
 	QueryParamLastExecutions    = "last_executions"
 	QueryParamBranch            = "branch"
+	QueryParamTestStatus        = "status"
+	QueryParamTestSuite         = "suite"
+	QueryParamTestName          = "name"
 )
 
 func GetPipelineIdentifierFromPath(r *http.Request) (string, error) {
@@ -60,6 +67,14 @@ func GetTriggerIdentifierFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamTriggerIdentifier)
 }
 
+func GetArtifactNameFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamArtifactName)
+}
+
+func GetCacheKeyFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamCacheKey)
+}
+
 func ParseListPipelinesFilterFromRequest(r *http.Request) (types.ListPipelinesFilter, error) {
 	lastExecs, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamLastExecutions, 10)
 	if err != nil {
@@ -75,3 +90,36 @@ func ParseListPipelinesFilterFromRequest(r *http.Request) (types.ListPipelinesFi
 		LastExecutions: lastExecs,
 	}, nil
 }
+
+// ParseTestReportFormat extracts the test report format from the url.
+func ParseTestReportFormat(r *http.Request) (enum.TestReportFormat, error) {
+	format, ok := enum.TestReportFormat(r.URL.Query().Get(QueryParamFormat)).Sanitize()
+	if !ok {
+		return "", usererror.BadRequest("Invalid test report format.")
+	}
+
+	return format, nil
+}
+
+// ParseTestCaseFilter extracts the test case filter from the url.
+func ParseTestCaseFilter(r *http.Request) (types.TestCaseFilter, error) {
+	status, ok := enum.TestStatus(r.URL.Query().Get(QueryParamTestStatus)).Sanitize()
+	if !ok {
+		return types.TestCaseFilter{}, usererror.BadRequest("Invalid test status.")
+	}
+
+	return types.TestCaseFilter{
+		Pagination: ParsePaginationFromRequest(r),
+		Status:     status,
+	}, nil
+}
+
+// GetTestSuiteFromQuery extracts the test suite from the url.
+func GetTestSuiteFromQuery(r *http.Request) string {
+	return r.URL.Query().Get(QueryParamTestSuite)
+}
+
+// GetTestNameFromQuery extracts the test case name from the url.
+func GetTestNameFromQuery(r *http.Request) (string, error) {
+	return QueryParamOrError(r, QueryParamTestName)
+}
@@ -30,6 +30,7 @@ const (
 	PathParamUserGroupID      = "user_group_id"
 	PathParamSourceBranch     = "source_branch"
 	PathParamTargetBranch     = "target_branch"
+	PathParamDependsOnNumber  = "pullreq_depends_on_number"
 
 	QueryParamCommenterID        = "commenter_id"
 	QueryParamReviewerID         = "reviewer_id"
@@ -56,6 +57,10 @@ func GetPullReqCommentIDPath(r *http.Request) (int64, error) {
 	return PathParamAsPositiveInt64(r, PathParamPullReqCommentID)
 }
 
+func GetDependsOnNumberFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamDependsOnNumber)
+}
+
 func GetPullReqSourceBranchFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamSourceBranch)
 }
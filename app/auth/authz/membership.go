@@ -17,6 +17,7 @@ package authz
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/paths"
@@ -144,7 +145,7 @@ func (a *MembershipAuthorizer) Check(
 
 	// accessPermissionMetadata contains the access permissions of per space
 	if accessPermissionMetadata, ok := session.Metadata.(*auth.AccessPermissionMetadata); ok {
-		return a.checkWithAccessPermissionMetadata(ctx, accessPermissionMetadata, spacePath, permission)
+		return a.checkWithAccessPermissionMetadata(ctx, accessPermissionMetadata, spacePath, resource, permission)
 	}
 
 	// ensure we aren't bypassing unknown metadata with impact on authorization
@@ -212,6 +213,7 @@ func (a *MembershipAuthorizer) checkWithAccessPermissionMetadata(
 	ctx context.Context,
 	accessPermissionMetadata *auth.AccessPermissionMetadata,
 	requestedSpacePath string,
+	requestedResource *types.Resource,
 	requestedPermission enum.Permission,
 ) (bool, error) {
 	space, err := a.spaceStore.FindByRef(ctx, requestedSpacePath)
@@ -224,6 +226,13 @@ func (a *MembershipAuthorizer) checkWithAccessPermissionMetadata(
 	}
 
 	for _, accessPermission := range accessPermissionMetadata.AccessPermissions.Permissions {
+		// permissions restricted to a single repository can't be used for any other resource
+		if accessPermission.RepoIdentifier != "" &&
+			(requestedResource.Type != enum.ResourceTypeRepo ||
+				!strings.EqualFold(requestedResource.Identifier, accessPermission.RepoIdentifier)) {
+			continue
+		}
+
 		if space.ID == accessPermission.SpaceID && slices.Contains(accessPermission.Permissions, requestedPermission) {
 			return true, nil
 		}
@@ -30,6 +30,7 @@ type ExecutedPayload struct {
 	RepoID       int64         `json:"repo_id"`
 	ExecutionNum int64         `json:"execution_number"`
 	Status       enum.CIStatus `json:"status"`
+	CommitSHA    string        `json:"commit_sha,omitempty"`
 }
 
 func (r *Reporter) Executed(ctx context.Context, payload *ExecutedPayload) {
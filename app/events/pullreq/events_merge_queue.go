@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+
+	"github.com/rs/zerolog/log"
+)
+
+const MergeQueueCommitUpdatedEvent events.EventType = "merge-queue-commit-updated"
+
+// MergeQueueCommitUpdatedPayload is sent when the merge queue creates a new speculative merge commit
+// of the pull request, which is stored in the refs/pullreq/<number>/queue reference.
+type MergeQueueCommitUpdatedPayload struct {
+	Base
+	BaseSHA  string `json:"base_sha"`
+	MergeSHA string `json:"merge_sha"`
+}
+
+func (r *Reporter) MergeQueueCommitUpdated(
+	ctx context.Context,
+	payload *MergeQueueCommitUpdatedPayload,
+) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, MergeQueueCommitUpdatedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request merge queue commit updated event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request merge queue commit updated event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterMergeQueueCommitUpdated(
+	fn events.HandlerFunc[*MergeQueueCommitUpdatedPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, MergeQueueCommitUpdatedEvent, fn, opts...)
+}
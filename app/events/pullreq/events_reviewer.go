@@ -24,6 +24,7 @@ import (
 
 const (
 	ReviewerAddedEvent     events.EventType = "reviewer-added"
+	ReviewerDeletedEvent   events.EventType = "reviewer-deleted"
 	UserGroupReviewerAdded events.EventType = "usergroup-reviewer-added"
 )
 
@@ -32,6 +33,11 @@ type ReviewerAddedPayload struct {
 	ReviewerID int64 `json:"reviewer_id"`
 }
 
+type ReviewerDeletedPayload struct {
+	Base
+	ReviewerID int64 `json:"reviewer_id"`
+}
+
 type UserGroupReviewerAddedPayload struct {
 	Base
 	UserGroupReviewerID int64 `json:"usergroup_reviewer_id"`
@@ -61,6 +67,30 @@ func (r *Reader) RegisterReviewerAdded(
 	return events.ReaderRegisterEvent(r.innerReader, ReviewerAddedEvent, fn, opts...)
 }
 
+func (r *Reporter) ReviewerDeleted(
+	ctx context.Context,
+	payload *ReviewerDeletedPayload,
+) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, ReviewerDeletedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request reviewer deleted event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request reviewer deleted event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterReviewerDeleted(
+	fn events.HandlerFunc[*ReviewerDeletedPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, ReviewerDeletedEvent, fn, opts...)
+}
+
 func (r *Reporter) UserGroupReviewerAdded(
 	ctx context.Context,
 	payload *UserGroupReviewerAddedPayload,
@@ -173,3 +173,30 @@ func (r *Reader) RegisterUpdated(
 ) error {
 	return events.ReaderRegisterEvent(r.innerReader, UpdatedEvent, fn, opts...)
 }
+
+const ReadyForReviewEvent events.EventType = "ready-for-review"
+
+type ReadyForReviewPayload struct {
+	Base
+}
+
+func (r *Reporter) ReadyForReview(ctx context.Context, payload *ReadyForReviewPayload) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, ReadyForReviewEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request ready for review event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request ready for review event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterReadyForReview(
+	fn events.HandlerFunc[*ReadyForReviewPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, ReadyForReviewEvent, fn, opts...)
+}
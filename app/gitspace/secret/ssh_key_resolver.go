@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/gitspace/secret/enum"
+	gitnesssecret "github.com/harness/gitness/secret"
+)
+
+// SSHKeyResolver resolves the ssh key of a gitspace from a secret of the gitspace's root space.
+// The secret is resolved when the gitspace starts, so it can reference an external secret provider.
+type SSHKeyResolver struct {
+	secretService gitnesssecret.Service
+}
+
+func NewSSHKeyResolver(secretService gitnesssecret.Service) *SSHKeyResolver {
+	return &SSHKeyResolver{
+		secretService: secretService,
+	}
+}
+
+func (r *SSHKeyResolver) Resolve(ctx context.Context, resolutionContext ResolutionContext) (ResolvedSecret, error) {
+	value, err := r.secretService.DecryptSecret(ctx, resolutionContext.SpaceIdentifier, resolutionContext.SecretRef)
+	if err != nil {
+		return ResolvedSecret{}, fmt.Errorf("failed to resolve ssh key secret %q: %w",
+			resolutionContext.SecretRef, err)
+	}
+
+	return ResolvedSecret{
+		SecretValue: value,
+	}, nil
+}
+
+func (r *SSHKeyResolver) Type() enum.SecretType {
+	return enum.SSHSecretType
+}
@@ -14,10 +14,15 @@
 
 package secret
 
-import "github.com/google/wire"
+import (
+	gitnesssecret "github.com/harness/gitness/secret"
+
+	"github.com/google/wire"
+)
 
 var WireSet = wire.NewSet(
 	ProvidePasswordResolver,
+	ProvideSSHKeyResolver,
 	ProvideResolverFactory,
 )
 
@@ -25,6 +30,10 @@ func ProvidePasswordResolver() *PasswordResolver {
 	return NewPasswordResolver()
 }
 
-func ProvideResolverFactory(passwordResolver *PasswordResolver) *ResolverFactory {
-	return NewFactoryWithProviders(passwordResolver)
+func ProvideSSHKeyResolver(secretService gitnesssecret.Service) *SSHKeyResolver {
+	return NewSSHKeyResolver(secretService)
+}
+
+func ProvideResolverFactory(passwordResolver *PasswordResolver, sshKeyResolver *SSHKeyResolver) *ResolverFactory {
+	return NewFactoryWithProviders(passwordResolver, sshKeyResolver)
 }
@@ -34,6 +34,7 @@ type Source string
 
 const (
 	OciSource Source = "oci"
+	LFSSource Source = "lfs"
 )
 
 // Claims defines Harness jwt claims.
@@ -69,6 +70,8 @@ type SubClaimsAccessPermissions struct {
 type AccessPermissions struct {
 	SpaceID     int64             `json:"sid,omitempty"`
 	Permissions []enum.Permission `json:"p"`
+	// RepoIdentifier (optional) restricts the permissions to the repository with the identifier inside the space.
+	RepoIdentifier string `json:"rid,omitempty"`
 }
 
 // GenerateForToken generates a jwt for a given token.
@@ -41,6 +41,9 @@ type service struct {
 type Canceler interface {
 	// Cancel cancels the provided execution.
 	Cancel(ctx context.Context, repo *types.Repository, execution *types.Execution) error
+
+	// Decline stops the provided execution after a deployment was rejected by an approver.
+	Decline(ctx context.Context, repo *types.Repository, execution *types.Execution) error
 }
 
 // New returns a cancellation service that encapsulates
@@ -63,8 +66,23 @@ func New(
 	}
 }
 
-//nolint:gocognit // refactor if needed.
 func (s *service) Cancel(ctx context.Context, repo *types.Repository, execution *types.Execution) error {
+	return s.stop(ctx, repo, execution, enum.CIStatusKilled)
+}
+
+func (s *service) Decline(ctx context.Context, repo *types.Repository, execution *types.Execution) error {
+	return s.stop(ctx, repo, execution, enum.CIStatusDeclined)
+}
+
+// stop kills or skips all the unfinished stages of the execution and sets the execution to the provided status.
+//
+//nolint:gocognit // refactor if needed.
+func (s *service) stop(
+	ctx context.Context,
+	repo *types.Repository,
+	execution *types.Execution,
+	status enum.CIStatus,
+) error {
 	log := log.With().
 		Int64("execution.id", execution.ID).
 		Str("execution.status", string(execution.Status)).
@@ -79,11 +97,11 @@ func (s *service) Cancel(ctx context.Context, repo *types.Repository, execution
 		return nil
 	}
 
-	// update the build status to killed. if the update fails
-	// due to an optimistic lock error it means the build has
+	// update the build status to killed (or declined). if the update
+	// fails due to an optimistic lock error it means the build has
 	// already started, and should now be ignored.
 	now := time.Now().UnixMilli()
-	execution.Status = enum.CIStatusKilled
+	execution.Status = status
 	execution.Finished = now
 	if execution.Started == 0 {
 		execution.Started = now
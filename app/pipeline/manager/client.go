@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/harness/gitness/app/pipeline/storage"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/livelog"
 	"github.com/harness/gitness/types"
@@ -101,8 +102,18 @@ func (e *embedded) Detail(ctx context.Context, stage *drone.Stage) (*client.Cont
 		return nil, err
 	}
 
+	build := ConvertToDroneBuild(details.Execution)
+
+	// params are exposed to the steps as environment variables, copy them to not modify the execution.
+	params := make(map[string]string, len(build.Params)+1)
+	for k, v := range build.Params {
+		params[k] = v
+	}
+	params[storage.EnvAPIURL] = e.urlProvider.GetContainerAPIURL(ctx)
+	build.Params = params
+
 	return &client.Context{
-		Build:   ConvertToDroneBuild(details.Execution),
+		Build:   build,
 		Repo:    ConvertToDroneRepo(details.Repo, details.RepoIsPublic),
 		Stage:   ConvertToDroneStage(details.Stage),
 		Secrets: ConvertToDroneSecrets(details.Secrets),
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/harness/gitness/app/bootstrap"
@@ -27,12 +28,15 @@ import (
 	"github.com/harness/gitness/app/jwt"
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
+	"github.com/harness/gitness/app/pipeline/matrix"
 	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/pipeline/storage"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	urlprovider "github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/livelog"
+	"github.com/harness/gitness/secret"
 	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -151,10 +155,13 @@ type Manager struct {
 	// System  *store.System
 	Users store.PrincipalStore
 	// Webhook store.WebhookSender
+	Environments store.EnvironmentStore
 
 	publicAccess publicaccess.Service
 	// events reporter
 	reporter events.Reporter
+
+	secretService secret.Service
 }
 
 func New(
@@ -174,8 +181,10 @@ func New(
 	stageStore store.StageStore,
 	stepStore store.StepStore,
 	userStore store.PrincipalStore,
+	environmentStore store.EnvironmentStore,
 	publicAccess publicaccess.Service,
 	reporter events.Reporter,
+	secretService secret.Service,
 ) *Manager {
 	return &Manager{
 		Config:           config,
@@ -194,8 +203,10 @@ func New(
 		Stages:           stageStore,
 		Steps:            stepStore,
 		Users:            userStore,
+		Environments:     environmentStore,
 		publicAccess:     publicAccess,
 		reporter:         reporter,
+		secretService:    secretService,
 	}
 }
 
@@ -249,6 +260,10 @@ func (m *Manager) Accept(_ context.Context, id int64, machine string) (*types.St
 		log.Debug().Msg("manager: stage already assigned. abort.")
 		return nil, fmt.Errorf("stage already assigned, abort")
 	}
+	if stage.Status.IsDone() {
+		log.Debug().Msg("manager: stage already done. abort.")
+		return nil, fmt.Errorf("stage already done, abort")
+	}
 
 	stage.Machine = machine
 	stage.Status = enum.CIStatusPending
@@ -362,6 +377,25 @@ func (m *Manager) Details(ctx context.Context, stageID int64) (*ExecutionContext
 		return nil, err
 	}
 
+	// Expand matrix pipelines the same way they were expanded into stages by the triggerer.
+	file.Data, _, err = matrix.Expand(file.Data)
+	if err != nil {
+		log.Warn().Err(err).Msg("manager: cannot expand pipeline matrix")
+		return nil, err
+	}
+
+	// Add the steps that restore and save the caches and upload the artifacts declared by the yaml.
+	file.Data, err = storage.Expand(file.Data, storage.Options{
+		Image:              m.Config.CI.StorageStepImage,
+		RepoRef:            repo.Path,
+		PipelineIdentifier: pipeline.Identifier,
+		ExecutionNumber:    execution.Number,
+	})
+	if err != nil {
+		log.Warn().Err(err).Msg("manager: cannot expand pipeline caches and artifacts")
+		return nil, err
+	}
+
 	netrc, err := m.createNetrc(repo)
 	if err != nil {
 		log.Warn().Err(err).Msg("manager: failed to create netrc")
@@ -373,12 +407,39 @@ func (m *Manager) Details(ctx context.Context, stageID int64) (*ExecutionContext
 		RepoIsPublic: repoIsPublic,
 		Execution:    execution,
 		Stage:        stage,
-		Secrets:      secrets,
+		Secrets:      m.resolveSecrets(ctx, secrets, string(file.Data)),
 		Config:       file,
 		Netrc:        netrc,
 	}, nil
 }
 
+// resolveSecrets returns copies of the secrets with their values. Local secrets are decrypted, while
+// secrets of external providers are only fetched if the pipeline yaml references them,
+// so an execution doesn't access every external secret of the space.
+// Secrets that can't be resolved are left out, steps using them fail as if the secret didn't exist.
+func (m *Manager) resolveSecrets(ctx context.Context, secrets []*types.Secret, yaml string) []*types.Secret {
+	principal := bootstrap.NewPipelineServiceSession().Principal
+
+	resolved := make([]*types.Secret, 0, len(secrets))
+	for _, s := range secrets {
+		if s.Provider.IsExternal() && !strings.Contains(yaml, s.Identifier) {
+			continue
+		}
+
+		value, err := m.secretService.ResolveSecret(ctx, principal, s)
+		if err != nil {
+			log.Warn().Err(err).Str("secret", s.Identifier).Msg("manager: cannot resolve secret")
+			continue
+		}
+
+		sec := *s
+		sec.Data = value
+		resolved = append(resolved, &sec)
+	}
+
+	return resolved
+}
+
 func (m *Manager) createNetrc(repo *types.Repository) (*Netrc, error) {
 	pipelinePrincipal := bootstrap.NewPipelineServiceSession().Principal
 	jwt, err := jwt.GenerateWithMembership(
@@ -477,16 +538,17 @@ func (m *Manager) BeforeStage(_ context.Context, stage *types.Stage) error {
 // AfterAll signals the build stage is complete.
 func (m *Manager) AfterStage(_ context.Context, stage *types.Stage) error {
 	t := &teardown{
-		Executions:  m.Executions,
-		Pipelines:   m.Pipelines,
-		Checks:      m.Checks,
-		SSEStreamer: m.SSEStreamer,
-		Logs:        m.Logz,
-		Repos:       m.Repos,
-		Scheduler:   m.Scheduler,
-		Steps:       m.Steps,
-		Stages:      m.Stages,
-		Reporter:    m.reporter,
+		Executions:   m.Executions,
+		Pipelines:    m.Pipelines,
+		Checks:       m.Checks,
+		SSEStreamer:  m.SSEStreamer,
+		Logs:         m.Logz,
+		Repos:        m.Repos,
+		Scheduler:    m.Scheduler,
+		Steps:        m.Steps,
+		Stages:       m.Stages,
+		Environments: m.Environments,
+		Reporter:     m.reporter,
 	}
 	return t.do(noContext, stage)
 }
@@ -35,16 +35,17 @@ import (
 )
 
 type teardown struct {
-	Executions  store.ExecutionStore
-	Checks      store.CheckStore
-	Pipelines   store.PipelineStore
-	SSEStreamer sse.Streamer
-	Logs        livelog.LogStream
-	Scheduler   scheduler.Scheduler
-	Repos       store.RepoStore
-	Steps       store.StepStore
-	Stages      store.StageStore
-	Reporter    events.Reporter
+	Executions   store.ExecutionStore
+	Checks       store.CheckStore
+	Pipelines    store.PipelineStore
+	SSEStreamer  sse.Streamer
+	Logs         livelog.LogStream
+	Scheduler    scheduler.Scheduler
+	Repos        store.RepoStore
+	Steps        store.StepStore
+	Stages       store.StageStore
+	Environments store.EnvironmentStore
+	Reporter     events.Reporter
 }
 
 //nolint:gocognit // refactor if needed.
@@ -115,6 +116,13 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 		return err
 	}
 
+	err = t.skipMatrixSiblings(ctx, stage, stages)
+	if err != nil {
+		log.Error().Err(err).
+			Msg("manager: cannot skip matrix stages")
+		return err
+	}
+
 	err = t.cancelDownstream(ctx, stages)
 	if err != nil {
 		log.Error().Err(err).
@@ -176,9 +184,6 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 			Msg("manager: could not publish execution completed event")
 	}
 
-	// send pipeline execution status
-	t.reportExecutionCompleted(ctx, execution)
-
 	pipeline, err := t.Pipelines.Find(ctx, execution.PipelineID)
 	if err != nil {
 		log.Error().Err(err).Msg("manager: cannot find pipeline")
@@ -190,6 +195,9 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 		log.Error().Err(err).Msg("manager: could not write to checks store")
 	}
 
+	// send pipeline execution status after the check is written, so consumers see the final check status.
+	t.reportExecutionCompleted(ctx, execution)
+
 	return nil
 }
 
@@ -261,11 +269,65 @@ func (t *teardown) cancelDownstream(
 	return errs
 }
 
+// skipMatrixSiblings is a helper function that skips the stages
+// of the same fail-fast matrix as the stage that haven't started
+// yet, if the stage failed.
+func (t *teardown) skipMatrixSiblings(
+	ctx context.Context,
+	stage *types.Stage,
+	stages []*types.Stage,
+) error {
+	if stage.Matrix == nil || !stage.Matrix.FailFast || !stage.Status.IsFailed() {
+		return nil
+	}
+
+	var errs error
+	for _, s := range stages {
+		if s.ID == stage.ID || s.Matrix == nil || s.Matrix.Name != stage.Matrix.Name {
+			continue
+		}
+
+		switch {
+		case s.Status == enum.CIStatusWaitingOnDeps,
+			s.Status == enum.CIStatusWaitingOnApproval,
+			s.Status == enum.CIStatusPending && s.Machine == "":
+		default:
+			continue
+		}
+
+		log := log.With().
+			Int64("stage.id", s.ID).
+			Str("stage.matrix", s.Matrix.Name).
+			Logger()
+
+		log.Debug().Msg("manager: skipping matrix stage")
+
+		s.Status = enum.CIStatusSkipped
+		s.Started = time.Now().UnixMilli()
+		s.Stopped = time.Now().UnixMilli()
+		err := t.Stages.Update(noContext, s)
+		if errors.Is(err, gitness_store.ErrVersionConflict) {
+			rErr := t.resync(ctx, s)
+			if rErr != nil {
+				log.Warn().Err(rErr).Msg("failed to resync after version conflict")
+			}
+			continue
+		}
+		if err != nil {
+			log.Error().Err(err).
+				Msg("manager: cannot update stage status")
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs
+}
+
 func isexecutionComplete(stages []*types.Stage) bool {
 	for _, stage := range stages {
 		if stage.Status == enum.CIStatusPending ||
 			stage.Status == enum.CIStatusRunning ||
 			stage.Status == enum.CIStatusWaitingOnDeps ||
+			stage.Status == enum.CIStatusWaitingOnApproval ||
 			stage.Status == enum.CIStatusDeclined ||
 			stage.Status == enum.CIStatusBlocked {
 			return false
@@ -325,9 +387,14 @@ func (t *teardown) scheduleDownstream(
 			Str("stage.depends_on", strings.Join(sibling.DependsOn, ",")).
 			Logger()
 
-		log.Debug().Msg("manager: schedule next stage")
-
 		sibling.Status = enum.CIStatusPending
+		if t.requiresApproval(ctx, sibling) {
+			log.Debug().Msg("manager: next stage is waiting on approval")
+			sibling.Status = enum.CIStatusWaitingOnApproval
+		} else {
+			log.Debug().Msg("manager: schedule next stage")
+		}
+
 		err := t.Stages.Update(noContext, sibling)
 		if errors.Is(err, gitness_store.ErrVersionConflict) {
 			rErr := t.resync(ctx, sibling)
@@ -342,6 +409,10 @@ func (t *teardown) scheduleDownstream(
 			errs = multierror.Append(errs, err)
 		}
 
+		if sibling.Status == enum.CIStatusWaitingOnApproval {
+			continue
+		}
+
 		err = t.Scheduler.Schedule(noContext, sibling)
 		if err != nil {
 			log.Error().Err(err).
@@ -352,6 +423,28 @@ func (t *teardown) scheduleDownstream(
 	return errs
 }
 
+// requiresApproval returns true if the stage deploys to a protected environment.
+// If the environment can't be loaded the stage waits for approval to be on the safe side,
+// unless the environment was deleted in the meantime.
+func (t *teardown) requiresApproval(ctx context.Context, stage *types.Stage) bool {
+	if stage.EnvironmentID == 0 {
+		return false
+	}
+
+	env, err := t.Environments.Find(ctx, stage.EnvironmentID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false
+	}
+	if err != nil {
+		log.Warn().Err(err).
+			Int64("stage.id", stage.ID).
+			Msg("manager: cannot find the environment of the stage")
+		return true
+	}
+
+	return env.IsProtected()
+}
+
 // resync updates the stage from the database. Note that it does
 // not update the Version field. This is by design. It prevents
 // the current go routine from updating a stage that has been
@@ -376,5 +469,6 @@ func (t *teardown) reportExecutionCompleted(ctx context.Context, execution *type
 		RepoID:       execution.RepoID,
 		ExecutionNum: execution.Number,
 		Status:       execution.Status,
+		CommitSHA:    execution.After,
 	})
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/livelog"
+	"github.com/harness/gitness/secret"
 	"github.com/harness/gitness/types"
 
 	"github.com/drone/runner-go/client"
@@ -54,12 +55,14 @@ func ProvideExecutionManager(
 	stageStore store.StageStore,
 	stepStore store.StepStore,
 	userStore store.PrincipalStore,
+	environmentStore store.EnvironmentStore,
 	publicAccess publicaccess.Service,
 	reporter *events.Reporter,
+	secretService secret.Service,
 ) ExecutionManager {
 	return New(config, executionStore, pipelineStore, urlProvider, sseStreamer, fileService, converterService,
 		logStore, logStream, checkStore, repoStore, scheduler, secretStore,
-		stageStore, stepStore, userStore, publicAccess, *reporter)
+		stageStore, stepStore, userStore, environmentStore, publicAccess, *reporter, secretService)
 }
 
 // ProvideExecutionClient provides a client implementation to interact with the execution manager.
@@ -0,0 +1,388 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package matrix expands the pipelines of a drone yaml over a matrix of parameters.
+package matrix
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/harness/gitness/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	keyMatrix    = "matrix"
+	keyFailFast  = "fail_fast"
+	keyExclude   = "exclude"
+	keyName      = "name"
+	keyKind      = "kind"
+	keyDependsOn = "depends_on"
+	keySteps     = "steps"
+	keyServices  = "services"
+	keyEnv       = "environment"
+
+	kindPipeline = "pipeline"
+
+	// MaxCombinations is the maximum number of stages a single pipeline can be expanded into.
+	MaxCombinations = 256
+)
+
+var (
+	v1Regex   = regexp.MustCompilePOSIX(`^spec:`)
+	axisRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// Expand rewrites a drone yaml so every pipeline with a `matrix` is replaced by one pipeline
+// per combination of the matrix parameters. The expanded pipelines are named after the pipeline
+// and the parameter values, get the parameters as environment variables of their steps and
+// services, and have `${PARAM}` references in their strings replaced with the parameter values.
+// Pipelines that depend on a matrix pipeline depend on all of its expanded pipelines.
+//
+// Besides the expanded yaml, it returns the matrix details of the expanded pipelines, keyed by name.
+// Yaml without a matrix pipeline, including v1 yaml, is returned as is.
+func Expand(data []byte) ([]byte, map[string]*types.StageMatrix, error) {
+	if v1Regex.Match(data) || !bytes.Contains(data, []byte(keyMatrix+":")) {
+		return data, nil, nil
+	}
+
+	var documents []*yaml.Node
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		document := &yaml.Node{}
+		err := decoder.Decode(document)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse yaml document: %w", err)
+		}
+		documents = append(documents, document)
+	}
+
+	expanded := make([]*yaml.Node, 0, len(documents))
+	matrices := map[string]*types.StageMatrix{}
+	children := map[string][]string{}
+	for _, document := range documents {
+		root := documentRoot(document)
+		if root == nil || scalar(root, keyKind) != kindPipeline || mappingValue(root, keyMatrix) == nil {
+			expanded = append(expanded, document)
+			continue
+		}
+
+		name := scalar(root, keyName)
+		if name == "" {
+			name = "default"
+		}
+
+		combinations, err := parseMatrix(name, mappingValue(root, keyMatrix))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		failFast := scalar(root, keyFailFast) == "true"
+		for _, combination := range combinations {
+			child := expandPipeline(document, name, combination)
+			childName := scalar(documentRoot(child), keyName)
+			if _, ok := matrices[childName]; ok {
+				return nil, nil, fmt.Errorf("matrix of pipeline %q has duplicate combinations", name)
+			}
+
+			matrices[childName] = &types.StageMatrix{
+				Name:     name,
+				Params:   combination.params(),
+				FailFast: failFast,
+			}
+			children[name] = append(children[name], childName)
+			expanded = append(expanded, child)
+		}
+	}
+
+	if len(matrices) == 0 {
+		return data, nil, nil
+	}
+
+	for _, document := range expanded {
+		if root := documentRoot(document); root != nil && scalar(root, keyKind) == kindPipeline {
+			expandDependencies(root, children)
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	encoder := yaml.NewEncoder(buf)
+	encoder.SetIndent(2)
+	for _, document := range expanded {
+		if err := encoder.Encode(document); err != nil {
+			return nil, nil, fmt.Errorf("failed to encode yaml document: %w", err)
+		}
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, nil, fmt.Errorf("failed to encode yaml: %w", err)
+	}
+
+	return buf.Bytes(), matrices, nil
+}
+
+// param is the value of a matrix parameter in a combination.
+type param struct {
+	key   string
+	value string
+}
+
+// combination is a set of matrix parameter values, in the order the parameters are declared.
+type combination []param
+
+func (c combination) params() map[string]string {
+	params := make(map[string]string, len(c))
+	for _, p := range c {
+		params[p.key] = p.value
+	}
+	return params
+}
+
+func (c combination) name(pipeline string) string {
+	values := make([]string, len(c))
+	for i, p := range c {
+		values[i] = p.value
+	}
+	return pipeline + " (" + strings.Join(values, ", ") + ")"
+}
+
+// matches returns true if the combination has all the parameter values of the filter.
+func (c combination) matches(filter map[string]string) bool {
+	for key, value := range filter {
+		found := false
+		for _, p := range c {
+			if p.key == key && p.value == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// parseMatrix returns all combinations of the matrix parameters, except the excluded ones.
+func parseMatrix(pipeline string, matrix *yaml.Node) ([]combination, error) {
+	if matrix.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("matrix of pipeline %q must be a map of parameters", pipeline)
+	}
+
+	combinations := []combination{{}}
+	var excludes []map[string]string
+	for i := 0; i+1 < len(matrix.Content); i += 2 {
+		key, values := matrix.Content[i].Value, matrix.Content[i+1]
+
+		if key == keyExclude {
+			if err := values.Decode(&excludes); err != nil {
+				return nil, fmt.Errorf("matrix exclude of pipeline %q must be a list of parameter maps: %w",
+					pipeline, err)
+			}
+			continue
+		}
+
+		if !axisRegex.MatchString(key) {
+			return nil, fmt.Errorf("matrix parameter %q of pipeline %q isn't a valid environment variable name",
+				key, pipeline)
+		}
+		if values.Kind != yaml.SequenceNode || len(values.Content) == 0 {
+			return nil, fmt.Errorf("matrix parameter %q of pipeline %q must be a non-empty list", key, pipeline)
+		}
+
+		next := make([]combination, 0, len(combinations)*len(values.Content))
+		for _, c := range combinations {
+			for _, value := range values.Content {
+				if value.Kind != yaml.ScalarNode {
+					return nil, fmt.Errorf("values of matrix parameter %q of pipeline %q must be scalars",
+						key, pipeline)
+				}
+				next = append(next, append(c[:len(c):len(c)], param{key: key, value: value.Value}))
+			}
+		}
+		if len(next) > MaxCombinations {
+			return nil, fmt.Errorf("matrix of pipeline %q exceeds the maximum of %d combinations",
+				pipeline, MaxCombinations)
+		}
+		combinations = next
+	}
+
+	if len(combinations[0]) == 0 {
+		return nil, fmt.Errorf("matrix of pipeline %q has no parameters", pipeline)
+	}
+
+	result := make([]combination, 0, len(combinations))
+	for _, c := range combinations {
+		excluded := false
+		for _, exclude := range excludes {
+			if c.matches(exclude) {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			result = append(result, c)
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("matrix of pipeline %q excludes all combinations", pipeline)
+	}
+
+	return result, nil
+}
+
+// expandPipeline returns a copy of the pipeline document for a combination of the matrix parameters.
+func expandPipeline(document *yaml.Node, pipeline string, c combination) *yaml.Node {
+	child := copyNode(document)
+	root := documentRoot(child)
+
+	removeKey(root, keyMatrix)
+	removeKey(root, keyFailFast)
+
+	replacer := make([]string, 0, 2*len(c))
+	for _, p := range c {
+		replacer = append(replacer, "${"+p.key+"}", p.value)
+	}
+	substitute(root, strings.NewReplacer(replacer...))
+
+	setScalar(root, keyName, c.name(pipeline))
+
+	for _, key := range []string{keySteps, keyServices} {
+		containers := mappingValue(root, key)
+		if containers == nil || containers.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, container := range containers.Content {
+			if container.Kind != yaml.MappingNode {
+				continue
+			}
+			env := mappingValue(container, keyEnv)
+			if env == nil || env.Kind != yaml.MappingNode {
+				env = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+				setValue(container, keyEnv, env)
+			}
+			for _, p := range c {
+				// variables set explicitly by the step take precedence.
+				if mappingValue(env, p.key) == nil {
+					setScalar(env, p.key, p.value)
+				}
+			}
+		}
+	}
+
+	return child
+}
+
+// expandDependencies replaces dependencies on matrix pipelines with dependencies on all their expanded pipelines.
+func expandDependencies(root *yaml.Node, children map[string][]string) {
+	dependsOn := mappingValue(root, keyDependsOn)
+	if dependsOn == nil || dependsOn.Kind != yaml.SequenceNode {
+		return
+	}
+
+	content := make([]*yaml.Node, 0, len(dependsOn.Content))
+	for _, dep := range dependsOn.Content {
+		names, ok := children[dep.Value]
+		if !ok {
+			content = append(content, dep)
+			continue
+		}
+		for _, name := range names {
+			content = append(content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name})
+		}
+	}
+	dependsOn.Content = content
+}
+
+func documentRoot(document *yaml.Node) *yaml.Node {
+	if document.Kind != yaml.DocumentNode || len(document.Content) == 0 {
+		return nil
+	}
+	if root := document.Content[0]; root.Kind == yaml.MappingNode {
+		return root
+	}
+	return nil
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func scalar(node *yaml.Node, key string) string {
+	value := mappingValue(node, key)
+	if value == nil || value.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return value.Value
+}
+
+func setValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+func setScalar(node *yaml.Node, key string, value string) {
+	setValue(node, key, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value})
+}
+
+func removeKey(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// substitute replaces the parameter references in all the string scalars of the node.
+func substitute(node *yaml.Node, replacer *strings.Replacer) {
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!str" {
+		node.Value = replacer.Replace(node.Value)
+	}
+	for _, child := range node.Content {
+		substitute(child, replacer)
+	}
+}
+
+func copyNode(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	c := *node
+	c.Alias = copyNode(node.Alias)
+	c.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		c.Content[i] = copyNode(child)
+	}
+	return &c
+}
@@ -0,0 +1,160 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+type pipeline struct {
+	Kind      string   `yaml:"kind"`
+	Name      string   `yaml:"name"`
+	DependsOn []string `yaml:"depends_on"`
+	Matrix    any      `yaml:"matrix"`
+	Steps     []struct {
+		Name        string            `yaml:"name"`
+		Image       string            `yaml:"image"`
+		Environment map[string]string `yaml:"environment"`
+	} `yaml:"steps"`
+}
+
+func decode(t *testing.T, data []byte) []pipeline {
+	t.Helper()
+	var pipelines []pipeline
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var p pipeline
+		err := decoder.Decode(&p)
+		if errors.Is(err, io.EOF) {
+			return pipelines
+		}
+		if err != nil {
+			t.Fatalf("failed to decode expanded yaml: %v", err)
+		}
+		pipelines = append(pipelines, p)
+	}
+}
+
+func TestExpand(t *testing.T) {
+	data := []byte(`kind: pipeline
+name: test
+fail_fast: true
+matrix:
+  GO_VERSION: [1.20, 1.21]
+  DB: [postgres, sqlite]
+  exclude:
+  - GO_VERSION: 1.20
+    DB: sqlite
+steps:
+- name: test
+  image: golang:${GO_VERSION}
+  environment:
+    DB: overridden
+---
+kind: pipeline
+name: publish
+depends_on: [test]
+steps:
+- name: publish
+  image: plugins/docker
+`)
+
+	expanded, matrices, err := Expand(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pipelines := decode(t, expanded)
+	if len(pipelines) != 4 {
+		t.Fatalf("got %d pipelines, want 4", len(pipelines))
+	}
+
+	names := []string{"test (1.20, postgres)", "test (1.21, postgres)", "test (1.21, sqlite)"}
+	for i, name := range names {
+		p := pipelines[i]
+		if p.Name != name {
+			t.Errorf("pipeline %d: got name %q, want %q", i, p.Name, name)
+		}
+		if p.Matrix != nil {
+			t.Errorf("pipeline %d: matrix wasn't removed", i)
+		}
+		params := matrices[name].Params
+		if want := "golang:" + params["GO_VERSION"]; p.Steps[0].Image != want {
+			t.Errorf("pipeline %d: got image %q, want %q", i, p.Steps[0].Image, want)
+		}
+		wantEnv := map[string]string{"GO_VERSION": params["GO_VERSION"], "DB": "overridden"}
+		if !reflect.DeepEqual(p.Steps[0].Environment, wantEnv) {
+			t.Errorf("pipeline %d: got environment %v, want %v", i, p.Steps[0].Environment, wantEnv)
+		}
+	}
+
+	if !reflect.DeepEqual(pipelines[3].DependsOn, names) {
+		t.Errorf("got depends_on %v, want %v", pipelines[3].DependsOn, names)
+	}
+
+	want := &types.StageMatrix{
+		Name:     "test",
+		Params:   map[string]string{"GO_VERSION": "1.21", "DB": "sqlite"},
+		FailFast: true,
+	}
+	if !reflect.DeepEqual(matrices["test (1.21, sqlite)"], want) {
+		t.Errorf("got matrix %+v, want %+v", matrices["test (1.21, sqlite)"], want)
+	}
+}
+
+func TestExpandWithoutMatrix(t *testing.T) {
+	data := []byte(`kind: pipeline
+name: build
+steps:
+- name: build
+  image: golang
+`)
+
+	expanded, matrices, err := Expand(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(expanded, data) {
+		t.Errorf("yaml without a matrix was modified:\n%s", expanded)
+	}
+	if matrices != nil {
+		t.Errorf("got matrices %v, want none", matrices)
+	}
+}
+
+func TestExpandInvalid(t *testing.T) {
+	tests := map[string]string{
+		"invalid parameter name": "kind: pipeline\nmatrix:\n  go-version: [1]\n",
+		"empty parameter":        "kind: pipeline\nmatrix:\n  GO: []\n",
+		"all excluded":           "kind: pipeline\nmatrix:\n  GO: [1]\n  exclude:\n  - GO: 1\n",
+		"not a map":              "kind: pipeline\nmatrix: [1, 2]\n",
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := Expand([]byte(data)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
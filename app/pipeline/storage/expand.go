@@ -0,0 +1,479 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/harness/gitness/types/enum"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	cachePolicyPull     = "pull"
+	cachePolicyPush     = "push"
+	cachePolicyPullPush = "pull-push"
+)
+
+var (
+	v1Regex         = regexp.MustCompilePOSIX(`^spec:`)
+	pathRegex       = regexp.MustCompile(`^[A-Za-z0-9._/-]+$`)
+	reportPathRegex = regexp.MustCompile(`^[A-Za-z0-9._/*-]+$`)
+)
+
+// Options configures the steps that are added to a pipeline to restore and save caches
+// and to upload artifacts and test reports.
+type Options struct {
+	// Image is the container image of the added steps, it has to provide sh, tar and wget.
+	Image string
+
+	RepoRef            string
+	PipelineIdentifier string
+	ExecutionNumber    int64
+}
+
+type cacheSpec struct {
+	Enabled *bool    `yaml:"enabled"`
+	Key     string   `yaml:"key"`
+	Paths   []string `yaml:"paths"`
+	Policy  string   `yaml:"policy"`
+}
+
+type reportSpec struct {
+	Type enum.TestReportFormat `yaml:"type"`
+	Path stringOrSlice         `yaml:"path"`
+}
+
+// stringOrSlice is a yaml value that can be either a single string or a list of strings.
+type stringOrSlice []string
+
+func (s *stringOrSlice) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*s = []string{value.Value}
+		return nil
+	}
+
+	var list []string
+	if err := value.Decode(&list); err != nil {
+		return err
+	}
+	*s = list
+
+	return nil
+}
+
+// Expand rewrites a v1 pipeline yaml so the caches declared by its CI stages are restored
+// before and saved after the stage steps, and the artifacts declared by its steps are uploaded
+// after the step that produces them. The test reports declared by run steps are uploaded
+// after the step, even if the step failed. Any other yaml is returned as is.
+//
+// The added steps talk to the api using the url found in the EnvAPIURL environment variable
+// and authenticate with the netrc password of the execution.
+func Expand(data []byte, opts Options) ([]byte, error) {
+	if !v1Regex.Match(data) {
+		return data, nil
+	}
+
+	config := map[string]any{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse pipeline yaml: %w", err)
+	}
+
+	spec, _ := config["spec"].(map[string]any)
+	stages, _ := spec["stages"].([]any)
+
+	e := &expander{opts: opts}
+	if err := e.stages(stages); err != nil {
+		return nil, err
+	}
+
+	if !e.changed {
+		return data, nil
+	}
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal pipeline yaml: %w", err)
+	}
+
+	return out, nil
+}
+
+type expander struct {
+	opts          Options
+	changed       bool
+	uploads       int
+	reportUploads int
+}
+
+func (e *expander) stages(stages []any) error {
+	for i, s := range stages {
+		stage, ok := s.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		spec, ok := stage["spec"].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		var err error
+		switch stage["type"] {
+		case "group", "parallel":
+			nested, _ := spec["stages"].([]any)
+			err = e.stages(nested)
+		case "ci":
+			err = e.ciStage(spec)
+		}
+		if err != nil {
+			name, _ := stage["name"].(string)
+			if name == "" {
+				name = fmt.Sprintf("#%d", i+1)
+			}
+			return fmt.Errorf("stage %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (e *expander) ciStage(spec map[string]any) error {
+	steps, _ := spec["steps"].([]any)
+	steps, err := e.steps(steps)
+	if err != nil {
+		return err
+	}
+
+	if raw, ok := spec["cache"]; ok {
+		delete(spec, "cache")
+		e.changed = true
+
+		cache := &cacheSpec{}
+		if err := decode(raw, cache); err != nil {
+			return fmt.Errorf("invalid cache: %w", err)
+		}
+
+		if (cache.Enabled == nil || *cache.Enabled) && len(cache.Paths) > 0 {
+			if err := checkCache(cache); err != nil {
+				return err
+			}
+
+			if cache.Policy != cachePolicyPush {
+				steps = append([]any{e.restoreCacheStep(cache)}, steps...)
+			}
+			if cache.Policy != cachePolicyPull {
+				steps = append(steps, e.saveCacheStep(cache))
+			}
+		}
+	}
+
+	spec["steps"] = steps
+
+	return nil
+}
+
+// steps returns the steps with an upload step added after every step that declares artifacts
+// or test reports.
+func (e *expander) steps(steps []any) ([]any, error) {
+	out := make([]any, 0, len(steps))
+	for _, s := range steps {
+		out = append(out, s)
+
+		step, ok := s.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		paths, err := e.stepArtifacts(step)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(paths) > 0 {
+			out = append(out, e.uploadArtifactsStep(paths))
+		}
+
+		reports, err := stepReports(step)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(reports) > 0 {
+			out = append(out, e.uploadReportsStep(reports))
+		}
+	}
+
+	return out, nil
+}
+
+// stepArtifacts removes the artifacts declared by the step and returns their paths.
+// Artifacts of steps running in parallel are uploaded once the whole parallel step is done.
+func (e *expander) stepArtifacts(step map[string]any) ([]string, error) {
+	var paths []string
+	if raw, ok := step["artifacts"]; ok {
+		delete(step, "artifacts")
+		e.changed = true
+
+		if err := decode(raw, &paths); err != nil {
+			return nil, fmt.Errorf("invalid artifacts: %w", err)
+		}
+		for _, p := range paths {
+			if err := checkPath(p); err != nil {
+				return nil, fmt.Errorf("invalid artifact: %w", err)
+			}
+		}
+	}
+
+	spec, ok := step["spec"].(map[string]any)
+	if !ok {
+		return paths, nil
+	}
+
+	nested, _ := spec["steps"].([]any)
+
+	switch step["type"] {
+	case "group":
+		steps, err := e.steps(nested)
+		if err != nil {
+			return nil, err
+		}
+		spec["steps"] = steps
+	case "parallel":
+		for _, s := range nested {
+			child, ok := s.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			childPaths, err := e.stepArtifacts(child)
+			if err != nil {
+				return nil, err
+			}
+			paths = append(paths, childPaths...)
+		}
+	}
+
+	return paths, nil
+}
+
+// stepReports returns the test reports declared by the step.
+// Test reports of steps running in parallel are uploaded once the whole parallel step is done.
+func stepReports(step map[string]any) ([]reportSpec, error) {
+	spec, ok := step["spec"].(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+
+	var reports []reportSpec
+	if raw, ok := spec["reports"]; ok && step["type"] == "run" {
+		if err := decode(raw, &reports); err != nil {
+			return nil, fmt.Errorf("invalid reports: %w", err)
+		}
+		for i := range reports {
+			if err := checkReport(&reports[i]); err != nil {
+				return nil, fmt.Errorf("invalid report: %w", err)
+			}
+		}
+	}
+
+	if step["type"] != "parallel" {
+		return reports, nil
+	}
+
+	nested, _ := spec["steps"].([]any)
+	for _, s := range nested {
+		child, ok := s.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		childReports, err := stepReports(child)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, childReports...)
+	}
+
+	return reports, nil
+}
+
+func (e *expander) restoreCacheStep(cache *cacheSpec) map[string]any {
+	return e.runStep("restore-cache",
+		`key="$(printf '%s' "`+cache.Key+`" | tr -c 'A-Za-z0-9._-' '_')"`,
+		`if wget -q -O /tmp/cache.tar.gz --header "Authorization: Bearer $DRONE_NETRC_PASSWORD" `+
+			`"`+e.cacheURL()+`/$key"; then `+
+			`tar -xzf /tmp/cache.tar.gz -C "$DRONE_WORKSPACE"; rm /tmp/cache.tar.gz; echo "restored cache $key"; `+
+			`else echo "cache $key not found"; fi`,
+	)
+}
+
+func (e *expander) saveCacheStep(cache *cacheSpec) map[string]any {
+	return e.runStep("save-cache",
+		`key="$(printf '%s' "`+cache.Key+`" | tr -c 'A-Za-z0-9._-' '_')"`,
+		`cd "$DRONE_WORKSPACE"`,
+		`paths=""; for p in `+strings.Join(cache.Paths, " ")+`; do if [ -e "$p" ]; then paths="$paths $p"; fi; done`,
+		`if [ -z "$paths" ]; then echo "nothing to cache"; exit 0; fi`,
+		`tar -czf /tmp/cache.tar.gz $paths`,
+		`wget -q -O /dev/null --header "Authorization: Bearer $DRONE_NETRC_PASSWORD" `+
+			`--post-file /tmp/cache.tar.gz "`+e.cacheURL()+`/$key"`,
+	)
+}
+
+func (e *expander) uploadArtifactsStep(paths []string) map[string]any {
+	e.uploads++
+	return e.runStep(fmt.Sprintf("upload-artifacts-%d", e.uploads),
+		`cd "$DRONE_WORKSPACE"`,
+		`upload() { wget -q -O /dev/null --header "Authorization: Bearer $DRONE_NETRC_PASSWORD" `+
+			`--post-file "$1" "`+e.artifactURL()+`/$2"; }`,
+		`for p in `+strings.Join(paths, " ")+`; do `+
+			`if [ -d "$p" ]; then n="$(basename "$p").tar.gz"; `+
+			`tar -czf "/tmp/$n" -C "$(dirname "$p")" "$(basename "$p")"; upload "/tmp/$n" "$n"; `+
+			`elif [ -f "$p" ]; then upload "$p" "$(basename "$p")"; `+
+			`else echo "artifact $p not found"; exit 1; fi; done`,
+	)
+}
+
+func (e *expander) uploadReportsStep(reports []reportSpec) map[string]any {
+	e.reportUploads++
+	e.changed = true
+
+	script := []string{
+		`cd "$DRONE_WORKSPACE"`,
+		`upload() { wget -q -O /dev/null --header "Authorization: Bearer $DRONE_NETRC_PASSWORD" ` +
+			`--post-file "$1" "` + e.testReportURL() + `?format=$2"; }`,
+	}
+	for _, report := range reports {
+		script = append(script, `for p in `+strings.Join(report.Path, " ")+`; do `+
+			`if [ -f "$p" ]; then upload "$p" `+string(report.Type)+`; `+
+			`else echo "test report $p not found"; fi; done`)
+	}
+
+	step := e.runStep(fmt.Sprintf("upload-test-reports-%d", e.reportUploads), script...)
+	// the reports are most useful when the tests failed.
+	step["when"] = "${{ always() }}"
+
+	return step
+}
+
+func (e *expander) runStep(name string, script ...string) map[string]any {
+	return map[string]any{
+		"name": name,
+		"type": "run",
+		"spec": map[string]any{
+			"container": map[string]any{"image": e.opts.Image},
+			"script":    script,
+		},
+	}
+}
+
+func (e *expander) cacheURL() string {
+	return fmt.Sprintf("${%s}/v1/repos/%s/+/pipeline-caches", EnvAPIURL, e.opts.RepoRef)
+}
+
+func (e *expander) artifactURL() string {
+	return fmt.Sprintf("${%s}/v1/repos/%s/+/pipelines/%s/executions/%d/artifacts",
+		EnvAPIURL, e.opts.RepoRef, e.opts.PipelineIdentifier, e.opts.ExecutionNumber)
+}
+
+func (e *expander) testReportURL() string {
+	return fmt.Sprintf("${%s}/v1/repos/%s/+/pipelines/%s/executions/%d/test-reports",
+		EnvAPIURL, e.opts.RepoRef, e.opts.PipelineIdentifier, e.opts.ExecutionNumber)
+}
+
+func checkReport(report *reportSpec) error {
+	var ok bool
+	report.Type, ok = report.Type.Sanitize()
+	if !ok {
+		return fmt.Errorf("unknown test report type %q", report.Type)
+	}
+
+	if len(report.Path) == 0 {
+		return errors.New("test report path is required")
+	}
+
+	// the paths are expanded by the shell so they can be glob patterns.
+	for _, p := range report.Path {
+		if !reportPathRegex.MatchString(p) {
+			return fmt.Errorf("path %q can only contain alphanumeric characters, dots, dashes, "+
+				"underscores, slashes and asterisks", p)
+		}
+		if err := checkWorkspacePath(p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkCache(cache *cacheSpec) error {
+	switch cache.Policy {
+	case "":
+		cache.Policy = cachePolicyPullPush
+	case cachePolicyPull, cachePolicyPush, cachePolicyPullPush:
+	default:
+		return fmt.Errorf("unknown cache policy %q", cache.Policy)
+	}
+
+	// the key is evaluated by the shell so it can refer to environment variables.
+	if cache.Key == "" {
+		return errors.New("cache key is required")
+	}
+	if strings.ContainsAny(cache.Key, "\"\\`\n") {
+		return errors.New("cache key can't contain quotes, backslashes or new lines")
+	}
+
+	for _, p := range cache.Paths {
+		if err := checkPath(p); err != nil {
+			return fmt.Errorf("invalid cache path: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// checkPath verifies that the path is relative to the workspace and safe to use in a shell script.
+func checkPath(p string) error {
+	if !pathRegex.MatchString(p) {
+		return fmt.Errorf("path %q can only contain alphanumeric characters, dots, dashes, "+
+			"underscores and slashes", p)
+	}
+
+	return checkWorkspacePath(p)
+}
+
+// checkWorkspacePath verifies that the path is relative to the workspace.
+func checkWorkspacePath(p string) error {
+	clean := path.Clean(p)
+	if path.IsAbs(clean) || clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+		return fmt.Errorf("path %q has to be inside of the workspace", p)
+	}
+
+	return nil
+}
+
+// decode converts a generic yaml value to the provided type.
+func decode(in any, out any) error {
+	raw, err := yaml.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(raw, out)
+}
@@ -0,0 +1,261 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+var testOptions = Options{
+	Image:              "alpine:3.20",
+	RepoRef:            "space/repo",
+	PipelineIdentifier: "build",
+	ExecutionNumber:    7,
+}
+
+func TestExpand(t *testing.T) {
+	data := []byte(`version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: build
+    type: ci
+    spec:
+      cache:
+        key: go-$DRONE_BRANCH
+        paths: [.cache/go]
+      steps:
+      - name: compile
+        type: run
+        artifacts: [bin/app]
+        spec:
+          container: golang
+          script: go build -o bin/app
+      - name: checks
+        type: parallel
+        spec:
+          steps:
+          - name: test
+            type: run
+            artifacts: [coverage]
+            spec:
+              script: go test ./...
+          - name: lint
+            type: run
+            spec:
+              script: go vet ./...
+`)
+
+	out, err := Expand(data, testOptions)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	steps := stageSteps(t, out)
+
+	want := []string{"restore-cache", "compile", "upload-artifacts-1", "checks", "upload-artifacts-2", "save-cache"}
+	if got := stepNames(steps); !reflect.DeepEqual(got, want) {
+		t.Errorf("steps: got %v, want %v", got, want)
+	}
+
+	if strings.Contains(string(out), "artifacts:") || strings.Contains(string(out), "cache:") {
+		t.Errorf("expected artifacts and cache to be removed from the yaml:\n%s", out)
+	}
+
+	script := strings.Join(stepScript(steps[2]), "\n")
+	if !strings.Contains(script, "${GITNESS_API_URL}/v1/repos/space/repo/+/pipelines/build/executions/7/artifacts") {
+		t.Errorf("unexpected artifact upload script: %s", script)
+	}
+	if !strings.Contains(script, "for p in bin/app;") {
+		t.Errorf("expected artifact path in upload script: %s", script)
+	}
+
+	script = strings.Join(stepScript(steps[4]), "\n")
+	if !strings.Contains(script, "for p in coverage;") {
+		t.Errorf("expected artifacts of parallel steps to be uploaded after them: %s", script)
+	}
+}
+
+func TestExpandReports(t *testing.T) {
+	data := []byte(`spec:
+  stages:
+  - type: ci
+    spec:
+      steps:
+      - name: test
+        type: run
+        spec:
+          script: go test -json ./... > report.json
+          reports:
+          - type: gotest
+            path: report.json
+      - name: checks
+        type: parallel
+        spec:
+          steps:
+          - name: unit
+            type: run
+            spec:
+              script: npm test
+              reports:
+              - path: [reports/*.xml]
+`)
+
+	out, err := Expand(data, testOptions)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	steps := stageSteps(t, out)
+
+	want := []string{"test", "upload-test-reports-1", "checks", "upload-test-reports-2"}
+	if got := stepNames(steps); !reflect.DeepEqual(got, want) {
+		t.Errorf("steps: got %v, want %v", got, want)
+	}
+
+	if when := steps[1].(map[string]any)["when"]; when != "${{ always() }}" {
+		t.Errorf("expected the test reports to be uploaded even if the step failed, got when=%v", when)
+	}
+
+	script := strings.Join(stepScript(steps[1]), "\n")
+	if !strings.Contains(script, "${GITNESS_API_URL}/v1/repos/space/repo/+/pipelines/build/executions/7/test-reports") {
+		t.Errorf("unexpected test report upload script: %s", script)
+	}
+	if !strings.Contains(script, `for p in report.json; do if [ -f "$p" ]; then upload "$p" gotest;`) {
+		t.Errorf("expected test report path and format in upload script: %s", script)
+	}
+
+	script = strings.Join(stepScript(steps[3]), "\n")
+	if !strings.Contains(script, `for p in reports/*.xml; do if [ -f "$p" ]; then upload "$p" junit;`) {
+		t.Errorf("expected junit reports of parallel steps to be uploaded after them: %s", script)
+	}
+}
+
+func TestExpandCachePolicy(t *testing.T) {
+	tests := []struct {
+		policy string
+		want   []string
+	}{
+		{policy: "pull", want: []string{"restore-cache", "test"}},
+		{policy: "push", want: []string{"test", "save-cache"}},
+		{policy: "pull-push", want: []string{"restore-cache", "test", "save-cache"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.policy, func(t *testing.T) {
+			data := []byte(`spec:
+  stages:
+  - type: ci
+    spec:
+      cache:
+        key: deps
+        paths: [node_modules]
+        policy: ` + test.policy + `
+      steps:
+      - name: test
+        type: run
+        spec:
+          script: npm test
+`)
+
+			out, err := Expand(data, testOptions)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got := stepNames(stageSteps(t, out)); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestExpandUnchanged(t *testing.T) {
+	tests := map[string]string{
+		"drone": "kind: pipeline\nsteps:\n- name: test\n  image: golang\n",
+		"v1":    "spec:\n  stages:\n  - type: ci\n    spec:\n      steps:\n      - type: run\n",
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, err := Expand([]byte(data), testOptions)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(out) != data {
+				t.Errorf("expected the yaml to be unchanged, got:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestExpandInvalid(t *testing.T) {
+	tests := map[string]string{
+		"absolute artifact path": "artifacts: [/etc/passwd]",
+		"parent artifact path":   "artifacts: [../secret]",
+		"unsafe artifact path":   "artifacts: [\"bin/$(id)\"]",
+		"workspace artifact":     "artifacts: [.]",
+		"unknown report type":    "spec:\n          reports: [{type: xunit, path: out.xml}]",
+		"report without path":    "spec:\n          reports: [{type: junit}]",
+		"unsafe report path":     "spec:\n          reports: [{path: \"$(id).xml\"}]",
+	}
+
+	for name, artifacts := range tests {
+		t.Run(name, func(t *testing.T) {
+			data := "spec:\n  stages:\n  - type: ci\n    spec:\n      steps:\n      - type: run\n        " +
+				artifacts + "\n"
+			if _, err := Expand([]byte(data), testOptions); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+
+	data := "spec:\n  stages:\n  - type: ci\n    spec:\n      cache:\n        paths: [deps]\n"
+	if _, err := Expand([]byte(data), testOptions); err == nil {
+		t.Errorf("expected an error for a cache without a key")
+	}
+}
+
+func stageSteps(t *testing.T, data []byte) []any {
+	t.Helper()
+
+	config := map[string]any{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		t.Fatalf("failed to parse expanded yaml: %s", err)
+	}
+
+	stage := config["spec"].(map[string]any)["stages"].([]any)[0].(map[string]any)
+	return stage["spec"].(map[string]any)["steps"].([]any)
+}
+
+func stepNames(steps []any) []string {
+	names := make([]string, len(steps))
+	for i, s := range steps {
+		names[i], _ = s.(map[string]any)["name"].(string)
+	}
+	return names
+}
+
+func stepScript(step any) []string {
+	var script []string
+	for _, line := range step.(map[string]any)["spec"].(map[string]any)["script"].([]any) {
+		script = append(script, line.(string))
+	}
+	return script
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/types/check"
+
+	"github.com/rs/zerolog/log"
+)
+
+// EnvAPIURL is the name of the environment variable that holds the base url of the api
+// as reachable from the containers of the pipeline steps.
+const EnvAPIURL = "GITNESS_API_URL"
+
+const maxNameLength = 256
+
+var nameRegex = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// CheckCacheKey verifies that the provided string can be used as a pipeline cache key.
+func CheckCacheKey(key string) error {
+	return checkName("Cache key", key)
+}
+
+// CheckArtifactName verifies that the provided string can be used as a pipeline artifact name.
+func CheckArtifactName(name string) error {
+	return checkName("Artifact name", name)
+}
+
+func checkName(what, name string) error {
+	if len(name) == 0 || len(name) > maxNameLength {
+		return check.NewValidationErrorf("%s has to be between 1 and %d characters long.", what, maxNameLength)
+	}
+	if name == "." || name == ".." || !nameRegex.MatchString(name) {
+		return check.NewValidationErrorf(
+			"%s can only contain alphanumeric characters, dots, dashes and underscores.", what)
+	}
+	return nil
+}
+
+// CacheBlobPath returns the path of the pipeline cache archive in the blob store.
+func CacheBlobPath(repoID int64, key string) string {
+	// keys are hashed to keep the paths short and independent of the case sensitivity of the store.
+	sum := sha256.Sum256([]byte(key))
+	return fmt.Sprintf("pipelines/%d/caches/%s", repoID, hex.EncodeToString(sum[:]))
+}
+
+// ArtifactBlobPath returns the path of the pipeline artifact in the blob store.
+func ArtifactBlobPath(repoID int64, executionID int64, name string) string {
+	return fmt.Sprintf("pipelines/%d/artifacts/%d/%s", repoID, executionID, name)
+}
+
+// Upload writes the content to the blob store and returns its size.
+// Content larger than maxSize is rejected and removed from the blob store.
+func Upload(
+	ctx context.Context,
+	blobStore blob.Store,
+	filePath string,
+	content io.Reader,
+	maxSize int64,
+) (int64, error) {
+	r := &countingReader{r: io.LimitReader(content, maxSize+1)}
+	if err := blobStore.Upload(ctx, r, filePath); err != nil {
+		return 0, fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	if r.n > maxSize {
+		if err := blobStore.Delete(ctx, filePath); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to delete oversized file %s", filePath)
+		}
+		return 0, usererror.RequestTooLargef("The file is larger than the limit of %d bytes.", maxSize)
+	}
+
+	return r.n, nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
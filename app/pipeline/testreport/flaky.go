@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testreport
+
+import (
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// HistorySize is the number of recent results of a test case considered by the flaky test detection.
+	HistorySize = 20
+
+	// minFlakyFlips is the number of changes between passing and failing within the recent
+	// results that makes a test case flaky even if it never passed and failed on the same commit.
+	minFlakyFlips = 3
+)
+
+// IsFlaky reports whether a test case that failed in the execution is flaky,
+// given its recent results in the executions of the pipeline, the newest first.
+//
+// A failed test case is flaky if it passed on the same commit in another execution,
+// or if its recent results keep changing between passing and failing.
+func IsFlaky(execution *types.Execution, history []*types.TestCaseRun) bool {
+	flips := 0
+	last := enum.TestStatusFailed
+
+	for _, run := range history {
+		if run.ExecutionNumber == execution.Number {
+			continue
+		}
+
+		if run.Status != enum.TestStatusPassed && run.Status != enum.TestStatusFailed {
+			continue
+		}
+
+		if run.Status == enum.TestStatusPassed && run.CommitSHA == execution.After {
+			return true
+		}
+
+		if run.Status != last {
+			flips++
+			last = run.Status
+		}
+	}
+
+	return flips >= minFlakyFlips
+}
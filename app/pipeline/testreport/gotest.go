@@ -0,0 +1,105 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testreport
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const maxGoTestLineLength = 1 << 20
+
+// goTestEvent is a line of the output of `go test -json`.
+type goTestEvent struct {
+	Action  string  `json:"Action"`
+	Package string  `json:"Package"`
+	Test    string  `json:"Test"`
+	Elapsed float64 `json:"Elapsed"`
+	Output  string  `json:"Output"`
+}
+
+func parseGoTest(r io.Reader) ([]*types.TestCase, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxGoTestLineLength)
+
+	var testCases []*types.TestCase
+	outputs := map[string]*strings.Builder{}
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+
+		// the output can contain lines that aren't test events, e.g. build errors.
+		if len(line) == 0 || line[0] != '{' {
+			continue
+		}
+
+		event := goTestEvent{}
+		if err := json.Unmarshal(line, &event); err != nil {
+			return nil, invalidReport(enum.TestReportFormatGoTest, err)
+		}
+
+		// events without a test are about the whole package.
+		if event.Test == "" {
+			continue
+		}
+
+		key := event.Package + "\x00" + event.Test
+
+		var status enum.TestStatus
+		switch event.Action {
+		case "output":
+			output, ok := outputs[key]
+			if !ok {
+				output = &strings.Builder{}
+				outputs[key] = output
+			}
+			if output.Len() < maxMessageLength {
+				output.WriteString(event.Output)
+			}
+			continue
+		case "pass":
+			status = enum.TestStatusPassed
+		case "fail":
+			status = enum.TestStatusFailed
+		case "skip":
+			status = enum.TestStatusSkipped
+		default:
+			continue
+		}
+
+		var message string
+		if output, ok := outputs[key]; ok && status != enum.TestStatusPassed {
+			message = output.String()
+		}
+		delete(outputs, key)
+
+		testCases = append(testCases, newTestCase(event.Package, event.Test, status, event.Elapsed, message))
+	}
+
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, invalidReport(enum.TestReportFormatGoTest, err)
+		}
+		return nil, err
+	}
+
+	return testCases, nil
+}
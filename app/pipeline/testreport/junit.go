@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testreport
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// junitSuite is either the testsuites root element or a (nested) testsuite element.
+type junitSuite struct {
+	Name   string       `xml:"name,attr"`
+	Suites []junitSuite `xml:"testsuite"`
+	Cases  []junitCase  `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string       `xml:"name,attr"`
+	ClassName string       `xml:"classname,attr"`
+	Time      string       `xml:"time,attr"`
+	Failure   *junitResult `xml:"failure"`
+	Error     *junitResult `xml:"error"`
+	Skipped   *junitResult `xml:"skipped"`
+}
+
+type junitResult struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func parseJUnit(r io.Reader) ([]*types.TestCase, error) {
+	root := junitSuite{}
+	if err := xml.NewDecoder(r).Decode(&root); err != nil {
+		return nil, invalidReport(enum.TestReportFormatJUnit, err)
+	}
+
+	var testCases []*types.TestCase
+	var walk func(suite *junitSuite)
+	walk = func(suite *junitSuite) {
+		for i := range suite.Cases {
+			testCases = append(testCases, junitTestCase(suite.Name, &suite.Cases[i]))
+		}
+		for i := range suite.Suites {
+			walk(&suite.Suites[i])
+		}
+	}
+	walk(&root)
+
+	return testCases, nil
+}
+
+func junitTestCase(suiteName string, c *junitCase) *types.TestCase {
+	suite := c.ClassName
+	if suite == "" {
+		suite = suiteName
+	}
+
+	// some tools format the time with thousands separators.
+	seconds, _ := strconv.ParseFloat(strings.ReplaceAll(c.Time, ",", ""), 64)
+
+	status := enum.TestStatusPassed
+	var result *junitResult
+	switch {
+	case c.Failure != nil:
+		status, result = enum.TestStatusFailed, c.Failure
+	case c.Error != nil:
+		status, result = enum.TestStatusFailed, c.Error
+	case c.Skipped != nil:
+		status, result = enum.TestStatusSkipped, c.Skipped
+	}
+
+	var message string
+	if result != nil {
+		message = result.Message
+		if text := strings.TrimSpace(result.Text); text != "" {
+			if message != "" {
+				message += "\n"
+			}
+			message += text
+		}
+	}
+
+	return newTestCase(suite, c.Name, status, seconds, message)
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package testreport parses the test reports uploaded by pipeline steps
+// and detects flaky test cases.
+package testreport
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// MaxReportSize is the maximum size of a test report in bytes.
+	MaxReportSize = 50 << 20
+
+	// MaxTestCases is the maximum number of test cases in a single test report.
+	MaxTestCases = 10000
+
+	maxNameLength    = 1024
+	maxMessageLength = 4096
+)
+
+// Parse reads a test report in the provided format and returns its test cases.
+// Only the suite, name, status, duration and message of the test cases are set.
+func Parse(format enum.TestReportFormat, r io.Reader) ([]*types.TestCase, error) {
+	var testCases []*types.TestCase
+	var err error
+
+	switch format {
+	case enum.TestReportFormatJUnit:
+		testCases, err = parseJUnit(r)
+	case enum.TestReportFormatGoTest:
+		testCases, err = parseGoTest(r)
+	default:
+		return nil, check.NewValidationErrorf("Unsupported test report format %q.", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(testCases) > MaxTestCases {
+		return nil, check.NewValidationErrorf("A test report can have at most %d test cases.", MaxTestCases)
+	}
+
+	return testCases, nil
+}
+
+func newTestCase(suite, name string, status enum.TestStatus, seconds float64, message string) *types.TestCase {
+	return &types.TestCase{
+		Suite:    truncate(strings.TrimSpace(suite), maxNameLength),
+		Name:     truncate(strings.TrimSpace(name), maxNameLength),
+		Status:   status,
+		Duration: int64(math.Round(seconds * 1000)),
+		Message:  truncate(strings.TrimSpace(message), maxMessageLength),
+	}
+}
+
+// truncate shortens the string to at most n bytes without splitting a multi-byte character.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+
+	return s[:n]
+}
+
+func invalidReport(format enum.TestReportFormat, err error) error {
+	// reports larger than MaxReportSize are rejected by the http handler while being read.
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return err
+	}
+
+	return check.NewValidationError(fmt.Sprintf("Invalid %s test report: %s", format, err.Error()))
+}
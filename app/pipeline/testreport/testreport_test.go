@@ -0,0 +1,179 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testreport
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestParseJUnit(t *testing.T) {
+	report := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="math">
+    <testcase classname="math.Add" name="positive" time="0.012"/>
+    <testcase classname="math.Add" name="overflow" time="1,002.5">
+      <failure message="expected 0">at add.go:12</failure>
+    </testcase>
+    <testsuite name="nested">
+      <testcase name="panics" time="0.1"><error message="nil pointer"/></testcase>
+      <testcase name="slow"><skipped message="short mode"/></testcase>
+    </testsuite>
+  </testsuite>
+</testsuites>`
+
+	got, err := Parse(enum.TestReportFormatJUnit, strings.NewReader(report))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []*types.TestCase{
+		{Suite: "math.Add", Name: "positive", Status: enum.TestStatusPassed, Duration: 12},
+		{Suite: "math.Add", Name: "overflow", Status: enum.TestStatusFailed, Duration: 1002500,
+			Message: "expected 0\nat add.go:12"},
+		{Suite: "nested", Name: "panics", Status: enum.TestStatusFailed, Duration: 100, Message: "nil pointer"},
+		{Suite: "nested", Name: "slow", Status: enum.TestStatusSkipped, Message: "short mode"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want=%+v got=%+v", want, got)
+	}
+}
+
+func TestParseJUnitSingleSuite(t *testing.T) {
+	report := `<testsuite name="pkg"><testcase name="works" time="2"/></testsuite>`
+
+	got, err := Parse(enum.TestReportFormatJUnit, strings.NewReader(report))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []*types.TestCase{
+		{Suite: "pkg", Name: "works", Status: enum.TestStatusPassed, Duration: 2000},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want=%+v got=%+v", want, got)
+	}
+}
+
+func TestParseJUnitInvalid(t *testing.T) {
+	if _, err := Parse(enum.TestReportFormatJUnit, strings.NewReader("<testsuite>")); err == nil {
+		t.Error("expected an error for a malformed report")
+	}
+}
+
+func TestParseGoTest(t *testing.T) {
+	report := `# example.com/pkg
+{"Action":"run","Package":"example.com/pkg","Test":"TestA"}
+{"Action":"output","Package":"example.com/pkg","Test":"TestA","Output":"=== RUN   TestA\n"}
+{"Action":"pass","Package":"example.com/pkg","Test":"TestA","Elapsed":0.01}
+{"Action":"run","Package":"example.com/pkg","Test":"TestB"}
+{"Action":"output","Package":"example.com/pkg","Test":"TestB","Output":"    b_test.go:9: boom\n"}
+{"Action":"fail","Package":"example.com/pkg","Test":"TestB","Elapsed":1.5}
+{"Action":"skip","Package":"example.com/pkg","Test":"TestC","Elapsed":0}
+{"Action":"fail","Package":"example.com/pkg","Elapsed":1.6}
+`
+
+	got, err := Parse(enum.TestReportFormatGoTest, strings.NewReader(report))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []*types.TestCase{
+		{Suite: "example.com/pkg", Name: "TestA", Status: enum.TestStatusPassed, Duration: 10},
+		{Suite: "example.com/pkg", Name: "TestB", Status: enum.TestStatusFailed, Duration: 1500,
+			Message: "b_test.go:9: boom"},
+		{Suite: "example.com/pkg", Name: "TestC", Status: enum.TestStatusSkipped},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want=%+v got=%+v", want, got)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	if got := truncate("héllo", 2); got != "h" {
+		t.Errorf("expected the multi-byte character to be dropped, got %q", got)
+	}
+	if got := truncate("hello", 10); got != "hello" {
+		t.Errorf("expected the string to be unchanged, got %q", got)
+	}
+}
+
+func TestIsFlaky(t *testing.T) {
+	execution := &types.Execution{Number: 10, After: "c10"}
+
+	run := func(number int64, sha string, status enum.TestStatus) *types.TestCaseRun {
+		return &types.TestCaseRun{ExecutionNumber: number, CommitSHA: sha, Status: status}
+	}
+
+	tests := []struct {
+		name    string
+		history []*types.TestCaseRun
+		want    bool
+	}{
+		{
+			name:    "no history",
+			history: nil,
+			want:    false,
+		},
+		{
+			name: "passed on the same commit",
+			history: []*types.TestCaseRun{
+				run(9, "c10", enum.TestStatusPassed),
+			},
+			want: true,
+		},
+		{
+			name: "same execution is ignored",
+			history: []*types.TestCaseRun{
+				run(10, "c10", enum.TestStatusPassed),
+			},
+			want: false,
+		},
+		{
+			name: "newly broken",
+			history: []*types.TestCaseRun{
+				run(9, "c9", enum.TestStatusPassed),
+				run(8, "c8", enum.TestStatusPassed),
+				run(7, "c7", enum.TestStatusPassed),
+			},
+			want: false,
+		},
+		{
+			name: "keeps flipping",
+			history: []*types.TestCaseRun{
+				run(9, "c9", enum.TestStatusPassed),
+				run(8, "c8", enum.TestStatusSkipped),
+				run(7, "c7", enum.TestStatusFailed),
+				run(6, "c6", enum.TestStatusPassed),
+			},
+			want: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := IsFlaky(execution, test.history); got != test.want {
+				t.Errorf("want=%t got=%t", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"gopkg.in/yaml.v3"
+)
+
+// droneDeployTargets returns the environments the pipelines of a drone yaml deploy to,
+// keyed by the pipeline name. The environment is set with the `deploy_to` key of the pipeline.
+func droneDeployTargets(data []byte) (map[string]string, error) {
+	targets := map[string]string{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var document struct {
+			Kind     string `yaml:"kind"`
+			Name     string `yaml:"name"`
+			DeployTo string `yaml:"deploy_to"`
+		}
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse yaml document: %w", err)
+		}
+
+		if document.Kind != "pipeline" || document.DeployTo == "" {
+			continue
+		}
+
+		name := document.Name
+		if name == "" {
+			name = "default"
+		}
+		targets[name] = document.DeployTo
+	}
+
+	return targets, nil
+}
+
+// v1DeployTargets returns the environments the stages of a v1 yaml deploy to, in the order of the stages.
+// The environment is set with the `deploy_to` key of the stage.
+func v1DeployTargets(data []byte) ([]string, error) {
+	var config struct {
+		Spec struct {
+			Stages []struct {
+				DeployTo string `yaml:"deploy_to"`
+			} `yaml:"stages"`
+		} `yaml:"spec"`
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse v1 yaml: %w", err)
+	}
+
+	targets := make([]string, len(config.Spec.Stages))
+	for i, stage := range config.Spec.Stages {
+		targets[i] = stage.DeployTo
+	}
+
+	return targets, nil
+}
+
+// resolveEnvironments looks up the environments the stages deploy to and holds the stages
+// deploying to protected environments until they're approved.
+// The execution records the environment of the first stage deploying to one.
+func (t *triggerer) resolveEnvironments(
+	ctx context.Context,
+	repo *types.Repository,
+	execution *types.Execution,
+	stages []*types.Stage,
+) error {
+	environments := map[string]*types.Environment{}
+	for _, stage := range stages {
+		if stage.Environment == "" {
+			continue
+		}
+
+		env, ok := environments[stage.Environment]
+		if !ok {
+			var err error
+			env, err = t.findEnvironment(ctx, repo, stage.Environment)
+			if err != nil {
+				return err
+			}
+			environments[stage.Environment] = env
+		}
+
+		stage.Environment = env.Identifier
+		stage.EnvironmentID = env.ID
+		if env.IsProtected() && stage.Status == enum.CIStatusPending {
+			stage.Status = enum.CIStatusWaitingOnApproval
+		}
+
+		if execution.DeployID == 0 {
+			execution.Deploy = env.Identifier
+			execution.DeployID = env.ID
+		}
+	}
+
+	return nil
+}
+
+// findEnvironment finds the environment in the space of the repository or, if it's not defined there,
+// in the closest of the ancestor spaces.
+func (t *triggerer) findEnvironment(
+	ctx context.Context,
+	repo *types.Repository,
+	identifier string,
+) (*types.Environment, error) {
+	spaceIDs, err := t.spaceStore.GetAncestorIDs(ctx, repo.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ancestor space IDs: %w", err)
+	}
+
+	for _, spaceID := range spaceIDs {
+		env, err := t.environmentStore.FindByIdentifier(ctx, spaceID, identifier)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find environment: %w", err)
+		}
+
+		return env, nil
+	}
+
+	return nil, errEnvironmentNotFound{identifier: identifier}
+}
+
+// errEnvironmentNotFound is returned if a stage deploys to an environment that doesn't exist.
+type errEnvironmentNotFound struct {
+	identifier string
+}
+
+func (e errEnvironmentNotFound) Error() string {
+	return fmt.Sprintf("environment %q not found", e.identifier)
+}
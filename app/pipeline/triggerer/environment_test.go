@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDroneDeployTargets(t *testing.T) {
+	data := []byte(`kind: pipeline
+name: build
+steps:
+- name: test
+  image: golang
+---
+kind: pipeline
+name: deploy
+deploy_to: production
+depends_on: [build]
+---
+kind: pipeline
+deploy_to: staging
+---
+kind: secret
+name: token
+deploy_to: ignored
+`)
+
+	targets, err := droneDeployTargets(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]string{
+		"deploy":  "production",
+		"default": "staging",
+	}
+	if !reflect.DeepEqual(targets, want) {
+		t.Errorf("got %v, want %v", targets, want)
+	}
+}
+
+func TestV1DeployTargets(t *testing.T) {
+	data := []byte(`version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: build
+    type: ci
+  - name: deploy
+    type: ci
+    deploy_to: production
+`)
+
+	targets, err := v1DeployTargets(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"", "production"}
+	if !reflect.DeepEqual(targets, want) {
+		t.Errorf("got %v, want %v", targets, want)
+	}
+}
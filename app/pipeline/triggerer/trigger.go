@@ -16,6 +16,7 @@ package triggerer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"runtime/debug"
@@ -25,6 +26,7 @@ import (
 	"github.com/harness/gitness/app/pipeline/converter"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/app/pipeline/matrix"
 	"github.com/harness/gitness/app/pipeline/resolver"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/pipeline/triggerer/dag"
@@ -94,6 +96,8 @@ type triggerer struct {
 	templateStore    store.TemplateStore
 	pluginStore      store.PluginStore
 	publicAccess     publicaccess.Service
+	spaceStore       store.SpaceStore
+	environmentStore store.EnvironmentStore
 }
 
 func New(
@@ -110,6 +114,8 @@ func New(
 	templateStore store.TemplateStore,
 	pluginStore store.PluginStore,
 	publicAccess publicaccess.Service,
+	spaceStore store.SpaceStore,
+	environmentStore store.EnvironmentStore,
 ) Triggerer {
 	return &triggerer{
 		executionStore:   executionStore,
@@ -125,6 +131,8 @@ func New(
 		templateStore:    templateStore,
 		pluginStore:      pluginStore,
 		publicAccess:     publicAccess,
+		spaceStore:       spaceStore,
+		environmentStore: environmentStore,
 	}
 }
 
@@ -150,7 +158,7 @@ func (t *triggerer) Trigger(
 		}
 	}()
 
-	event := base.Action.GetTriggerEvent()
+	event := triggerEvent(base)
 
 	repo, err := t.repoStore.Find(ctx, pipeline.RepoID)
 	if err != nil {
@@ -221,6 +229,14 @@ func (t *triggerer) Trigger(
 			return t.createExecutionWithError(ctx, pipeline, base, err.Error())
 		}
 
+		// Expand matrix pipelines into a pipeline per combination of the matrix parameters.
+		var matrices map[string]*types.StageMatrix
+		file.Data, matrices, err = matrix.Expand(file.Data)
+		if err != nil {
+			log.Warn().Err(err).Msg("trigger: cannot expand matrix")
+			return t.createExecutionWithError(ctx, pipeline, base, err.Error())
+		}
+
 		manifest, err := yaml.ParseString(string(file.Data))
 		if err != nil {
 			log.Warn().Err(err).Msg("trigger: cannot parse yaml")
@@ -319,6 +335,7 @@ func (t *triggerer) Trigger(
 			if stage.Name == "" {
 				stage.Name = "default"
 			}
+			stage.Matrix = matrices[stage.Name]
 			if len(stage.DependsOn) == 0 {
 				stage.Status = enum.CIStatusPending
 			}
@@ -339,12 +356,42 @@ func (t *triggerer) Trigger(
 				stage.Status = enum.CIStatusPending
 			}
 		}
+
+		targets, err := droneDeployTargets(file.Data)
+		if err != nil {
+			log.Warn().Err(err).Msg("trigger: cannot parse deployment environments")
+			return t.createExecutionWithError(ctx, pipeline, base, err.Error())
+		}
+		for _, stage := range stages {
+			stage.Environment = targets[stage.Name]
+		}
 	} else {
 		stages, err = parseV1Stages(
 			ctx, file.Data, repo, execution, t.templateStore, t.pluginStore, t.publicAccess)
 		if err != nil {
 			return nil, fmt.Errorf("could not parse v1 YAML into stages: %w", err)
 		}
+
+		targets, err := v1DeployTargets(file.Data)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse deployment environments from v1 YAML: %w", err)
+		}
+		for _, stage := range stages {
+			if idx := int(stage.Number) - 1; idx < len(targets) {
+				stage.Environment = targets[idx]
+			}
+		}
+	}
+
+	var errNotFound errEnvironmentNotFound
+	err = t.resolveEnvironments(ctx, repo, execution, stages)
+	if errors.As(err, &errNotFound) {
+		log.Warn().Err(err).Msg("trigger: cannot resolve deployment environment")
+		return t.createExecutionWithError(ctx, pipeline, base, err.Error())
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("trigger: cannot resolve deployment environments")
+		return nil, err
 	}
 
 	// Increment pipeline number using optimistic locking.
@@ -557,7 +604,7 @@ func (t *triggerer) createExecutionWithError(
 		Parent:       base.Parent,
 		Status:       enum.CIStatusError,
 		Error:        message,
-		Event:        base.Action.GetTriggerEvent(),
+		Event:        triggerEvent(base),
 		Action:       base.Action,
 		Link:         base.Link,
 		Title:        base.Title,
@@ -595,3 +642,15 @@ func (t *triggerer) createExecutionWithError(
 
 	return execution, nil
 }
+
+// triggerEvent returns the event of the execution created from the hook.
+func triggerEvent(hook *Hook) enum.TriggerEvent {
+	switch hook.Trigger {
+	case enum.TriggerCron:
+		return enum.TriggerEventCron
+	case enum.TriggerPipeline:
+		return enum.TriggerEventPipeline
+	default:
+		return hook.Action.GetTriggerEvent()
+	}
+}
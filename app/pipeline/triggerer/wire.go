@@ -46,8 +46,10 @@ func ProvideTriggerer(
 	templateStore store.TemplateStore,
 	pluginStore store.PluginStore,
 	publicAccess publicaccess.Service,
+	spaceStore store.SpaceStore,
+	environmentStore store.EnvironmentStore,
 ) Triggerer {
 	return New(executionStore, checkStore, stageStore, pipelineStore,
 		tx, repoStore, urlProvider, scheduler, fileService, converterService,
-		templateStore, pluginStore, publicAccess)
+		templateStore, pluginStore, publicAccess, spaceStore, environmentStore)
 }
@@ -20,9 +20,11 @@ import (
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/aiagent"
+	"github.com/harness/gitness/app/api/controller/auditlog"
 	"github.com/harness/gitness/app/api/controller/capabilities"
 	"github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
+	"github.com/harness/gitness/app/api/controller/environment"
 	"github.com/harness/gitness/app/api/controller/execution"
 	controllergithook "github.com/harness/gitness/app/api/controller/githook"
 	"github.com/harness/gitness/app/api/controller/gitspace"
@@ -31,11 +33,13 @@ import (
 	"github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/migrate"
 	"github.com/harness/gitness/app/api/controller/pipeline"
+	"github.com/harness/gitness/app/api/controller/pipelinecache"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/reposettings"
+	"github.com/harness/gitness/app/api/controller/runner"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
 	"github.com/harness/gitness/app/api/controller/space"
@@ -48,9 +52,11 @@ import (
 	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/api/handler/account"
 	handleraiagent "github.com/harness/gitness/app/api/handler/aiagent"
+	handlerauditlog "github.com/harness/gitness/app/api/handler/auditlog"
 	handlercapabilities "github.com/harness/gitness/app/api/handler/capabilities"
 	handlercheck "github.com/harness/gitness/app/api/handler/check"
 	handlerconnector "github.com/harness/gitness/app/api/handler/connector"
+	handlerenvironment "github.com/harness/gitness/app/api/handler/environment"
 	handlerexecution "github.com/harness/gitness/app/api/handler/execution"
 	handlergithook "github.com/harness/gitness/app/api/handler/githook"
 	handlergitspace "github.com/harness/gitness/app/api/handler/gitspace"
@@ -59,12 +65,14 @@ import (
 	handlerlogs "github.com/harness/gitness/app/api/handler/logs"
 	handlermigrate "github.com/harness/gitness/app/api/handler/migrate"
 	handlerpipeline "github.com/harness/gitness/app/api/handler/pipeline"
+	handlerpipelinecache "github.com/harness/gitness/app/api/handler/pipelinecache"
 	handlerplugin "github.com/harness/gitness/app/api/handler/plugin"
 	handlerprincipal "github.com/harness/gitness/app/api/handler/principal"
 	handlerpullreq "github.com/harness/gitness/app/api/handler/pullreq"
 	handlerrepo "github.com/harness/gitness/app/api/handler/repo"
 	handlerreposettings "github.com/harness/gitness/app/api/handler/reposettings"
 	"github.com/harness/gitness/app/api/handler/resource"
+	handlerrunner "github.com/harness/gitness/app/api/handler/runner"
 	handlersecret "github.com/harness/gitness/app/api/handler/secret"
 	handlerserviceaccount "github.com/harness/gitness/app/api/handler/serviceaccount"
 	handlerspace "github.com/harness/gitness/app/api/handler/space"
@@ -78,10 +86,12 @@ import (
 	handlerwebhook "github.com/harness/gitness/app/api/handler/webhook"
 	"github.com/harness/gitness/app/api/middleware/address"
 	middlewareauthn "github.com/harness/gitness/app/api/middleware/authn"
+	middlewareauthz "github.com/harness/gitness/app/api/middleware/authz"
 	"github.com/harness/gitness/app/api/middleware/encode"
 	"github.com/harness/gitness/app/api/middleware/logging"
 	"github.com/harness/gitness/app/api/middleware/nocache"
 	middlewareprincipal "github.com/harness/gitness/app/api/middleware/principal"
+	middlewarerunner "github.com/harness/gitness/app/api/middleware/runner"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/githook"
@@ -136,6 +146,10 @@ func NewAPIHandler(
 	gitspaceCtrl *gitspace.Controller,
 	aiagentCtrl *aiagent.Controller,
 	capabilitiesCtrl *capabilities.Controller,
+	auditLogCtrl *auditlog.Controller,
+	runnerCtrl *runner.Controller,
+	pipelineCacheCtrl *pipelinecache.Controller,
+	environmentCtrl *environment.Controller,
 ) http.Handler {
 	// Use go-chi router for inner routing.
 	r := chi.NewRouter()
@@ -162,13 +176,18 @@ func NewAPIHandler(
 		setupSystem(r, config, sysCtrl)
 		setupResources(r)
 
+		// remote runners authenticate with their own tokens
+		setupRunnerProtocol(r, runnerCtrl)
+
 		r.Group(func(r chi.Router) {
 			r.Use(middlewareauthn.Attempt(authenticator))
+			r.Use(middlewareauthz.BlockLFSToken)
 
 			setupRoutesV1WithAuth(r, appCtx, config, repoCtrl, repoSettingsCtrl, executionCtrl, triggerCtrl, logCtrl,
 				pipelineCtrl, connectorCtrl, templateCtrl, pluginCtrl, secretCtrl, spaceCtrl, pullreqCtrl,
 				webhookCtrl, githookCtrl, git, saCtrl, userCtrl, principalCtrl, userGroupCtrl, checkCtrl, uploadCtrl,
-				searchCtrl, gitspaceCtrl, infraProviderCtrl, migrateCtrl, aiagentCtrl, capabilitiesCtrl)
+				searchCtrl, gitspaceCtrl, infraProviderCtrl, migrateCtrl, aiagentCtrl, capabilitiesCtrl,
+				auditLogCtrl, runnerCtrl, pipelineCacheCtrl, environmentCtrl)
 		})
 	})
 
@@ -220,11 +239,15 @@ func setupRoutesV1WithAuth(r chi.Router,
 	migrateCtrl *migrate.Controller,
 	aiagentCtrl *aiagent.Controller,
 	capabilitiesCtrl *capabilities.Controller,
+	auditLogCtrl *auditlog.Controller,
+	runnerCtrl *runner.Controller,
+	pipelineCacheCtrl *pipelinecache.Controller,
+	environmentCtrl *environment.Controller,
 ) {
 	setupAccountWithAuth(r, userCtrl, config)
-	setupSpaces(r, appCtx, spaceCtrl, userGroupCtrl, webhookCtrl, checkCtrl)
+	setupSpaces(r, appCtx, spaceCtrl, userGroupCtrl, webhookCtrl, checkCtrl, auditLogCtrl, environmentCtrl)
 	setupRepos(r, repoCtrl, repoSettingsCtrl, pipelineCtrl, executionCtrl, triggerCtrl,
-		logCtrl, pullreqCtrl, webhookCtrl, checkCtrl, uploadCtrl)
+		logCtrl, pullreqCtrl, webhookCtrl, checkCtrl, uploadCtrl, auditLogCtrl, pipelineCacheCtrl)
 	setupConnectors(r, connectorCtrl)
 	setupTemplates(r, templateCtrl)
 	setupSecrets(r, secretCtrl)
@@ -233,7 +256,7 @@ func setupRoutesV1WithAuth(r chi.Router,
 	setupServiceAccounts(r, saCtrl)
 	setupPrincipals(r, principalCtrl)
 	setupInternal(r, githookCtrl, git)
-	setupAdmin(r, userCtrl)
+	setupAdmin(r, userCtrl, auditLogCtrl, runnerCtrl)
 	setupPlugins(r, pluginCtrl)
 	setupKeywordSearch(r, searchCtrl)
 	setupInfraProviders(r, infraProviderCtrl)
@@ -249,6 +272,8 @@ func setupSpaces(
 	userGroupCtrl *usergroup.Controller,
 	webhookCtrl *webhook.Controller,
 	checkCtrl *check.Controller,
+	auditLogCtrl *auditlog.Controller,
+	environmentCtrl *environment.Controller,
 ) {
 	r.Route("/spaces", func(r chi.Router) {
 		// Create takes path and parentId via body, not uri
@@ -281,6 +306,8 @@ func setupSpaces(
 			r.Get("/export-progress", handlerspace.HandleExportProgress(spaceCtrl))
 			r.Post("/public-access", handlerspace.HandleUpdatePublicAccess(spaceCtrl))
 			r.Get("/pullreq", handlerspace.HandleListPullReqs(spaceCtrl))
+			r.Get("/notification-channels", handlerspace.HandleNotificationChannelsFind(spaceCtrl))
+			r.Patch("/notification-channels", handlerspace.HandleNotificationChannelsUpdate(spaceCtrl))
 
 			r.Route("/members", func(r chi.Router) {
 				r.Get("/", handlerspace.HandleMembershipList(spaceCtrl))
@@ -294,8 +321,10 @@ func setupSpaces(
 			SetupSpaceLabels(r, spaceCtrl)
 			SetupWebhookSpace(r, webhookCtrl)
 			SetupRulesSpace(r, spaceCtrl)
+			setupEnvironments(r, environmentCtrl)
 
 			r.Get("/checks/recent", handlercheck.HandleCheckListRecentSpace(checkCtrl))
+			r.Get("/audit-logs", handlerauditlog.HandleListSpace(auditLogCtrl))
 		})
 	})
 }
@@ -357,6 +386,19 @@ func SetupRulesSpace(r chi.Router, spaceCtrl *space.Controller) {
 	})
 }
 
+func setupEnvironments(r chi.Router, environmentCtrl *environment.Controller) {
+	r.Route("/environments", func(r chi.Router) {
+		r.Post("/", handlerenvironment.HandleCreate(environmentCtrl))
+		r.Get("/", handlerenvironment.HandleList(environmentCtrl))
+
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamEnvironmentIdentifier), func(r chi.Router) {
+			r.Get("/", handlerenvironment.HandleFind(environmentCtrl))
+			r.Patch("/", handlerenvironment.HandleUpdate(environmentCtrl))
+			r.Delete("/", handlerenvironment.HandleDelete(environmentCtrl))
+		})
+	})
+}
+
 func setupRepos(r chi.Router,
 	repoCtrl *repo.Controller,
 	repoSettingsCtrl *reposettings.Controller,
@@ -368,6 +410,8 @@ func setupRepos(r chi.Router,
 	webhookCtrl *webhook.Controller,
 	checkCtrl *check.Controller,
 	uploadCtrl *upload.Controller,
+	auditLogCtrl *auditlog.Controller,
+	pipelineCacheCtrl *pipelinecache.Controller,
 ) {
 	r.Route("/repos", func(r chi.Router) {
 		// Create takes path and parentId via body, not uri
@@ -392,6 +436,7 @@ func setupRepos(r chi.Router,
 			r.Get("/summary", handlerrepo.HandleSummary(repoCtrl))
 
 			r.Post("/move", handlerrepo.HandleMove(repoCtrl))
+			r.Post("/fork", handlerrepo.HandleFork(repoCtrl))
 			r.Get("/service-accounts", handlerrepo.HandleListServiceAccounts(repoCtrl))
 
 			r.Get("/import-progress", handlerrepo.HandleImportProgress(repoCtrl))
@@ -472,6 +517,8 @@ func setupRepos(r chi.Router,
 
 			setupPipelines(r, repoCtrl, pipelineCtrl, executionCtrl, triggerCtrl, logCtrl)
 
+			setupPipelineCaches(r, pipelineCacheCtrl)
+
 			SetupChecks(r, checkCtrl)
 
 			SetupUploads(r, uploadCtrl)
@@ -479,6 +526,8 @@ func setupRepos(r chi.Router,
 			SetupRulesRepo(r, repoCtrl)
 
 			SetupRepoLabels(r, repoCtrl)
+
+			r.Get("/audit-logs", handlerauditlog.HandleListRepo(auditLogCtrl))
 		})
 	})
 }
@@ -528,12 +577,24 @@ func setupPipelines(
 			r.Get("/", handlerpipeline.HandleFind(pipelineCtrl))
 			r.Patch("/", handlerpipeline.HandleUpdate(pipelineCtrl))
 			r.Delete("/", handlerpipeline.HandleDelete(pipelineCtrl))
+			r.Get("/tests/history", handlerexecution.HandleListTestHistory(executionCtrl))
 			setupExecutions(r, executionCtrl, logCtrl)
 			setupTriggers(r, triggerCtrl)
 		})
 	})
 }
 
+func setupPipelineCaches(r chi.Router, pipelineCacheCtrl *pipelinecache.Controller) {
+	r.Route("/pipeline-caches", func(r chi.Router) {
+		r.Get("/", handlerpipelinecache.HandleList(pipelineCacheCtrl))
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamCacheKey), func(r chi.Router) {
+			r.Get("/", handlerpipelinecache.HandleRestore(pipelineCacheCtrl))
+			r.Post("/", handlerpipelinecache.HandleSave(pipelineCacheCtrl))
+			r.Delete("/", handlerpipelinecache.HandleDelete(pipelineCacheCtrl))
+		})
+	})
+}
+
 func setupConnectors(
 	r chi.Router,
 	connectorCtrl *connector.Controller,
@@ -607,7 +668,13 @@ func setupExecutions(
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamExecutionNumber), func(r chi.Router) {
 			r.Get("/", handlerexecution.HandleFind(executionCtrl))
 			r.Post("/cancel", handlerexecution.HandleCancel(executionCtrl))
+			r.Post("/retry", handlerexecution.HandleRetry(executionCtrl))
 			r.Delete("/", handlerexecution.HandleDelete(executionCtrl))
+			r.Get("/approvals", handlerexecution.HandleListApprovals(executionCtrl))
+			r.Route(fmt.Sprintf("/stages/{%s}", request.PathParamStageNumber), func(r chi.Router) {
+				r.Post("/approve", handlerexecution.HandleApproveStage(executionCtrl))
+				r.Post("/reject", handlerexecution.HandleRejectStage(executionCtrl))
+			})
 			r.Get(
 				fmt.Sprintf("/logs/{%s}/{%s}",
 					request.PathParamStageNumber,
@@ -619,6 +686,18 @@ func setupExecutions(
 					request.PathParamStageNumber,
 					request.PathParamStepNumber,
 				), handlerlogs.HandleTail(logCtrl))
+			r.Route("/tests", func(r chi.Router) {
+				r.Get("/", handlerexecution.HandleListTests(executionCtrl))
+				r.Get("/summary", handlerexecution.HandleFindTestSummary(executionCtrl))
+			})
+			r.Post("/test-reports", handlerexecution.HandleUploadTestReport(executionCtrl))
+			r.Route("/artifacts", func(r chi.Router) {
+				r.Get("/", handlerexecution.HandleListArtifacts(executionCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamArtifactName), func(r chi.Router) {
+					r.Get("/", handlerexecution.HandleDownloadArtifact(executionCtrl))
+					r.Post("/", handlerexecution.HandleUploadArtifact(executionCtrl))
+				})
+			})
 		})
 	})
 }
@@ -656,6 +735,8 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 	r.Route("/pullreq", func(r chi.Router) {
 		r.Post("/", handlerpullreq.HandleCreate(pullreqCtrl))
 		r.Get("/", handlerpullreq.HandleList(pullreqCtrl))
+		r.Get("/merge-queue", handlerpullreq.HandleMergeQueueList(pullreqCtrl))
+		r.Get("/templates", handlerpullreq.HandleTemplateList(pullreqCtrl))
 		r.Get(
 			fmt.Sprintf("/{%s}...{%s}", request.PathParamTargetBranch, request.PathParamSourceBranch),
 			handlerpullreq.HandleFindByBranches(pullreqCtrl),
@@ -695,6 +776,21 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 				r.Post("/", handlerpullreq.HandleReviewSubmit(pullreqCtrl))
 			})
 			r.Post("/merge", handlerpullreq.HandleMerge(pullreqCtrl))
+			r.Route("/merge-queue", func(r chi.Router) {
+				r.Post("/", handlerpullreq.HandleMergeQueueAdd(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleMergeQueueRemove(pullreqCtrl))
+			})
+			r.Route("/auto-merge", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandleAutoMergeFind(pullreqCtrl))
+				r.Post("/", handlerpullreq.HandleAutoMergeEnable(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleAutoMergeDisable(pullreqCtrl))
+			})
+			r.Route("/dependencies", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandleDependencyList(pullreqCtrl))
+				r.Post("/", handlerpullreq.HandleDependencyAdd(pullreqCtrl))
+				r.Delete(fmt.Sprintf("/{%s}", request.PathParamDependsOnNumber),
+					handlerpullreq.HandleDependencyDelete(pullreqCtrl))
+			})
 			r.Get("/commits", handlerpullreq.HandleCommits(pullreqCtrl))
 			r.Get("/metadata", handlerpullreq.HandleMetadata(pullreqCtrl))
 			r.Route("/branch", func(r chi.Router) {
@@ -711,6 +807,7 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 			r.Get("/diff", handlerpullreq.HandleDiff(pullreqCtrl))
 			r.Post("/diff", handlerpullreq.HandleDiff(pullreqCtrl))
 			r.Get("/checks", handlerpullreq.HandleCheckList(pullreqCtrl))
+			r.Get("/checks/annotations", handlerpullreq.HandleCheckAnnotationList(pullreqCtrl))
 
 			setupPullReqLabels(r, pullreqCtrl)
 		})
@@ -755,6 +852,7 @@ func SetupChecks(r chi.Router, checkCtrl *check.Controller) {
 		r.Route(fmt.Sprintf("/commits/{%s}", request.PathParamCommitSHA), func(r chi.Router) {
 			r.Put("/", handlercheck.HandleCheckReport(checkCtrl))
 			r.Get("/", handlercheck.HandleCheckList(checkCtrl))
+			r.Get("/annotations", handlercheck.HandleCheckAnnotationList(checkCtrl))
 		})
 	})
 }
@@ -808,6 +906,15 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 			r.Delete(fmt.Sprintf("/{%s}", request.PathParamPublicKeyIdentifier),
 				handleruser.HandleDeletePublicKey(userCtrl))
 		})
+
+		// Chat application accounts
+		r.Route("/chat-identities", func(r chi.Router) {
+			r.Get("/", handleruser.HandleListChatIdentities(userCtrl))
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamChatProvider), func(r chi.Router) {
+				r.Put("/", handleruser.HandleUpdateChatIdentity(userCtrl))
+				r.Delete("/", handleruser.HandleDeleteChatIdentity(userCtrl))
+			})
+		})
 	})
 }
 
@@ -886,7 +993,12 @@ func setupInfraProviders(r chi.Router, infraProviderCtrl *infraprovider.Controll
 	})
 }
 
-func setupAdmin(r chi.Router, userCtrl *user.Controller) {
+func setupAdmin(
+	r chi.Router,
+	userCtrl *user.Controller,
+	auditLogCtrl *auditlog.Controller,
+	runnerCtrl *runner.Controller,
+) {
 	r.Route("/admin", func(r chi.Router) {
 		r.Use(middlewareprincipal.RestrictToAdmin())
 		r.Route("/users", func(r chi.Router) {
@@ -900,6 +1012,35 @@ func setupAdmin(r chi.Router, userCtrl *user.Controller) {
 				r.Patch("/admin", handleruser.HandleUpdateAdmin(userCtrl))
 			})
 		})
+		r.Get("/audit-logs/export", handlerauditlog.HandleExport(auditLogCtrl))
+		r.Route("/runners", func(r chi.Router) {
+			r.Get("/", handlerrunner.HandleList(runnerCtrl))
+			r.Post("/", handlerrunner.HandleRegister(runnerCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamRunnerIdentifier), func(r chi.Router) {
+				r.Get("/", handlerrunner.HandleFind(runnerCtrl))
+				r.Delete("/", handlerrunner.HandleDelete(runnerCtrl))
+			})
+		})
+	})
+}
+
+func setupRunnerProtocol(r chi.Router, runnerCtrl *runner.Controller) {
+	r.Route("/runner", func(r chi.Router) {
+		r.Use(middlewarerunner.Authenticate(runnerCtrl))
+		r.Post("/request", handlerrunner.HandleRequest(runnerCtrl))
+		r.Route(fmt.Sprintf("/stages/{%s}", request.PathParamStageID), func(r chi.Router) {
+			r.Put("/", handlerrunner.HandleUpdateStage(runnerCtrl))
+			r.Post("/accept", handlerrunner.HandleAccept(runnerCtrl))
+			r.Get("/details", handlerrunner.HandleDetails(runnerCtrl))
+		})
+		r.Route(fmt.Sprintf("/steps/{%s}", request.PathParamStepID), func(r chi.Router) {
+			r.Put("/", handlerrunner.HandleUpdateStep(runnerCtrl))
+			r.Post("/logs", handlerrunner.HandleWriteLogs(runnerCtrl))
+			r.Post("/logs/upload", handlerrunner.HandleUploadLogs(runnerCtrl))
+		})
+		r.Get(fmt.Sprintf("/executions/{%s}/watch", request.PathParamExecutionID),
+			handlerrunner.HandleWatch(runnerCtrl))
 	})
 }
 
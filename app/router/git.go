@@ -18,7 +18,9 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/repo"
+	handlerlfs "github.com/harness/gitness/app/api/handler/lfs"
 	handlerrepo "github.com/harness/gitness/app/api/handler/repo"
 	middlewareauthn "github.com/harness/gitness/app/api/middleware/authn"
 	middlewareauthz "github.com/harness/gitness/app/api/middleware/authz"
@@ -43,6 +45,7 @@ func NewGitHandler(
 	urlProvider url.Provider,
 	authenticator authn.Authenticator,
 	repoCtrl *repo.Controller,
+	lfsCtrl *lfs.Controller,
 ) http.Handler {
 	// maxRepoDepth depends on config
 	maxRepoDepth := check.MaxRepoPathDepth
@@ -78,22 +81,32 @@ func NewGitHandler(
 		r.Group(func(r chi.Router) {
 			r.Use(middlewareauthz.BlockSessionToken)
 
-			// smart protocol
-			r.Post("/git-upload-pack", handlerrepo.HandleGitServicePack(
-				enum.GitServiceTypeUploadPack, repoCtrl, urlProvider))
-			r.Post("/git-receive-pack", handlerrepo.HandleGitServicePack(
-				enum.GitServiceTypeReceivePack, repoCtrl, urlProvider))
-			r.Get("/info/refs", handlerrepo.HandleGitInfoRefs(repoCtrl, urlProvider))
-
-			// dumb protocol
-			r.Get("/HEAD", stubGitHandler())
-			r.Get("/objects/info/alternates", stubGitHandler())
-			r.Get("/objects/info/http-alternates", stubGitHandler())
-			r.Get("/objects/info/packs", stubGitHandler())
-			r.Get("/objects/info/{file:[^/]*}", stubGitHandler())
-			r.Get("/objects/{head:[0-9a-f]{2}}/{hash:[0-9a-f]{38}}", stubGitHandler())
-			r.Get("/objects/pack/pack-{file:[0-9a-f]{40}}.pack", stubGitHandler())
-			r.Get("/objects/pack/pack-{file:[0-9a-f]{40}}.idx", stubGitHandler())
+			r.Group(func(r chi.Router) {
+				// tokens issued for git lfs are only allowed for the lfs API
+				r.Use(middlewareauthz.BlockLFSToken)
+
+				// smart protocol
+				r.Post("/git-upload-pack", handlerrepo.HandleGitServicePack(
+					enum.GitServiceTypeUploadPack, repoCtrl, urlProvider))
+				r.Post("/git-receive-pack", handlerrepo.HandleGitServicePack(
+					enum.GitServiceTypeReceivePack, repoCtrl, urlProvider))
+				r.Get("/info/refs", handlerrepo.HandleGitInfoRefs(repoCtrl, urlProvider))
+
+				// dumb protocol
+				r.Get("/HEAD", stubGitHandler())
+				r.Get("/objects/info/alternates", stubGitHandler())
+				r.Get("/objects/info/http-alternates", stubGitHandler())
+				r.Get("/objects/info/packs", stubGitHandler())
+				r.Get("/objects/info/{file:[^/]*}", stubGitHandler())
+				r.Get("/objects/{head:[0-9a-f]{2}}/{hash:[0-9a-f]{38}}", stubGitHandler())
+				r.Get("/objects/pack/pack-{file:[0-9a-f]{40}}.pack", stubGitHandler())
+				r.Get("/objects/pack/pack-{file:[0-9a-f]{40}}.idx", stubGitHandler())
+			})
+
+			// git lfs
+			r.Route("/info/lfs", func(r chi.Router) {
+				setupLFS(r, lfsCtrl, urlProvider)
+			})
 		})
 	})
 
@@ -101,6 +114,21 @@ func NewGitHandler(
 	return encode.GitPathBefore(r)
 }
 
+func setupLFS(r chi.Router, lfsCtrl *lfs.Controller, urlProvider url.Provider) {
+	r.Route("/objects", func(r chi.Router) {
+		r.Post("/batch", handlerlfs.HandleBatch(lfsCtrl, urlProvider))
+		r.Put(fmt.Sprintf("/{%s}", request.PathParamLFSObjectID), handlerlfs.HandleUpload(lfsCtrl, urlProvider))
+		r.Get(fmt.Sprintf("/{%s}", request.PathParamLFSObjectID), handlerlfs.HandleDownload(lfsCtrl, urlProvider))
+	})
+
+	r.Route("/locks", func(r chi.Router) {
+		r.Get("/", handlerlfs.HandleLockList(lfsCtrl, urlProvider))
+		r.Post("/", handlerlfs.HandleLockCreate(lfsCtrl, urlProvider))
+		r.Post("/verify", handlerlfs.HandleLockVerify(lfsCtrl, urlProvider))
+		r.Post(fmt.Sprintf("/{%s}/unlock", request.PathParamLFSLockID), handlerlfs.HandleLockDelete(lfsCtrl, urlProvider))
+	})
+}
+
 func stubGitHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, _ *http.Request) {
 		_, _ = w.Write([]byte("Seems like an asteroid destroyed the ancient git protocol"))
@@ -0,0 +1,127 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+)
+
+var _ audit.Service = (*Persister)(nil)
+
+// Persister is an audit Service that stores audit events in the database.
+type Persister struct {
+	auditEventStore store.AuditEventStore
+}
+
+func NewPersister(auditEventStore store.AuditEventStore) *Persister {
+	return &Persister{
+		auditEventStore: auditEventStore,
+	}
+}
+
+func (s *Persister) Log(
+	ctx context.Context,
+	user types.Principal,
+	resource audit.Resource,
+	action audit.Action,
+	spacePath string,
+	options ...audit.Option,
+) error {
+	event := audit.Event{
+		Timestamp:     time.Now().UnixMilli(),
+		Action:        action,
+		User:          user,
+		SpacePath:     spacePath,
+		Resource:      resource,
+		ClientIP:      audit.GetRealIP(ctx),
+		RequestMethod: audit.GetRequestMethod(ctx),
+	}
+
+	for _, option := range options {
+		option.Apply(&event)
+	}
+
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid audit event: %w", err)
+	}
+
+	oldObject, err := marshalObject(event.DiffObject.OldObject)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit event old object: %w", err)
+	}
+
+	newObject, err := marshalObject(event.DiffObject.NewObject)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit event new object: %w", err)
+	}
+
+	err = s.auditEventStore.Create(ctx, &types.AuditEvent{
+		Created:            event.Timestamp,
+		Action:             string(event.Action),
+		ResourceType:       string(event.Resource.Type),
+		ResourceIdentifier: event.Resource.Identifier,
+		ResourceData:       event.Resource.Data,
+		SpacePath:          event.SpacePath,
+		RepoPath:           repoPathOf(&event),
+		Actor:              *event.User.ToPrincipalInfo(),
+		OldObject:          oldObject,
+		NewObject:          newObject,
+		ClientIP:           event.ClientIP,
+		RequestMethod:      event.RequestMethod,
+		RequestID:          audit.GetRequestID(ctx),
+		Data:               event.Data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store audit event: %w", err)
+	}
+
+	return nil
+}
+
+func marshalObject(object any) (json.RawMessage, error) {
+	if object == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(object)
+}
+
+// repoPathOf returns path of the repository the event belongs to,
+// or an empty string if the event isn't related to a single repository.
+func repoPathOf(event *audit.Event) string {
+	if repoPath := event.Resource.Data[audit.RepoPath]; repoPath != "" {
+		return repoPath
+	}
+
+	switch event.Resource.Type {
+	case audit.ResourceTypeRepository, audit.ResourceTypeRepositorySettings:
+		return paths.Concatenate(event.SpacePath, event.Resource.Identifier)
+	default:
+	}
+
+	if repoName := event.Resource.Data[audit.RepoName]; repoName != "" {
+		return paths.Concatenate(event.SpacePath, repoName)
+	}
+
+	return ""
+}
@@ -0,0 +1,142 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/pipeline/storage"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypePipelineStorage        = "gitness:cleanup:pipeline-storage"
+	jobCronPipelineStorage        = "35 */6 * * *" // At minute 35 past every 6th hour.
+	jobMaxDurationPipelineStorage = 10 * time.Minute
+
+	pipelineStorageBatchSize = 100
+)
+
+type pipelineStorageCleanupJob struct {
+	cacheRetentionTime    time.Duration
+	artifactRetentionTime time.Duration
+
+	cacheStore    store.PipelineCacheStore
+	artifactStore store.PipelineArtifactStore
+	blobStore     blob.Store
+}
+
+func newPipelineStorageCleanupJob(
+	cacheRetentionTime time.Duration,
+	artifactRetentionTime time.Duration,
+	cacheStore store.PipelineCacheStore,
+	artifactStore store.PipelineArtifactStore,
+	blobStore blob.Store,
+) *pipelineStorageCleanupJob {
+	return &pipelineStorageCleanupJob{
+		cacheRetentionTime:    cacheRetentionTime,
+		artifactRetentionTime: artifactRetentionTime,
+
+		cacheStore:    cacheStore,
+		artifactStore: artifactStore,
+		blobStore:     blobStore,
+	}
+}
+
+// Handle purges pipeline caches that weren't used and pipeline artifacts that were created
+// before the retention time.
+func (j *pipelineStorageCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	cachesUnusedSince := time.Now().Add(-j.cacheRetentionTime)
+	artifactsCreatedBefore := time.Now().Add(-j.artifactRetentionTime)
+
+	log.Ctx(ctx).Info().Msgf(
+		"start purging pipeline caches unused since %s and pipeline artifacts created before %s",
+		cachesUnusedSince.Format(time.RFC3339Nano),
+		artifactsCreatedBefore.Format(time.RFC3339Nano))
+
+	caches, err := j.purgeCaches(ctx, cachesUnusedSince.UnixMilli())
+	if err != nil {
+		return "", err
+	}
+
+	artifacts, err := j.purgeArtifacts(ctx, artifactsCreatedBefore.UnixMilli())
+	if err != nil {
+		return "", err
+	}
+
+	result := "no old pipeline caches or artifacts found"
+	if caches > 0 || artifacts > 0 {
+		result = fmt.Sprintf("deleted %d pipeline caches and %d pipeline artifacts", caches, artifacts)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+func (j *pipelineStorageCleanupJob) purgeCaches(ctx context.Context, lastUsedBefore int64) (int, error) {
+	count := 0
+	for {
+		caches, err := j.cacheStore.ListUnused(ctx, lastUsedBefore, pipelineStorageBatchSize)
+		if err != nil {
+			return count, fmt.Errorf("failed to list unused pipeline caches: %w", err)
+		}
+
+		for _, cache := range caches {
+			if err := j.blobStore.Delete(ctx, storage.CacheBlobPath(cache.RepoID, cache.Key)); err != nil {
+				return count, fmt.Errorf("failed to delete pipeline cache archive: %w", err)
+			}
+			if err := j.cacheStore.Delete(ctx, cache.RepoID, cache.Key); err != nil {
+				return count, fmt.Errorf("failed to delete pipeline cache: %w", err)
+			}
+			count++
+		}
+
+		if len(caches) < pipelineStorageBatchSize {
+			return count, nil
+		}
+	}
+}
+
+func (j *pipelineStorageCleanupJob) purgeArtifacts(ctx context.Context, createdBefore int64) (int, error) {
+	count := 0
+	for {
+		artifacts, err := j.artifactStore.ListCreatedBefore(ctx, createdBefore, pipelineStorageBatchSize)
+		if err != nil {
+			return count, fmt.Errorf("failed to list old pipeline artifacts: %w", err)
+		}
+
+		for _, artifact := range artifacts {
+			blobPath := storage.ArtifactBlobPath(artifact.RepoID, artifact.ExecutionID, artifact.Name)
+			if err := j.blobStore.Delete(ctx, blobPath); err != nil {
+				return count, fmt.Errorf("failed to delete pipeline artifact file: %w", err)
+			}
+			if err := j.artifactStore.Delete(ctx, artifact.ID); err != nil {
+				return count, fmt.Errorf("failed to delete pipeline artifact: %w", err)
+			}
+			count++
+		}
+
+		if len(artifacts) < pipelineStorageBatchSize {
+			return count, nil
+		}
+	}
+}
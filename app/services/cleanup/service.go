@@ -22,12 +22,15 @@ import (
 
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/job"
 )
 
 type Config struct {
 	WebhookExecutionsRetentionTime   time.Duration
 	DeletedRepositoriesRetentionTime time.Duration
+	PipelineCacheRetentionTime       time.Duration
+	PipelineArtifactRetentionTime    time.Duration
 }
 
 func (c *Config) Prepare() error {
@@ -41,6 +44,14 @@ func (c *Config) Prepare() error {
 	if c.DeletedRepositoriesRetentionTime <= 0 {
 		return errors.New("config.DeletedRepositoriesRetentionTime has to be provided")
 	}
+
+	if c.PipelineCacheRetentionTime <= 0 {
+		return errors.New("config.PipelineCacheRetentionTime has to be provided")
+	}
+
+	if c.PipelineArtifactRetentionTime <= 0 {
+		return errors.New("config.PipelineArtifactRetentionTime has to be provided")
+	}
 	return nil
 }
 
@@ -53,6 +64,9 @@ type Service struct {
 	tokenStore            store.TokenStore
 	repoStore             store.RepoStore
 	repoCtrl              *repo.Controller
+	pipelineCacheStore    store.PipelineCacheStore
+	pipelineArtifactStore store.PipelineArtifactStore
+	blobStore             blob.Store
 }
 
 func NewService(
@@ -63,6 +77,9 @@ func NewService(
 	tokenStore store.TokenStore,
 	repoStore store.RepoStore,
 	repoCtrl *repo.Controller,
+	pipelineCacheStore store.PipelineCacheStore,
+	pipelineArtifactStore store.PipelineArtifactStore,
+	blobStore blob.Store,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided cleanup config is invalid: %w", err)
@@ -77,6 +94,9 @@ func NewService(
 		tokenStore:            tokenStore,
 		repoStore:             repoStore,
 		repoCtrl:              repoCtrl,
+		pipelineCacheStore:    pipelineCacheStore,
+		pipelineArtifactStore: pipelineArtifactStore,
+		blobStore:             blobStore,
 	}, nil
 }
 
@@ -126,6 +146,17 @@ func (s *Service) scheduleRecurringCleanupJobs(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to schedule deleted repo cleanup job: %w", err)
 	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypePipelineStorage,
+		jobTypePipelineStorage,
+		jobCronPipelineStorage,
+		jobMaxDurationPipelineStorage,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule pipeline storage cleanup job: %w", err)
+	}
 	return nil
 }
 
@@ -160,5 +191,18 @@ func (s *Service) registerJobHandlers() error {
 	); err != nil {
 		return fmt.Errorf("failed to register job handler for deleted repos cleanup: %w", err)
 	}
+
+	if err := s.executor.Register(
+		jobTypePipelineStorage,
+		newPipelineStorageCleanupJob(
+			s.config.PipelineCacheRetentionTime,
+			s.config.PipelineArtifactRetentionTime,
+			s.pipelineCacheStore,
+			s.pipelineArtifactStore,
+			s.blobStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for pipeline storage cleanup: %w", err)
+	}
 	return nil
 }
@@ -122,7 +122,8 @@ func (r *Repository) processPipelines(ctx context.Context,
 				CreatedBy:   principal.ID,
 				Identifier:  "default",
 				Actions: []enum.TriggerAction{enum.TriggerActionPullReqCreated,
-					enum.TriggerActionPullReqReopened, enum.TriggerActionPullReqBranchUpdated},
+					enum.TriggerActionPullReqReopened, enum.TriggerActionPullReqBranchUpdated,
+					enum.TriggerActionPullReqMergeQueueUpdated},
 				Disabled: false,
 				Version:  0,
 			}
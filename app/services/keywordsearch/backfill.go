@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const jobTypeBackfill = "keyword-search-backfill"
+
+// Register registers the recurring job that indexes all repositories without an up-to-date index.
+// The repositories that already have an up-to-date index are skipped by the indexer.
+func (s *Service) Register(ctx context.Context) error {
+	err := s.scheduler.AddRecurring(ctx, jobTypeBackfill, jobTypeBackfill,
+		s.config.BackfillCRON, s.config.BackfillMaxDuration)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for keyword search backfill: %w", err)
+	}
+
+	return nil
+}
+
+// Handle is the backfill job handler.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	repoInfos, err := s.repoStore.ListSizeInfos(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	var processed, failed int
+	for _, repoInfo := range repoInfos {
+		if ctx.Err() != nil {
+			break
+		}
+
+		repo, err := s.repoStore.Find(ctx, repoInfo.ID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repoInfo.ID).Msg("failed to find repository")
+			failed++
+			continue
+		}
+
+		if err = s.indexer.Index(ctx, repo); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).Msg("failed to index repository")
+			failed++
+			continue
+		}
+
+		processed++
+	}
+
+	log.Ctx(ctx).Info().
+		Int("processed", processed).
+		Int("failed", failed).
+		Msg("keyword search backfill finished")
+
+	return "", nil
+}
@@ -0,0 +1,239 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+// localIndexVersion is increased every time the format of the local index changes.
+// Indexes with a different version are discarded and rebuilt from scratch.
+const localIndexVersion = 2
+
+// localIndex is the on-disk keyword search index of the default branch of a single repository.
+// The index doesn't contain the content of the files, it's read from the repository for the files
+// that contain all trigrams of the query.
+type localIndex struct {
+	Header localIndexHeader
+	Files  []localIndexFile
+
+	// Trigrams maps every trigram of the lower-cased file contents
+	// to the sorted list of indices of the files that contain it.
+	Trigrams map[trigram][]uint32
+}
+
+// localIndexHeader is stored at the beginning of the index file.
+// It can be read without decoding the rest of the index.
+type localIndexHeader struct {
+	Version   int
+	RepoID    int64
+	RepoUID   string
+	Branch    string
+	CommitSHA string
+}
+
+// localIndexFile is a single indexed file.
+// Files that are not indexed (e.g. binary files) are kept in the index
+// only to avoid reading them again on every update.
+type localIndexFile struct {
+	Path    string
+	BlobSHA string
+	Indexed bool
+}
+
+// addTrigrams adds the trigrams of the content to the posting lists of the provided files.
+func (idx *localIndex) addTrigrams(content []byte, fileIndices []uint32) {
+	for t := range trigramsOf(bytes.ToLower(content)) {
+		idx.Trigrams[t] = append(idx.Trigrams[t], fileIndices...)
+	}
+}
+
+// sortTrigrams sorts the posting lists, which is required by the intersection of the lists.
+func (idx *localIndex) sortTrigrams() {
+	for _, list := range idx.Trigrams {
+		slices.Sort(list)
+	}
+}
+
+// candidates returns the indices of all files that contain all the provided trigrams.
+func (idx *localIndex) candidates(trigrams []trigram) []uint32 {
+	if len(trigrams) == 0 {
+		all := make([]uint32, 0, len(idx.Files))
+		for i := range idx.Files {
+			if idx.Files[i].Indexed {
+				all = append(all, uint32(i))
+			}
+		}
+		return all
+	}
+
+	// start with the shortest posting list to keep the intersection small
+	lists := make([][]uint32, len(trigrams))
+	for i, t := range trigrams {
+		lists[i] = idx.Trigrams[t]
+		if len(lists[i]) == 0 {
+			return nil
+		}
+	}
+	sort.Slice(lists, func(i, j int) bool {
+		return len(lists[i]) < len(lists[j])
+	})
+
+	result := lists[0]
+	for _, list := range lists[1:] {
+		result = intersect(result, list)
+		if len(result) == 0 {
+			return nil
+		}
+	}
+
+	return result
+}
+
+// intersect returns the intersection of two sorted lists.
+func intersect(a, b []uint32) []uint32 {
+	result := make([]uint32, 0, min(len(a), len(b)))
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+func (s *LocalIndexSearcher) indexPath(repoID int64) string {
+	return filepath.Join(s.config.IndexDir, strconv.FormatInt(repoID, 10)+".idx")
+}
+
+// loadIndexHeader reads only the header of the index of the repository from disk.
+// It returns nil if the repository hasn't been indexed yet or the index is outdated.
+func (s *LocalIndexSearcher) loadIndexHeader(repoID int64) (*localIndexHeader, error) {
+	idx, err := s.readIndex(repoID, true)
+	if err != nil || idx == nil {
+		return nil, err
+	}
+
+	return &idx.Header, nil
+}
+
+// loadIndex reads the index of the repository from disk.
+// It returns nil if the repository hasn't been indexed yet or the index is outdated.
+func (s *LocalIndexSearcher) loadIndex(repoID int64) (*localIndex, error) {
+	return s.readIndex(repoID, false)
+}
+
+func (s *LocalIndexSearcher) readIndex(repoID int64, headerOnly bool) (*localIndex, error) {
+	f, err := os.Open(s.indexPath(repoID))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open index file: %w", err)
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gzip reader for index file: %w", err)
+	}
+	defer zr.Close()
+
+	dec := gob.NewDecoder(zr)
+
+	idx := &localIndex{}
+	if err = dec.Decode(&idx.Header); err != nil {
+		return nil, fmt.Errorf("failed to decode index file header: %w", err)
+	}
+
+	if idx.Header.Version != localIndexVersion || idx.Header.RepoID != repoID {
+		return nil, nil
+	}
+
+	if headerOnly {
+		return idx, nil
+	}
+
+	if err = dec.Decode(&idx.Files); err != nil {
+		return nil, fmt.Errorf("failed to decode index file list: %w", err)
+	}
+	if err = dec.Decode(&idx.Trigrams); err != nil {
+		return nil, fmt.Errorf("failed to decode index trigrams: %w", err)
+	}
+
+	return idx, nil
+}
+
+// storeIndex writes the index of the repository to disk.
+// The index is written to a temporary file first to ensure readers never see a partially written index.
+func (s *LocalIndexSearcher) storeIndex(idx *localIndex) error {
+	f, err := os.CreateTemp(s.config.IndexDir, strconv.FormatInt(idx.Header.RepoID, 10)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary index file: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+
+	zw := gzip.NewWriter(f)
+	enc := gob.NewEncoder(zw)
+	if err = enc.Encode(idx.Header); err != nil {
+		return fmt.Errorf("failed to encode index header: %w", err)
+	}
+	if err = enc.Encode(idx.Files); err != nil {
+		return fmt.Errorf("failed to encode index file list: %w", err)
+	}
+	if err = enc.Encode(idx.Trigrams); err != nil {
+		return fmt.Errorf("failed to encode index trigrams: %w", err)
+	}
+	if err = zw.Close(); err != nil {
+		return fmt.Errorf("failed to flush index: %w", err)
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary index file: %w", err)
+	}
+
+	if err = os.Rename(f.Name(), s.indexPath(idx.Header.RepoID)); err != nil {
+		return fmt.Errorf("failed to move index file into place: %w", err)
+	}
+
+	return nil
+}
+
+// deleteIndex removes the index of the repository from disk.
+func (s *LocalIndexSearcher) deleteIndex(repoID int64) error {
+	err := os.Remove(s.indexPath(repoID))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete index file: %w", err)
+	}
+	return nil
+}
@@ -15,28 +15,402 @@
 package keywordsearch
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync"
 
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/exp/maps"
+)
+
+const (
+	// readBlobsBatchSize is the max number of blobs read from git at once during indexing.
+	readBlobsBatchSize = 500
+
+	// binaryProbeSize is the number of bytes checked for a NUL byte to detect binary files.
+	binaryProbeSize = 8000
 )
 
+// LocalIndexSearcher is an embedded keyword search backend.
+// It maintains a trigram index of the default branch of every repository in the local file system,
+// which means that all instances that serve search requests have to share the index directory.
 type LocalIndexSearcher struct {
+	config Config
+	git    git.Interface
+
+	repoMx sync.Map // map[int64]*sync.Mutex
+}
+
+func NewLocalIndexSearcher(config Config, git git.Interface) (*LocalIndexSearcher, error) {
+	if config.IndexDir == "" {
+		return nil, errors.New("config.IndexDir is required")
+	}
+
+	if err := os.MkdirAll(config.IndexDir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create keyword search index directory: %w", err)
+	}
+
+	return &LocalIndexSearcher{
+		config: config,
+		git:    git,
+	}, nil
+}
+
+// Index updates the index of the default branch of the repository.
+// Files that didn't change since the last update are taken over from the existing index.
+func (s *LocalIndexSearcher) Index(ctx context.Context, repo *types.Repository) error {
+	mx, _ := s.repoMx.LoadOrStore(repo.ID, &sync.Mutex{})
+	mx.(*sync.Mutex).Lock()
+	defer mx.(*sync.Mutex).Unlock()
+
+	readParams := git.CreateReadParams(repo)
+
+	branchOut, err := s.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: readParams,
+		BranchName: repo.DefaultBranch,
+	})
+	if errors.IsNotFound(err) {
+		// the default branch doesn't exist (e.g. empty repository) - nothing to search in
+		return s.deleteIndex(repo.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get default branch: %w", err)
+	}
+
+	commitSHA := branchOut.Branch.SHA.String()
+
+	header, err := s.loadIndexHeader(repo.ID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).Msg("failed to load existing index, rebuilding")
+		header = nil
+	}
+
+	if header != nil && header.CommitSHA == commitSHA && header.Branch == repo.DefaultBranch {
+		return nil
+	}
+
+	var oldIdx *localIndex
+	if header != nil {
+		oldIdx, err = s.loadIndex(repo.ID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).Msg("failed to load existing index, rebuilding")
+			oldIdx = nil
+		}
+	}
+
+	filesOut, err := s.git.ListFiles(ctx, &git.ListFilesParams{
+		ReadParams: readParams,
+		GitREF:     commitSHA,
+	})
+	if errors.IsNotFound(err) {
+		// the commit has an empty tree
+		filesOut = &git.ListFilesOutput{}
+	} else if err != nil {
+		return fmt.Errorf("failed to list files: %w", err)
+	}
+
+	// oldFiles maps blob SHAs to the index of the file in the old index
+	oldFiles := map[string]uint32{}
+	if oldIdx != nil {
+		for i := range oldIdx.Files {
+			if _, ok := oldFiles[oldIdx.Files[i].BlobSHA]; !ok {
+				oldFiles[oldIdx.Files[i].BlobSHA] = uint32(i)
+			}
+		}
+	}
+
+	idx := &localIndex{
+		Header: localIndexHeader{
+			Version:   localIndexVersion,
+			RepoID:    repo.ID,
+			RepoUID:   repo.GitUID,
+			Branch:    repo.DefaultBranch,
+			CommitSHA: commitSHA,
+		},
+		Files:    make([]localIndexFile, 0, len(filesOut.Files)),
+		Trigrams: make(map[trigram][]uint32),
+	}
+
+	reused := map[uint32][]uint32{}  // index of the file in the old index -> indices of the files in the new index
+	missing := map[string][]uint32{} // blob SHA -> indices of the files in the new index
+	for _, f := range filesOut.Files {
+		if s.config.MaxFileSize > 0 && f.Size > s.config.MaxFileSize {
+			continue
+		}
+
+		fileIdx := uint32(len(idx.Files))
+		file := localIndexFile{
+			Path:    f.Path,
+			BlobSHA: f.SHA.String(),
+		}
+
+		if oldFileIdx, ok := oldFiles[file.BlobSHA]; ok {
+			file.Indexed = oldIdx.Files[oldFileIdx].Indexed
+			if file.Indexed {
+				reused[oldFileIdx] = append(reused[oldFileIdx], fileIdx)
+			}
+		} else {
+			missing[file.BlobSHA] = append(missing[file.BlobSHA], fileIdx)
+		}
+
+		idx.Files = append(idx.Files, file)
+	}
+
+	// take over the posting lists of the unchanged files
+	if len(reused) > 0 {
+		for t, list := range oldIdx.Trigrams {
+			for _, oldFileIdx := range list {
+				idx.Trigrams[t] = append(idx.Trigrams[t], reused[oldFileIdx]...)
+			}
+		}
+	}
+
+	err = s.readBlobs(ctx, readParams, maps.Keys(missing), func(blobSHA string, content []byte) {
+		for _, fileIdx := range missing[blobSHA] {
+			idx.Files[fileIdx].Indexed = true
+		}
+		idx.addTrigrams(content, missing[blobSHA])
+	})
+	if err != nil {
+		return err
+	}
+
+	idx.sortTrigrams()
+
+	if err = s.storeIndex(idx); err != nil {
+		return fmt.Errorf("failed to store index: %w", err)
+	}
+
+	log.Ctx(ctx).Debug().
+		Int64("repo_id", repo.ID).
+		Str("commit_sha", commitSHA).
+		Int("files", len(idx.Files)).
+		Int("files_read", len(missing)).
+		Msg("keyword search index updated")
+
+	return nil
+}
+
+// Delete removes the index of the repository.
+func (s *LocalIndexSearcher) Delete(_ context.Context, repoID int64) error {
+	mx, _ := s.repoMx.LoadOrStore(repoID, &sync.Mutex{})
+	mx.(*sync.Mutex).Lock()
+	defer mx.(*sync.Mutex).Unlock()
+
+	return s.deleteIndex(repoID)
 }
 
-func NewLocalIndexSearcher() *LocalIndexSearcher {
-	return &LocalIndexSearcher{}
+// readBlobs reads the content of the blobs in batches and calls fn for each of them.
+// Binary blobs are skipped which excludes them from the search.
+func (s *LocalIndexSearcher) readBlobs(
+	ctx context.Context,
+	readParams git.ReadParams,
+	shas []string,
+	fn func(blobSHA string, content []byte),
+) error {
+	for len(shas) > 0 {
+		batch := shas[:min(len(shas), readBlobsBatchSize)]
+		shas = shas[len(batch):]
+
+		out, err := s.git.ReadBlobs(ctx, &git.ReadBlobsParams{
+			ReadParams: readParams,
+			SHAs:       batch,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to read blobs: %w", err)
+		}
+
+		for _, blob := range out.Blobs {
+			if bytes.IndexByte(blob.Content[:min(len(blob.Content), binaryProbeSize)], 0) >= 0 {
+				continue
+			}
+
+			fn(blob.SHA.String(), blob.Content)
+		}
+	}
+
+	return nil
 }
 
+// Search searches the indexed default branches of the provided repositories.
+// The maxResultCount limits the number of returned files (0 means no limit).
 func (s *LocalIndexSearcher) Search(
-	_ context.Context,
-	_ []int64,
-	_ string,
-	_ bool,
-	_ int,
+	ctx context.Context,
+	repoIDs []int64,
+	query string,
+	enableRegex bool,
+	maxResultCount int,
 ) (types.SearchResult, error) {
-	return types.SearchResult{}, nil
+	q, err := compileQuery(query, enableRegex)
+	if err != nil {
+		return types.SearchResult{}, err
+	}
+
+	repoIDs = slices.Clone(repoIDs)
+	slices.Sort(repoIDs)
+
+	result := types.SearchResult{
+		FileMatches: []types.FileMatch{},
+	}
+
+	for _, repoID := range repoIDs {
+		if err := ctx.Err(); err != nil {
+			return types.SearchResult{}, err
+		}
+
+		idx, err := s.loadIndex(repoID)
+		if err != nil {
+			return types.SearchResult{}, fmt.Errorf("failed to load index of repo %d: %w", repoID, err)
+		}
+		if idx == nil {
+			continue
+		}
+
+		// the content of the candidate files is read from the repository in batches
+		candidates := idx.candidates(q.trigrams)
+		for len(candidates) > 0 {
+			batch := candidates[:min(len(candidates), readBlobsBatchSize)]
+			candidates = candidates[len(batch):]
+
+			blobSHAs := make([]string, len(batch))
+			for i, fileIdx := range batch {
+				blobSHAs[i] = idx.Files[fileIdx].BlobSHA
+			}
+			slices.Sort(blobSHAs)
+			blobSHAs = slices.Compact(blobSHAs)
+
+			contents := make(map[string][]byte, len(blobSHAs))
+			err = s.readBlobs(ctx, git.ReadParams{RepoUID: idx.Header.RepoUID}, blobSHAs,
+				func(blobSHA string, content []byte) {
+					contents[blobSHA] = content
+				})
+			if err != nil {
+				return types.SearchResult{}, fmt.Errorf("failed to read files of repo %d: %w", repoID, err)
+			}
+
+			for _, fileIdx := range batch {
+				file := &idx.Files[fileIdx]
+
+				matches := findMatches(q, contents[file.BlobSHA])
+				if len(matches) == 0 {
+					continue
+				}
+
+				result.FileMatches = append(result.FileMatches, types.FileMatch{
+					FileName:   file.Path,
+					RepoID:     repoID,
+					RepoBranch: idx.Header.Branch,
+					Language:   languageOf(file.Path),
+					Matches:    matches,
+				})
+				result.Stats.TotalMatches += len(matches)
+
+				if maxResultCount > 0 && len(result.FileMatches) >= maxResultCount {
+					result.Stats.TotalFiles = len(result.FileMatches)
+					return result, nil
+				}
+			}
+		}
+	}
+
+	result.Stats.TotalFiles = len(result.FileMatches)
+
+	return result, nil
 }
 
-func (s *LocalIndexSearcher) Index(_ context.Context, _ *types.Repository) error {
-	return nil
+// findMatches returns all lines of the content that match the query.
+func findMatches(q *localQuery, content []byte) []types.Match {
+	lines := strings.Split(string(content), "\n")
+
+	var matches []types.Match
+	for i, line := range lines {
+		locs := q.matcher.FindAllStringIndex(line, -1)
+		if len(locs) == 0 {
+			continue
+		}
+
+		fragments := make([]types.Fragment, 0, len(locs))
+		prevEnd := 0
+		for _, loc := range locs {
+			if loc[0] == loc[1] {
+				// ignore empty matches (e.g. of patterns like "a*")
+				continue
+			}
+
+			fragments = append(fragments, types.Fragment{
+				Pre:   line[prevEnd:loc[0]],
+				Match: line[loc[0]:loc[1]],
+			})
+			prevEnd = loc[1]
+		}
+		if len(fragments) == 0 {
+			continue
+		}
+
+		// the rest of the line after the last non-empty match
+		fragments[len(fragments)-1].Post = line[prevEnd:]
+
+		match := types.Match{
+			LineNum:   i + 1,
+			Fragments: fragments,
+		}
+		if i > 0 {
+			match.Before = lines[i-1]
+		}
+		if i < len(lines)-1 {
+			match.After = lines[i+1]
+		}
+
+		matches = append(matches, match)
+	}
+
+	return matches
+}
+
+// languages maps the file extensions to the language reported in search results.
+var languages = map[string]string{
+	".c":     "C",
+	".cc":    "C++",
+	".cpp":   "C++",
+	".cs":    "C#",
+	".css":   "CSS",
+	".go":    "Go",
+	".h":     "C",
+	".hpp":   "C++",
+	".html":  "HTML",
+	".java":  "Java",
+	".js":    "JavaScript",
+	".json":  "JSON",
+	".jsx":   "JavaScript",
+	".kt":    "Kotlin",
+	".md":    "Markdown",
+	".php":   "PHP",
+	".py":    "Python",
+	".rb":    "Ruby",
+	".rs":    "Rust",
+	".scala": "Scala",
+	".sh":    "Shell",
+	".sql":   "SQL",
+	".swift": "Swift",
+	".ts":    "TypeScript",
+	".tsx":   "TypeScript",
+	".xml":   "XML",
+	".yaml":  "YAML",
+	".yml":   "YAML",
+}
+
+func languageOf(filePath string) string {
+	if path.Base(filePath) == "Dockerfile" {
+		return "Dockerfile"
+	}
+	return languages[strings.ToLower(path.Ext(filePath))]
 }
@@ -0,0 +1,127 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+)
+
+// trigram is a sequence of three bytes packed into an integer.
+type trigram uint32
+
+func newTrigram(a, b, c byte) trigram {
+	return trigram(uint32(a)<<16 | uint32(b)<<8 | uint32(c))
+}
+
+// trigramsOf returns the set of all trigrams of the provided data.
+func trigramsOf(data []byte) map[trigram]struct{} {
+	set := make(map[trigram]struct{})
+	for i := 0; i+2 < len(data); i++ {
+		set[newTrigram(data[i], data[i+1], data[i+2])] = struct{}{}
+	}
+	return set
+}
+
+// localQuery is a compiled keyword search query.
+type localQuery struct {
+	// matcher is used to find the matches within a single line.
+	matcher *regexp.Regexp
+
+	// trigrams are the lower-cased trigrams every matching file is guaranteed to contain.
+	trigrams []trigram
+}
+
+// compileQuery compiles the query. Plain text queries are matched case-insensitively.
+func compileQuery(query string, enableRegex bool) (*localQuery, error) {
+	if !enableRegex {
+		return &localQuery{
+			matcher:  regexp.MustCompile("(?i)" + regexp.QuoteMeta(query)),
+			trigrams: literalTrigrams([]string{query}),
+		}, nil
+	}
+
+	matcher, err := regexp.Compile(query)
+	if err != nil {
+		return nil, errors.InvalidArgument("invalid regular expression: %s", err)
+	}
+
+	re, err := syntax.Parse(query, syntax.Perl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse regular expression: %w", err)
+	}
+
+	return &localQuery{
+		matcher:  matcher,
+		trigrams: literalTrigrams(requiredLiterals(re.Simplify())),
+	}, nil
+}
+
+// literalTrigrams returns the distinct lower-cased trigrams of all provided literals.
+func literalTrigrams(literals []string) []trigram {
+	set := make(map[trigram]struct{})
+	for _, literal := range literals {
+		for t := range trigramsOf([]byte(strings.ToLower(literal))) {
+			set[t] = struct{}{}
+		}
+	}
+
+	trigrams := make([]trigram, 0, len(set))
+	for t := range set {
+		trigrams = append(trigrams, t)
+	}
+	return trigrams
+}
+
+// requiredLiterals returns literal strings that have to be part of every match of the regular expression.
+// The result is conservative - if nothing can be said about the expression, no literals are returned.
+func requiredLiterals(re *syntax.Regexp) []string {
+	switch re.Op {
+	case syntax.OpLiteral:
+		return []string{string(re.Rune)}
+	case syntax.OpCapture, syntax.OpPlus:
+		return requiredLiterals(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min < 1 {
+			return nil
+		}
+		return requiredLiterals(re.Sub[0])
+	case syntax.OpConcat:
+		var literals []string
+		var current strings.Builder
+		for _, sub := range re.Sub {
+			if sub.Op == syntax.OpLiteral {
+				current.WriteString(string(sub.Rune))
+				continue
+			}
+
+			if current.Len() > 0 {
+				literals = append(literals, current.String())
+				current.Reset()
+			}
+			literals = append(literals, requiredLiterals(sub)...)
+		}
+		if current.Len() > 0 {
+			literals = append(literals, current.String())
+		}
+		return literals
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"reflect"
+	"regexp/syntax"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestRequiredLiterals(t *testing.T) {
+	tests := []struct {
+		name  string
+		regex string
+		want  []string
+	}{
+		{
+			name:  "literal",
+			regex: "hello",
+			want:  []string{"hello"},
+		},
+		{
+			name:  "concat-with-wildcard",
+			regex: "func .*Search",
+			want:  []string{"func ", "Search"},
+		},
+		{
+			name:  "optional-is-ignored",
+			regex: "(abc)?def",
+			want:  []string{"def"},
+		},
+		{
+			name:  "plus-is-required",
+			regex: "x(abc)+",
+			want:  []string{"x", "abc"},
+		},
+		{
+			name:  "alternation-yields-nothing",
+			regex: "abc|def",
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			re, err := syntax.Parse(test.regex, syntax.Perl)
+			if err != nil {
+				t.Fatalf("failed to parse regex: %s", err)
+			}
+
+			got := requiredLiterals(re.Simplify())
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want=%q got=%q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestLocalIndex_Search(t *testing.T) {
+	contents := [][]byte{
+		[]byte("package main\n\nfunc main() {\n\tprintln(\"Hello\")\n}\n"),
+		[]byte("# hello world\n"),
+		nil,
+	}
+	idx := &localIndex{
+		Files: []localIndexFile{
+			{Path: "main.go", Indexed: true},
+			{Path: "README.md", Indexed: true},
+			{Path: "image.png", Indexed: false},
+		},
+		Trigrams: make(map[trigram][]uint32),
+	}
+	for i := range idx.Files {
+		if idx.Files[i].Indexed {
+			idx.addTrigrams(contents[i], []uint32{uint32(i)})
+		}
+	}
+	idx.sortTrigrams()
+
+	tests := []struct {
+		name        string
+		query       string
+		enableRegex bool
+		wantFiles   []string
+		wantLines   []int
+	}{
+		{
+			name:      "plain-text-is-case-insensitive",
+			query:     "HELLO",
+			wantFiles: []string{"main.go", "README.md"},
+			wantLines: []int{4, 1},
+		},
+		{
+			name:      "short-query-scans-all-files",
+			query:     "ma",
+			wantFiles: []string{"main.go"},
+			wantLines: []int{1},
+		},
+		{
+			name:        "regex",
+			query:       `func \w+\(`,
+			enableRegex: true,
+			wantFiles:   []string{"main.go"},
+			wantLines:   []int{3},
+		},
+		{
+			name:      "no-match",
+			query:     "goodbye",
+			wantFiles: nil,
+			wantLines: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := compileQuery(test.query, test.enableRegex)
+			if err != nil {
+				t.Fatalf("failed to compile query: %s", err)
+			}
+
+			var gotFiles []string
+			var gotLines []int
+			for _, i := range idx.candidates(q.trigrams) {
+				matches := findMatches(q, contents[i])
+				if len(matches) == 0 {
+					continue
+				}
+				gotFiles = append(gotFiles, idx.Files[i].Path)
+				gotLines = append(gotLines, matches[0].LineNum)
+			}
+
+			if !reflect.DeepEqual(gotFiles, test.wantFiles) {
+				t.Errorf("files: want=%v got=%v", test.wantFiles, gotFiles)
+			}
+			if !reflect.DeepEqual(gotLines, test.wantLines) {
+				t.Errorf("lines: want=%v got=%v", test.wantLines, gotLines)
+			}
+		})
+	}
+}
+
+func TestFindMatches_Fragments(t *testing.T) {
+	q, err := compileQuery("ab", false)
+	if err != nil {
+		t.Fatalf("failed to compile query: %s", err)
+	}
+
+	got := findMatches(q, []byte("first\nxabyABz\nlast"))
+	want := []types.Match{
+		{
+			LineNum: 2,
+			Fragments: []types.Fragment{
+				{Pre: "x", Match: "ab"},
+				{Pre: "y", Match: "AB", Post: "z"},
+			},
+			Before: "first",
+			After:  "last",
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want=%+v got=%+v", want, got)
+	}
+}
+
+func TestFindMatches_TrailingEmptyMatch(t *testing.T) {
+	q, err := compileQuery("b*", true)
+	if err != nil {
+		t.Fatalf("failed to compile query: %s", err)
+	}
+
+	got := findMatches(q, []byte("abbc"))
+	want := []types.Match{
+		{
+			LineNum: 1,
+			Fragments: []types.Fragment{
+				{Pre: "a", Match: "bb", Post: "c"},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want=%+v got=%+v", want, got)
+	}
+}
@@ -24,6 +24,7 @@ import (
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/stream"
 )
 
@@ -33,6 +34,16 @@ type Config struct {
 	EventReaderName string
 	Concurrency     int
 	MaxRetries      int
+
+	// IndexDir is the directory in which the local index is stored.
+	IndexDir string
+	// MaxFileSize is the max size of files that are indexed (0 means no limit).
+	MaxFileSize int64
+
+	// BackfillCRON is the schedule of the job that indexes all repositories
+	// that don't have an up-to-date index (e.g. repositories created before the index existed).
+	BackfillCRON        string
+	BackfillMaxDuration time.Duration
 }
 
 func (c *Config) Prepare() error {
@@ -48,6 +59,12 @@ func (c *Config) Prepare() error {
 	if c.MaxRetries < 0 {
 		return errors.New("config.MaxRetries can't be negative")
 	}
+	if c.MaxFileSize < 0 {
+		return errors.New("config.MaxFileSize can't be negative")
+	}
+	if c.BackfillCRON == "" {
+		return errors.New("config.BackfillCRON is required")
+	}
 	return nil
 }
 
@@ -56,6 +73,7 @@ type Service struct {
 	config    Config
 	indexer   Indexer
 	repoStore store.RepoStore
+	scheduler *job.Scheduler
 }
 
 func NewService(
@@ -65,6 +83,8 @@ func NewService(
 	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
 	repoStore store.RepoStore,
 	indexer Indexer,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided codesearch service config is invalid: %w", err)
@@ -73,6 +93,11 @@ func NewService(
 		config:    config,
 		repoStore: repoStore,
 		indexer:   indexer,
+		scheduler: scheduler,
+	}
+
+	if err := executor.Register(jobTypeBackfill, service); err != nil {
+		return nil, fmt.Errorf("failed to register backfill job executor: %w", err)
 	}
 
 	_, err := gitReaderFactory.Launch(ctx, groupGitEvents, config.EventReaderName,
@@ -106,6 +131,7 @@ func NewService(
 				))
 
 			_ = r.RegisterDefaultBranchUpdated((service.handleUpdateDefaultBranch))
+			_ = r.RegisterRepoDeleted(service.handleRepoDeleted)
 			return nil
 		})
 	if err != nil {
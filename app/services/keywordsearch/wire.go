@@ -21,6 +21,8 @@ import (
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
 
 	"github.com/google/wire"
 )
@@ -39,17 +41,21 @@ func ProvideService(ctx context.Context,
 	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
 	repoStore store.RepoStore,
 	indexer Indexer,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
 ) (*Service, error) {
 	return NewService(ctx,
 		config,
 		gitReaderFactory,
 		repoReaderFactory,
 		repoStore,
-		indexer)
+		indexer,
+		scheduler,
+		executor)
 }
 
-func ProvideLocalIndexSearcher() *LocalIndexSearcher {
-	return NewLocalIndexSearcher()
+func ProvideLocalIndexSearcher(config Config, git git.Interface) (*LocalIndexSearcher, error) {
+	return NewLocalIndexSearcher(config, git)
 }
 
 func ProvideIndexer(l *LocalIndexSearcher) Indexer {
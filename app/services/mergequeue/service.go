@@ -0,0 +1,760 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+	"github.com/rs/zerolog/log"
+)
+
+const jobType = "merge-queue-processor"
+
+// Service processes merge queues of all branches.
+//
+// For every branch the queued pull requests are merged speculatively on top of each other.
+// Each speculative merge commit is stored in the refs/pullreq/<number>/queue reference of its pull request,
+// and the branch is fast-forwarded to it as soon as all required status checks pass on it.
+// Every new speculative merge commit is reported as an event, which fires the pipeline triggers
+// that run the status checks on it.
+type Service struct {
+	enabled           bool
+	cron              string
+	maxDur            time.Duration
+	scheduler         *job.Scheduler
+	urlProvider       url.Provider
+	authorizer        authz.Authorizer
+	git               git.Interface
+	locker            *locker.Locker
+	merger            *pullreq.Merger
+	eventReporter     *pullreqevents.Reporter
+	mergeQueueStore   store.MergeQueueStore
+	pullreqStore      store.PullReqStore
+	reviewerStore     store.PullReqReviewerStore
+	repoStore         store.RepoStore
+	principalStore    store.PrincipalStore
+	checkStore        store.CheckStore
+	protectionManager *protection.Manager
+	codeOwners        *codeowners.Service
+	userGroupService  usergroup.SearchService
+	signatureVerifier *publickey.SignatureVerifier
+	dependencyService *pullreq.DependencyService
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	if !s.enabled {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for merge queue: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !s.enabled {
+		return "", nil
+	}
+
+	branches, err := s.mergeQueueStore.ListBranches(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list branches with merge queue entries: %w", err)
+	}
+
+	for _, branch := range branches {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if err := s.processBranch(ctx, branch); err != nil {
+			log.Ctx(ctx).Error().Err(err).
+				Int64("repo_id", branch.RepoID).
+				Str("branch", branch.Branch).
+				Msg("failed to process merge queue")
+		}
+	}
+
+	return "", nil
+}
+
+// processBranch processes the merge queue of a single branch.
+// It's executed under the same repository level lock that is used for merging pull requests.
+func (s *Service) processBranch(ctx context.Context, branch types.MergeQueueBranch) error {
+	unlock, err := s.locker.LockPR(ctx, branch.RepoID, 0, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to lock repository: %w", err)
+	}
+	defer unlock()
+
+	repo, err := s.repoStore.Find(ctx, branch.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	writeParams, err := createSystemRPCWriteParams(ctx, s.urlProvider, repo.ID, repo.GitUID)
+	if err != nil {
+		return fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	for {
+		progressed, err := s.processQueue(ctx, repo, writeParams, branch.Branch)
+		if err != nil {
+			return err
+		}
+
+		if !progressed {
+			return nil
+		}
+	}
+}
+
+// processQueue updates speculative merge commits of all entries in the merge queue
+// and merges or rejects the entry at the front of the queue if its status checks have completed.
+// It returns true if the front of the queue has changed, in which case the queue should be processed again.
+func (s *Service) processQueue(
+	ctx context.Context,
+	repo *types.Repository,
+	writeParams git.WriteParams,
+	branchName string,
+) (bool, error) {
+	entries, err := s.mergeQueueStore.ListByBranch(ctx, repo.ID, branchName)
+	if err != nil {
+		return false, fmt.Errorf("failed to list merge queue entries: %w", err)
+	}
+
+	branch, err := s.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		BranchName: branchName,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get branch: %w", err)
+	}
+
+	var (
+		head    *types.MergeQueueEntry
+		headPR  *types.PullReq
+		baseSHA = branch.Branch.SHA.String()
+	)
+
+	for _, entry := range entries {
+		if entry.State == enum.MergeQueueEntryStateFailed {
+			continue
+		}
+
+		pr, err := s.pullreqStore.Find(ctx, entry.PullReqID)
+		if err != nil {
+			return false, fmt.Errorf("failed to find pull request: %w", err)
+		}
+
+		if pr.State != enum.PullReqStateOpen || pr.TargetBranch != branchName {
+			if err = s.removeEntry(ctx, writeParams, entry); err != nil {
+				return false, err
+			}
+			continue
+		}
+
+		// The branch has been fast-forwarded to the speculative merge commit of the entry,
+		// but marking the pull request as merged failed, so only that step is repeated.
+		if head == nil && entry.MergeSHA != "" && entry.MergeSHA == baseSHA {
+			if err = s.completeLandedEntry(ctx, repo, writeParams, entry, pr); err != nil {
+				return false, err
+			}
+			return true, nil
+		}
+
+		if pr.SourceSHA != entry.HeadSHA {
+			if err = s.rejectEntry(ctx, writeParams, entry,
+				"The source branch has been updated after the pull request has been added to the merge queue."); err != nil {
+				return false, err
+			}
+			continue
+		}
+
+		if entry.BaseSHA != baseSHA || entry.MergeSHA == "" {
+			ok, err := s.mergeEntry(ctx, writeParams, entry, pr, baseSHA)
+			if err != nil {
+				return false, err
+			}
+			if !ok {
+				continue
+			}
+		}
+
+		baseSHA = entry.MergeSHA
+
+		if head == nil {
+			head = entry
+			headPR = pr
+		}
+	}
+
+	if head == nil {
+		return false, nil
+	}
+
+	principal, err := s.principalStore.Find(ctx, head.CreatedBy)
+	if err != nil {
+		return false, fmt.Errorf("failed to find principal who added the pull request to the queue: %w", err)
+	}
+
+	// The pull request is merged on behalf of the principal who added it to the queue,
+	// who could have lost the permission to merge in the meantime.
+	isRepoOwner, err := s.checkAccess(ctx, repo, principal)
+	if errors.Is(err, apiauth.ErrNotAuthorized) {
+		if err = s.rejectEntry(ctx, writeParams, head,
+			"The principal who added the pull request to the merge queue is not allowed to merge it."); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	status, err := s.checkStatus(ctx, repo, headPR, head, principal, isRepoOwner)
+	if err != nil {
+		return false, err
+	}
+
+	switch status {
+	case enum.CheckStatusSuccess:
+		if err = s.completeEntry(ctx, repo, writeParams, head, headPR, principal, isRepoOwner); err != nil {
+			return false, err
+		}
+		return true, nil
+	case enum.CheckStatusFailure, enum.CheckStatusError:
+		if err = s.rejectEntry(ctx, writeParams, head, "Required status checks failed."); err != nil {
+			return false, err
+		}
+		return true, nil
+	case enum.CheckStatusPending, enum.CheckStatusRunning:
+	}
+
+	return false, nil
+}
+
+// mergeEntry creates the speculative merge commit of the entry on top of the provided base commit.
+// It returns false if the entry has been rejected from the queue because of merge conflicts.
+func (s *Service) mergeEntry(
+	ctx context.Context,
+	writeParams git.WriteParams,
+	entry *types.MergeQueueEntry,
+	pr *types.PullReq,
+	baseSHA string,
+) (bool, error) {
+	headRepoUID := writeParams.RepoUID
+	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, err := s.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return false, fmt.Errorf("failed to find source repository: %w", err)
+		}
+		headRepoUID = sourceRepo.GitUID
+	}
+
+	principal, err := s.principalStore.Find(ctx, entry.CreatedBy)
+	if err != nil {
+		return false, fmt.Errorf("failed to find principal who added the pull request to the queue: %w", err)
+	}
+
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+	author, committer := pullreq.MergeCommitIdentities(entry.Method, principal, &pr.Author, &systemPrincipal)
+
+	now := time.Now()
+	mergeOutput, err := s.git.Merge(ctx, &git.MergeParams{
+		WriteParams:     writeParams,
+		BaseSHA:         sha.Must(baseSHA),
+		HeadRepoUID:     headRepoUID,
+		HeadBranch:      entry.HeadSHA,
+		Message:         git.CommitMessage(entry.Title, entry.Message),
+		Committer:       committer,
+		CommitterDate:   &now,
+		Author:          author,
+		AuthorDate:      &now,
+		RefType:         gitenum.RefTypePullReqQueue,
+		RefName:         strconv.FormatInt(entry.PullReqNumber, 10),
+		HeadExpectedSHA: sha.Must(entry.HeadSHA),
+		Method:          gitenum.MergeMethod(entry.Method),
+	})
+	if errors.IsInvalidArgument(err) || errors.IsPreconditionFailed(err) || errors.IsConflict(err) {
+		return false, s.rejectEntry(ctx, writeParams, entry, errors.Message(err))
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create speculative merge commit: %w", err)
+	}
+
+	if len(mergeOutput.ConflictFiles) > 0 {
+		return false, s.rejectEntry(ctx, writeParams, entry,
+			fmt.Sprintf("Merge blocked by conflicting files: %v", mergeOutput.ConflictFiles))
+	}
+
+	entry.State = enum.MergeQueueEntryStateChecking
+	entry.BaseSHA = mergeOutput.BaseSHA.String()
+	entry.MergeSHA = mergeOutput.MergeSHA.String()
+	entry.Error = ""
+	entry.Updated = now.UnixMilli()
+
+	if err = s.mergeQueueStore.Update(ctx, entry); err != nil {
+		return false, fmt.Errorf("failed to update merge queue entry: %w", err)
+	}
+
+	// The event triggers the pipelines which report the status checks of the speculative merge commit.
+	s.eventReporter.MergeQueueCommitUpdated(ctx, &pullreqevents.MergeQueueCommitUpdatedPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    pr.ID,
+			SourceRepoID: pr.SourceRepoID,
+			TargetRepoID: pr.TargetRepoID,
+			PrincipalID:  entry.CreatedBy,
+			Number:       pr.Number,
+		},
+		BaseSHA:  entry.BaseSHA,
+		MergeSHA: entry.MergeSHA,
+	})
+
+	return true, nil
+}
+
+// checkAccess verifies that the principal is still allowed to merge pull requests in the repository
+// and returns whether the principal is the repository owner.
+func (s *Service) checkAccess(
+	ctx context.Context,
+	repo *types.Repository,
+	principal *types.Principal,
+) (bool, error) {
+	session := &auth.Session{Principal: *principal}
+
+	if err := apiauth.CheckRepo(ctx, s.authorizer, session, repo, enum.PermissionRepoPush); err != nil {
+		return false, fmt.Errorf("failed to check permission of principal: %w", err)
+	}
+
+	isRepoOwner, err := apiauth.IsRepoOwner(ctx, s.authorizer, session, repo)
+	if err != nil {
+		return false, fmt.Errorf("failed to determine if principal is repo owner: %w", err)
+	}
+
+	return isRepoOwner, nil
+}
+
+// checkStatus returns the combined status of all required status checks of the speculative merge commit.
+// The checks that can be bypassed are ignored only if the entry has been added to the queue with bypassing the rules.
+func (s *Service) checkStatus(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	entry *types.MergeQueueEntry,
+	principal *types.Principal,
+	isRepoOwner bool,
+) (enum.CheckStatus, error) {
+	protectionRules, err := s.protectionManager.ForRepository(ctx, repo.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	reqChecks, err := protectionRules.RequiredChecks(ctx, protection.RequiredChecksInput{
+		ResolveUserGroupID: s.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              principal,
+		IsRepoOwner:        isRepoOwner,
+		Repo:               repo,
+		PullReq:            pr,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get identifiers of required checks: %w", err)
+	}
+
+	checkResults, err := s.checkStore.ListResults(ctx, repo.ID, entry.MergeSHA)
+	if err != nil {
+		return "", fmt.Errorf("failed to list status checks: %w", err)
+	}
+
+	statuses := make(map[string]enum.CheckStatus, len(checkResults))
+	for _, result := range checkResults {
+		statuses[result.Identifier] = result.Status
+	}
+
+	identifiers := make([]string, 0, len(reqChecks.RequiredIdentifiers)+len(reqChecks.BypassableIdentifiers))
+	for identifier := range reqChecks.RequiredIdentifiers {
+		identifiers = append(identifiers, identifier)
+	}
+	if !entry.BypassRules {
+		for identifier := range reqChecks.BypassableIdentifiers {
+			identifiers = append(identifiers, identifier)
+		}
+	}
+
+	result := enum.CheckStatusSuccess
+	for _, identifier := range identifiers {
+		status, ok := statuses[identifier]
+		switch {
+		case !ok || !status.IsCompleted():
+			result = enum.CheckStatusPending
+		case status != enum.CheckStatusSuccess:
+			return status, nil
+		}
+	}
+
+	return result, nil
+}
+
+// completeEntry verifies the protection rules again, because reviews or rules could have changed
+// while the pull request was waiting in the queue. If they are still satisfied, it fast-forwards
+// the branch to the speculative merge commit of the entry and marks the pull request as merged.
+func (s *Service) completeEntry(
+	ctx context.Context,
+	repo *types.Repository,
+	writeParams git.WriteParams,
+	entry *types.MergeQueueEntry,
+	pr *types.PullReq,
+	principal *types.Principal,
+	isRepoOwner bool,
+) error {
+	sourceRepo, sourceWriteParams, err := s.sourceRepo(ctx, repo, writeParams, pr)
+	if err != nil {
+		return err
+	}
+
+	ruleOut, violations, err := s.verifyEntry(ctx, repo, sourceRepo, entry, pr, principal, isRepoOwner)
+	if err != nil {
+		return err
+	}
+
+	if protection.IsCritical(violations) {
+		return s.rejectEntry(ctx, writeParams, entry, protection.GenerateErrorMessageForBlockingViolations(violations))
+	}
+
+	blockedBy, err := s.dependencyService.CheckDependenciesMerged(ctx, pr)
+	if err != nil {
+		return err
+	}
+
+	if blockedBy != "" {
+		return s.rejectEntry(ctx, writeParams, entry, blockedBy)
+	}
+
+	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Type:        gitenum.RefTypeBranch,
+		Name:        entry.Branch,
+		NewValue:    sha.Must(entry.MergeSHA),
+		OldValue:    sha.Must(entry.BaseSHA),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fast-forward branch to the merge queue commit: %w", err)
+	}
+
+	return s.markEntryMerged(ctx, repo, sourceRepo, writeParams, sourceWriteParams, entry, pr, principal,
+		ruleOut.DeleteSourceBranch, protection.IsBypassed(violations))
+}
+
+// completeLandedEntry marks the pull request of the entry as merged when the branch
+// already points to the speculative merge commit of the entry.
+// The protection rules aren't enforced, because the commit is already on the branch,
+// they are only used to find out if the source branch should be deleted.
+func (s *Service) completeLandedEntry(
+	ctx context.Context,
+	repo *types.Repository,
+	writeParams git.WriteParams,
+	entry *types.MergeQueueEntry,
+	pr *types.PullReq,
+) error {
+	principal, err := s.principalStore.Find(ctx, entry.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to find principal who added the pull request to the queue: %w", err)
+	}
+
+	isRepoOwner, err := apiauth.IsRepoOwner(ctx, s.authorizer, &auth.Session{Principal: *principal}, repo)
+	if err != nil {
+		return fmt.Errorf("failed to determine if principal is repo owner: %w", err)
+	}
+
+	sourceRepo, sourceWriteParams, err := s.sourceRepo(ctx, repo, writeParams, pr)
+	if err != nil {
+		return err
+	}
+
+	ruleOut, violations, err := s.verifyEntry(ctx, repo, sourceRepo, entry, pr, principal, isRepoOwner)
+	if err != nil {
+		return err
+	}
+
+	log.Ctx(ctx).Warn().
+		Int64("repo_id", repo.ID).
+		Int64("pullreq_number", pr.Number).
+		Str("merge_sha", entry.MergeSHA).
+		Msg("completing merge of a pull request already fast-forwarded by the merge queue")
+
+	return s.markEntryMerged(ctx, repo, sourceRepo, writeParams, sourceWriteParams, entry, pr, principal,
+		ruleOut.DeleteSourceBranch, protection.IsBypassed(violations))
+}
+
+// markEntryMerged marks the pull request of the entry as merged and removes the entry from the queue.
+func (s *Service) markEntryMerged(
+	ctx context.Context,
+	repo *types.Repository,
+	sourceRepo *types.Repository,
+	writeParams git.WriteParams,
+	sourceWriteParams git.WriteParams,
+	entry *types.MergeQueueEntry,
+	pr *types.PullReq,
+	principal *types.Principal,
+	deleteSourceBranch bool,
+	rulesBypassed bool,
+) error {
+	readParams := git.ReadParams{RepoUID: repo.GitUID}
+
+	mergeBaseOutput, err := s.git.MergeBase(ctx, git.MergeBaseParams{
+		ReadParams: readParams,
+		Ref1:       entry.BaseSHA,
+		Ref2:       entry.HeadSHA,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find merge base: %w", err)
+	}
+
+	diffStats, err := s.git.DiffStats(ctx, &git.DiffParams{
+		ReadParams: readParams,
+		BaseRef:    entry.BaseSHA,
+		HeadRef:    entry.HeadSHA,
+		MergeBase:  true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get diff stats: %w", err)
+	}
+
+	result, err := s.merger.CompleteMerge(ctx, &pullreq.MergeParams{
+		TargetRepo:         repo,
+		SourceRepo:         sourceRepo,
+		TargetWriteParams:  writeParams,
+		SourceWriteParams:  sourceWriteParams,
+		PullReq:            pr,
+		MergedBy:           principal,
+		Method:             entry.Method,
+		SourceSHA:          entry.HeadSHA,
+		DeleteSourceBranch: deleteSourceBranch,
+		RulesBypassed:      rulesBypassed,
+	}, git.MergeOutput{
+		BaseSHA:          sha.Must(entry.BaseSHA),
+		HeadSHA:          sha.Must(entry.HeadSHA),
+		MergeBaseSHA:     mergeBaseOutput.MergeBaseSHA,
+		MergeSHA:         sha.Must(entry.MergeSHA),
+		CommitCount:      diffStats.Commits,
+		ChangedFileCount: diffStats.FilesChanged,
+		Additions:        diffStats.Additions,
+		Deletions:        diffStats.Deletions,
+	}, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err = s.removeEntry(ctx, writeParams, entry); err != nil {
+		return err
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("repo_id", repo.ID).
+		Int64("pullreq_number", result.PullReq.Number).
+		Str("merge_sha", result.MergeSHA).
+		Msg("pull request merged through the merge queue")
+
+	return nil
+}
+
+// sourceRepo returns the source repository of the pull request and the RPC write params for it.
+func (s *Service) sourceRepo(
+	ctx context.Context,
+	repo *types.Repository,
+	writeParams git.WriteParams,
+	pr *types.PullReq,
+) (*types.Repository, git.WriteParams, error) {
+	if pr.SourceRepoID == pr.TargetRepoID {
+		return repo, writeParams, nil
+	}
+
+	sourceRepo, err := s.repoStore.Find(ctx, pr.SourceRepoID)
+	if err != nil {
+		return nil, git.WriteParams{}, fmt.Errorf("failed to find source repository: %w", err)
+	}
+
+	sourceWriteParams, err := createSystemRPCWriteParams(ctx, s.urlProvider, sourceRepo.ID, sourceRepo.GitUID)
+	if err != nil {
+		return nil, git.WriteParams{},
+			fmt.Errorf("failed to create RPC write params for the source repository: %w", err)
+	}
+
+	return sourceRepo, sourceWriteParams, nil
+}
+
+// verifyEntry verifies the protection rules of the pull request, except the status checks
+// which are verified on the speculative merge commit by checkStatus.
+func (s *Service) verifyEntry(
+	ctx context.Context,
+	targetRepo *types.Repository,
+	sourceRepo *types.Repository,
+	entry *types.MergeQueueEntry,
+	pr *types.PullReq,
+	principal *types.Principal,
+	isRepoOwner bool,
+) (protection.MergeVerifyOutput, []types.RuleViolations, error) {
+	reviewers, err := s.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return protection.MergeVerifyOutput{}, nil, fmt.Errorf("failed to load list of reviewers: %w", err)
+	}
+
+	protectionRules, err := s.protectionManager.ForRepository(ctx, targetRepo.ID)
+	if err != nil {
+		return protection.MergeVerifyOutput{}, nil,
+			fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	codeOwnerWithApproval, err := s.codeOwners.Evaluate(ctx, targetRepo, pr, reviewers)
+	if err != nil && !errors.Is(err, codeowners.ErrNotFound) {
+		return protection.MergeVerifyOutput{}, nil, fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
+	}
+
+	ruleOut, violations, err := protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
+		ResolveUserGroupID: s.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              principal,
+		AllowBypass:        entry.BypassRules,
+		IsRepoOwner:        isRepoOwner,
+		TargetRepo:         targetRepo,
+		SourceRepo:         sourceRepo,
+		PullReq:            pr,
+		Reviewers:          reviewers,
+		Method:             entry.Method,
+		CodeOwners:         codeOwnerWithApproval,
+		MergeQueue:         true,
+		FindUnverifiedCommits: func(ctx context.Context) ([]string, error) {
+			return s.signatureVerifier.FindUnverifiedPullReqCommits(ctx, s.git, targetRepo, pr)
+		},
+	})
+	if err != nil {
+		return protection.MergeVerifyOutput{}, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	return ruleOut, violations, nil
+}
+
+// rejectEntry marks the entry as failed. Failed entries remain in the queue table
+// (to let users see the reason), but they are ignored by the merge queue processing.
+func (s *Service) rejectEntry(
+	ctx context.Context,
+	writeParams git.WriteParams,
+	entry *types.MergeQueueEntry,
+	reason string,
+) error {
+	if err := s.deleteQueueRef(ctx, writeParams, entry.PullReqNumber); err != nil {
+		return err
+	}
+
+	entry.State = enum.MergeQueueEntryStateFailed
+	entry.BaseSHA = ""
+	entry.MergeSHA = ""
+	entry.Error = reason
+	entry.Updated = time.Now().UnixMilli()
+
+	if err := s.mergeQueueStore.Update(ctx, entry); err != nil {
+		return fmt.Errorf("failed to update merge queue entry: %w", err)
+	}
+
+	return nil
+}
+
+// removeEntry removes the entry from the merge queue.
+func (s *Service) removeEntry(
+	ctx context.Context,
+	writeParams git.WriteParams,
+	entry *types.MergeQueueEntry,
+) error {
+	if err := s.deleteQueueRef(ctx, writeParams, entry.PullReqNumber); err != nil {
+		return err
+	}
+
+	if err := s.mergeQueueStore.Delete(ctx, entry.ID); err != nil {
+		return fmt.Errorf("failed to delete merge queue entry: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) deleteQueueRef(ctx context.Context, writeParams git.WriteParams, prNum int64) error {
+	err := s.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Name:        strconv.FormatInt(prNum, 10),
+		Type:        gitenum.RefTypePullReqQueue,
+		NewValue:    sha.None, // when NewValue is empty will delete the ref.
+		OldValue:    sha.None, // we don't care about the old value
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove PR queue ref: %w", err)
+	}
+
+	return nil
+}
+
+func createSystemRPCWriteParams(
+	ctx context.Context,
+	urlProvider url.Provider,
+	repoID int64,
+	repoGITUID string,
+) (git.WriteParams, error) {
+	principal := bootstrap.NewSystemServiceSession().Principal
+
+	// generate envars (add everything githook CLI needs for execution)
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		urlProvider.GetInternalAPIURL(ctx),
+		repoID,
+		principal.ID,
+		false,
+		true,
+	)
+	if err != nil {
+		return git.WriteParams{}, fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	return git.WriteParams{
+		Actor: git.Identity{
+			Name:  principal.DisplayName,
+			Email: principal.Email,
+		},
+		RepoUID: repoGITUID,
+		EnvVars: envVars,
+	}, nil
+}
@@ -0,0 +1,242 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeRuleStore struct {
+	store.RuleStore
+	rules []types.RuleInfoInternal
+}
+
+func (f fakeRuleStore) ListAllRepoRules(context.Context, int64) ([]types.RuleInfoInternal, error) {
+	return f.rules, nil
+}
+
+type fakeCheckStore struct {
+	store.CheckStore
+	results []types.CheckResult
+}
+
+func (f fakeCheckStore) ListResults(context.Context, int64, string) ([]types.CheckResult, error) {
+	return f.results, nil
+}
+
+type fakeReviewerStore struct {
+	store.PullReqReviewerStore
+}
+
+func (fakeReviewerStore) List(context.Context, int64) ([]*types.PullReqReviewer, error) {
+	return nil, nil
+}
+
+type fakeMergeQueueStore struct {
+	store.MergeQueueStore
+	updated []types.MergeQueueEntry
+}
+
+func (f *fakeMergeQueueStore) Update(_ context.Context, entry *types.MergeQueueEntry) error {
+	f.updated = append(f.updated, *entry)
+	return nil
+}
+
+type fakeGit struct {
+	git.Interface
+	updatedRefs []git.UpdateRefParams
+}
+
+func (f *fakeGit) UpdateRef(_ context.Context, params git.UpdateRefParams) error {
+	f.updatedRefs = append(f.updatedRefs, params)
+	return nil
+}
+
+// branchRule returns an active branch protection rule of the main branch
+// with the provided pull request definition, which can be bypassed by the user with ID 1.
+func branchRule(t *testing.T, pullreq protection.DefPullReq) types.RuleInfoInternal {
+	t.Helper()
+
+	definition, err := json.Marshal(protection.Branch{
+		Bypass:  protection.DefBypass{UserIDs: []int64{1}},
+		PullReq: pullreq,
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal rule definition: %s", err)
+	}
+
+	pattern := protection.Pattern{Include: []string{"main"}}
+
+	return types.RuleInfoInternal{
+		RuleInfo: types.RuleInfo{
+			ID:         1,
+			Identifier: "rule",
+			Type:       protection.TypeBranch,
+			State:      enum.RuleStateActive,
+		},
+		Pattern:    pattern.JSON(),
+		Definition: definition,
+	}
+}
+
+func setupService(t *testing.T, rules ...types.RuleInfoInternal) *Service {
+	t.Helper()
+
+	protectionManager, err := protection.ProvideManager(fakeRuleStore{rules: rules})
+	if err != nil {
+		t.Fatalf("failed to create protection manager: %s", err)
+	}
+
+	return &Service{
+		git:               &fakeGit{},
+		mergeQueueStore:   &fakeMergeQueueStore{},
+		reviewerStore:     fakeReviewerStore{},
+		checkStore:        fakeCheckStore{},
+		protectionManager: protectionManager,
+		codeOwners:        codeowners.New(nil, nil, codeowners.Config{}, nil, nil),
+		userGroupService:  usergroup.ProvideSearchService(),
+	}
+}
+
+func TestService_checkStatus(t *testing.T) {
+	rule := branchRule(t, protection.DefPullReq{
+		StatusChecks: protection.DefStatusChecks{RequireIdentifiers: []string{"build"}},
+	})
+
+	repo := &types.Repository{ID: 1, DefaultBranch: "main"}
+	pr := &types.PullReq{ID: 1, TargetBranch: "main"}
+
+	tests := []struct {
+		name        string
+		principalID int64
+		bypassRules bool
+		results     []types.CheckResult
+		exp         enum.CheckStatus
+	}{
+		{
+			name:        "no-result",
+			principalID: 2,
+			exp:         enum.CheckStatusPending,
+		},
+		{
+			name:        "running",
+			principalID: 2,
+			results:     []types.CheckResult{{Identifier: "build", Status: enum.CheckStatusRunning}},
+			exp:         enum.CheckStatusPending,
+		},
+		{
+			name:        "success",
+			principalID: 2,
+			results:     []types.CheckResult{{Identifier: "build", Status: enum.CheckStatusSuccess}},
+			exp:         enum.CheckStatusSuccess,
+		},
+		{
+			name:        "failure",
+			principalID: 2,
+			results:     []types.CheckResult{{Identifier: "build", Status: enum.CheckStatusFailure}},
+			exp:         enum.CheckStatusFailure,
+		},
+		{
+			name:        "bypassable-not-bypassed",
+			principalID: 1,
+			exp:         enum.CheckStatusPending,
+		},
+		{
+			name:        "bypassed",
+			principalID: 1,
+			bypassRules: true,
+			exp:         enum.CheckStatusSuccess,
+		},
+		{
+			name:        "bypass-requested-not-allowed",
+			principalID: 2,
+			bypassRules: true,
+			exp:         enum.CheckStatusPending,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := setupService(t, rule)
+			s.checkStore = fakeCheckStore{results: test.results}
+
+			entry := &types.MergeQueueEntry{MergeSHA: "merge", BypassRules: test.bypassRules}
+			principal := &types.Principal{ID: test.principalID}
+
+			status, err := s.checkStatus(context.Background(), repo, pr, entry, principal, false)
+			if err != nil {
+				t.Fatalf("failed to get check status: %s", err)
+			}
+
+			if status != test.exp {
+				t.Errorf("want status %s, got %s", test.exp, status)
+			}
+		})
+	}
+}
+
+func TestService_completeEntry_RulesViolated(t *testing.T) {
+	s := setupService(t, branchRule(t, protection.DefPullReq{
+		Approvals: protection.DefApprovals{RequireMinimumCount: 1},
+	}))
+
+	repo := &types.Repository{ID: 1, GitUID: "repo", DefaultBranch: "main"}
+	pr := &types.PullReq{ID: 1, Number: 7, TargetRepoID: 1, SourceRepoID: 1, TargetBranch: "main"}
+	entry := &types.MergeQueueEntry{
+		ID:            1,
+		PullReqID:     pr.ID,
+		PullReqNumber: pr.Number,
+		Branch:        "main",
+		State:         enum.MergeQueueEntryStateChecking,
+		Method:        enum.MergeMethodMerge,
+		BaseSHA:       "base",
+		MergeSHA:      "merge",
+	}
+
+	err := s.completeEntry(context.Background(), repo, git.WriteParams{RepoUID: repo.GitUID},
+		entry, pr, &types.Principal{ID: 2}, false)
+	if err != nil {
+		t.Fatalf("failed to complete merge queue entry: %s", err)
+	}
+
+	// the branch must not be updated, only the queue reference of the pull request is removed.
+	gitFake := s.git.(*fakeGit)
+	if len(gitFake.updatedRefs) != 1 ||
+		gitFake.updatedRefs[0].Type != gitenum.RefTypePullReqQueue ||
+		gitFake.updatedRefs[0].Name != "7" {
+		t.Errorf("unexpected updated references: %+v", gitFake.updatedRefs)
+	}
+
+	updated := s.mergeQueueStore.(*fakeMergeQueueStore).updated
+	if len(updated) != 1 {
+		t.Fatalf("want one merge queue entry update, got %d", len(updated))
+	}
+
+	if updated[0].State != enum.MergeQueueEntryStateFailed || updated[0].Error == "" ||
+		updated[0].MergeSHA != "" || updated[0].BaseSHA != "" {
+		t.Errorf("merge queue entry not rejected: %+v", updated[0])
+	}
+}
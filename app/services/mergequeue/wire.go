@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergequeue
+
+import (
+	"github.com/harness/gitness/app/auth/authz"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	config *types.Config,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	urlProvider url.Provider,
+	authorizer authz.Authorizer,
+	git git.Interface,
+	locker *locker.Locker,
+	merger *pullreq.Merger,
+	eventReporter *pullreqevents.Reporter,
+	mergeQueueStore store.MergeQueueStore,
+	pullreqStore store.PullReqStore,
+	reviewerStore store.PullReqReviewerStore,
+	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
+	checkStore store.CheckStore,
+	protectionManager *protection.Manager,
+	codeOwners *codeowners.Service,
+	userGroupService usergroup.SearchService,
+	signatureVerifier *publickey.SignatureVerifier,
+	dependencyService *pullreq.DependencyService,
+) (*Service, error) {
+	service := &Service{
+		enabled:           config.MergeQueue.Enabled,
+		cron:              config.MergeQueue.CRON,
+		maxDur:            config.MergeQueue.MaxDuration,
+		scheduler:         scheduler,
+		urlProvider:       urlProvider,
+		authorizer:        authorizer,
+		git:               git,
+		locker:            locker,
+		merger:            merger,
+		eventReporter:     eventReporter,
+		mergeQueueStore:   mergeQueueStore,
+		pullreqStore:      pullreqStore,
+		reviewerStore:     reviewerStore,
+		repoStore:         repoStore,
+		principalStore:    principalStore,
+		checkStore:        checkStore,
+		protectionManager: protectionManager,
+		codeOwners:        codeOwners,
+		userGroupService:  userGroupService,
+		signatureVerifier: signatureVerifier,
+		dependencyService: dependencyService,
+	}
+
+	err := executor.Register(jobType, service)
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
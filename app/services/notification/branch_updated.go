@@ -43,10 +43,6 @@ func (s *Service) notifyPullReqBranchUpdated(
 		)
 	}
 
-	if len(reviewers) == 0 {
-		return nil
-	}
-
 	err = s.notificationClient.SendPullReqBranchUpdated(ctx, reviewers, payload)
 	if err != nil {
 		return fmt.Errorf(
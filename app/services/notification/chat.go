@@ -0,0 +1,230 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// chatHTTPClient is used for requests to chat applications. It is configured with a timeout for reliability.
+var chatHTTPClient = &http.Client{
+	Transport: &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		TLSHandshakeTimeout: 30 * time.Second,
+	},
+	Timeout: 30 * time.Second,
+}
+
+// chatMessage is a chat application agnostic representation of a pull request notification.
+type chatMessage struct {
+	// Title is a short, plain text summary of the event.
+	Title string
+	// Quote is optional user provided content of the event, e.g. text of a comment.
+	Quote string
+	// Channel is true if the message should be also delivered to the channel of the space.
+	Channel bool
+
+	RepoPath      string
+	PullReqNumber int64
+	PullReqTitle  string
+	PullReqURL    string
+}
+
+func newChatMessage(base *BasePullReqPayload, title string) chatMessage {
+	return chatMessage{
+		Title:         title,
+		Channel:       true,
+		RepoPath:      base.Repo.Path,
+		PullReqNumber: base.PullReq.Number,
+		PullReqTitle:  base.PullReq.Title,
+		PullReqURL:    base.PullReqURL,
+	}
+}
+
+func chatMessageCommentPRAuthor(payload *CommentPayload) chatMessage {
+	msg := newChatMessage(payload.Base, fmt.Sprintf("%s commented on your pull request",
+		payload.Commenter.DisplayName))
+	msg.Quote = payload.Text
+	msg.Channel = false
+	return msg
+}
+
+func chatMessageCommentMentions(payload *CommentPayload) chatMessage {
+	msg := newChatMessage(payload.Base, fmt.Sprintf("%s mentioned you in a comment",
+		payload.Commenter.DisplayName))
+	msg.Quote = payload.Text
+	msg.Channel = false
+	return msg
+}
+
+func chatMessageCommentParticipants(payload *CommentPayload) chatMessage {
+	msg := newChatMessage(payload.Base, fmt.Sprintf("%s replied to a thread you participated in",
+		payload.Commenter.DisplayName))
+	msg.Quote = payload.Text
+	msg.Channel = false
+	return msg
+}
+
+func chatMessageReviewerAdded(payload *ReviewerAddedPayload) chatMessage {
+	return newChatMessage(payload.Base, fmt.Sprintf("%s has been requested to review the pull request",
+		payload.Reviewer.DisplayName))
+}
+
+func chatMessagePullReqBranchUpdated(payload *PullReqBranchUpdatedPayload) chatMessage {
+	return newChatMessage(payload.Base, fmt.Sprintf("%s pushed new commits to the pull request (head %s)",
+		payload.Committer.DisplayName, shortSHA(payload.NewSHA)))
+}
+
+func chatMessageReviewSubmitted(payload *ReviewSubmittedPayload) chatMessage {
+	var verb string
+	switch payload.Decision {
+	case enum.PullReqReviewDecisionApproved:
+		verb = "approved"
+	case enum.PullReqReviewDecisionChangeReq:
+		verb = "requested changes on"
+	default:
+		verb = "reviewed"
+	}
+
+	return newChatMessage(payload.Base, fmt.Sprintf("%s %s the pull request",
+		payload.Reviewer.DisplayName, verb))
+}
+
+func chatMessagePullReqStateChanged(payload *PullReqStateChangedPayload) chatMessage {
+	return newChatMessage(payload.Base, fmt.Sprintf("The pull request has been %s by %s",
+		payload.State, payload.ChangedBy.DisplayName))
+}
+
+func shortSHA(sha string) string {
+	const shortLen = 8
+	if len(sha) <= shortLen {
+		return sha
+	}
+	return sha[:shortLen]
+}
+
+// chatRecipient is a notification recipient that has linked its account in a chat application.
+type chatRecipient struct {
+	Principal  *types.PrincipalInfo
+	Identifier string
+}
+
+// chatTargets finds where in a chat application a notification should be delivered.
+type chatTargets struct {
+	chatIdentityStore store.ChatIdentityStore
+	settingsService   *settings.Service
+	spaceStore        store.SpaceStore
+}
+
+// recipients returns the recipients that have linked their account in the chat application.
+func (t *chatTargets) recipients(
+	ctx context.Context,
+	provider enum.ChatProvider,
+	principals []*types.PrincipalInfo,
+) ([]chatRecipient, error) {
+	ids := make([]int64, len(principals))
+	for i, principal := range principals {
+		ids[i] = principal.ID
+	}
+
+	identifiers, err := t.chatIdentityStore.Map(ctx, provider, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find %s identities of notification recipients: %w", provider, err)
+	}
+
+	recipients := make([]chatRecipient, 0, len(identifiers))
+	for _, principal := range principals {
+		if identifier, ok := identifiers[principal.ID]; ok {
+			recipients = append(recipients, chatRecipient{Principal: principal, Identifier: identifier})
+		}
+	}
+
+	return recipients, nil
+}
+
+// channelWebhookURL returns the webhook URL configured in the closest space of the repository.
+// It returns an empty string if none of the parent spaces has the setting configured.
+func (t *chatTargets) channelWebhookURL(
+	ctx context.Context,
+	key settings.Key,
+	repo *types.Repository,
+) (string, error) {
+	for spaceID := repo.ParentID; spaceID != 0; {
+		url, err := settings.SpaceGet(ctx, t.settingsService, spaceID, key, "")
+		if err != nil {
+			return "", fmt.Errorf("failed to get setting %q of space %d: %w", key, spaceID, err)
+		}
+
+		if url != "" {
+			return url, nil
+		}
+
+		space, err := t.spaceStore.Find(ctx, spaceID)
+		if err != nil {
+			return "", fmt.Errorf("failed to find space %d: %w", spaceID, err)
+		}
+
+		spaceID = space.ParentID
+	}
+
+	return "", nil
+}
+
+// postJSON sends the JSON encoded body to the URL and returns the response body.
+func postJSON(ctx context.Context, url string, header http.Header, body any) ([]byte, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	resp, err := chatHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	const maxResponseSize = 64 << 10
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("request failed with status %q: %s", resp.Status, respBody)
+	}
+
+	return respBody, nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestSlackMessage_Escaping(t *testing.T) {
+	msg := chatMessage{
+		Title:         "a <b> & c",
+		Quote:         "line1\nline2",
+		RepoPath:      "space/repo",
+		PullReqNumber: 7,
+		PullReqTitle:  "fix <stuff>",
+		PullReqURL:    "https://example.com/pr/7",
+	}
+
+	text := slackMessage(msg)["blocks"].([]map[string]any)[0]["text"].(map[string]any)["text"].(string)
+
+	want := "*a &lt;b&gt; &amp; c*\n<https://example.com/pr/7|#7 fix &lt;stuff&gt;>\n>line1\n>line2"
+	if text != want {
+		t.Errorf("unexpected text:\n got: %q\nwant: %q", text, want)
+	}
+}
+
+func TestTeamsMessage_Mentions(t *testing.T) {
+	recipients := []chatRecipient{
+		{Principal: &types.PrincipalInfo{ID: 1, DisplayName: "Jane"}, Identifier: "jane@example.com"},
+		{Principal: &types.PrincipalInfo{ID: 2, DisplayName: "John"}, Identifier: "john@example.com"},
+	}
+
+	card := teamsMessage(chatMessage{Title: "title"}, recipients)["attachments"].([]map[string]any)[0]["content"]
+	entities := card.(map[string]any)["msteams"].(map[string]any)["entities"].([]map[string]any)
+
+	if len(entities) != len(recipients) {
+		t.Fatalf("expected %d mention entities, got %d", len(recipients), len(entities))
+	}
+
+	for i, entity := range entities {
+		mentioned := entity["mentioned"].(map[string]any)
+		if mentioned["id"] != recipients[i].Identifier {
+			t.Errorf("entity %d: expected id %q, got %q", i, recipients[i].Identifier, mentioned["id"])
+		}
+		if !strings.Contains(entity["text"].(string), recipients[i].Principal.DisplayName) {
+			t.Errorf("entity %d: mention text %q doesn't contain the display name", i, entity["text"])
+		}
+	}
+}
+
+type failingClient struct {
+	Client
+	err   error
+	calls *int
+}
+
+func (c failingClient) SendReviewerAdded(context.Context, []*types.PrincipalInfo, *ReviewerAddedPayload) error {
+	*c.calls++
+	return c.err
+}
+
+func TestMultiClient_DeliversToAll(t *testing.T) {
+	calls := 0
+
+	err := MultiClient{
+		failingClient{err: errors.New("a"), calls: &calls},
+		failingClient{err: nil, calls: &calls},
+		failingClient{err: errors.New("b"), calls: &calls},
+	}.SendReviewerAdded(context.Background(), nil, nil)
+
+	if err != nil {
+		t.Errorf("expected client failures not to be returned, got: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected notification to be delivered through all 3 clients, got %d", calls)
+	}
+}
@@ -60,7 +60,7 @@ func (m MailClient) SendCommentPRAuthor(
 			pullreqevents.CommentCreatedEvent, err)
 	}
 
-	return m.Mailer.Send(ctx, *email)
+	return m.send(ctx, email)
 }
 func (m MailClient) SendCommentMentions(
 	ctx context.Context,
@@ -78,7 +78,7 @@ func (m MailClient) SendCommentMentions(
 			pullreqevents.CommentCreatedEvent, err)
 	}
 
-	return m.Mailer.Send(ctx, *email)
+	return m.send(ctx, email)
 }
 func (m MailClient) SendCommentParticipants(
 	ctx context.Context,
@@ -96,7 +96,7 @@ func (m MailClient) SendCommentParticipants(
 			pullreqevents.CommentCreatedEvent, err)
 	}
 
-	return m.Mailer.Send(ctx, *email)
+	return m.send(ctx, email)
 }
 
 func (m MailClient) SendReviewerAdded(
@@ -115,7 +115,7 @@ func (m MailClient) SendReviewerAdded(
 			pullreqevents.ReviewerAddedEvent, err)
 	}
 
-	return m.Mailer.Send(ctx, *email)
+	return m.send(ctx, email)
 }
 
 func (m MailClient) SendPullReqBranchUpdated(
@@ -134,7 +134,7 @@ func (m MailClient) SendPullReqBranchUpdated(
 			pullreqevents.BranchUpdatedEvent, err)
 	}
 
-	return m.Mailer.Send(ctx, *email)
+	return m.send(ctx, email)
 }
 
 func (m MailClient) SendReviewSubmitted(
@@ -150,7 +150,7 @@ func (m MailClient) SendReviewSubmitted(
 			err,
 		)
 	}
-	return m.Mailer.Send(ctx, *email)
+	return m.send(ctx, email)
 }
 
 func (m MailClient) SendPullReqStateChanged(
@@ -166,7 +166,7 @@ func (m MailClient) SendPullReqStateChanged(
 		)
 	}
 
-	return m.Mailer.Send(ctx, *email)
+	return m.send(ctx, email)
 }
 
 func GetSubjectPullRequest(
@@ -219,3 +219,13 @@ func RetrieveEmailsFromPrincipals(principals []*types.PrincipalInfo) []string {
 	}
 	return emails
 }
+
+// send delivers the email, skipping it if it has no recipients.
+// Events are delivered with empty recipients as well, so chat clients can post to the channel.
+func (m MailClient) send(ctx context.Context, email *mailer.Payload) error {
+	if len(email.ToRecipients) == 0 {
+		return nil
+	}
+
+	return m.Mailer.Send(ctx, *email)
+}
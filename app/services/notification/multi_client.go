@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// MultiClient delivers every notification through all of its clients.
+// A failure of one client doesn't prevent delivery through the others.
+type MultiClient []Client
+
+func (m MultiClient) SendCommentPRAuthor(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return m.each(ctx, func(c Client) error { return c.SendCommentPRAuthor(ctx, recipients, payload) })
+}
+
+func (m MultiClient) SendCommentMentions(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return m.each(ctx, func(c Client) error { return c.SendCommentMentions(ctx, recipients, payload) })
+}
+
+func (m MultiClient) SendCommentParticipants(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return m.each(ctx, func(c Client) error { return c.SendCommentParticipants(ctx, recipients, payload) })
+}
+
+func (m MultiClient) SendReviewerAdded(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewerAddedPayload,
+) error {
+	return m.each(ctx, func(c Client) error { return c.SendReviewerAdded(ctx, recipients, payload) })
+}
+
+func (m MultiClient) SendPullReqBranchUpdated(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqBranchUpdatedPayload,
+) error {
+	return m.each(ctx, func(c Client) error { return c.SendPullReqBranchUpdated(ctx, recipients, payload) })
+}
+
+func (m MultiClient) SendReviewSubmitted(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewSubmittedPayload,
+) error {
+	return m.each(ctx, func(c Client) error { return c.SendReviewSubmitted(ctx, recipients, payload) })
+}
+
+func (m MultiClient) SendPullReqStateChanged(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqStateChangedPayload,
+) error {
+	return m.each(ctx, func(c Client) error { return c.SendPullReqStateChanged(ctx, recipients, payload) })
+}
+
+// each delivers the notification through all clients. Failures are only logged, because returning an error
+// would make the event reader retry the event and resend the notification through the clients that succeeded.
+func (m MultiClient) each(ctx context.Context, fn func(c Client) error) error {
+	for _, c := range m {
+		if err := fn(c); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to deliver notification through %T", c)
+		}
+	}
+
+	return nil
+}
@@ -82,6 +82,10 @@ type Config struct {
 	EventReaderName string
 	Concurrency     int
 	MaxRetries      int
+
+	SlackEnabled  bool
+	SlackBotToken string
+	TeamsEnabled  bool
 }
 
 type Service struct {
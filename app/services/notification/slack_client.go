@@ -0,0 +1,198 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const slackPostMessageURL = "https://slack.com/api/chat.postMessage"
+
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// SlackClient delivers notifications as Slack direct messages to the recipients
+// and posts repository events to the Slack channel configured in the space.
+type SlackClient struct {
+	targets  *chatTargets
+	botToken string
+}
+
+func NewSlackClient(targets *chatTargets, botToken string) *SlackClient {
+	return &SlackClient{
+		targets:  targets,
+		botToken: botToken,
+	}
+}
+
+func (c *SlackClient) SendCommentPRAuthor(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return c.send(ctx, recipients, payload.Base.Repo, chatMessageCommentPRAuthor(payload))
+}
+
+func (c *SlackClient) SendCommentMentions(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return c.send(ctx, recipients, payload.Base.Repo, chatMessageCommentMentions(payload))
+}
+
+func (c *SlackClient) SendCommentParticipants(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return c.send(ctx, recipients, payload.Base.Repo, chatMessageCommentParticipants(payload))
+}
+
+func (c *SlackClient) SendReviewerAdded(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewerAddedPayload,
+) error {
+	return c.send(ctx, recipients, payload.Base.Repo, chatMessageReviewerAdded(payload))
+}
+
+func (c *SlackClient) SendPullReqBranchUpdated(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqBranchUpdatedPayload,
+) error {
+	return c.send(ctx, recipients, payload.Base.Repo, chatMessagePullReqBranchUpdated(payload))
+}
+
+func (c *SlackClient) SendReviewSubmitted(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewSubmittedPayload,
+) error {
+	return c.send(ctx, recipients, payload.Base.Repo, chatMessageReviewSubmitted(payload))
+}
+
+func (c *SlackClient) SendPullReqStateChanged(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqStateChangedPayload,
+) error {
+	return c.send(ctx, recipients, payload.Base.Repo, chatMessagePullReqStateChanged(payload))
+}
+
+func (c *SlackClient) send(
+	ctx context.Context,
+	principals []*types.PrincipalInfo,
+	repo *types.Repository,
+	msg chatMessage,
+) error {
+	var errs []error
+
+	// direct messages can be sent only with a bot token
+	if c.botToken != "" {
+		recipients, err := c.targets.recipients(ctx, enum.ChatProviderSlack, principals)
+		if err != nil {
+			return err
+		}
+
+		for _, recipient := range recipients {
+			if err := c.postMessage(ctx, recipient.Identifier, msg); err != nil {
+				errs = append(errs, fmt.Errorf("failed to send slack message to principal %d: %w",
+					recipient.Principal.ID, err))
+			}
+		}
+	}
+
+	if msg.Channel {
+		webhookURL, err := c.targets.channelWebhookURL(ctx, settings.KeyNotificationSlackWebhookURL, repo)
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+
+		if webhookURL != "" {
+			if _, err := postJSON(ctx, webhookURL, nil, slackMessage(msg)); err != nil {
+				errs = append(errs, fmt.Errorf("failed to post slack channel message: %w", err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// postMessage sends a direct message to the Slack user using the Web API.
+func (c *SlackClient) postMessage(ctx context.Context, userID string, msg chatMessage) error {
+	body := slackMessage(msg)
+	body["channel"] = userID
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+c.botToken)
+
+	respBody, err := postJSON(ctx, slackPostMessageURL, header, body)
+	if err != nil {
+		return err
+	}
+
+	// the Web API returns errors with status code 200
+	var resp struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err = json.Unmarshal(respBody, &resp); err != nil {
+		return fmt.Errorf("failed to unmarshal slack response: %w", err)
+	}
+	if !resp.OK {
+		return fmt.Errorf("slack responded with error: %s", resp.Error)
+	}
+
+	return nil
+}
+
+// slackMessage renders the message using Slack's Block Kit.
+func slackMessage(msg chatMessage) map[string]any {
+	text := fmt.Sprintf("*%s*\n<%s|#%d %s>",
+		slackEscaper.Replace(msg.Title),
+		msg.PullReqURL,
+		msg.PullReqNumber,
+		slackEscaper.Replace(msg.PullReqTitle))
+
+	if msg.Quote != "" {
+		text += "\n>" + strings.ReplaceAll(slackEscaper.Replace(msg.Quote), "\n", "\n>")
+	}
+
+	return map[string]any{
+		"text": fmt.Sprintf("%s: #%d %s", msg.Title, msg.PullReqNumber, msg.PullReqTitle),
+		"blocks": []map[string]any{
+			{
+				"type": "section",
+				"text": map[string]any{"type": "mrkdwn", "text": text},
+			},
+			{
+				"type": "context",
+				"elements": []map[string]any{
+					{"type": "mrkdwn", "text": slackEscaper.Replace(msg.RepoPath)},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// TeamsClient delivers notifications as adaptive cards to the Microsoft Teams channel configured in the space.
+// Incoming webhooks can't send direct messages, so the recipients that linked their Teams account
+// are mentioned in the card instead.
+type TeamsClient struct {
+	targets *chatTargets
+}
+
+func NewTeamsClient(targets *chatTargets) *TeamsClient {
+	return &TeamsClient{
+		targets: targets,
+	}
+}
+
+func (c *TeamsClient) SendCommentPRAuthor(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return c.send(ctx, recipients, payload.Base.Repo, chatMessageCommentPRAuthor(payload))
+}
+
+func (c *TeamsClient) SendCommentMentions(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return c.send(ctx, recipients, payload.Base.Repo, chatMessageCommentMentions(payload))
+}
+
+func (c *TeamsClient) SendCommentParticipants(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return c.send(ctx, recipients, payload.Base.Repo, chatMessageCommentParticipants(payload))
+}
+
+func (c *TeamsClient) SendReviewerAdded(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewerAddedPayload,
+) error {
+	return c.send(ctx, recipients, payload.Base.Repo, chatMessageReviewerAdded(payload))
+}
+
+func (c *TeamsClient) SendPullReqBranchUpdated(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqBranchUpdatedPayload,
+) error {
+	return c.send(ctx, recipients, payload.Base.Repo, chatMessagePullReqBranchUpdated(payload))
+}
+
+func (c *TeamsClient) SendReviewSubmitted(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReviewSubmittedPayload,
+) error {
+	return c.send(ctx, recipients, payload.Base.Repo, chatMessageReviewSubmitted(payload))
+}
+
+func (c *TeamsClient) SendPullReqStateChanged(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqStateChangedPayload,
+) error {
+	return c.send(ctx, recipients, payload.Base.Repo, chatMessagePullReqStateChanged(payload))
+}
+
+func (c *TeamsClient) send(
+	ctx context.Context,
+	principals []*types.PrincipalInfo,
+	repo *types.Repository,
+	msg chatMessage,
+) error {
+	recipients, err := c.targets.recipients(ctx, enum.ChatProviderTeams, principals)
+	if err != nil {
+		return err
+	}
+
+	// personal notifications are posted only if there's someone to mention
+	if !msg.Channel && len(recipients) == 0 {
+		return nil
+	}
+
+	webhookURL, err := c.targets.channelWebhookURL(ctx, settings.KeyNotificationTeamsWebhookURL, repo)
+	if err != nil {
+		return err
+	}
+
+	if webhookURL == "" {
+		return nil
+	}
+
+	if _, err = postJSON(ctx, webhookURL, nil, teamsMessage(msg, recipients)); err != nil {
+		return fmt.Errorf("failed to post teams channel message: %w", err)
+	}
+
+	return nil
+}
+
+// teamsMessage renders the message as an adaptive card that mentions the recipients.
+func teamsMessage(msg chatMessage, recipients []chatRecipient) map[string]any {
+	body := []map[string]any{
+		{
+			"type":   "TextBlock",
+			"text":   msg.Title,
+			"weight": "Bolder",
+			"wrap":   true,
+		},
+		{
+			"type":    "TextBlock",
+			"text":    fmt.Sprintf("[#%d %s](%s)", msg.PullReqNumber, msg.PullReqTitle, msg.PullReqURL),
+			"wrap":    true,
+			"spacing": "None",
+		},
+		{
+			"type":     "TextBlock",
+			"text":     msg.RepoPath,
+			"wrap":     true,
+			"spacing":  "None",
+			"isSubtle": true,
+		},
+	}
+
+	if msg.Quote != "" {
+		body = append(body, map[string]any{
+			"type":  "Container",
+			"style": "emphasis",
+			"items": []map[string]any{
+				{"type": "TextBlock", "text": msg.Quote, "wrap": true},
+			},
+		})
+	}
+
+	entities := make([]map[string]any, len(recipients))
+	mentions := make([]string, len(recipients))
+	for i, recipient := range recipients {
+		mention := "<at>" + recipient.Principal.DisplayName + "</at>"
+		mentions[i] = mention
+		entities[i] = map[string]any{
+			"type": "mention",
+			"text": mention,
+			"mentioned": map[string]any{
+				"id":   recipient.Identifier,
+				"name": recipient.Principal.DisplayName,
+			},
+		}
+	}
+
+	if len(mentions) > 0 {
+		body = append(body, map[string]any{
+			"type": "TextBlock",
+			"text": "cc " + strings.Join(mentions, ", "),
+			"wrap": true,
+		})
+	}
+
+	return map[string]any{
+		"type": "message",
+		"attachments": []map[string]any{
+			{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]any{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"body":    body,
+					"actions": []map[string]any{
+						{
+							"type":  "Action.OpenUrl",
+							"title": "View pull request",
+							"url":   msg.PullReqURL,
+						},
+					},
+					"msteams": map[string]any{
+						"entities": entities,
+					},
+				},
+			},
+		},
+	}
+}
@@ -19,6 +19,7 @@ import (
 
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/events"
@@ -27,7 +28,7 @@ import (
 )
 
 var WireSet = wire.NewSet(
-	ProvideMailClient,
+	ProvideNotificationClient,
 	ProvideNotificationService,
 )
 
@@ -64,3 +65,34 @@ func ProvideNotificationService(
 func ProvideMailClient(mailer mailer.Mailer) Client {
 	return NewMailClient(mailer)
 }
+
+// ProvideNotificationClient provides the client that delivers notifications through email
+// and through the chat applications enabled in the config.
+func ProvideNotificationClient(
+	config Config,
+	mailer mailer.Mailer,
+	chatIdentityStore store.ChatIdentityStore,
+	settingsService *settings.Service,
+	spaceStore store.SpaceStore,
+) Client {
+	mailClient := ProvideMailClient(mailer)
+	if !config.SlackEnabled && !config.TeamsEnabled {
+		return mailClient
+	}
+
+	targets := &chatTargets{
+		chatIdentityStore: chatIdentityStore,
+		settingsService:   settingsService,
+		spaceStore:        spaceStore,
+	}
+
+	clients := MultiClient{mailClient}
+	if config.SlackEnabled {
+		clients = append(clients, NewSlackClient(targets, config.SlackBotToken))
+	}
+	if config.TeamsEnabled {
+		clients = append(clients, NewTeamsClient(targets))
+	}
+
+	return clients
+}
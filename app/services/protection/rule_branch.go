@@ -18,7 +18,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/harness/gitness/cache"
 	"github.com/harness/gitness/types"
+
+	"golang.org/x/exp/slices"
 )
 
 const TypeBranch types.RuleType = "branch"
@@ -28,6 +31,7 @@ type Branch struct {
 	Bypass    DefBypass    `json:"bypass"`
 	PullReq   DefPullReq   `json:"pullreq"`
 	Lifecycle DefLifecycle `json:"lifecycle"`
+	Commits   DefCommits   `json:"commits"`
 }
 
 var (
@@ -44,6 +48,13 @@ func (v *Branch) MergeVerify(
 		return out, violations, fmt.Errorf("merge verify error: %w", err)
 	}
 
+	commitsViolations, err := v.Commits.MergeVerify(ctx, in)
+	if err != nil {
+		return out, violations, fmt.Errorf("commits error: %w", err)
+	}
+
+	violations = append(violations, commitsViolations...)
+
 	bypassable := v.Bypass.matches(ctx, in.Actor, in.IsRepoOwner, in.ResolveUserGroupID)
 	bypassed := in.AllowBypass && bypassable
 	for i := range violations {
@@ -85,6 +96,13 @@ func (v *Branch) RequiredChecks(
 	}, nil
 }
 
+func (v *Branch) DefaultReviewers(
+	ctx context.Context,
+	in DefaultReviewersInput,
+) (DefaultReviewersOutput, error) {
+	return v.PullReq.DefaultReviewers(ctx, in)
+}
+
 func (v *Branch) RefChangeVerify(
 	ctx context.Context,
 	in RefChangeVerifyInput,
@@ -98,6 +116,13 @@ func (v *Branch) RefChangeVerify(
 		return nil, fmt.Errorf("lifecycle error: %w", err)
 	}
 
+	commitsViolations, err := v.Commits.RefChangeVerify(ctx, in)
+	if err != nil {
+		return nil, fmt.Errorf("commits error: %w", err)
+	}
+
+	violations = append(violations, commitsViolations...)
+
 	bypassable := v.Bypass.matches(ctx, in.Actor, in.IsRepoOwner, in.ResolveUserGroupID)
 	bypassed := in.AllowBypass && bypassable
 	for i := range violations {
@@ -109,11 +134,11 @@ func (v *Branch) RefChangeVerify(
 }
 
 func (v *Branch) UserIDs() ([]int64, error) {
-	return v.Bypass.UserIDs, nil
+	return cache.Deduplicate(append(slices.Clone(v.Bypass.UserIDs), v.PullReq.Reviewers.UserIDs...)), nil
 }
 
 func (v *Branch) UserGroupIDs() ([]int64, error) {
-	return v.Bypass.UserGroupIDs, nil
+	return cache.Deduplicate(append(slices.Clone(v.Bypass.UserGroupIDs), v.PullReq.Reviewers.UserGroupIDs...)), nil
 }
 
 func (v *Branch) Sanitize() error {
@@ -129,5 +154,9 @@ func (v *Branch) Sanitize() error {
 		return fmt.Errorf("lifecycle: %w", err)
 	}
 
+	if err := v.Commits.Sanitize(); err != nil {
+		return fmt.Errorf("commits: %w", err)
+	}
+
 	return nil
 }
@@ -245,6 +245,48 @@ func TestBranch_MergeVerify(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "unsigned-commits",
+			branch: Branch{
+				Commits: DefCommits{RequireSigned: true},
+			},
+			in: MergeVerifyInput{
+				Actor:   user,
+				PullReq: &types.PullReq{},
+				FindUnverifiedCommits: func(context.Context) ([]string, error) {
+					return []string{"abc"}, nil
+				},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+			expVs: []types.RuleViolations{
+				{
+					Bypassable: false,
+					Bypassed:   false,
+					Violations: []types.Violation{
+						{Code: codeCommitsReqSigned},
+					},
+				},
+			},
+		},
+		{
+			name: "signed-commits",
+			branch: Branch{
+				Commits: DefCommits{RequireSigned: true},
+			},
+			in: MergeVerifyInput{
+				Actor:   user,
+				PullReq: &types.PullReq{},
+				FindUnverifiedCommits: func(context.Context) ([]string, error) {
+					return nil, nil
+				},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+			expVs: []types.RuleViolations{},
+		},
 	}
 
 	ctx := context.Background()
@@ -507,6 +549,71 @@ func TestBranch_RefChangeVerify(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "unsigned-commits",
+			branch: Branch{
+				Bypass:  DefBypass{RepoOwners: true},
+				Commits: DefCommits{RequireSigned: true},
+			},
+			in: RefChangeVerifyInput{
+				Actor:                 user,
+				AllowBypass:           true,
+				IsRepoOwner:           false,
+				RefAction:             RefActionUpdate,
+				RefType:               RefTypeBranch,
+				RefNames:              []string{"abc"},
+				FindUnverifiedCommits: mockUnverifiedCommits("abc"),
+			},
+			expVs: []types.RuleViolations{
+				{
+					Bypassable: false,
+					Bypassed:   false,
+					Violations: []types.Violation{
+						{Code: codeCommitsReqSigned},
+					},
+				},
+			},
+		},
+		{
+			name: "unsigned-commits-owner-bypass",
+			branch: Branch{
+				Bypass:  DefBypass{RepoOwners: true},
+				Commits: DefCommits{RequireSigned: true},
+			},
+			in: RefChangeVerifyInput{
+				Actor:                 user,
+				AllowBypass:           true,
+				IsRepoOwner:           true,
+				RefAction:             RefActionCreate,
+				RefType:               RefTypeBranch,
+				RefNames:              []string{"abc"},
+				FindUnverifiedCommits: mockUnverifiedCommits("abc"),
+			},
+			expVs: []types.RuleViolations{
+				{
+					Bypassable: true,
+					Bypassed:   true,
+					Violations: []types.Violation{
+						{Code: codeCommitsReqSigned},
+					},
+				},
+			},
+		},
+		{
+			name: "signed-commits",
+			branch: Branch{
+				Commits: DefCommits{RequireSigned: true},
+			},
+			in: RefChangeVerifyInput{
+				Actor:                 user,
+				AllowBypass:           true,
+				RefAction:             RefActionUpdateForce,
+				RefType:               RefTypeBranch,
+				RefNames:              []string{"xyz"},
+				FindUnverifiedCommits: mockUnverifiedCommits("abc"),
+			},
+			expVs: []types.RuleViolations{},
+		},
 	}
 
 	ctx := context.Background()
@@ -555,6 +662,16 @@ func TestBranch_RefChangeVerify(t *testing.T) {
 	}
 }
 
+// mockUnverifiedCommits returns a commit without a verified signature only for the provided branch.
+func mockUnverifiedCommits(branchName string) func(context.Context, string) ([]string, error) {
+	return func(_ context.Context, name string) ([]string, error) {
+		if name != branchName {
+			return nil, nil
+		}
+		return []string{"1234567890"}, nil
+	}
+}
+
 func mockUserGroupResolver(_ context.Context, _ []int64) ([]int64, error) {
 	return []int64{43}, nil
 }
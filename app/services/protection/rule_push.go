@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/cache"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+const TypePush types.RuleType = "push"
+
+// Push implements protection rules for the rule type TypePush.
+// The rules are verified in the pre-receive git hook for every new commit of a push.
+type Push struct {
+	Bypass DefBypass `json:"bypass"`
+	Push   DefPush   `json:"push"`
+}
+
+var (
+	// ensures that the Push type implements Definition interface.
+	_ Definition = (*Push)(nil)
+)
+
+// MergeVerify doesn't restrict merging of pull requests, push rules only verify pushed commits.
+func (v *Push) MergeVerify(
+	context.Context,
+	MergeVerifyInput,
+) (MergeVerifyOutput, []types.RuleViolations, error) {
+	return MergeVerifyOutput{
+		AllowedMethods: slices.Clone(enum.MergeMethods),
+	}, nil, nil
+}
+
+// RequiredChecks doesn't require any status checks, push rules only verify pushed commits.
+func (v *Push) RequiredChecks(
+	context.Context,
+	RequiredChecksInput,
+) (RequiredChecksOutput, error) {
+	return RequiredChecksOutput{}, nil
+}
+
+// DefaultReviewers doesn't request any reviewers, push rules only verify pushed commits.
+func (v *Push) DefaultReviewers(
+	context.Context,
+	DefaultReviewersInput,
+) (DefaultReviewersOutput, error) {
+	return DefaultReviewersOutput{}, nil
+}
+
+func (v *Push) RefChangeVerify(
+	ctx context.Context,
+	in RefChangeVerifyInput,
+) (violations []types.RuleViolations, err error) {
+	if in.RefType != RefTypeBranch && in.RefType != RefTypeTag || len(in.RefNames) == 0 {
+		return []types.RuleViolations{}, nil
+	}
+
+	violations, err = v.Push.RefChangeVerify(ctx, in)
+	if err != nil {
+		return nil, fmt.Errorf("push error: %w", err)
+	}
+
+	bypassable := v.Bypass.matches(ctx, in.Actor, in.IsRepoOwner, in.ResolveUserGroupID)
+	bypassed := in.AllowBypass && bypassable
+	for i := range violations {
+		violations[i].Bypassable = bypassable
+		violations[i].Bypassed = bypassed
+	}
+
+	return
+}
+
+func (v *Push) UserIDs() ([]int64, error) {
+	return cache.Deduplicate(append(slices.Clone(v.Bypass.UserIDs), v.Push.Paths.Exempt.UserIDs...)), nil
+}
+
+func (v *Push) UserGroupIDs() ([]int64, error) {
+	return cache.Deduplicate(append(slices.Clone(v.Bypass.UserGroupIDs), v.Push.Paths.Exempt.UserGroupIDs...)), nil
+}
+
+func (v *Push) Sanitize() error {
+	if err := v.Bypass.Sanitize(); err != nil {
+		return fmt.Errorf("bypass: %w", err)
+	}
+
+	if err := v.Push.Sanitize(); err != nil {
+		return fmt.Errorf("push: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestTag_RefChangeVerify(t *testing.T) {
+	user := &types.Principal{ID: 42}
+
+	tests := []struct {
+		name  string
+		tag   Tag
+		in    RefChangeVerifyInput
+		expVs []types.RuleViolations
+	}{
+		{
+			name: "empty",
+			tag:  Tag{},
+			in: RefChangeVerifyInput{
+				Actor:     user,
+				RefAction: RefActionDelete,
+				RefType:   RefTypeTag,
+				RefNames:  []string{"v1.0.0"},
+			},
+			expVs: []types.RuleViolations{},
+		},
+		{
+			name: "branch-ignored",
+			tag: Tag{
+				Lifecycle: DefTagLifecycle{DeleteForbidden: true},
+			},
+			in: RefChangeVerifyInput{
+				Actor:     user,
+				RefAction: RefActionDelete,
+				RefType:   RefTypeBranch,
+				RefNames:  []string{"v1.0.0"},
+			},
+			expVs: []types.RuleViolations{},
+		},
+		{
+			name: "create-forbidden",
+			tag: Tag{
+				Bypass:    DefBypass{RepoOwners: true},
+				Lifecycle: DefTagLifecycle{CreateForbidden: true},
+			},
+			in: RefChangeVerifyInput{
+				Actor:       user,
+				AllowBypass: true,
+				RefAction:   RefActionCreate,
+				RefType:     RefTypeTag,
+				RefNames:    []string{"v1.0.0"},
+			},
+			expVs: []types.RuleViolations{
+				{
+					Bypassable: false,
+					Bypassed:   false,
+					Violations: []types.Violation{
+						{Code: codeLifecycleCreate},
+					},
+				},
+			},
+		},
+		{
+			name: "update-forbidden-owner-bypass",
+			tag: Tag{
+				Bypass:    DefBypass{RepoOwners: true},
+				Lifecycle: DefTagLifecycle{UpdateForbidden: true},
+			},
+			in: RefChangeVerifyInput{
+				Actor:       user,
+				AllowBypass: true,
+				IsRepoOwner: true,
+				RefAction:   RefActionUpdate,
+				RefType:     RefTypeTag,
+				RefNames:    []string{"v1.0.0"},
+			},
+			expVs: []types.RuleViolations{
+				{
+					Bypassable: true,
+					Bypassed:   true,
+					Violations: []types.Violation{
+						{Code: codeLifecycleUpdate},
+					},
+				},
+			},
+		},
+		{
+			name: "delete-forbidden-owner-no-bypass-requested",
+			tag: Tag{
+				Bypass:    DefBypass{RepoOwners: true},
+				Lifecycle: DefTagLifecycle{DeleteForbidden: true},
+			},
+			in: RefChangeVerifyInput{
+				Actor:       user,
+				AllowBypass: false,
+				IsRepoOwner: true,
+				RefAction:   RefActionDelete,
+				RefType:     RefTypeTag,
+				RefNames:    []string{"v1.0.0"},
+			},
+			expVs: []types.RuleViolations{
+				{
+					Bypassable: true,
+					Bypassed:   false,
+					Violations: []types.Violation{
+						{Code: codeLifecycleDelete},
+					},
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.tag.Sanitize(); err != nil {
+				t.Errorf("invalid: %s", err.Error())
+				return
+			}
+
+			results, err := test.tag.RefChangeVerify(ctx, test.in)
+			if err != nil {
+				t.Errorf("error: %s", err.Error())
+				return
+			}
+
+			if want, got := len(test.expVs), len(results); want != got {
+				t.Errorf("number of violations mismatch: want=%d got=%d", want, got)
+				return
+			}
+
+			for i := range results {
+				if want, got := test.expVs[i].Bypassable, results[i].Bypassable; want != got {
+					t.Errorf("rule result %d, bypassable mismatch: want=%t got=%t", i, want, got)
+				}
+				if want, got := test.expVs[i].Bypassed, results[i].Bypassed; want != got {
+					t.Errorf("rule result %d, bypassed mismatch: want=%t got=%t", i, want, got)
+				}
+				if want, got := len(test.expVs[i].Violations), len(results[i].Violations); want != got {
+					t.Errorf("rule result %d, violations count mismatch: want=%d got=%d", i, want, got)
+					continue
+				}
+				for j := range results[i].Violations {
+					if want, got := test.expVs[i].Violations[j].Code, results[i].Violations[j].Code; want != got {
+						t.Errorf("rule result %d, violation %d, code mismatch: want=%s got=%s", i, j, want, got)
+					}
+				}
+			}
+		})
+	}
+}
@@ -58,6 +58,8 @@ func (s ruleSet) MergeVerify(
 			out.RequiresCodeOwnersApprovalLatest = out.RequiresCodeOwnersApprovalLatest || rOut.RequiresCodeOwnersApprovalLatest
 			out.RequiresCommentResolution = out.RequiresCommentResolution || rOut.RequiresCommentResolution
 			out.RequiresNoChangeRequests = out.RequiresNoChangeRequests || rOut.RequiresNoChangeRequests
+			out.RequiresMergeQueue = out.RequiresMergeQueue || rOut.RequiresMergeQueue
+			out.RequiresChecklistCompletion = out.RequiresChecklistCompletion || rOut.RequiresChecklistCompletion
 
 			return nil
 		})
@@ -104,10 +106,75 @@ func (s ruleSet) RequiredChecks(
 	}, nil
 }
 
+// DefaultReviewers combines the default reviewers of all active rules that match the pull request target branch.
+func (s ruleSet) DefaultReviewers(
+	ctx context.Context,
+	in DefaultReviewersInput,
+) (DefaultReviewersOutput, error) {
+	var out DefaultReviewersOutput
+
+	userIDMap := map[int64]struct{}{}
+	userGroupIdx := map[int64]int{}
+
+	err := s.forEachRuleMatchBranch(in.Repo.DefaultBranch, in.PullReq.TargetBranch,
+		func(r *types.RuleInfoInternal, p Protection) error {
+			// Rules in monitor mode don't have any effect, reviewers are requested only by the active rules.
+			if r.State != enum.RuleStateActive {
+				return nil
+			}
+
+			rOut, err := p.DefaultReviewers(ctx, in)
+			if err != nil {
+				return err
+			}
+
+			for _, userID := range rOut.UserIDs {
+				if _, ok := userIDMap[userID]; ok {
+					continue
+				}
+				userIDMap[userID] = struct{}{}
+				out.UserIDs = append(out.UserIDs, userID)
+			}
+
+			// If several rules list the same user group, the one that requests the most reviewers is used.
+			for _, userGroup := range rOut.UserGroups {
+				idx, ok := userGroupIdx[userGroup.UserGroupID]
+				if !ok {
+					userGroupIdx[userGroup.UserGroupID] = len(out.UserGroups)
+					out.UserGroups = append(out.UserGroups, userGroup)
+					continue
+				}
+
+				existing := out.UserGroups[idx]
+				if existing.Selection == enum.ReviewerSelectionAll {
+					continue
+				}
+				if userGroup.Selection == enum.ReviewerSelectionAll || userGroup.Count > existing.Count {
+					out.UserGroups[idx] = userGroup
+				}
+			}
+
+			out.CodeOwners = out.CodeOwners || rOut.CodeOwners
+
+			return nil
+		})
+	if err != nil {
+		return DefaultReviewersOutput{}, fmt.Errorf("failed to process each rule in ruleSet: %w", err)
+	}
+
+	return out, nil
+}
+
 func (s ruleSet) RefChangeVerify(ctx context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
 	var violations []types.RuleViolations
 
-	err := s.forEachRuleMatchRefs(in.Repo.DefaultBranch, in.RefNames,
+	// The default branch pattern applies only to branches, it must not match a tag with the same name.
+	defaultBranch := in.Repo.DefaultBranch
+	if in.RefType == RefTypeTag {
+		defaultBranch = ""
+	}
+
+	err := s.forEachRuleMatchRefs(defaultBranch, in.RefNames,
 		func(r *types.RuleInfoInternal, p Protection, matched []string) error {
 			ruleIn := in
 			ruleIn.RefNames = matched
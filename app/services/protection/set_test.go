@@ -321,6 +321,32 @@ func TestRuleSet_MergeVerify(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "tag-rule-ignored",
+			rules: []types.RuleInfoInternal{
+				{
+					RuleInfo: types.RuleInfo{
+						RepoPath:   "space/repo",
+						ID:         1,
+						Identifier: "tags",
+						Type:       TypeTag,
+						State:      enum.RuleStateActive,
+					},
+					Pattern:    []byte(`{"include":["main"]}`),
+					Definition: []byte(`{"lifecycle":{"update_forbidden":true}}`),
+				},
+			},
+			input: MergeVerifyInput{
+				Actor:      &types.Principal{ID: 1},
+				TargetRepo: &types.Repository{ID: 1, DefaultBranch: "main"},
+				PullReq:    &types.PullReq{ID: 1, SourceBranch: "pr", TargetBranch: "main"},
+				Method:     enum.MergeMethodMerge,
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+			expViol: nil,
+		},
 	}
 
 	ctx := context.Background()
@@ -329,6 +355,9 @@ func TestRuleSet_MergeVerify(t *testing.T) {
 	_ = m.Register(TypeBranch, func() Definition {
 		return &Branch{}
 	})
+	_ = m.Register(TypeTag, func() Definition {
+		return &Tag{}
+	})
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -462,6 +491,110 @@ func TestRuleSet_RequiredChecks(t *testing.T) {
 	}
 }
 
+func TestRuleSet_RefChangeVerify(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    []types.RuleInfoInternal
+		input    RefChangeVerifyInput
+		expCodes map[string][]string // rule identifier -> violation codes
+	}{
+		{
+			name: "tag-rule-applies-to-tags-only",
+			rules: []types.RuleInfoInternal{
+				{
+					RuleInfo:   types.RuleInfo{ID: 1, Identifier: "branches", Type: TypeBranch, State: enum.RuleStateActive},
+					Pattern:    []byte(`{"include":["v*"]}`),
+					Definition: []byte(`{"lifecycle":{"delete_forbidden":true}}`),
+				},
+				{
+					RuleInfo:   types.RuleInfo{ID: 2, Identifier: "releases", Type: TypeTag, State: enum.RuleStateActive},
+					Pattern:    []byte(`{"include":["v*"]}`),
+					Definition: []byte(`{"lifecycle":{"delete_forbidden":true,"update_forbidden":true}}`),
+				},
+			},
+			input: RefChangeVerifyInput{
+				Actor:     &types.Principal{ID: 1},
+				Repo:      &types.Repository{ID: 1, DefaultBranch: "main"},
+				RefAction: RefActionDelete,
+				RefType:   RefTypeTag,
+				RefNames:  []string{"v1.0.0"},
+			},
+			expCodes: map[string][]string{"releases": {codeLifecycleDelete}},
+		},
+		{
+			name: "tag-not-matching-pattern",
+			rules: []types.RuleInfoInternal{
+				{
+					RuleInfo:   types.RuleInfo{ID: 1, Identifier: "releases", Type: TypeTag, State: enum.RuleStateActive},
+					Pattern:    []byte(`{"include":["v*"]}`),
+					Definition: []byte(`{"lifecycle":{"create_forbidden":true}}`),
+				},
+			},
+			input: RefChangeVerifyInput{
+				Actor:     &types.Principal{ID: 1},
+				Repo:      &types.Repository{ID: 1, DefaultBranch: "main"},
+				RefAction: RefActionCreate,
+				RefType:   RefTypeTag,
+				RefNames:  []string{"nightly"},
+			},
+			expCodes: map[string][]string{},
+		},
+		{
+			name: "default-pattern-does-not-match-tag",
+			rules: []types.RuleInfoInternal{
+				{
+					RuleInfo:   types.RuleInfo{ID: 1, Identifier: "default", Type: TypeTag, State: enum.RuleStateActive},
+					Pattern:    []byte(`{"default":true}`),
+					Definition: []byte(`{"lifecycle":{"create_forbidden":true}}`),
+				},
+			},
+			input: RefChangeVerifyInput{
+				Actor:     &types.Principal{ID: 1},
+				Repo:      &types.Repository{ID: 1, DefaultBranch: "main"},
+				RefAction: RefActionCreate,
+				RefType:   RefTypeTag,
+				RefNames:  []string{"main"},
+			},
+			expCodes: map[string][]string{},
+		},
+	}
+
+	ctx := context.Background()
+
+	m := NewManager(nil)
+	_ = m.Register(TypeBranch, func() Definition {
+		return &Branch{}
+	})
+	_ = m.Register(TypeTag, func() Definition {
+		return &Tag{}
+	})
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			set := ruleSet{
+				rules:   test.rules,
+				manager: m,
+			}
+
+			violations, err := set.RefChangeVerify(ctx, test.input)
+			if err != nil {
+				t.Fatalf("got error: %s", err.Error())
+			}
+
+			codes := map[string][]string{}
+			for _, v := range violations {
+				for _, violation := range v.Violations {
+					codes[v.Rule.Identifier] = append(codes[v.Rule.Identifier], violation.Code)
+				}
+			}
+
+			if want, got := test.expCodes, codes; !reflect.DeepEqual(want, got) {
+				t.Errorf("violations: want=%v got=%v", want, got)
+			}
+		})
+	}
+}
+
 func TestIntersectSorted(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+)
+
+const codeCommitsReqSigned = "commits.require_signed"
+
+// DefCommits defines the requirements for the commits pushed to branches
+// and for the commits of the pull requests targeting the branches.
+type DefCommits struct {
+	RequireSigned bool `json:"require_signed,omitempty"`
+}
+
+// ensures that the DefCommits type implements Sanitizer and RefChangeVerifier interface.
+var (
+	_ Sanitizer         = (*DefCommits)(nil)
+	_ RefChangeVerifier = (*DefCommits)(nil)
+)
+
+func (v *DefCommits) MergeVerify(ctx context.Context, in MergeVerifyInput) ([]types.RuleViolations, error) {
+	if !v.RequireSigned || in.FindUnverifiedCommits == nil {
+		return nil, nil
+	}
+
+	unverifiedSHAs, err := in.FindUnverifiedCommits(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find commits without a verified signature: %w", err)
+	}
+
+	if len(unverifiedSHAs) == 0 {
+		return nil, nil
+	}
+
+	var violations types.RuleViolations
+
+	violations.Addf(codeCommitsReqSigned,
+		"All commits must have a verified signature. Commits without one: %s",
+		formatList(unverifiedSHAs))
+
+	return []types.RuleViolations{violations}, nil
+}
+
+func (v *DefCommits) RefChangeVerify(ctx context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
+	if !v.RequireSigned || in.FindUnverifiedCommits == nil || in.RefAction == RefActionDelete {
+		return nil, nil
+	}
+
+	var violations types.RuleViolations
+
+	for _, refName := range in.RefNames {
+		unverifiedSHAs, err := in.FindUnverifiedCommits(ctx, refName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find commits without a verified signature: %w", err)
+		}
+
+		if len(unverifiedSHAs) > 0 {
+			violations.Addf(codeCommitsReqSigned,
+				"Commits pushed to branch %q must have a verified signature. Commits without one: %s",
+				refName, formatList(unverifiedSHAs))
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		return []types.RuleViolations{violations}, nil
+	}
+
+	return nil, nil
+}
+
+func (*DefCommits) Sanitize() error {
+	return nil
+}
@@ -34,6 +34,12 @@ type (
 		RefAction          RefAction
 		RefType            RefType
 		RefNames           []string
+		// FindUnverifiedCommits returns the new commits pushed to the branch without a verified signature.
+		// Commit signatures aren't verified if it's nil.
+		FindUnverifiedCommits func(ctx context.Context, branchName string) ([]string, error)
+		// FindPushChanges returns the new commits and the changed paths pushed to the branch or tag.
+		// Push rules aren't verified if it's nil.
+		FindPushChanges func(ctx context.Context, refType RefType, refName string) (PushChanges, error)
 	}
 
 	RefType int
@@ -46,6 +52,13 @@ type (
 		UpdateForbidden      bool `json:"update_forbidden,omitempty"`
 		UpdateForceForbidden bool `json:"update_force_forbidden,omitempty"`
 	}
+
+	// DefTagLifecycle controls creation, deletion and moving of tags.
+	DefTagLifecycle struct {
+		CreateForbidden bool `json:"create_forbidden,omitempty"`
+		DeleteForbidden bool `json:"delete_forbidden,omitempty"`
+		UpdateForbidden bool `json:"update_forbidden,omitempty"`
+	}
 )
 
 const (
@@ -61,10 +74,12 @@ const (
 	RefActionUpdateForce
 )
 
-// ensures that the DefLifecycle type implements Sanitizer and RefChangeVerifier interfaces.
+// ensures that the DefLifecycle and DefTagLifecycle types implement Sanitizer and RefChangeVerifier interfaces.
 var (
 	_ Sanitizer         = (*DefLifecycle)(nil)
 	_ RefChangeVerifier = (*DefLifecycle)(nil)
+	_ Sanitizer         = (*DefTagLifecycle)(nil)
+	_ RefChangeVerifier = (*DefTagLifecycle)(nil)
 )
 
 const (
@@ -110,3 +125,35 @@ func (v *DefLifecycle) RefChangeVerify(_ context.Context, in RefChangeVerifyInpu
 func (*DefLifecycle) Sanitize() error {
 	return nil
 }
+
+func (v *DefTagLifecycle) RefChangeVerify(_ context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
+	var violations types.RuleViolations
+
+	switch in.RefAction {
+	case RefActionCreate:
+		if v.CreateForbidden {
+			violations.Addf(codeLifecycleCreate,
+				"Creation of tag %q is not allowed.", in.RefNames[0])
+		}
+	case RefActionDelete:
+		if v.DeleteForbidden {
+			violations.Addf(codeLifecycleDelete,
+				"Delete of tag %q is not allowed.", in.RefNames[0])
+		}
+	case RefActionUpdate, RefActionUpdateForce:
+		if v.UpdateForbidden {
+			violations.Addf(codeLifecycleUpdate,
+				"Update of tag %q is not allowed.", in.RefNames[0])
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		return []types.RuleViolations{violations}, nil
+	}
+
+	return nil, nil
+}
+
+func (*DefTagLifecycle) Sanitize() error {
+	return nil
+}
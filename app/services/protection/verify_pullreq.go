@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/harness/gitness/app/services/codeowners"
@@ -32,6 +33,7 @@ type (
 	MergeVerifier interface {
 		MergeVerify(ctx context.Context, in MergeVerifyInput) (MergeVerifyOutput, []types.RuleViolations, error)
 		RequiredChecks(ctx context.Context, in RequiredChecksInput) (RequiredChecksOutput, error)
+		DefaultReviewers(ctx context.Context, in DefaultReviewersInput) (DefaultReviewersOutput, error)
 	}
 
 	MergeVerifyInput struct {
@@ -46,6 +48,12 @@ type (
 		Method             enum.MergeMethod
 		CheckResults       []types.CheckResult
 		CodeOwners         *codeowners.Evaluation
+		// MergeQueue should be set to true if the pull request is being merged through the merge queue.
+		// The required status checks are not verified in that case, the merge queue checks them separately.
+		MergeQueue bool
+		// FindUnverifiedCommits returns the commits of the pull request without a verified signature.
+		// Commit signatures aren't verified if it's nil.
+		FindUnverifiedCommits func(ctx context.Context) ([]string, error)
 	}
 
 	MergeVerifyOutput struct {
@@ -57,6 +65,8 @@ type (
 		RequiresCodeOwnersApprovalLatest    bool
 		RequiresCommentResolution           bool
 		RequiresNoChangeRequests            bool
+		RequiresMergeQueue                  bool
+		RequiresChecklistCompletion         bool
 	}
 
 	RequiredChecksInput struct {
@@ -93,23 +103,33 @@ const (
 	codePullReqMergeStrategiesAllowed = "pullreq.merge.strategies_allowed"
 	codePullReqMergeDeleteBranch      = "pullreq.merge.delete_branch"
 	codePullReqMergeBlock             = "pullreq.merge.blocked"
+	codePullReqMergeQueueRequired     = "pullreq.merge.queue_required"
 
 	codePullReqCommentsReqResolveAll      = "pullreq.comments.require_resolve_all"
+	codePullReqDescriptionReqChecklist    = "pullreq.description.require_checklist_complete"
 	codePullReqStatusChecksReqIdentifiers = "pullreq.status_checks.required_identifiers"
 )
 
+// isCrossRepo returns true if the pull request is coming from a different repository (a fork).
+func (in *MergeVerifyInput) isCrossRepo() bool {
+	return in.SourceRepo != nil && in.TargetRepo != nil && in.SourceRepo.ID != in.TargetRepo.ID
+}
+
 //nolint:gocognit,gocyclo,cyclop // well aware of this
 func (v *DefPullReq) MergeVerify(
-	_ context.Context,
+	ctx context.Context,
 	in MergeVerifyInput,
 ) (MergeVerifyOutput, []types.RuleViolations, error) {
 	var out MergeVerifyOutput
 	var violations types.RuleViolations
 
 	// set static merge verify output that comes from the PR definition
-	out.DeleteSourceBranch = v.Merge.DeleteBranch
+	// the source branch of a pull request from a fork is in a different repository and is never deleted.
+	out.DeleteSourceBranch = v.Merge.DeleteBranch && !in.isCrossRepo()
 	out.RequiresCommentResolution = v.Comments.RequireResolveAll
 	out.RequiresNoChangeRequests = v.Approvals.RequireNoChangeRequest
+	out.RequiresMergeQueue = v.Merge.RequireMergeQueue
+	out.RequiresChecklistCompletion = v.Description.RequireChecklistComplete
 
 	// output that depends on approval of latest commit
 	if v.Approvals.RequireLatestCommit {
@@ -201,10 +221,26 @@ func (v *DefPullReq) MergeVerify(
 			in.PullReq.UnresolvedCount)
 	}
 
+	// pullreq.description
+
+	if v.Description.RequireChecklistComplete {
+		if count := countUncheckedTasks(in.PullReq.Description); count > 0 {
+			violations.Addf(codePullReqDescriptionReqChecklist,
+				"All checklist items in the description must be checked. There are %d unchecked items.",
+				count)
+		}
+	}
+
 	// pullreq.status_checks
 
+	// The merge queue evaluates the required status checks on its own speculative merge commit.
+	var requiredIdentifiers []string
+	if !in.MergeQueue {
+		requiredIdentifiers = v.StatusChecks.RequireIdentifiers
+	}
+
 	var violatingStatusCheckIdentifiers []string
-	for _, requiredIdentifier := range v.StatusChecks.RequireIdentifiers {
+	for _, requiredIdentifier := range requiredIdentifiers {
 		var succeeded bool
 		for i := range in.CheckResults {
 			if in.CheckResults[i].Identifier == requiredIdentifier {
@@ -251,6 +287,12 @@ func (v *DefPullReq) MergeVerify(
 			"The merge for the branch %s is not allowed.", in.PullReq.TargetBranch)
 	}
 
+	if v.Merge.RequireMergeQueue && !in.MergeQueue {
+		violations.Addf(
+			codePullReqMergeQueueRequired,
+			"Pull requests for the branch %s must be merged through the merge queue.", in.PullReq.TargetBranch)
+	}
+
 	if len(violations.Violations) > 0 {
 		return out, []types.RuleViolations{violations}, nil
 	}
@@ -271,6 +313,13 @@ func (v *DefPullReq) RequiredChecks(
 	}, nil
 }
 
+func (v *DefPullReq) DefaultReviewers(
+	ctx context.Context,
+	in DefaultReviewersInput,
+) (DefaultReviewersOutput, error) {
+	return v.Reviewers.DefaultReviewers(ctx, in)
+}
+
 type DefApprovals struct {
 	RequireCodeOwners      bool `json:"require_code_owners,omitempty"`
 	RequireMinimumCount    int  `json:"require_minimum_count,omitempty"`
@@ -298,6 +347,51 @@ func (DefComments) Sanitize() error {
 	return nil
 }
 
+// DefDescription defines the requirements for the pull request description.
+type DefDescription struct {
+	RequireChecklistComplete bool `json:"require_checklist_complete,omitempty"`
+}
+
+func (DefDescription) Sanitize() error {
+	return nil
+}
+
+var (
+	// regexpTaskItem matches a markdown task list item, e.g. "- [ ] task", "* [x] task" or "1. [ ] task".
+	regexpTaskItem = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+\[([ xX])\](?:\s|$)`)
+	// regexpCodeFence matches the opening or closing line of a fenced code block.
+	regexpCodeFence = regexp.MustCompile("^\\s*(```|~~~)")
+)
+
+// countUncheckedTasks returns the number of unchecked task list items in the markdown text.
+// Task items inside fenced code blocks are ignored.
+func countUncheckedTasks(text string) int {
+	var count int
+	var fence string
+
+	for _, line := range strings.Split(text, "\n") {
+		if m := regexpCodeFence.FindStringSubmatch(line); m != nil {
+			switch fence {
+			case "":
+				fence = m[1]
+			case m[1]:
+				fence = ""
+			}
+			continue
+		}
+
+		if fence != "" {
+			continue
+		}
+
+		if m := regexpTaskItem.FindStringSubmatch(line); m != nil && m[1] == " " {
+			count++
+		}
+	}
+
+	return count
+}
+
 type DefStatusChecks struct {
 	RequireIdentifiers []string `json:"require_identifiers,omitempty"`
 }
@@ -349,6 +443,7 @@ type DefMerge struct {
 	StrategiesAllowed []enum.MergeMethod `json:"strategies_allowed,omitempty"`
 	DeleteBranch      bool               `json:"delete_branch,omitempty"`
 	Block             bool               `json:"block,omitempty"`
+	RequireMergeQueue bool               `json:"require_merge_queue,omitempty"`
 }
 
 func (v *DefMerge) Sanitize() error {
@@ -365,24 +460,34 @@ func (v *DefMerge) Sanitize() error {
 		m[strategy] = struct{}{}
 	}
 
+	if v.RequireMergeQueue && len(v.StrategiesAllowed) == 1 &&
+		v.StrategiesAllowed[0] == enum.MergeMethodFastForward {
+		return errors.New("merge queue can't be required if fast-forward is the only allowed merge strategy")
+	}
+
 	slices.Sort(v.StrategiesAllowed)
 
 	return nil
 }
 
-type DefPush struct {
-	Block bool `json:"block,omitempty"`
-}
+// formatList returns a comma separated list of the first few items (commit SHAs, paths) for violation messages.
+func formatList(items []string) string {
+	const maxCount = 5
 
-func (v *DefPush) Sanitize() error {
-	return nil
+	if len(items) <= maxCount {
+		return strings.Join(items, ", ")
+	}
+
+	return fmt.Sprintf("%s and %d more", strings.Join(items[:maxCount], ", "), len(items)-maxCount)
 }
 
 type DefPullReq struct {
 	Approvals    DefApprovals    `json:"approvals"`
 	Comments     DefComments     `json:"comments"`
+	Description  DefDescription  `json:"description"`
 	StatusChecks DefStatusChecks `json:"status_checks"`
 	Merge        DefMerge        `json:"merge"`
+	Reviewers    DefReviewers    `json:"reviewers"`
 }
 
 func (v *DefPullReq) Sanitize() error {
@@ -394,6 +499,10 @@ func (v *DefPullReq) Sanitize() error {
 		return fmt.Errorf("comments: %w", err)
 	}
 
+	if err := v.Description.Sanitize(); err != nil {
+		return fmt.Errorf("description: %w", err)
+	}
+
 	if err := v.StatusChecks.Sanitize(); err != nil {
 		return fmt.Errorf("status checks: %w", err)
 	}
@@ -402,6 +511,10 @@ func (v *DefPullReq) Sanitize() error {
 		return fmt.Errorf("merge: %w", err)
 	}
 
+	if err := v.Reviewers.Sanitize(); err != nil {
+		return fmt.Errorf("reviewers: %w", err)
+	}
+
 	return nil
 }
 
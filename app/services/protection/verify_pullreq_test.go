@@ -52,6 +52,30 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 				DeleteSourceBranch: false,
 			},
 		},
+		{
+			name: "delete-branch",
+			def:  DefPullReq{Merge: DefMerge{DeleteBranch: true}},
+			in: MergeVerifyInput{
+				TargetRepo: &types.Repository{ID: 1},
+				SourceRepo: &types.Repository{ID: 1},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:     enum.MergeMethods,
+				DeleteSourceBranch: true,
+			},
+		},
+		{
+			name: "delete-branch-from-fork",
+			def:  DefPullReq{Merge: DefMerge{DeleteBranch: true}},
+			in: MergeVerifyInput{
+				TargetRepo: &types.Repository{ID: 1},
+				SourceRepo: &types.Repository{ID: 2, ForkID: 1},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:     enum.MergeMethods,
+				DeleteSourceBranch: false,
+			},
+		},
 		{
 			name: codePullReqApprovalReqMinCount + "-fail",
 			def:  DefPullReq{Approvals: DefApprovals{RequireMinimumCount: 1}},
@@ -463,6 +487,91 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 				AllowedMethods: enum.MergeMethods,
 			},
 		},
+		{
+			name: codePullReqMergeQueueRequired,
+			def: DefPullReq{
+				Merge: DefMerge{
+					RequireMergeQueue: true,
+				},
+			},
+			in: MergeVerifyInput{
+				Method: enum.MergeMethodMerge,
+				PullReq: &types.PullReq{
+					TargetBranch: "abc",
+				},
+			},
+			expCodes:  []string{codePullReqMergeQueueRequired},
+			expParams: [][]any{{"abc"}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:     enum.MergeMethods,
+				RequiresMergeQueue: true,
+			},
+		},
+		{
+			name: "merge-queue-required-merged-through-queue",
+			def: DefPullReq{
+				Merge: DefMerge{
+					RequireMergeQueue: true,
+				},
+			},
+			in: MergeVerifyInput{
+				Method:     enum.MergeMethodMerge,
+				MergeQueue: true,
+				PullReq: &types.PullReq{
+					TargetBranch: "abc",
+				},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:     enum.MergeMethods,
+				RequiresMergeQueue: true,
+			},
+		},
+		{
+			name: "merge-queue-skips-status-checks",
+			def: DefPullReq{
+				StatusChecks: DefStatusChecks{
+					RequireIdentifiers: []string{"check1"},
+				},
+			},
+			in: MergeVerifyInput{
+				Method:     enum.MergeMethodMerge,
+				MergeQueue: true,
+				PullReq:    &types.PullReq{},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqDescriptionReqChecklist + "-fail",
+			def: DefPullReq{
+				Description: DefDescription{RequireChecklistComplete: true},
+			},
+			in: MergeVerifyInput{
+				Method:  enum.MergeMethodMerge,
+				PullReq: &types.PullReq{Description: "- [x] tests\n- [ ] docs\n- [ ] changelog"},
+			},
+			expCodes:  []string{codePullReqDescriptionReqChecklist},
+			expParams: [][]any{{2}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:              enum.MergeMethods,
+				RequiresChecklistCompletion: true,
+			},
+		},
+		{
+			name: codePullReqDescriptionReqChecklist + "-success",
+			def: DefPullReq{
+				Description: DefDescription{RequireChecklistComplete: true},
+			},
+			in: MergeVerifyInput{
+				Method:  enum.MergeMethodMerge,
+				PullReq: &types.PullReq{Description: "- [x] tests\n- [X] docs"},
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:              enum.MergeMethods,
+				RequiresChecklistCompletion: true,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -486,3 +595,50 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 		})
 	}
 }
+
+func TestCountUncheckedTasks(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		exp  int
+	}{
+		{
+			name: "empty",
+			text: "",
+			exp:  0,
+		},
+		{
+			name: "no-tasks",
+			text: "Some description\n- item\n- [link](http://example.com)",
+			exp:  0,
+		},
+		{
+			name: "all-checked",
+			text: "- [x] one\n* [X] two\n1. [x] three",
+			exp:  0,
+		},
+		{
+			name: "unchecked",
+			text: "- [ ] one\n  * [ ] nested\n+ [x] two\n1. [ ] three\n2) [ ]",
+			exp:  4,
+		},
+		{
+			name: "not-a-task",
+			text: "[ ] no bullet\n-[ ] no space\n- [ ]no space after",
+			exp:  0,
+		},
+		{
+			name: "code-block",
+			text: "- [ ] one\n```md\n- [ ] in code\n~~~\n- [ ] still in code\n```\n~~~\n- [ ] in code\n~~~",
+			exp:  1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := countUncheckedTasks(test.text); got != test.exp {
+				t.Errorf("want=%d got=%d", test.exp, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,319 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"golang.org/x/exp/slices"
+)
+
+type (
+	// PushChanges are the changes a git push introduces to a branch or a tag.
+	PushChanges struct {
+		// Commits are the new commits.
+		Commits []PushCommit
+		// ChangedPaths are the paths of the files that differ between the old and the new commit of the reference.
+		ChangedPaths []string
+	}
+
+	// PushCommit is a commit introduced by a git push.
+	PushCommit struct {
+		// SHA is empty if the commit is verified before it's created.
+		SHA            string
+		Message        string
+		AuthorEmail    string
+		CommitterEmail string
+	}
+
+	// DefPush defines the policies for the commits pushed to branches and tags.
+	DefPush struct {
+		CommitMessage  DefPushCommitMessage  `json:"commit_message"`
+		Email          DefPushEmail          `json:"email"`
+		Paths          DefPushPaths          `json:"paths"`
+		FileExtensions DefPushFileExtensions `json:"file_extensions"`
+	}
+
+	// DefPushCommitMessage requires commit messages to match the regular expression,
+	// for example a Jira issue key or the Conventional Commits format.
+	DefPushCommitMessage struct {
+		Pattern string `json:"pattern,omitempty"`
+	}
+
+	// DefPushEmail restricts the author and committer emails of the commits.
+	// If restricted, the emails must be the email of the pushing user or belong to one of the allowed domains.
+	DefPushEmail struct {
+		Restricted     bool     `json:"restricted,omitempty"`
+		AllowedDomains []string `json:"allowed_domains,omitempty"`
+	}
+
+	// DefPushPaths forbids changes of the files matching the glob patterns.
+	// The users and user groups listed in Exempt are allowed to change them.
+	DefPushPaths struct {
+		Forbidden []string  `json:"forbidden,omitempty"`
+		Exempt    DefBypass `json:"exempt"`
+	}
+
+	// DefPushFileExtensions forbids adding or modifying files with the listed extensions.
+	DefPushFileExtensions struct {
+		Blocked []string `json:"blocked,omitempty"`
+	}
+)
+
+// ensures that the DefPush type implements Sanitizer and RefChangeVerifier interfaces.
+var (
+	_ Sanitizer         = (*DefPush)(nil)
+	_ RefChangeVerifier = (*DefPush)(nil)
+)
+
+const (
+	codePushCommitMessage = "push.commit_message"
+	codePushEmail         = "push.email"
+	codePushPaths         = "push.paths"
+	codePushFileExtension = "push.file_extensions"
+)
+
+func (v *DefPush) RefChangeVerify(ctx context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
+	if !v.enabled() || in.FindPushChanges == nil || in.RefAction == RefActionDelete {
+		return nil, nil
+	}
+
+	var messagePattern *regexp.Regexp
+	if v.CommitMessage.Pattern != "" {
+		var err error
+		messagePattern, err = regexp.Compile(v.CommitMessage.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile commit message pattern: %w", err)
+		}
+	}
+
+	checkPaths := len(v.Paths.Forbidden) > 0 &&
+		!v.Paths.Exempt.matches(ctx, in.Actor, in.IsRepoOwner, in.ResolveUserGroupID)
+
+	var actorEmail string
+	if in.Actor != nil {
+		actorEmail = in.Actor.Email
+	}
+
+	var violations types.RuleViolations
+
+	for _, refName := range in.RefNames {
+		changes, err := in.FindPushChanges(ctx, in.RefType, refName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find pushed changes: %w", err)
+		}
+
+		var (
+			invalidMessageSHAs []string
+			invalidEmailSHAs   []string
+			forbiddenPaths     []string
+			blockedPaths       []string
+		)
+
+		for _, commit := range changes.Commits {
+			if messagePattern != nil && !messagePattern.MatchString(commit.Message) {
+				invalidMessageSHAs = append(invalidMessageSHAs, commit.name())
+			}
+
+			if v.Email.Restricted &&
+				(!v.Email.allowed(commit.AuthorEmail, actorEmail) || !v.Email.allowed(commit.CommitterEmail, actorEmail)) {
+				invalidEmailSHAs = append(invalidEmailSHAs, commit.name())
+			}
+		}
+
+		for _, changedPath := range changes.ChangedPaths {
+			if checkPaths && v.Paths.matches(changedPath) {
+				forbiddenPaths = append(forbiddenPaths, changedPath)
+			}
+
+			if v.FileExtensions.matches(changedPath) {
+				blockedPaths = append(blockedPaths, changedPath)
+			}
+		}
+
+		if len(invalidMessageSHAs) > 0 {
+			violations.Addf(codePushCommitMessage,
+				"Commit messages pushed to %q must match the pattern %q. Commits that don't: %s",
+				refName, v.CommitMessage.Pattern, formatList(invalidMessageSHAs))
+		}
+
+		if len(invalidEmailSHAs) > 0 {
+			violations.Addf(codePushEmail,
+				"Author and committer emails of commits pushed to %q must be your own or from an allowed domain. "+
+					"Commits that aren't: %s",
+				refName, formatList(invalidEmailSHAs))
+		}
+
+		if len(forbiddenPaths) > 0 {
+			violations.Addf(codePushPaths,
+				"Changes pushed to %q modify protected paths: %s",
+				refName, formatList(forbiddenPaths))
+		}
+
+		if len(blockedPaths) > 0 {
+			violations.Addf(codePushFileExtension,
+				"Changes pushed to %q contain files with blocked extensions: %s",
+				refName, formatList(blockedPaths))
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		return []types.RuleViolations{violations}, nil
+	}
+
+	return nil, nil
+}
+
+// name returns the SHA of the commit for violation messages.
+func (c PushCommit) name() string {
+	if c.SHA == "" {
+		return "the new commit"
+	}
+	return c.SHA
+}
+
+func (v *DefPush) enabled() bool {
+	return v.CommitMessage.Pattern != "" ||
+		v.Email.Restricted ||
+		len(v.Paths.Forbidden) > 0 ||
+		len(v.FileExtensions.Blocked) > 0
+}
+
+func (v *DefPush) Sanitize() error {
+	if err := v.CommitMessage.Sanitize(); err != nil {
+		return fmt.Errorf("commit message: %w", err)
+	}
+
+	if err := v.Email.Sanitize(); err != nil {
+		return fmt.Errorf("email: %w", err)
+	}
+
+	if err := v.Paths.Sanitize(); err != nil {
+		return fmt.Errorf("paths: %w", err)
+	}
+
+	if err := v.FileExtensions.Sanitize(); err != nil {
+		return fmt.Errorf("file extensions: %w", err)
+	}
+
+	return nil
+}
+
+func (v *DefPushCommitMessage) Sanitize() error {
+	if v.Pattern == "" {
+		return nil
+	}
+
+	if _, err := regexp.Compile(v.Pattern); err != nil {
+		return fmt.Errorf("invalid pattern: %w", err)
+	}
+
+	return nil
+}
+
+// allowed returns true if the email is the actor's email or if it belongs to one of the allowed domains.
+func (v *DefPushEmail) allowed(email, actorEmail string) bool {
+	if actorEmail != "" && strings.EqualFold(email, actorEmail) {
+		return true
+	}
+
+	idx := strings.LastIndexByte(email, '@')
+	if idx < 0 {
+		return false
+	}
+
+	return slices.Contains(v.AllowedDomains, strings.ToLower(email[idx+1:]))
+}
+
+func (v *DefPushEmail) Sanitize() error {
+	if len(v.AllowedDomains) > maxElements {
+		return errors.New("too many allowed domains provided")
+	}
+
+	for i, domain := range v.AllowedDomains {
+		domain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "@"))
+		if domain == "" {
+			return errors.New("allowed domain mustn't be an empty string")
+		}
+
+		v.AllowedDomains[i] = domain
+	}
+
+	slices.Sort(v.AllowedDomains)
+	v.AllowedDomains = slices.Compact(v.AllowedDomains)
+
+	return nil
+}
+
+func (v *DefPushPaths) matches(filePath string) bool {
+	for _, pattern := range v.Forbidden {
+		if ok, _ := doublestar.Match(pattern, filePath); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (v *DefPushPaths) Sanitize() error {
+	if len(v.Forbidden) > maxElements {
+		return errors.New("too many forbidden paths provided")
+	}
+
+	for _, pattern := range v.Forbidden {
+		if err := patternValidate(pattern); err != nil {
+			return fmt.Errorf("invalid path %q: %w", pattern, err)
+		}
+	}
+
+	if err := v.Exempt.Sanitize(); err != nil {
+		return fmt.Errorf("exempt: %w", err)
+	}
+
+	return nil
+}
+
+func (v *DefPushFileExtensions) matches(filePath string) bool {
+	ext := strings.ToLower(path.Ext(filePath))
+	return ext != "" && slices.Contains(v.Blocked, ext)
+}
+
+func (v *DefPushFileExtensions) Sanitize() error {
+	if len(v.Blocked) > maxElements {
+		return errors.New("too many blocked file extensions provided")
+	}
+
+	for i, ext := range v.Blocked {
+		ext = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(ext), "."))
+		if ext == "" {
+			return errors.New("file extension mustn't be an empty string")
+		}
+
+		v.Blocked[i] = "." + ext
+	}
+
+	slices.Sort(v.Blocked)
+	v.Blocked = slices.Compact(v.Blocked)
+
+	return nil
+}
@@ -0,0 +1,348 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestDefPush_RefChangeVerify(t *testing.T) {
+	user := &types.Principal{ID: 42, Email: "john@example.com"}
+
+	changes := PushChanges{
+		Commits: []PushCommit{
+			{
+				SHA:            "abc",
+				Message:        "feat: add login page",
+				AuthorEmail:    "john@example.com",
+				CommitterEmail: "John@Example.com",
+			},
+			{
+				SHA:            "def",
+				Message:        "JIRA-123 update deployment",
+				AuthorEmail:    "jane@partner.io",
+				CommitterEmail: "john@example.com",
+			},
+		},
+		ChangedPaths: []string{"web/login.tsx", "deploy/prod/values.yaml", "tools/setup.EXE"},
+	}
+
+	findChanges := func(context.Context, RefType, string) (PushChanges, error) {
+		return changes, nil
+	}
+
+	tests := []struct {
+		name     string
+		def      DefPush
+		in       RefChangeVerifyInput
+		expCodes []string
+	}{
+		{
+			name: "empty",
+			def:  DefPush{},
+			in: RefChangeVerifyInput{
+				Actor:           user,
+				RefAction:       RefActionUpdate,
+				RefType:         RefTypeBranch,
+				RefNames:        []string{"main"},
+				FindPushChanges: findChanges,
+			},
+		},
+		{
+			name: "delete-ignored",
+			def: DefPush{
+				CommitMessage: DefPushCommitMessage{Pattern: `^JIRA-\d+ `},
+			},
+			in: RefChangeVerifyInput{
+				Actor:           user,
+				RefAction:       RefActionDelete,
+				RefType:         RefTypeBranch,
+				RefNames:        []string{"main"},
+				FindPushChanges: findChanges,
+			},
+		},
+		{
+			name: "commit-message",
+			def: DefPush{
+				CommitMessage: DefPushCommitMessage{Pattern: `^JIRA-\d+ `},
+			},
+			in: RefChangeVerifyInput{
+				Actor:           user,
+				RefAction:       RefActionUpdate,
+				RefType:         RefTypeBranch,
+				RefNames:        []string{"main"},
+				FindPushChanges: findChanges,
+			},
+			expCodes: []string{codePushCommitMessage},
+		},
+		{
+			name: "commit-message-conventional",
+			def: DefPush{
+				CommitMessage: DefPushCommitMessage{Pattern: `^(feat|fix|chore)(\(.+\))?: |^JIRA-\d+ `},
+			},
+			in: RefChangeVerifyInput{
+				Actor:           user,
+				RefAction:       RefActionCreate,
+				RefType:         RefTypeTag,
+				RefNames:        []string{"v1.0.0"},
+				FindPushChanges: findChanges,
+			},
+		},
+		{
+			name: "email-own-only",
+			def: DefPush{
+				Email: DefPushEmail{Restricted: true},
+			},
+			in: RefChangeVerifyInput{
+				Actor:           user,
+				RefAction:       RefActionUpdate,
+				RefType:         RefTypeBranch,
+				RefNames:        []string{"main"},
+				FindPushChanges: findChanges,
+			},
+			expCodes: []string{codePushEmail},
+		},
+		{
+			name: "email-allowed-domain",
+			def: DefPush{
+				Email: DefPushEmail{Restricted: true, AllowedDomains: []string{"@Partner.io"}},
+			},
+			in: RefChangeVerifyInput{
+				Actor:           user,
+				RefAction:       RefActionUpdate,
+				RefType:         RefTypeBranch,
+				RefNames:        []string{"main"},
+				FindPushChanges: findChanges,
+			},
+		},
+		{
+			name: "paths-forbidden",
+			def: DefPush{
+				Paths: DefPushPaths{Forbidden: []string{"deploy/**"}},
+			},
+			in: RefChangeVerifyInput{
+				Actor:           user,
+				RefAction:       RefActionUpdateForce,
+				RefType:         RefTypeBranch,
+				RefNames:        []string{"main"},
+				FindPushChanges: findChanges,
+			},
+			expCodes: []string{codePushPaths},
+		},
+		{
+			name: "paths-forbidden-exempt-user",
+			def: DefPush{
+				Paths: DefPushPaths{
+					Forbidden: []string{"deploy/**"},
+					Exempt:    DefBypass{UserIDs: []int64{42}},
+				},
+			},
+			in: RefChangeVerifyInput{
+				Actor:           user,
+				RefAction:       RefActionUpdate,
+				RefType:         RefTypeBranch,
+				RefNames:        []string{"main"},
+				FindPushChanges: findChanges,
+			},
+		},
+		{
+			name: "paths-forbidden-exempt-user-group",
+			def: DefPush{
+				Paths: DefPushPaths{
+					Forbidden: []string{"deploy/**"},
+					Exempt:    DefBypass{UserGroupIDs: []int64{7}},
+				},
+			},
+			in: RefChangeVerifyInput{
+				Actor:     user,
+				RefAction: RefActionUpdate,
+				RefType:   RefTypeBranch,
+				RefNames:  []string{"main"},
+				ResolveUserGroupID: func(context.Context, []int64) ([]int64, error) {
+					return []int64{42}, nil
+				},
+				FindPushChanges: findChanges,
+			},
+		},
+		{
+			name: "file-extensions",
+			def: DefPush{
+				FileExtensions: DefPushFileExtensions{Blocked: []string{"exe", ".DLL"}},
+			},
+			in: RefChangeVerifyInput{
+				Actor:           user,
+				RefAction:       RefActionUpdate,
+				RefType:         RefTypeBranch,
+				RefNames:        []string{"main"},
+				FindPushChanges: findChanges,
+			},
+			expCodes: []string{codePushFileExtension},
+		},
+		{
+			name: "all",
+			def: DefPush{
+				CommitMessage:  DefPushCommitMessage{Pattern: `^JIRA-\d+ `},
+				Email:          DefPushEmail{Restricted: true},
+				Paths:          DefPushPaths{Forbidden: []string{"web/*"}},
+				FileExtensions: DefPushFileExtensions{Blocked: []string{".exe"}},
+			},
+			in: RefChangeVerifyInput{
+				Actor:           user,
+				RefAction:       RefActionUpdate,
+				RefType:         RefTypeBranch,
+				RefNames:        []string{"main"},
+				FindPushChanges: findChanges,
+			},
+			expCodes: []string{codePushCommitMessage, codePushEmail, codePushPaths, codePushFileExtension},
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.def.Sanitize(); err != nil {
+				t.Errorf("invalid: %s", err.Error())
+				return
+			}
+
+			results, err := test.def.RefChangeVerify(ctx, test.in)
+			if err != nil {
+				t.Errorf("error: %s", err.Error())
+				return
+			}
+
+			var codes []string
+			for i := range results {
+				for _, violation := range results[i].Violations {
+					codes = append(codes, violation.Code)
+				}
+			}
+
+			if want, got := len(test.expCodes), len(codes); want != got {
+				t.Errorf("number of violations mismatch: want=%d got=%d", want, got)
+				return
+			}
+
+			for i := range codes {
+				if want, got := test.expCodes[i], codes[i]; want != got {
+					t.Errorf("violation %d, code mismatch: want=%s got=%s", i, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestDefPush_Sanitize(t *testing.T) {
+	tests := []struct {
+		name   string
+		def    DefPush
+		expErr bool
+	}{
+		{
+			name: "empty",
+			def:  DefPush{},
+		},
+		{
+			name: "invalid-commit-message-pattern",
+			def: DefPush{
+				CommitMessage: DefPushCommitMessage{Pattern: `^(feat`},
+			},
+			expErr: true,
+		},
+		{
+			name: "empty-domain",
+			def: DefPush{
+				Email: DefPushEmail{Restricted: true, AllowedDomains: []string{"@"}},
+			},
+			expErr: true,
+		},
+		{
+			name: "empty-path",
+			def: DefPush{
+				Paths: DefPushPaths{Forbidden: []string{""}},
+			},
+			expErr: true,
+		},
+		{
+			name: "invalid-exempt-user",
+			def: DefPush{
+				Paths: DefPushPaths{Forbidden: []string{"a/**"}, Exempt: DefBypass{UserIDs: []int64{0}}},
+			},
+			expErr: true,
+		},
+		{
+			name: "empty-extension",
+			def: DefPush{
+				FileExtensions: DefPushFileExtensions{Blocked: []string{" . "}},
+			},
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.def.Sanitize()
+			if test.expErr && err == nil {
+				t.Error("expected error")
+			}
+			if !test.expErr && err != nil {
+				t.Errorf("unexpected error: %s", err.Error())
+			}
+		})
+	}
+}
+
+func TestPush_RefChangeVerify(t *testing.T) {
+	user := &types.Principal{ID: 42, Email: "john@example.com"}
+
+	push := Push{
+		Bypass: DefBypass{RepoOwners: true},
+		Push:   DefPush{CommitMessage: DefPushCommitMessage{Pattern: `^JIRA-\d+ `}},
+	}
+
+	in := RefChangeVerifyInput{
+		Actor:       user,
+		AllowBypass: true,
+		IsRepoOwner: true,
+		RefAction:   RefActionUpdate,
+		RefType:     RefTypeBranch,
+		RefNames:    []string{"main"},
+		FindPushChanges: func(context.Context, RefType, string) (PushChanges, error) {
+			return PushChanges{Commits: []PushCommit{{SHA: "abc", Message: "fix typo"}}}, nil
+		},
+	}
+
+	results, err := push.RefChangeVerify(context.Background(), in)
+	if err != nil {
+		t.Fatalf("error: %s", err.Error())
+	}
+
+	if len(results) != 1 || !results[0].Bypassable || !results[0].Bypassed {
+		t.Errorf("expected a bypassed violation, got: %+v", results)
+	}
+
+	in.FindPushChanges = func(context.Context, RefType, string) (PushChanges, error) {
+		return PushChanges{}, errors.New("failed")
+	}
+
+	if _, err = push.RefChangeVerify(context.Background(), in); err == nil {
+		t.Error("expected error")
+	}
+}
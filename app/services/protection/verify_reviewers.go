@@ -0,0 +1,113 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type (
+	DefaultReviewersInput struct {
+		Repo    *types.Repository
+		PullReq *types.PullReq
+	}
+
+	DefaultReviewersOutput struct {
+		UserIDs    []int64
+		UserGroups []DefaultReviewersUserGroup
+		CodeOwners bool
+	}
+
+	// DefaultReviewersUserGroup describes how reviewers should be picked from the members of a user group.
+	DefaultReviewersUserGroup struct {
+		UserGroupID int64
+		Selection   enum.ReviewerSelection
+		// Count is the number of members to pick. It's zero if all members should be requested to review.
+		Count int
+	}
+
+	// DefReviewers defines the reviewers that are automatically requested to review the pull requests.
+	DefReviewers struct {
+		UserIDs      []int64 `json:"user_ids,omitempty"`
+		UserGroupIDs []int64 `json:"user_group_ids,omitempty"`
+		// UserGroupSelection defines how the reviewers are picked from the members of the user groups.
+		UserGroupSelection enum.ReviewerSelection `json:"user_group_selection,omitempty"`
+		// UserGroupPickCount is the number of members picked from each user group.
+		// It's ignored if all members of the user groups are requested to review.
+		UserGroupPickCount int `json:"user_group_pick_count,omitempty"`
+		// CodeOwners requests review from the code owners of the changed files.
+		CodeOwners bool `json:"code_owners,omitempty"`
+	}
+)
+
+// ensures that the DefReviewers type implements Sanitizer interface.
+var _ Sanitizer = (*DefReviewers)(nil)
+
+func (v *DefReviewers) DefaultReviewers(
+	_ context.Context,
+	in DefaultReviewersInput,
+) (DefaultReviewersOutput, error) {
+	out := DefaultReviewersOutput{
+		CodeOwners: v.CodeOwners,
+	}
+
+	for _, userID := range v.UserIDs {
+		if userID != in.PullReq.CreatedBy {
+			out.UserIDs = append(out.UserIDs, userID)
+		}
+	}
+
+	for _, userGroupID := range v.UserGroupIDs {
+		out.UserGroups = append(out.UserGroups, DefaultReviewersUserGroup{
+			UserGroupID: userGroupID,
+			Selection:   v.UserGroupSelection,
+			Count:       v.UserGroupPickCount,
+		})
+	}
+
+	return out, nil
+}
+
+func (v *DefReviewers) Sanitize() error {
+	if err := validateIDSlice(v.UserIDs); err != nil {
+		return fmt.Errorf("user IDs error: %w", err)
+	}
+
+	if err := validateIDSlice(v.UserGroupIDs); err != nil {
+		return fmt.Errorf("user group IDs error: %w", err)
+	}
+
+	selection, ok := v.UserGroupSelection.Sanitize()
+	if !ok {
+		return fmt.Errorf("unrecognized user group selection: %s", v.UserGroupSelection)
+	}
+
+	v.UserGroupSelection = selection
+
+	if selection == enum.ReviewerSelectionAll {
+		v.UserGroupPickCount = 0
+		return nil
+	}
+
+	if v.UserGroupPickCount <= 0 || v.UserGroupPickCount > maxElements {
+		return fmt.Errorf("user group pick count must be a positive number not greater than %d", maxElements)
+	}
+
+	return nil
+}
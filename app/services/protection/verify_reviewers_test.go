@@ -0,0 +1,119 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDefReviewers_Sanitize(t *testing.T) {
+	tests := []struct {
+		name     string
+		def      DefReviewers
+		expErr   bool
+		expCount int
+	}{
+		{
+			name: "empty",
+			def:  DefReviewers{},
+		},
+		{
+			name:     "all-ignores-count",
+			def:      DefReviewers{UserGroupIDs: []int64{1}, UserGroupPickCount: 3},
+			expCount: 0,
+		},
+		{
+			name: "round-robin",
+			def: DefReviewers{
+				UserGroupIDs:       []int64{1},
+				UserGroupSelection: enum.ReviewerSelectionRoundRobin,
+				UserGroupPickCount: 2,
+			},
+			expCount: 2,
+		},
+		{
+			name: "load-balanced-without-count",
+			def: DefReviewers{
+				UserGroupIDs:       []int64{1},
+				UserGroupSelection: enum.ReviewerSelectionLoadBalanced,
+			},
+			expErr: true,
+		},
+		{
+			name:   "unknown-selection",
+			def:    DefReviewers{UserGroupSelection: "random"},
+			expErr: true,
+		},
+		{
+			name:   "invalid-user-id",
+			def:    DefReviewers{UserIDs: []int64{-1}},
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.def.Sanitize()
+			if test.expErr {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %s", err.Error())
+				return
+			}
+			if want, got := test.expCount, test.def.UserGroupPickCount; want != got {
+				t.Errorf("pick count mismatch: want=%d got=%d", want, got)
+			}
+		})
+	}
+}
+
+func TestDefReviewers_DefaultReviewers(t *testing.T) {
+	def := DefReviewers{
+		UserIDs:            []int64{1, 2},
+		UserGroupIDs:       []int64{10},
+		UserGroupSelection: enum.ReviewerSelectionRoundRobin,
+		UserGroupPickCount: 1,
+		CodeOwners:         true,
+	}
+
+	out, err := def.DefaultReviewers(context.Background(), DefaultReviewersInput{
+		Repo:    &types.Repository{},
+		PullReq: &types.PullReq{CreatedBy: 2},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	exp := DefaultReviewersOutput{
+		UserIDs: []int64{1},
+		UserGroups: []DefaultReviewersUserGroup{
+			{UserGroupID: 10, Selection: enum.ReviewerSelectionRoundRobin, Count: 1},
+		},
+		CodeOwners: true,
+	}
+
+	if !reflect.DeepEqual(exp, out) {
+		t.Errorf("output mismatch: want=%+v got=%+v", exp, out)
+	}
+}
@@ -31,5 +31,13 @@ func ProvideManager(ruleStore store.RuleStore) (*Manager, error) {
 		return nil, err
 	}
 
+	if err := m.Register(TypeTag, func() Definition { return &Tag{} }); err != nil {
+		return nil, err
+	}
+
+	if err := m.Register(TypePush, func() Definition { return &Push{} }); err != nil {
+		return nil, err
+	}
+
 	return m, nil
 }
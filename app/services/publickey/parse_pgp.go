@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+
+	"golang.org/x/crypto/openpgp" //nolint:staticcheck // sufficient for key parsing and signature verification
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+const pgpPublicKeyBlockHeader = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+
+// IsPGP returns true if the provided key data is an armored PGP public key.
+func IsPGP(keyData string) bool {
+	return strings.HasPrefix(strings.TrimSpace(keyData), pgpPublicKeyBlockHeader)
+}
+
+// PGPKeyInfo holds a parsed PGP public key.
+type PGPKeyInfo struct {
+	Entity *openpgp.Entity
+}
+
+// ParsePGP parses an armored PGP public key. The key must contain exactly one primary key.
+func ParsePGP(keyData string) (PGPKeyInfo, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(keyData))
+	if err != nil {
+		return PGPKeyInfo{}, errors.InvalidArgument("failed to parse PGP public key")
+	}
+
+	if len(entities) != 1 {
+		return PGPKeyInfo{}, errors.InvalidArgument("exactly one PGP public key must be provided")
+	}
+
+	return PGPKeyInfo{Entity: entities[0]}, nil
+}
+
+// Fingerprint returns the fingerprint of the primary key.
+func (key PGPKeyInfo) Fingerprint() string {
+	return fmt.Sprintf("%X", key.Entity.PrimaryKey.Fingerprint)
+}
+
+// KeyIDs returns the key IDs of the primary key and all the sub keys.
+func (key PGPKeyInfo) KeyIDs() []string {
+	keyIDs := make([]string, 0, len(key.Entity.Subkeys)+1)
+	keyIDs = append(keyIDs, pgpKeyID(key.Entity.PrimaryKey.KeyId))
+	for _, subKey := range key.Entity.Subkeys {
+		keyIDs = append(keyIDs, pgpKeyID(subKey.PublicKey.KeyId))
+	}
+	return keyIDs
+}
+
+// Comment returns the name of the first identity of the key.
+func (key PGPKeyInfo) Comment() string {
+	names := maps.Keys(key.Entity.Identities)
+	if len(names) == 0 {
+		return ""
+	}
+
+	slices.Sort(names)
+
+	return names[0]
+}
+
+func (key PGPKeyInfo) Type() string {
+	return "pgp"
+}
+
+func pgpKeyID(keyID uint64) string {
+	return fmt.Sprintf("%016X", keyID)
+}
@@ -0,0 +1,443 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/crypto/openpgp"        //nolint:staticcheck // sufficient for signature verification
+	"golang.org/x/crypto/openpgp/armor"  //nolint:staticcheck // sufficient for signature verification
+	"golang.org/x/crypto/openpgp/packet" //nolint:staticcheck // sufficient for signature verification
+	gossh "golang.org/x/crypto/ssh"
+)
+
+const (
+	pgpSignatureHeader = "-----BEGIN PGP SIGNATURE-----"
+	sshSignatureHeader = "-----BEGIN SSH SIGNATURE-----"
+	sshSignatureFooter = "-----END SSH SIGNATURE-----"
+
+	sshSignatureMagic     = "SSHSIG"
+	sshSignatureNamespace = "git"
+)
+
+// SignatureVerifier verifies signatures of commits and tags
+// against the signing keys registered by the users.
+// Signatures made with the server signing key (used for the commits created by the server)
+// are attributed to the system principal.
+type SignatureVerifier struct {
+	publicKeyStore     store.PublicKeyStore
+	principalStore     store.PrincipalStore
+	pCache             store.PrincipalInfoCache
+	serverKey          gossh.PublicKey
+	systemPrincipalUID string
+}
+
+func NewSignatureVerifier(
+	publicKeyStore store.PublicKeyStore,
+	principalStore store.PrincipalStore,
+	pCache store.PrincipalInfoCache,
+	serverKey gossh.PublicKey,
+	systemPrincipalUID string,
+) *SignatureVerifier {
+	return &SignatureVerifier{
+		publicKeyStore:     publicKeyStore,
+		principalStore:     principalStore,
+		pCache:             pCache,
+		serverKey:          serverKey,
+		systemPrincipalUID: systemPrincipalUID,
+	}
+}
+
+// GitCommitReader is the subset of git.Interface needed to find the commits without a verified signature.
+type GitCommitReader interface {
+	ListCommitSHAs(ctx context.Context, params *git.ListCommitSHAsParams) (*git.ListCommitSHAsOutput, error)
+	GetCommitSignatures(ctx context.Context, params *git.GetCommitSignaturesParams) (*git.GetCommitSignaturesOutput, error)
+}
+
+// FindUnverifiedCommits lists the commits using the provided parameters
+// and returns SHAs of all the commits that don't have a verified signature.
+func (v *SignatureVerifier) FindUnverifiedCommits(
+	ctx context.Context,
+	gitReader GitCommitReader,
+	params *git.ListCommitSHAsParams,
+) ([]string, error) {
+	listOut, err := gitReader.ListCommitSHAs(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits: %w", err)
+	}
+
+	if len(listOut.SHAs) == 0 {
+		return nil, nil
+	}
+
+	sigOut, err := gitReader.GetCommitSignatures(ctx, &git.GetCommitSignaturesParams{
+		ReadParams: params.ReadParams,
+		CommitSHAs: listOut.SHAs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit signatures: %w", err)
+	}
+
+	var unverified []string
+	for i, signature := range sigOut.Signatures {
+		if signature == nil {
+			unverified = append(unverified, listOut.SHAs[i])
+			continue
+		}
+
+		verification, err := v.Verify(ctx, signature, sigOut.CommitterEmails[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify signature of commit %s: %w", listOut.SHAs[i], err)
+		}
+
+		if verification.Status != enum.SignatureVerificationStatusVerified {
+			unverified = append(unverified, listOut.SHAs[i])
+		}
+	}
+
+	return unverified, nil
+}
+
+// FindUnverifiedPullReqCommits returns SHAs of the pull request commits that don't have a verified signature.
+func (v *SignatureVerifier) FindUnverifiedPullReqCommits(
+	ctx context.Context,
+	gitReader GitCommitReader,
+	targetRepo *types.Repository,
+	pr *types.PullReq,
+) ([]string, error) {
+	return v.FindUnverifiedCommits(ctx, gitReader, &git.ListCommitSHAsParams{
+		ReadParams: git.CreateReadParams(targetRepo),
+		GitREF:     pr.SourceSHA,
+		After:      pr.MergeBaseSHA,
+	})
+}
+
+// Verify verifies the signature of a git object.
+// The signerEmail is the email of the committer (or the tagger) of the object.
+func (v *SignatureVerifier) Verify(
+	ctx context.Context,
+	signature *git.ObjectSignature,
+	signerEmail string,
+) (*types.SignatureVerification, error) {
+	armored := strings.TrimSpace(string(signature.Signature))
+
+	var key *types.PublicKey
+	var status enum.SignatureVerificationStatus
+	var err error
+
+	switch {
+	case strings.HasPrefix(armored, sshSignatureHeader):
+		if verification, ok, err := v.verifyServerSSH(ctx, armored, signature.SignedData); err != nil || ok {
+			return verification, err
+		}
+
+		key, status, err = v.verifySSH(ctx, armored, signature.SignedData)
+	case strings.HasPrefix(armored, pgpSignatureHeader):
+		key, status, err = v.verifyPGP(ctx, armored, signature.SignedData)
+	default:
+		return &types.SignatureVerification{Status: enum.SignatureVerificationStatusUnverified}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if key == nil {
+		return &types.SignatureVerification{Status: status}, nil
+	}
+
+	principal, err := v.pCache.Get(ctx, key.PrincipalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get principal info of the signing key owner: %w", err)
+	}
+
+	if status == enum.SignatureVerificationStatusVerified && !strings.EqualFold(principal.Email, signerEmail) {
+		status = enum.SignatureVerificationStatusBadEmail
+	}
+
+	return &types.SignatureVerification{
+		Status:         status,
+		Scheme:         key.Scheme,
+		KeyFingerprint: key.Fingerprint,
+		Signer:         principal,
+	}, nil
+}
+
+// verifySSH verifies an SSH signature (created by "ssh-keygen -Y sign").
+// The signature contains the public key, so the key is found by its fingerprint.
+func (v *SignatureVerifier) verifySSH(
+	ctx context.Context,
+	armored string,
+	signedData []byte,
+) (*types.PublicKey, enum.SignatureVerificationStatus, error) {
+	sig, err := parseSSHSignature(armored)
+	if err != nil {
+		return nil, enum.SignatureVerificationStatusUnverified, nil
+	}
+
+	signingKey := From(sig.publicKey)
+
+	existingKeys, err := v.publicKeyStore.ListByFingerprint(ctx, signingKey.Fingerprint())
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read keys by fingerprint: %w", err)
+	}
+
+	var key *types.PublicKey
+	for i := range existingKeys {
+		if existingKeys[i].Usage == enum.PublicKeyUsageSign && signingKey.Matches(existingKeys[i].Content) {
+			key = &existingKeys[i]
+			break
+		}
+	}
+
+	if key == nil {
+		return nil, enum.SignatureVerificationStatusUnknownKey, nil
+	}
+
+	if err = sig.verify(signedData); err != nil {
+		return key, enum.SignatureVerificationStatusUnverified, nil
+	}
+
+	return key, enum.SignatureVerificationStatusVerified, nil
+}
+
+// verifyServerSSH verifies an SSH signature made with the server signing key.
+// It returns false if the signature isn't made with the server signing key.
+// The committer email isn't checked, the server signs commits on behalf of the users (e.g. merge commits).
+func (v *SignatureVerifier) verifyServerSSH(
+	ctx context.Context,
+	armored string,
+	signedData []byte,
+) (*types.SignatureVerification, bool, error) {
+	if v.serverKey == nil {
+		return nil, false, nil
+	}
+
+	sig, err := parseSSHSignature(armored)
+	if err != nil {
+		return nil, false, nil //nolint:nilerr // not a valid signature, the regular verification will report it
+	}
+
+	if !bytes.Equal(sig.publicKey.Marshal(), v.serverKey.Marshal()) {
+		return nil, false, nil
+	}
+
+	if err = sig.verify(signedData); err != nil {
+		return &types.SignatureVerification{
+			Status:         enum.SignatureVerificationStatusUnverified,
+			Scheme:         enum.PublicKeySchemeSSH,
+			KeyFingerprint: From(v.serverKey).Fingerprint(),
+		}, true, nil
+	}
+
+	systemPrincipal, err := v.principalStore.FindByUID(ctx, v.systemPrincipalUID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to find system principal: %w", err)
+	}
+
+	return &types.SignatureVerification{
+		Status:         enum.SignatureVerificationStatusVerified,
+		Scheme:         enum.PublicKeySchemeSSH,
+		KeyFingerprint: From(v.serverKey).Fingerprint(),
+		Signer:         systemPrincipal.ToPrincipalInfo(),
+	}, true, nil
+}
+
+// verifyPGP verifies an OpenPGP signature. The key is found by the issuer key ID of the signature.
+func (v *SignatureVerifier) verifyPGP(
+	ctx context.Context,
+	armored string,
+	signedData []byte,
+) (*types.PublicKey, enum.SignatureVerificationStatus, error) {
+	issuerKeyID, err := pgpIssuerKeyID(armored)
+	if err != nil {
+		return nil, enum.SignatureVerificationStatusUnverified, nil
+	}
+
+	existingKeys, err := v.publicKeyStore.ListBySubKeyID(ctx, pgpKeyID(issuerKeyID))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read keys by key ID: %w", err)
+	}
+
+	var key *types.PublicKey
+	for i := range existingKeys {
+		if existingKeys[i].Usage != enum.PublicKeyUsageSign {
+			continue
+		}
+
+		key = &existingKeys[i]
+
+		keyInfo, err := ParsePGP(existingKeys[i].Content)
+		if err != nil {
+			continue
+		}
+
+		_, err = openpgp.CheckArmoredDetachedSignature(
+			openpgp.EntityList{keyInfo.Entity},
+			bytes.NewReader(signedData),
+			strings.NewReader(armored),
+		)
+		if err == nil {
+			return key, enum.SignatureVerificationStatusVerified, nil
+		}
+	}
+
+	if key == nil {
+		return nil, enum.SignatureVerificationStatusUnknownKey, nil
+	}
+
+	return key, enum.SignatureVerificationStatusUnverified, nil
+}
+
+func pgpIssuerKeyID(armored string) (uint64, error) {
+	block, err := armor.Decode(strings.NewReader(armored))
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode armored signature: %w", err)
+	}
+
+	p, err := packet.Read(block.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read signature packet: %w", err)
+	}
+
+	switch sig := p.(type) {
+	case *packet.Signature:
+		if sig.IssuerKeyId == nil {
+			return 0, errors.InvalidArgument("signature has no issuer key ID")
+		}
+		return *sig.IssuerKeyId, nil
+	case *packet.SignatureV3:
+		return sig.IssuerKeyId, nil
+	default:
+		return 0, errors.InvalidArgument("not a signature packet")
+	}
+}
+
+// sshSignature is a parsed SSH signature,
+// see https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig
+type sshSignature struct {
+	publicKey     gossh.PublicKey
+	namespace     string
+	reserved      []byte
+	hashAlgorithm string
+	signature     *gossh.Signature
+}
+
+func parseSSHSignature(armored string) (*sshSignature, error) {
+	body := strings.TrimPrefix(armored, sshSignatureHeader)
+	body = strings.TrimSuffix(body, sshSignatureFooter)
+	body = strings.Join(strings.Fields(body), "")
+
+	data, err := base64.StdEncoding.DecodeString(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	if !bytes.HasPrefix(data, []byte(sshSignatureMagic)) {
+		return nil, errors.InvalidArgument("invalid signature magic preamble")
+	}
+	data = data[len(sshSignatureMagic):]
+
+	const versionLen = 4
+	if len(data) < versionLen || binary.BigEndian.Uint32(data) != 1 {
+		return nil, errors.InvalidArgument("unsupported signature version")
+	}
+	data = data[versionLen:]
+
+	fields := make([][]byte, 5)
+	for i := range fields {
+		fields[i], data, err = readSSHString(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	publicKey, err := gossh.ParsePublicKey(fields[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signature public key: %w", err)
+	}
+
+	signature := &gossh.Signature{}
+	if err = gossh.Unmarshal(fields[4], signature); err != nil {
+		return nil, fmt.Errorf("failed to parse signature blob: %w", err)
+	}
+
+	return &sshSignature{
+		publicKey:     publicKey,
+		namespace:     string(fields[1]),
+		reserved:      fields[2],
+		hashAlgorithm: string(fields[3]),
+		signature:     signature,
+	}, nil
+}
+
+func (sig *sshSignature) verify(signedData []byte) error {
+	if sig.namespace != sshSignatureNamespace {
+		return errors.InvalidArgument("unexpected signature namespace %q", sig.namespace)
+	}
+
+	var h hash.Hash
+	switch sig.hashAlgorithm {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return errors.InvalidArgument("unsupported signature hash algorithm %q", sig.hashAlgorithm)
+	}
+
+	h.Write(signedData)
+
+	message := []byte(sshSignatureMagic)
+	message = appendSSHString(message, []byte(sig.namespace))
+	message = appendSSHString(message, sig.reserved)
+	message = appendSSHString(message, []byte(sig.hashAlgorithm))
+	message = appendSSHString(message, h.Sum(nil))
+
+	return sig.publicKey.Verify(message, sig.signature)
+}
+
+func readSSHString(data []byte) ([]byte, []byte, error) {
+	const lenSize = 4
+	if len(data) < lenSize {
+		return nil, nil, errors.InvalidArgument("malformed signature")
+	}
+
+	n := binary.BigEndian.Uint32(data)
+	data = data[lenSize:]
+	if uint64(len(data)) < uint64(n) {
+		return nil, nil, errors.InvalidArgument("malformed signature")
+	}
+
+	return data[:n], data[n:], nil
+}
+
+func appendSSHString(buf []byte, s []byte) []byte {
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(s)))
+	return append(buf, s...)
+}
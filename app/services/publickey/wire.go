@@ -15,13 +15,19 @@
 package publickey
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
+	gossh "golang.org/x/crypto/ssh"
 )
 
 var WireSet = wire.NewSet(
 	ProvidePublicKey,
+	ProvideSignatureVerifier,
 )
 
 func ProvidePublicKey(
@@ -30,3 +36,33 @@ func ProvidePublicKey(
 ) Service {
 	return NewService(publicKeyStore, pCache)
 }
+
+func ProvideSignatureVerifier(
+	config *types.Config,
+	publicKeyStore store.PublicKeyStore,
+	principalStore store.PrincipalStore,
+	pCache store.PrincipalInfoCache,
+) (*SignatureVerifier, error) {
+	var serverKey gossh.PublicKey
+	if config.Git.SigningKeyPath != "" {
+		keyData, err := os.ReadFile(config.Git.SigningKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the git signing key: %w", err)
+		}
+
+		signer, err := gossh.ParsePrivateKey(keyData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the git signing key: %w", err)
+		}
+
+		serverKey = signer.PublicKey()
+	}
+
+	return NewSignatureVerifier(
+		publicKeyStore,
+		principalStore,
+		pCache,
+		serverKey,
+		config.Principal.System.UID,
+	), nil
+}
@@ -80,17 +80,27 @@ func (s *Service) triggerPREventOnBranchUpdate(ctx context.Context,
 		log.Ctx(ctx).Warn().Err(err).Msgf("failed to get commit info from git")
 	}
 
-	// TODO: This function is currently executed directly on branch update event.
-	// TODO: But it should be executed after the PR's head ref has been updated.
-	// TODO: This is to make sure the commit exists on the target repository for forked repositories.
 	s.forEveryOpenPR(ctx, event.Payload.RepoID, event.Payload.Ref, func(pr *types.PullReq) error {
-		// First check if the merge base has changed
-
 		targetRepo, err := s.repoGitInfoCache.Get(ctx, pr.TargetRepoID)
 		if err != nil {
 			return fmt.Errorf("failed to get target repo git info: %w", err)
 		}
 
+		// For pull requests from forks the new commit has to be pulled into the target repository first.
+		if pr.SourceRepoID != pr.TargetRepoID {
+			writeParams, err := createSystemRPCWriteParams(ctx, s.urlProvider, targetRepo.ID, targetRepo.GitUID)
+			if err != nil {
+				return fmt.Errorf("failed to generate rpc write params: %w", err)
+			}
+
+			err = s.fetchSourceCommit(ctx, pr.SourceRepoID, targetRepo, writeParams, event.Payload.NewSHA)
+			if err != nil {
+				return err
+			}
+		}
+
+		// First check if the merge base has changed
+
 		mergeBaseInfo, err := s.git.MergeBase(ctx, git.MergeBaseParams{
 			ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
 			Ref1:       event.Payload.NewSHA,
@@ -24,6 +24,7 @@ import (
 	"github.com/harness/gitness/git"
 	gitenum "github.com/harness/gitness/git/enum"
 	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
 )
 
 // createHeadRefOnCreated handles pull request Created events.
@@ -41,8 +42,11 @@ func (s *Service) createHeadRefOnCreated(ctx context.Context,
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos (only works when sourceRepo==targetRepo).
-	// This is because commits from the source repository must be first pulled into the target repository.
+	err = s.fetchSourceCommit(ctx, event.Payload.SourceRepoID, repoGit, writeParams, event.Payload.SourceSHA)
+	if err != nil {
+		return err
+	}
+
 	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
 		WriteParams: writeParams,
 		Name:        strconv.Itoa(int(event.Payload.Number)),
@@ -72,8 +76,11 @@ func (s *Service) updateHeadRefOnBranchUpdate(ctx context.Context,
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos (only works when sourceRepo==targetRepo)
-	// This is because commits from the source repository must be first pulled into the target repository.
+	err = s.fetchSourceCommit(ctx, event.Payload.SourceRepoID, repoGit, writeParams, event.Payload.NewSHA)
+	if err != nil {
+		return err
+	}
+
 	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
 		WriteParams: writeParams,
 		Name:        strconv.Itoa(int(event.Payload.Number)),
@@ -103,8 +110,11 @@ func (s *Service) updateHeadRefOnReopen(ctx context.Context,
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos (only works when sourceRepo==targetRepo)
-	// This is because commits from the source repository must be first pulled into the target repository.
+	err = s.fetchSourceCommit(ctx, event.Payload.SourceRepoID, repoGit, writeParams, event.Payload.SourceSHA)
+	if err != nil {
+		return err
+	}
+
 	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
 		WriteParams: writeParams,
 		Name:        strconv.Itoa(int(event.Payload.Number)),
@@ -118,3 +128,33 @@ func (s *Service) updateHeadRefOnReopen(ctx context.Context,
 
 	return nil
 }
+
+// fetchSourceCommit makes the source commit of a pull request from a fork available in the target repository.
+// Nothing is done for pull requests within a single repository.
+func (s *Service) fetchSourceCommit(
+	ctx context.Context,
+	sourceRepoID int64,
+	targetRepo *types.RepositoryGitInfo,
+	targetWriteParams git.WriteParams,
+	commitSHA string,
+) error {
+	if sourceRepoID == targetRepo.ID {
+		return nil
+	}
+
+	sourceRepo, err := s.repoGitInfoCache.Get(ctx, sourceRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to get source repo git info: %w", err)
+	}
+
+	err = s.git.FetchObjects(ctx, &git.FetchObjectsParams{
+		WriteParams:   targetWriteParams,
+		SourceRepoUID: sourceRepo.GitUID,
+		ObjectSHAs:    []sha.SHA{sha.Must(commitSHA)},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch source commit from the source repository: %w", err)
+	}
+
+	return nil
+}
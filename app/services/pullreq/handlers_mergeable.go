@@ -84,14 +84,14 @@ func (s *Service) mergeCheckOnReopen(ctx context.Context,
 func (s *Service) mergeCheckOnClosed(ctx context.Context,
 	event *events.Event[*pullreqevents.ClosedPayload],
 ) error {
-	return s.deleteMergeRef(ctx, event.Payload.SourceRepoID, event.Payload.Number)
+	return s.deleteMergeRef(ctx, event.Payload.TargetRepoID, event.Payload.Number)
 }
 
 // mergeCheckOnMerged deletes the merge ref.
 func (s *Service) mergeCheckOnMerged(ctx context.Context,
 	event *events.Event[*pullreqevents.MergedPayload],
 ) error {
-	return s.deleteMergeRef(ctx, event.Payload.SourceRepoID, event.Payload.Number)
+	return s.deleteMergeRef(ctx, event.Payload.TargetRepoID, event.Payload.Number)
 }
 
 func (s *Service) deleteMergeRef(ctx context.Context, repoID int64, prNum int64) error {
@@ -105,7 +105,6 @@ func (s *Service) deleteMergeRef(ctx context.Context, repoID int64, prNum int64)
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos
 	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
 		WriteParams: writeParams,
 		Name:        strconv.Itoa(int(prNum)),
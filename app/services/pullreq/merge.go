@@ -0,0 +1,316 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/rs/zerolog/log"
+)
+
+// Merger merges pull requests and does all the bookkeeping of a merged pull request.
+// It's shared by the merge API, the auto merge and the merge queue.
+type Merger struct {
+	git               git.Interface
+	pullreqStore      store.PullReqStore
+	activityStore     store.PullReqActivityStore
+	pullreqEvReporter *pullreqevents.Reporter
+	sseStreamer       sse.Streamer
+
+	// systemPrincipal returns the principal used as committer of merge and squash commits.
+	systemPrincipal func() types.Principal
+}
+
+func NewMerger(
+	git git.Interface,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	pullreqEvReporter *pullreqevents.Reporter,
+	sseStreamer sse.Streamer,
+) *Merger {
+	return &Merger{
+		git:               git,
+		pullreqStore:      pullreqStore,
+		activityStore:     activityStore,
+		pullreqEvReporter: pullreqEvReporter,
+		sseStreamer:       sseStreamer,
+		systemPrincipal: func() types.Principal {
+			return bootstrap.NewSystemServiceSession().Principal
+		},
+	}
+}
+
+type MergeParams struct {
+	TargetRepo        *types.Repository
+	SourceRepo        *types.Repository
+	TargetWriteParams git.WriteParams
+	SourceWriteParams git.WriteParams
+
+	PullReq   *types.PullReq
+	MergedBy  *types.Principal
+	Method    enum.MergeMethod
+	SourceSHA string
+	Title     string
+	Message   string
+
+	DeleteSourceBranch bool
+	RulesBypassed      bool
+}
+
+type MergeResult struct {
+	PullReq       *types.PullReq
+	MergeSHA      string
+	ConflictFiles []string
+	BranchDeleted bool
+}
+
+// Merge merges the pull request into its target branch and marks it as merged.
+// If the merge is blocked by conflicts, the pull request is updated with the conflicting files
+// and the returned result contains them.
+func (m *Merger) Merge(ctx context.Context, params *MergeParams) (*MergeResult, error) {
+	pr := params.PullReq
+
+	systemPrincipal := m.systemPrincipal()
+	author, committer := MergeCommitIdentities(params.Method, params.MergedBy, &pr.Author, &systemPrincipal)
+
+	// backfill commit title if none provided
+	title := params.Title
+	if title == "" {
+		switch params.Method {
+		case enum.MergeMethodMerge:
+			title = fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, params.SourceRepo.Path, pr.Number)
+		case enum.MergeMethodSquash:
+			title = fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
+		case enum.MergeMethodRebase, enum.MergeMethodFastForward:
+			// Not used.
+		}
+	}
+
+	now := time.Now()
+	mergeOutput, err := m.git.Merge(ctx, &git.MergeParams{
+		WriteParams:     params.TargetWriteParams,
+		BaseBranch:      pr.TargetBranch,
+		HeadRepoUID:     params.SourceRepo.GitUID,
+		HeadBranch:      pr.SourceBranch,
+		Message:         git.CommitMessage(title, params.Message),
+		Committer:       committer,
+		CommitterDate:   &now,
+		Author:          author,
+		AuthorDate:      &now,
+		RefType:         gitenum.RefTypeBranch,
+		RefName:         pr.TargetBranch,
+		HeadExpectedSHA: sha.Must(params.SourceSHA),
+		Method:          gitenum.MergeMethod(params.Method),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("merge execution failed: %w", err)
+	}
+
+	if mergeOutput.MergeSHA.IsEmpty() || len(mergeOutput.ConflictFiles) > 0 {
+		m.updateConflicts(ctx, params, mergeOutput)
+
+		return &MergeResult{
+			PullReq:       pr,
+			ConflictFiles: mergeOutput.ConflictFiles,
+		}, nil
+	}
+
+	return m.CompleteMerge(ctx, params, mergeOutput, now)
+}
+
+// updateConflicts stores the merge outcome of a failed merge attempt in the pull request.
+func (m *Merger) updateConflicts(ctx context.Context, params *MergeParams, mergeOutput git.MergeOutput) {
+	pr, err := m.pullreqStore.UpdateOptLock(ctx, params.PullReq, func(pr *types.PullReq) error {
+		if pr.SourceSHA != mergeOutput.HeadSHA.String() {
+			return errors.New("source SHA has changed")
+		}
+
+		// update all Merge specific information
+		pr.MergeBaseSHA = mergeOutput.MergeBaseSHA.String()
+		pr.MergeTargetSHA = ptr.String(mergeOutput.BaseSHA.String())
+		pr.MergeSHA = nil
+		pr.UpdateMergeOutcome(params.Method, mergeOutput.ConflictFiles)
+		pr.Stats.DiffStats = types.NewDiffStats(
+			mergeOutput.CommitCount,
+			mergeOutput.ChangedFileCount,
+			mergeOutput.Additions,
+			mergeOutput.Deletions,
+		)
+		return nil
+	})
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to update pull request with conflict files")
+		return
+	}
+
+	if err = m.sseStreamer.Publish(ctx, params.TargetRepo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+}
+
+// CompleteMerge marks the pull request as merged after its changes have been merged into the target branch.
+// It writes the merge activity, reports the merged event and deletes the source branch if requested.
+func (m *Merger) CompleteMerge(
+	ctx context.Context,
+	params *MergeParams,
+	mergeOutput git.MergeOutput,
+	mergedAt time.Time,
+) (*MergeResult, error) {
+	mergedBy := params.MergedBy.ID
+	method := params.Method
+
+	var activitySeqMerge, activitySeqBranchDeleted int64
+	pr, err := m.pullreqStore.UpdateOptLock(ctx, params.PullReq, func(pr *types.PullReq) error {
+		pr.State = enum.PullReqStateMerged
+
+		nowMilli := mergedAt.UnixMilli()
+
+		pr.Merged = &nowMilli
+		pr.MergedBy = &mergedBy
+		pr.MergeMethod = &method
+
+		// update all Merge specific information (might be empty if previous merge check failed)
+		// since this is the final operation on the PR, we update any sha that might've changed by now.
+		pr.SourceSHA = mergeOutput.HeadSHA.String()
+		pr.MergeTargetSHA = ptr.String(mergeOutput.BaseSHA.String())
+		pr.MergeBaseSHA = mergeOutput.MergeBaseSHA.String()
+		pr.MergeSHA = ptr.String(mergeOutput.MergeSHA.String())
+		pr.MarkAsMerged()
+		pr.Stats.DiffStats = types.NewDiffStats(
+			mergeOutput.CommitCount,
+			mergeOutput.ChangedFileCount,
+			mergeOutput.Additions,
+			mergeOutput.Deletions,
+		)
+
+		// update sequence for PR activities
+		pr.ActivitySeq++
+		activitySeqMerge = pr.ActivitySeq
+
+		if params.DeleteSourceBranch {
+			pr.ActivitySeq++
+			activitySeqBranchDeleted = pr.ActivitySeq
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pull request: %w", err)
+	}
+
+	pr.ActivitySeq = activitySeqMerge
+	activityPayload := &types.PullRequestActivityPayloadMerge{
+		MergeMethod:   method,
+		MergeSHA:      mergeOutput.MergeSHA.String(),
+		TargetSHA:     mergeOutput.BaseSHA.String(),
+		SourceSHA:     mergeOutput.HeadSHA.String(),
+		RulesBypassed: params.RulesBypassed,
+	}
+	if _, errAct := m.activityStore.CreateWithPayload(ctx, pr, mergedBy, activityPayload, nil); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull req merge activity")
+	}
+
+	m.pullreqEvReporter.Merged(ctx, &pullreqevents.MergedPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    pr.ID,
+			SourceRepoID: pr.SourceRepoID,
+			TargetRepoID: pr.TargetRepoID,
+			PrincipalID:  mergedBy,
+			Number:       pr.Number,
+		},
+		MergeMethod: method,
+		MergeSHA:    mergeOutput.MergeSHA.String(),
+		TargetSHA:   mergeOutput.BaseSHA.String(),
+		SourceSHA:   mergeOutput.HeadSHA.String(),
+	})
+
+	var branchDeleted bool
+	if params.DeleteSourceBranch {
+		errDelete := m.git.DeleteBranch(ctx, &git.DeleteBranchParams{
+			WriteParams: params.SourceWriteParams,
+			BranchName:  pr.SourceBranch,
+		})
+		if errDelete != nil {
+			// non-critical error
+			log.Ctx(ctx).Err(errDelete).Msgf("failed to delete source branch after merging")
+		} else {
+			branchDeleted = true
+
+			// NOTE: there is a chance someone pushed on the branch between merge and delete.
+			// Either way, we'll use the SHA that was merged with for the activity to be consistent from PR perspective.
+			pr.ActivitySeq = activitySeqBranchDeleted
+			if _, errAct := m.activityStore.CreateWithPayload(ctx, pr, mergedBy,
+				&types.PullRequestActivityPayloadBranchDelete{SHA: mergeOutput.HeadSHA.String()}, nil); errAct != nil {
+				// non-critical error
+				log.Ctx(ctx).Err(errAct).
+					Msgf("failed to write pull request activity for successful automatic branch delete")
+			}
+		}
+	}
+
+	if err = m.sseStreamer.Publish(ctx, params.TargetRepo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+
+	return &MergeResult{
+		PullReq:       pr,
+		MergeSHA:      mergeOutput.MergeSHA.String(),
+		BranchDeleted: branchDeleted,
+	}, nil
+}
+
+// MergeCommitIdentities returns the author and the committer of the commits created by merging a pull request.
+// For rebase and fast-forward merges the authors of the original commits are preserved.
+func MergeCommitIdentities(
+	method enum.MergeMethod,
+	mergedBy *types.Principal,
+	prAuthor *types.PrincipalInfo,
+	systemPrincipal *types.Principal,
+) (*git.Identity, *git.Identity) {
+	switch method {
+	case enum.MergeMethodMerge:
+		return identityFromPrincipal(mergedBy), identityFromPrincipal(systemPrincipal)
+	case enum.MergeMethodSquash:
+		return &git.Identity{Name: prAuthor.DisplayName, Email: prAuthor.Email}, identityFromPrincipal(systemPrincipal)
+	case enum.MergeMethodRebase:
+		return nil, identityFromPrincipal(mergedBy)
+	case enum.MergeMethodFastForward:
+	}
+
+	return nil, nil
+}
+
+func identityFromPrincipal(p *types.Principal) *git.Identity {
+	return &git.Identity{
+		Name:  p.DisplayName,
+		Email: p.Email,
+	}
+}
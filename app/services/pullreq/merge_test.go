@@ -0,0 +1,259 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	testBaseSHA  = "1111111111111111111111111111111111111111"
+	testHeadSHA  = "2222222222222222222222222222222222222222"
+	testMergeSHA = "3333333333333333333333333333333333333333"
+)
+
+type fakeMergeGit struct {
+	git.Interface
+	mergeOutput   git.MergeOutput
+	mergeParams   *git.MergeParams
+	deletedBranch string
+}
+
+func (g *fakeMergeGit) Merge(_ context.Context, params *git.MergeParams) (git.MergeOutput, error) {
+	g.mergeParams = params
+	return g.mergeOutput, nil
+}
+
+func (g *fakeMergeGit) DeleteBranch(_ context.Context, params *git.DeleteBranchParams) error {
+	g.deletedBranch = params.BranchName
+	return nil
+}
+
+type fakeMergePullReqStore struct {
+	store.PullReqStore
+}
+
+func (fakeMergePullReqStore) UpdateOptLock(
+	_ context.Context,
+	pr *types.PullReq,
+	mutateFn func(pr *types.PullReq) error,
+) (*types.PullReq, error) {
+	clone := *pr
+	if err := mutateFn(&clone); err != nil {
+		return nil, err
+	}
+	clone.Version++
+	return &clone, nil
+}
+
+type fakeMergeActivityStore struct {
+	store.PullReqActivityStore
+	payloads []types.PullReqActivityPayload
+}
+
+func (s *fakeMergeActivityStore) CreateWithPayload(
+	_ context.Context,
+	_ *types.PullReq,
+	_ int64,
+	payload types.PullReqActivityPayload,
+	_ *types.PullReqActivityMetadata,
+) (*types.PullReqActivity, error) {
+	s.payloads = append(s.payloads, payload)
+	return &types.PullReqActivity{}, nil
+}
+
+type fakeStreamer struct {
+	sse.Streamer
+}
+
+func (fakeStreamer) Publish(context.Context, int64, enum.SSEType, any) error {
+	return nil
+}
+
+func testSystemPrincipal() types.Principal {
+	return types.Principal{DisplayName: "System", Email: "system@example.com"}
+}
+
+func setupMerger(t *testing.T, mergeOutput git.MergeOutput) (*Merger, *fakeMergeGit, *fakeMergeActivityStore) {
+	t.Helper()
+
+	eventsSystem, err := events.ProvideSystem(events.Config{
+		Mode:            events.ModeInMemory,
+		Namespace:       "test",
+		MaxStreamLength: 100,
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to create events system: %s", err)
+	}
+
+	reporter, err := pullreqevents.NewReporter(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create pull request events reporter: %s", err)
+	}
+
+	gitFake := &fakeMergeGit{mergeOutput: mergeOutput}
+	activityStore := &fakeMergeActivityStore{}
+
+	m := NewMerger(gitFake, fakeMergePullReqStore{}, activityStore, reporter, fakeStreamer{})
+	m.systemPrincipal = testSystemPrincipal
+
+	return m, gitFake, activityStore
+}
+
+func testMergeParams() *MergeParams {
+	repo := &types.Repository{ID: 1, ParentID: 10, GitUID: "repo-uid", Path: "space/repo"}
+
+	return &MergeParams{
+		TargetRepo: repo,
+		SourceRepo: repo,
+		PullReq: &types.PullReq{
+			ID:           1,
+			Number:       7,
+			Title:        "Add feature",
+			State:        enum.PullReqStateOpen,
+			SourceBranch: "feature",
+			TargetBranch: "main",
+			SourceSHA:    testHeadSHA,
+			Author:       types.PrincipalInfo{ID: 2, DisplayName: "Author", Email: "author@example.com"},
+		},
+		MergedBy:  &types.Principal{ID: 3, DisplayName: "Merger", Email: "merger@example.com"},
+		Method:    enum.MergeMethodSquash,
+		SourceSHA: testHeadSHA,
+	}
+}
+
+func TestMerger_Merge(t *testing.T) {
+	ctx := context.Background()
+
+	m, gitFake, activityStore := setupMerger(t, git.MergeOutput{
+		BaseSHA:      sha.Must(testBaseSHA),
+		HeadSHA:      sha.Must(testHeadSHA),
+		MergeBaseSHA: sha.Must(testBaseSHA),
+		MergeSHA:     sha.Must(testMergeSHA),
+		CommitCount:  1,
+	})
+
+	params := testMergeParams()
+	params.DeleteSourceBranch = true
+
+	result, err := m.Merge(ctx, params)
+	if err != nil {
+		t.Fatalf("merge failed: %s", err)
+	}
+
+	if want := "Add feature (#7)"; gitFake.mergeParams.Message != want {
+		t.Errorf("want commit message %q got %q", want, gitFake.mergeParams.Message)
+	}
+
+	pr := result.PullReq
+	if pr.State != enum.PullReqStateMerged || pr.Merged == nil || pr.MergedBy == nil || *pr.MergedBy != 3 {
+		t.Errorf("pull request isn't marked as merged by the merger: %+v", pr)
+	}
+	if result.MergeSHA != testMergeSHA || pr.MergeSHA == nil || *pr.MergeSHA != testMergeSHA {
+		t.Errorf("want merge sha %s got %s", testMergeSHA, result.MergeSHA)
+	}
+
+	if !result.BranchDeleted || gitFake.deletedBranch != "feature" {
+		t.Errorf("source branch isn't deleted: %t %q", result.BranchDeleted, gitFake.deletedBranch)
+	}
+
+	if len(activityStore.payloads) != 2 {
+		t.Fatalf("want 2 activities got %d", len(activityStore.payloads))
+	}
+	if _, ok := activityStore.payloads[0].(*types.PullRequestActivityPayloadMerge); !ok {
+		t.Errorf("want merge activity got %T", activityStore.payloads[0])
+	}
+	if _, ok := activityStore.payloads[1].(*types.PullRequestActivityPayloadBranchDelete); !ok {
+		t.Errorf("want branch delete activity got %T", activityStore.payloads[1])
+	}
+}
+
+func TestMerger_Merge_Conflicts(t *testing.T) {
+	ctx := context.Background()
+
+	conflicts := []string{"file.txt"}
+	m, gitFake, activityStore := setupMerger(t, git.MergeOutput{
+		BaseSHA:       sha.Must(testBaseSHA),
+		HeadSHA:       sha.Must(testHeadSHA),
+		MergeBaseSHA:  sha.Must(testBaseSHA),
+		ConflictFiles: conflicts,
+	})
+
+	params := testMergeParams()
+	params.DeleteSourceBranch = true
+
+	result, err := m.Merge(ctx, params)
+	if err != nil {
+		t.Fatalf("merge failed: %s", err)
+	}
+
+	if !reflect.DeepEqual(result.ConflictFiles, conflicts) {
+		t.Errorf("want conflicts %v got %v", conflicts, result.ConflictFiles)
+	}
+	if result.PullReq.State != enum.PullReqStateOpen || result.MergeSHA != "" {
+		t.Errorf("pull request shouldn't be merged: %+v", result.PullReq)
+	}
+	if result.BranchDeleted || gitFake.deletedBranch != "" {
+		t.Error("source branch shouldn't be deleted")
+	}
+	if len(activityStore.payloads) != 0 {
+		t.Errorf("want no activities got %d", len(activityStore.payloads))
+	}
+}
+
+func TestMergeCommitIdentities(t *testing.T) {
+	mergedBy := &types.Principal{DisplayName: "Merger", Email: "merger@example.com"}
+	prAuthor := &types.PrincipalInfo{DisplayName: "Author", Email: "author@example.com"}
+	systemPrincipal := testSystemPrincipal()
+
+	merger := &git.Identity{Name: "Merger", Email: "merger@example.com"}
+	author := &git.Identity{Name: "Author", Email: "author@example.com"}
+	system := &git.Identity{Name: "System", Email: "system@example.com"}
+
+	tests := []struct {
+		method        enum.MergeMethod
+		wantAuthor    *git.Identity
+		wantCommitter *git.Identity
+	}{
+		{method: enum.MergeMethodMerge, wantAuthor: merger, wantCommitter: system},
+		{method: enum.MergeMethodSquash, wantAuthor: author, wantCommitter: system},
+		{method: enum.MergeMethodRebase, wantCommitter: merger},
+		{method: enum.MergeMethodFastForward},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.method), func(t *testing.T) {
+			gotAuthor, gotCommitter := MergeCommitIdentities(test.method, mergedBy, prAuthor, &systemPrincipal)
+
+			if !reflect.DeepEqual(gotAuthor, test.wantAuthor) {
+				t.Errorf("want author %+v got %+v", test.wantAuthor, gotAuthor)
+			}
+			if !reflect.DeepEqual(gotCommitter, test.wantCommitter) {
+				t.Errorf("want committer %+v got %+v", test.wantCommitter, gotCommitter)
+			}
+		})
+	}
+}
@@ -0,0 +1,554 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	checkevents "github.com/harness/gitness/app/events/check"
+	gitevents "github.com/harness/gitness/app/events/git"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/contextutil"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// AutoMergeService merges pull requests that have auto merge enabled
+// as soon as they satisfy all merge requirements.
+type AutoMergeService struct {
+	urlProvider       url.Provider
+	authorizer        authz.Authorizer
+	git               git.Interface
+	locker            *locker.Locker
+	merger            *Merger
+	autoMergeStore    store.PullReqAutoMergeStore
+	pullreqStore      store.PullReqStore
+	reviewerStore     store.PullReqReviewerStore
+	repoStore         store.RepoStore
+	principalStore    store.PrincipalStore
+	checkStore        store.CheckStore
+	protectionManager *protection.Manager
+	codeOwners        *codeowners.Service
+	userGroupService  usergroup.SearchService
+	signatureVerifier *publickey.SignatureVerifier
+	dependencyService *DependencyService
+}
+
+//nolint:funlen // it only launches the event readers
+func NewAutoMergeService(ctx context.Context,
+	config *types.Config,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	checkEvReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	pipelineEvReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
+	urlProvider url.Provider,
+	authorizer authz.Authorizer,
+	git git.Interface,
+	locker *locker.Locker,
+	merger *Merger,
+	autoMergeStore store.PullReqAutoMergeStore,
+	pullreqStore store.PullReqStore,
+	reviewerStore store.PullReqReviewerStore,
+	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
+	checkStore store.CheckStore,
+	protectionManager *protection.Manager,
+	codeOwners *codeowners.Service,
+	userGroupService usergroup.SearchService,
+	signatureVerifier *publickey.SignatureVerifier,
+	dependencyService *DependencyService,
+) (*AutoMergeService, error) {
+	service := &AutoMergeService{
+		urlProvider:       urlProvider,
+		authorizer:        authorizer,
+		git:               git,
+		locker:            locker,
+		merger:            merger,
+		autoMergeStore:    autoMergeStore,
+		pullreqStore:      pullreqStore,
+		reviewerStore:     reviewerStore,
+		repoStore:         repoStore,
+		principalStore:    principalStore,
+		checkStore:        checkStore,
+		protectionManager: protectionManager,
+		codeOwners:        codeOwners,
+		userGroupService:  userGroupService,
+		signatureVerifier: signatureVerifier,
+		dependencyService: dependencyService,
+	}
+
+	// pull request events either cancel the auto merge or could make the pull request ready for merging.
+
+	const groupPullReqAutoMerge = "gitness:pullreq:automerge"
+	_, err := pullreqEvReaderFactory.Launch(ctx, groupPullReqAutoMerge, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 3 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterBranchUpdated(service.cancelAutoMergeOnBranchUpdate)
+			_ = r.RegisterClosed(service.cancelAutoMergeOnClosed)
+			_ = r.RegisterMerged(service.cleanupAutoMergeOnMerged)
+			_ = r.RegisterAutoMergeEnabled(service.autoMergeOnEnabled)
+			_ = r.RegisterReviewSubmitted(service.autoMergeOnReviewSubmitted)
+			_ = r.RegisterReviewerAdded(service.autoMergeOnReviewerAdded)
+			_ = r.RegisterReviewerDeleted(service.autoMergeOnReviewerDeleted)
+			_ = r.RegisterUserGroupReviewerAdded(service.autoMergeOnUserGroupReviewerAdded)
+			_ = r.RegisterCommentStatusUpdated(service.autoMergeOnCommentStatusUpdated)
+			_ = r.RegisterReadyForReview(service.autoMergeOnReadyForReview)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	// updates of the target branch could change the rules, e.g. the code owners.
+
+	const groupGitAutoMerge = "gitness:git:automerge"
+	_, err = gitReaderFactory.Launch(ctx, groupGitAutoMerge, config.InstanceID,
+		func(r *gitevents.Reader) error {
+			const idleTimeout = 3 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterBranchUpdated(service.autoMergeOnTargetBranchUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	// completed status checks could make the pull request ready for merging.
+
+	const groupCheckAutoMerge = "gitness:check:automerge"
+	_, err = checkEvReaderFactory.Launch(ctx, groupCheckAutoMerge, config.InstanceID,
+		func(r *checkevents.Reader) error {
+			const idleTimeout = 3 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterReported(service.autoMergeOnCheckReported)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	const groupPipelineAutoMerge = "gitness:pipeline:automerge"
+	_, err = pipelineEvReaderFactory.Launch(ctx, groupPipelineAutoMerge, config.InstanceID,
+		func(r *pipelineevents.Reader) error {
+			const idleTimeout = 3 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterExecuted(service.autoMergeOnPipelineExecuted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+// cancelAutoMergeOnBranchUpdate disables auto merge of a pull request when new commits arrive:
+// Auto merge is always enabled for a specific source branch commit.
+func (s *AutoMergeService) cancelAutoMergeOnBranchUpdate(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload],
+) error {
+	return s.cancelAutoMerge(ctx, event.Payload.PullReqID, "source branch updated")
+}
+
+// cancelAutoMergeOnClosed disables auto merge of a pull request when it gets closed.
+func (s *AutoMergeService) cancelAutoMergeOnClosed(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload],
+) error {
+	return s.cancelAutoMerge(ctx, event.Payload.PullReqID, "pull request closed")
+}
+
+// cleanupAutoMergeOnMerged removes auto merge settings of a pull request that got merged by other means.
+func (s *AutoMergeService) cleanupAutoMergeOnMerged(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	return s.cancelAutoMerge(ctx, event.Payload.PullReqID, "pull request merged")
+}
+
+func (s *AutoMergeService) cancelAutoMerge(ctx context.Context, pullreqID int64, reason string) error {
+	err := s.autoMergeStore.Delete(ctx, pullreqID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to disable auto merge: %w", err)
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("pullreq_id", pullreqID).
+		Msgf("auto merge disabled: %s", reason)
+
+	return nil
+}
+
+func (s *AutoMergeService) autoMergeOnEnabled(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.AutoMergeEnabledPayload],
+) error {
+	return s.tryAutoMerge(ctx, event.Payload.TargetRepoID, event.Payload.PullReqID)
+}
+
+func (s *AutoMergeService) autoMergeOnReviewSubmitted(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewSubmittedPayload],
+) error {
+	if event.Payload.Decision != enum.PullReqReviewDecisionApproved {
+		return nil
+	}
+
+	return s.tryAutoMerge(ctx, event.Payload.TargetRepoID, event.Payload.PullReqID)
+}
+
+func (s *AutoMergeService) autoMergeOnReviewerAdded(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewerAddedPayload],
+) error {
+	return s.tryAutoMerge(ctx, event.Payload.TargetRepoID, event.Payload.PullReqID)
+}
+
+func (s *AutoMergeService) autoMergeOnReviewerDeleted(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewerDeletedPayload],
+) error {
+	return s.tryAutoMerge(ctx, event.Payload.TargetRepoID, event.Payload.PullReqID)
+}
+
+func (s *AutoMergeService) autoMergeOnUserGroupReviewerAdded(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.UserGroupReviewerAddedPayload],
+) error {
+	return s.tryAutoMerge(ctx, event.Payload.TargetRepoID, event.Payload.PullReqID)
+}
+
+func (s *AutoMergeService) autoMergeOnCommentStatusUpdated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.CommentStatusUpdatedPayload],
+) error {
+	if event.Payload.Status != enum.PullReqCommentStatusResolved {
+		return nil
+	}
+
+	return s.tryAutoMerge(ctx, event.Payload.TargetRepoID, event.Payload.PullReqID)
+}
+
+func (s *AutoMergeService) autoMergeOnReadyForReview(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReadyForReviewPayload],
+) error {
+	return s.tryAutoMerge(ctx, event.Payload.TargetRepoID, event.Payload.PullReqID)
+}
+
+// autoMergeOnTargetBranchUpdated attempts to merge all pull requests that target the updated branch,
+// because the branch update could have changed the merge requirements (e.g. the CODEOWNERS file).
+func (s *AutoMergeService) autoMergeOnTargetBranchUpdated(
+	ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload],
+) error {
+	branch, err := getBranchFromRef(event.Payload.Ref)
+	if err != nil {
+		return events.NewDiscardEventError(err)
+	}
+
+	autoMerges, err := s.autoMergeStore.ListByTargetBranch(ctx, event.Payload.RepoID, branch)
+	if err != nil {
+		return fmt.Errorf("failed to list pull requests with auto merge enabled: %w", err)
+	}
+
+	for _, autoMerge := range autoMerges {
+		if err = s.tryAutoMerge(ctx, event.Payload.RepoID, autoMerge.PullReqID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *AutoMergeService) autoMergeOnCheckReported(
+	ctx context.Context,
+	event *events.Event[*checkevents.ReportedPayload],
+) error {
+	if !event.Payload.Status.IsCompleted() {
+		return nil
+	}
+
+	return s.tryAutoMergeForCommit(ctx, event.Payload.RepoID, event.Payload.CommitSHA)
+}
+
+func (s *AutoMergeService) autoMergeOnPipelineExecuted(
+	ctx context.Context,
+	event *events.Event[*pipelineevents.ExecutedPayload],
+) error {
+	if !event.Payload.Status.IsDone() || event.Payload.CommitSHA == "" {
+		return nil
+	}
+
+	return s.tryAutoMergeForCommit(ctx, event.Payload.RepoID, event.Payload.CommitSHA)
+}
+
+// tryAutoMergeForCommit attempts to merge all pull requests of the repository
+// that have auto merge enabled for the provided source branch commit.
+func (s *AutoMergeService) tryAutoMergeForCommit(ctx context.Context, repoID int64, commitSHA string) error {
+	autoMerges, err := s.autoMergeStore.ListBySourceSHA(ctx, repoID, commitSHA)
+	if err != nil {
+		return fmt.Errorf("failed to list pull requests with auto merge enabled: %w", err)
+	}
+
+	for _, autoMerge := range autoMerges {
+		if err = s.tryAutoMerge(ctx, repoID, autoMerge.PullReqID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// tryAutoMerge merges the pull request if it has auto merge enabled and all merge requirements are met.
+// If the pull request can't be merged yet, it's left intact and the merge is attempted again on the next event.
+//
+//nolint:gocognit,funlen // refactor if needed.
+func (s *AutoMergeService) tryAutoMerge(ctx context.Context, repoID, pullreqID int64) error {
+	// the max time we give a merge to succeed
+	const timeout = 3 * time.Minute
+
+	unlock, err := s.locker.LockPR(ctx, repoID, 0, timeout+30*time.Second)
+	if err != nil {
+		return fmt.Errorf("failed to lock repository for pull request auto merge: %w", err)
+	}
+	defer unlock()
+
+	autoMerge, err := s.autoMergeStore.Find(ctx, pullreqID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find auto merge settings: %w", err)
+	}
+
+	pr, err := s.pullreqStore.Find(ctx, pullreqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return s.cancelAutoMerge(ctx, pr.ID, "pull request is not open")
+	}
+
+	if pr.SourceSHA != autoMerge.SourceSHA {
+		return s.cancelAutoMerge(ctx, pr.ID, "source branch updated")
+	}
+
+	if pr.IsDraft {
+		return nil
+	}
+
+	targetRepo, err := s.repoStore.Find(ctx, pr.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find target repository: %w", err)
+	}
+
+	sourceRepo := targetRepo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, err = s.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return fmt.Errorf("failed to find source repository: %w", err)
+		}
+	}
+
+	principal, err := s.principalStore.Find(ctx, autoMerge.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to find principal who enabled auto merge: %w", err)
+	}
+
+	// The pull request is merged on behalf of the principal who enabled auto merge,
+	// who could have lost the permission to merge in the meantime.
+	session := &auth.Session{Principal: *principal}
+	err = apiauth.CheckRepo(ctx, s.authorizer, session, targetRepo, enum.PermissionRepoPush)
+	if errors.Is(err, apiauth.ErrNotAuthorized) {
+		return s.cancelAutoMerge(ctx, pr.ID, "principal who enabled auto merge is not allowed to merge")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check permission of principal who enabled auto merge: %w", err)
+	}
+
+	reviewers, err := s.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load list of reviwers: %w", err)
+	}
+
+	protectionRules, err := s.protectionManager.ForRepository(ctx, targetRepo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	checkResults, err := s.checkStore.ListResults(ctx, targetRepo.ID, pr.SourceSHA)
+	if err != nil {
+		return fmt.Errorf("failed to list status checks: %w", err)
+	}
+
+	codeOwnerWithApproval, err := s.codeOwners.Evaluate(ctx, targetRepo, pr, reviewers)
+	if err != nil && !errors.Is(err, codeowners.ErrNotFound) {
+		return fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
+	}
+
+	// Rules are never bypassed by auto merge, all the requirements must be met.
+	ruleOut, violations, err := protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
+		ResolveUserGroupID: s.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              principal,
+		AllowBypass:        false,
+		TargetRepo:         targetRepo,
+		SourceRepo:         sourceRepo,
+		PullReq:            pr,
+		Reviewers:          reviewers,
+		Method:             autoMerge.Method,
+		CheckResults:       checkResults,
+		CodeOwners:         codeOwnerWithApproval,
+		FindUnverifiedCommits: func(ctx context.Context) ([]string, error) {
+			return s.signatureVerifier.FindUnverifiedPullReqCommits(ctx, s.git, targetRepo, pr)
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if protection.IsCritical(violations) {
+		log.Ctx(ctx).Debug().
+			Int64("pullreq_id", pr.ID).
+			Msg("auto merge postponed because of rule violations")
+		return nil
+	}
+
+	blockedBy, err := s.dependencyService.CheckDependenciesMerged(ctx, pr)
+	if err != nil {
+		return err
+	}
+
+	if blockedBy != "" {
+		log.Ctx(ctx).Debug().
+			Int64("pullreq_id", pr.ID).
+			Msg("auto merge postponed because of open dependencies")
+		return nil
+	}
+
+	// we want to complete the merge independent of the event handling timeout.
+	ctx, cancel := context.WithTimeout(
+		contextutil.WithNewValues(context.Background(), ctx),
+		timeout,
+	)
+	defer cancel()
+
+	targetWriteParams, err := createSystemRPCWriteParams(ctx, s.urlProvider, targetRepo.ID, targetRepo.GitUID)
+	if err != nil {
+		return fmt.Errorf("failed to generate target repo write params: %w", err)
+	}
+
+	sourceWriteParams := targetWriteParams
+	if sourceRepo.ID != targetRepo.ID {
+		sourceWriteParams, err = createSystemRPCWriteParams(ctx, s.urlProvider, sourceRepo.ID, sourceRepo.GitUID)
+		if err != nil {
+			return fmt.Errorf("failed to generate source repo write params: %w", err)
+		}
+	}
+
+	result, err := s.merger.Merge(ctx, &MergeParams{
+		TargetRepo:         targetRepo,
+		SourceRepo:         sourceRepo,
+		TargetWriteParams:  targetWriteParams,
+		SourceWriteParams:  sourceWriteParams,
+		PullReq:            pr,
+		MergedBy:           principal,
+		Method:             autoMerge.Method,
+		SourceSHA:          autoMerge.SourceSHA,
+		Title:              autoMerge.Title,
+		Message:            autoMerge.Message,
+		DeleteSourceBranch: ruleOut.DeleteSourceBranch,
+	})
+	if err != nil {
+		return fmt.Errorf("auto merge failed: %w", err)
+	}
+
+	if len(result.ConflictFiles) > 0 {
+		// The user needs to resolve the conflicts, which results in a new source branch commit
+		// and cancels the auto merge.
+		log.Ctx(ctx).Info().
+			Int64("pullreq_id", pr.ID).
+			Strs("conflicts", result.ConflictFiles).
+			Msg("auto merge postponed because of conflicts")
+		return nil
+	}
+
+	if err = s.autoMergeStore.Delete(ctx, pr.ID); err != nil {
+		// non-critical error, the auto merge settings of merged pull requests are ignored
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to delete auto merge settings of merged pull request")
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("repo_id", targetRepo.ID).
+		Int64("pullreq_number", pr.Number).
+		Str("merge_sha", result.MergeSHA).
+		Msg("pull request auto merged")
+
+	return nil
+}
@@ -0,0 +1,414 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultReviewersService requests review from the default reviewers defined by the protection rules
+// when a pull request is created, marked as ready for review or when its source branch gets updated.
+type DefaultReviewersService struct {
+	authorizer        authz.Authorizer
+	pullreqEvReporter *pullreqevents.Reporter
+	repoStore         store.RepoStore
+	pullreqStore      store.PullReqStore
+	reviewerStore     store.PullReqReviewerStore
+	activityStore     store.PullReqActivityStore
+	principalStore    store.PrincipalStore
+	protectionManager *protection.Manager
+	codeOwners        *codeowners.Service
+	userGroupService  usergroup.SearchService
+	sseStreamer       sse.Streamer
+}
+
+func NewDefaultReviewersService(ctx context.Context,
+	config *types.Config,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	pullreqEvReporter *pullreqevents.Reporter,
+	authorizer authz.Authorizer,
+	repoStore store.RepoStore,
+	pullreqStore store.PullReqStore,
+	reviewerStore store.PullReqReviewerStore,
+	activityStore store.PullReqActivityStore,
+	principalStore store.PrincipalStore,
+	protectionManager *protection.Manager,
+	codeOwners *codeowners.Service,
+	userGroupService usergroup.SearchService,
+	sseStreamer sse.Streamer,
+) (*DefaultReviewersService, error) {
+	service := &DefaultReviewersService{
+		authorizer:        authorizer,
+		pullreqEvReporter: pullreqEvReporter,
+		repoStore:         repoStore,
+		pullreqStore:      pullreqStore,
+		reviewerStore:     reviewerStore,
+		activityStore:     activityStore,
+		principalStore:    principalStore,
+		protectionManager: protectionManager,
+		codeOwners:        codeOwners,
+		userGroupService:  userGroupService,
+		sseStreamer:       sseStreamer,
+	}
+
+	const groupPullReqDefaultReviewers = "gitness:pullreq:defaultreviewers"
+	_, err := pullreqEvReaderFactory.Launch(ctx, groupPullReqDefaultReviewers, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = time.Minute
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterCreated(service.assignReviewersOnCreated)
+			_ = r.RegisterBranchUpdated(service.assignReviewersOnBranchUpdated)
+			_ = r.RegisterReadyForReview(service.assignReviewersOnReadyForReview)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+func (s *DefaultReviewersService) assignReviewersOnCreated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.CreatedPayload],
+) error {
+	return s.assignReviewers(ctx, event.Payload.PullReqID)
+}
+
+// assignReviewersOnBranchUpdated requests review from the default reviewers again,
+// because the new commits could change the code owners of the pull request.
+func (s *DefaultReviewersService) assignReviewersOnBranchUpdated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload],
+) error {
+	return s.assignReviewers(ctx, event.Payload.PullReqID)
+}
+
+func (s *DefaultReviewersService) assignReviewersOnReadyForReview(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReadyForReviewPayload],
+) error {
+	return s.assignReviewers(ctx, event.Payload.PullReqID)
+}
+
+// assignReviewers adds the default reviewers of the protection rules that match the target branch
+// to the pull request. Draft pull requests are ignored, the reviewers are added once they are ready for review.
+//
+//nolint:gocognit // refactor if needed.
+func (s *DefaultReviewersService) assignReviewers(ctx context.Context, pullreqID int64) error {
+	pr, err := s.pullreqStore.Find(ctx, pullreqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen || pr.IsDraft {
+		return nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, pr.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find target repository: %w", err)
+	}
+
+	protectionRules, err := s.protectionManager.ForRepository(ctx, repo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	defaults, err := protectionRules.DefaultReviewers(ctx, protection.DefaultReviewersInput{
+		Repo:    repo,
+		PullReq: pr,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get default reviewers: %w", err)
+	}
+
+	if len(defaults.UserIDs) == 0 && len(defaults.UserGroups) == 0 && !defaults.CodeOwners {
+		return nil
+	}
+
+	reviewers, err := s.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list pull request reviewers: %w", err)
+	}
+
+	existing := make(map[int64]struct{}, len(reviewers)+1)
+	existing[pr.CreatedBy] = struct{}{}
+	for _, reviewer := range reviewers {
+		existing[reviewer.PrincipalID] = struct{}{}
+	}
+
+	candidateIDs := defaults.UserIDs
+
+	if defaults.CodeOwners {
+		codeOwnerIDs, err := s.codeOwnerIDs(ctx, repo, pr)
+		if err != nil {
+			return err
+		}
+
+		candidateIDs = append(candidateIDs, codeOwnerIDs...)
+	}
+
+	for _, userGroup := range defaults.UserGroups {
+		memberIDs, err := s.userGroupService.ListUserIDsByGroupIDs(ctx, []int64{userGroup.UserGroupID})
+		if err != nil {
+			return fmt.Errorf("failed to list members of user group %d: %w", userGroup.UserGroupID, err)
+		}
+
+		pickedIDs, err := s.pickGroupReviewers(ctx, userGroup, memberIDs, existing)
+		if err != nil {
+			return err
+		}
+
+		candidateIDs = append(candidateIDs, pickedIDs...)
+	}
+
+	var added bool
+
+	for _, principalID := range candidateIDs {
+		if _, ok := existing[principalID]; ok {
+			continue
+		}
+
+		existing[principalID] = struct{}{}
+
+		ok, err := s.addReviewer(ctx, repo, pr, principalID)
+		if err != nil {
+			return err
+		}
+
+		added = added || ok
+	}
+
+	if !added {
+		return nil
+	}
+
+	if err = s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestReviewerAdded, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to publish %s event", enum.SSETypePullRequestReviewerAdded)
+	}
+
+	return nil
+}
+
+// codeOwnerIDs returns IDs of the code owners of the files changed by the pull request.
+func (s *DefaultReviewersService) codeOwnerIDs(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+) ([]int64, error) {
+	evaluation, err := s.codeOwners.Evaluate(ctx, repo, pr, nil)
+	if errors.Is(err, codeowners.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
+	}
+
+	var ids []int64
+	for _, entry := range evaluation.EvaluationEntries {
+		for _, owner := range entry.OwnerEvaluations {
+			ids = append(ids, owner.Owner.ID)
+		}
+		for _, userGroupOwner := range entry.UserGroupOwnerEvaluations {
+			for _, owner := range userGroupOwner.Evaluations {
+				ids = append(ids, owner.Owner.ID)
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+// pickGroupReviewers returns the members of the user group that should be requested to review.
+// Members that are already reviewers count towards the number of reviewers picked from the group.
+func (s *DefaultReviewersService) pickGroupReviewers(
+	ctx context.Context,
+	userGroup protection.DefaultReviewersUserGroup,
+	memberIDs []int64,
+	existing map[int64]struct{},
+) ([]int64, error) {
+	if userGroup.Selection == enum.ReviewerSelectionAll {
+		return memberIDs, nil
+	}
+
+	count := userGroup.Count
+	available := make([]int64, 0, len(memberIDs))
+	for _, memberID := range memberIDs {
+		if _, ok := existing[memberID]; ok {
+			count--
+			continue
+		}
+		available = append(available, memberID)
+	}
+
+	if count <= 0 || len(available) == 0 {
+		return nil, nil
+	}
+
+	workloads, err := s.reviewerStore.ListWorkload(ctx, available)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviewer workload: %w", err)
+	}
+
+	return pickReviewers(available, workloads, userGroup.Selection, count), nil
+}
+
+// pickReviewers picks count principals from the candidates. Round-robin selection picks the principals
+// who were requested to review least recently, load-balanced selection picks the principals with
+// the fewest pending reviews of open pull requests.
+func pickReviewers(
+	candidateIDs []int64,
+	workloads []types.PullReqReviewerWorkload,
+	selection enum.ReviewerSelection,
+	count int,
+) []int64 {
+	workloadMap := make(map[int64]types.PullReqReviewerWorkload, len(workloads))
+	for _, workload := range workloads {
+		workloadMap[workload.PrincipalID] = workload
+	}
+
+	picked := make([]int64, len(candidateIDs))
+	copy(picked, candidateIDs)
+
+	sort.SliceStable(picked, func(i, j int) bool {
+		a, b := workloadMap[picked[i]], workloadMap[picked[j]]
+		if selection == enum.ReviewerSelectionLoadBalanced && a.PendingReviews != b.PendingReviews {
+			return a.PendingReviews < b.PendingReviews
+		}
+		if a.LastRequested != b.LastRequested {
+			return a.LastRequested < b.LastRequested
+		}
+		return picked[i] < picked[j]
+	})
+
+	if count < len(picked) {
+		picked = picked[:count]
+	}
+
+	return picked
+}
+
+// addReviewer adds the principal as an assigned reviewer of the pull request on behalf of the system.
+// It returns false if the principal can't review the pull request or is already a reviewer.
+func (s *DefaultReviewersService) addReviewer(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	principalID int64,
+) (bool, error) {
+	principal, err := s.principalStore.Find(ctx, principalID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find reviewer principal: %w", err)
+	}
+
+	err = apiauth.CheckRepo(ctx, s.authorizer, &auth.Session{Principal: *principal}, repo, enum.PermissionRepoReview)
+	if errors.Is(err, apiauth.ErrNotAuthorized) {
+		log.Ctx(ctx).Info().
+			Int64("pullreq_id", pr.ID).
+			Str("principal_uid", principal.UID).
+			Msg("default reviewer skipped because of insufficient permissions")
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check reviewer permissions: %w", err)
+	}
+
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+	now := time.Now().UnixMilli()
+
+	reviewer := &types.PullReqReviewer{
+		PullReqID:      pr.ID,
+		PrincipalID:    principal.ID,
+		CreatedBy:      systemPrincipal.ID,
+		Created:        now,
+		Updated:        now,
+		RepoID:         repo.ID,
+		Type:           enum.PullReqReviewerTypeAssigned,
+		LatestReviewID: nil,
+		ReviewDecision: enum.PullReqReviewDecisionPending,
+		SHA:            "",
+		Reviewer:       *principal.ToPrincipalInfo(),
+		AddedBy:        *systemPrincipal.ToPrincipalInfo(),
+	}
+
+	err = s.reviewerStore.Create(ctx, reviewer)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create pull request reviewer: %w", err)
+	}
+
+	payload := &types.PullRequestActivityPayloadReviewerAdd{
+		PrincipalID:  principal.ID,
+		ReviewerType: enum.PullReqReviewerTypeAssigned,
+	}
+
+	metadata := &types.PullReqActivityMetadata{
+		Mentions: &types.PullReqActivityMentionsMetadata{IDs: []int64{principal.ID}},
+	}
+
+	if pr, err = s.pullreqStore.UpdateActivitySeq(ctx, pr); err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to increment pull request activity sequence")
+	} else if _, err = s.activityStore.CreateWithPayload(ctx, pr, systemPrincipal.ID, payload, metadata); err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to write pull request activity after adding a default reviewer")
+	}
+
+	s.pullreqEvReporter.ReviewerAdded(ctx, &pullreqevents.ReviewerAddedPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    pr.ID,
+			SourceRepoID: pr.SourceRepoID,
+			TargetRepoID: pr.TargetRepoID,
+			PrincipalID:  systemPrincipal.ID,
+			Number:       pr.Number,
+		},
+		ReviewerID: principal.ID,
+	})
+
+	return true, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestPickReviewers(t *testing.T) {
+	workloads := []types.PullReqReviewerWorkload{
+		{PrincipalID: 1, PendingReviews: 5, LastRequested: 100},
+		{PrincipalID: 2, PendingReviews: 0, LastRequested: 300},
+		{PrincipalID: 3, PendingReviews: 2, LastRequested: 200},
+		// principal 4 was never requested to review
+	}
+
+	tests := []struct {
+		name      string
+		selection enum.ReviewerSelection
+		count     int
+		exp       []int64
+	}{
+		{
+			name:      "round-robin",
+			selection: enum.ReviewerSelectionRoundRobin,
+			count:     2,
+			exp:       []int64{4, 1},
+		},
+		{
+			name:      "load-balanced",
+			selection: enum.ReviewerSelectionLoadBalanced,
+			count:     2,
+			exp:       []int64{4, 2},
+		},
+		{
+			name:      "load-balanced-all",
+			selection: enum.ReviewerSelectionLoadBalanced,
+			count:     10,
+			exp:       []int64{4, 2, 3, 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := pickReviewers([]int64{1, 2, 3, 4}, workloads, test.selection, test.count)
+			if !reflect.DeepEqual(test.exp, got) {
+				t.Errorf("picked reviewers mismatch: want=%v got=%v", test.exp, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,496 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	gitnesserrors "github.com/harness/gitness/errors"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DependencyService maintains stacks of dependent pull requests.
+//
+// A pull request depends on another pull request if the dependency is explicitly declared,
+// or if it targets the source branch of the other pull request. When a pull request gets merged,
+// the pull requests that target its source branch are retargeted to the branch it was merged into
+// and their source branches are rebased onto it.
+type DependencyService struct {
+	git              git.Interface
+	urlProvider      url.Provider
+	repoGitInfoCache store.RepoGitInfoCache
+	pullreqStore     store.PullReqStore
+	dependencyStore  store.PullReqDependencyStore
+	activityStore    store.PullReqActivityStore
+	sseStreamer      sse.Streamer
+}
+
+func NewDependencyService(ctx context.Context,
+	config *types.Config,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	git git.Interface,
+	urlProvider url.Provider,
+	repoGitInfoCache store.RepoGitInfoCache,
+	pullreqStore store.PullReqStore,
+	dependencyStore store.PullReqDependencyStore,
+	activityStore store.PullReqActivityStore,
+	sseStreamer sse.Streamer,
+) (*DependencyService, error) {
+	service := &DependencyService{
+		git:              git,
+		urlProvider:      urlProvider,
+		repoGitInfoCache: repoGitInfoCache,
+		pullreqStore:     pullreqStore,
+		dependencyStore:  dependencyStore,
+		activityStore:    activityStore,
+		sseStreamer:      sseStreamer,
+	}
+
+	const groupPullReqDependencies = "gitness:pullreq:dependencies"
+	_, err := pullreqEvReaderFactory.Launch(ctx, groupPullReqDependencies, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 3 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterMerged(service.retargetDependentsOnMerged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+// FindOpenDependencies returns the numbers of the open pull requests the pull request depends on.
+func (s *DependencyService) FindOpenDependencies(ctx context.Context, pr *types.PullReq) ([]int64, error) {
+	dependsOn, err := s.listDependsOn(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	var numbers []int64
+	for _, dependency := range dependsOn {
+		if dependency.pr.State == enum.PullReqStateOpen {
+			numbers = append(numbers, dependency.pr.Number)
+		}
+	}
+
+	return numbers, nil
+}
+
+// CheckDependenciesMerged returns the reason why the pull request can't be merged
+// if any of the pull requests it depends on is still open. It returns an empty string otherwise.
+func (s *DependencyService) CheckDependenciesMerged(ctx context.Context, pr *types.PullReq) (string, error) {
+	numbers, err := s.FindOpenDependencies(ctx, pr)
+	if err != nil {
+		return "", fmt.Errorf("failed to find open pull request dependencies: %w", err)
+	}
+
+	if len(numbers) == 0 {
+		return "", nil
+	}
+
+	dependencies := make([]string, len(numbers))
+	for i, number := range numbers {
+		dependencies[i] = "#" + strconv.FormatInt(number, 10)
+	}
+
+	return fmt.Sprintf("The pull request depends on pull requests that must be merged first: %s",
+		strings.Join(dependencies, ", ")), nil
+}
+
+// List returns the pull requests the pull request depends on and the open pull requests that depend on it.
+func (s *DependencyService) List(ctx context.Context, pr *types.PullReq) (*types.PullReqDependencies, error) {
+	dependsOn, err := s.listDependsOn(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	dependents, err := s.listDependents(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.PullReqDependencies{
+		DependsOn:  mapDependencyInfos(dependsOn),
+		Dependents: mapDependencyInfos(dependents),
+	}, nil
+}
+
+// IsDependency returns true if the pull request with the ID dependsOnID is, directly or transitively,
+// an explicitly declared dependency of the pull request. It's used to prevent cyclic dependencies.
+func (s *DependencyService) IsDependency(ctx context.Context, pullreqID, dependsOnID int64) (bool, error) {
+	visited := map[int64]struct{}{pullreqID: {}}
+	queue := []int64{pullreqID}
+
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+
+		dependencies, err := s.dependencyStore.List(ctx, id)
+		if err != nil {
+			return false, fmt.Errorf("failed to list dependencies of pull request: %w", err)
+		}
+
+		for _, dependency := range dependencies {
+			if dependency.DependsOnID == dependsOnID {
+				return true, nil
+			}
+
+			if _, ok := visited[dependency.DependsOnID]; ok {
+				continue
+			}
+
+			visited[dependency.DependsOnID] = struct{}{}
+			queue = append(queue, dependency.DependsOnID)
+		}
+	}
+
+	return false, nil
+}
+
+type dependency struct {
+	pr       *types.PullReq
+	implicit bool
+}
+
+// listDependsOn returns all explicitly declared dependencies of the pull request
+// and the open pull requests whose source branch the pull request targets.
+func (s *DependencyService) listDependsOn(ctx context.Context, pr *types.PullReq) ([]dependency, error) {
+	explicit, err := s.dependencyStore.List(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request dependencies: %w", err)
+	}
+
+	result := make([]dependency, 0, len(explicit))
+	seen := make(map[int64]struct{}, len(explicit))
+
+	for _, d := range explicit {
+		dependsOn, err := s.pullreqStore.Find(ctx, d.DependsOnID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find pull request dependency: %w", err)
+		}
+
+		seen[dependsOn.ID] = struct{}{}
+		result = append(result, dependency{pr: dependsOn})
+	}
+
+	implicit, err := s.listOpen(ctx, &types.PullReqFilter{
+		SourceRepoID: pr.TargetRepoID,
+		SourceBranch: pr.TargetBranch,
+		TargetRepoID: pr.TargetRepoID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dependsOn := range implicit {
+		if _, ok := seen[dependsOn.ID]; ok || dependsOn.ID == pr.ID {
+			continue
+		}
+
+		result = append(result, dependency{pr: dependsOn, implicit: true})
+	}
+
+	return result, nil
+}
+
+// listDependents returns the open pull requests that explicitly depend on the pull request
+// and the open pull requests that target its source branch.
+func (s *DependencyService) listDependents(ctx context.Context, pr *types.PullReq) ([]dependency, error) {
+	explicit, err := s.dependencyStore.ListDependents(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request dependents: %w", err)
+	}
+
+	result := make([]dependency, 0, len(explicit))
+	seen := make(map[int64]struct{}, len(explicit))
+
+	for _, d := range explicit {
+		dependent, err := s.pullreqStore.Find(ctx, d.PullReqID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find dependent pull request: %w", err)
+		}
+
+		if dependent.State != enum.PullReqStateOpen {
+			continue
+		}
+
+		seen[dependent.ID] = struct{}{}
+		result = append(result, dependency{pr: dependent})
+	}
+
+	if pr.SourceRepoID != pr.TargetRepoID {
+		// pull requests can't target branches of forks.
+		return result, nil
+	}
+
+	implicit, err := s.listImplicitDependents(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dependent := range implicit {
+		if _, ok := seen[dependent.ID]; ok {
+			continue
+		}
+
+		result = append(result, dependency{pr: dependent, implicit: true})
+	}
+
+	return result, nil
+}
+
+// listImplicitDependents returns the open pull requests that target the source branch of the pull request.
+func (s *DependencyService) listImplicitDependents(ctx context.Context, pr *types.PullReq) ([]*types.PullReq, error) {
+	dependents, err := s.listOpen(ctx, &types.PullReqFilter{
+		TargetRepoID: pr.SourceRepoID,
+		TargetBranch: pr.SourceBranch,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := dependents[:0]
+	for _, dependent := range dependents {
+		if dependent.ID != pr.ID {
+			result = append(result, dependent)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *DependencyService) listOpen(ctx context.Context, filter *types.PullReqFilter) ([]*types.PullReq, error) {
+	const largeLimit = 1000
+
+	filter.Page = 0
+	filter.Size = largeLimit
+	filter.States = []enum.PullReqState{enum.PullReqStateOpen}
+	filter.Sort = enum.PullReqSortNumber
+	filter.Order = enum.OrderAsc
+	filter.ExcludeDescription = true
+
+	list, err := s.pullreqStore.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open pull requests: %w", err)
+	}
+
+	return list, nil
+}
+
+func mapDependencyInfos(dependencies []dependency) []types.PullReqDependencyInfo {
+	infos := make([]types.PullReqDependencyInfo, len(dependencies))
+	for i, d := range dependencies {
+		infos[i] = types.PullReqDependencyInfo{
+			Number:       d.pr.Number,
+			Title:        d.pr.Title,
+			State:        d.pr.State,
+			IsDraft:      d.pr.IsDraft,
+			SourceBranch: d.pr.SourceBranch,
+			TargetBranch: d.pr.TargetBranch,
+			Implicit:     d.implicit,
+		}
+	}
+	return infos
+}
+
+// retargetDependentsOnMerged handles the pull request merged event. The open pull requests that target
+// the source branch of the merged pull request are retargeted to the branch it was merged into.
+// Their source branches are then rebased onto the new target branch. The push of the rebased branch
+// is handled as any other branch update, which updates the source SHA and the merge base of the pull request.
+func (s *DependencyService) retargetDependentsOnMerged(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	merged, err := s.pullreqStore.Find(ctx, event.Payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find merged pull request: %w", err)
+	}
+
+	if merged.SourceRepoID != merged.TargetRepoID {
+		// pull requests can't target branches of forks.
+		return nil
+	}
+
+	dependents, err := s.listImplicitDependents(ctx, merged)
+	if err != nil {
+		return err
+	}
+
+	for _, dependent := range dependents {
+		if err := s.retarget(ctx, merged, dependent); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("pullreq_number", dependent.Number).
+				Msg("failed to retarget dependent pull request")
+		}
+	}
+
+	return nil
+}
+
+// retarget changes the target branch of the dependent pull request to the target branch
+// of the merged pull request and rebases the dependent pull request's source branch onto it.
+func (s *DependencyService) retarget(ctx context.Context, merged, dependent *types.PullReq) error {
+	repo, err := s.repoGitInfoCache.Get(ctx, dependent.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to get repo git info: %w", err)
+	}
+
+	oldTargetBranch := dependent.TargetBranch
+	newTargetBranch := merged.TargetBranch
+
+	mergeBase, err := s.git.MergeBase(ctx, git.MergeBaseParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		Ref1:       dependent.SourceSHA,
+		Ref2:       newTargetBranch,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find merge base with the new target branch: %w", err)
+	}
+
+	updated, err := s.pullreqStore.UpdateOptLock(ctx, dependent, func(pr *types.PullReq) error {
+		if pr.State != enum.PullReqStateOpen || pr.TargetBranch != oldTargetBranch {
+			return errPRNotOpen
+		}
+
+		pr.ActivitySeq++
+		pr.TargetBranch = newTargetBranch
+		pr.MergeBaseSHA = mergeBase.MergeBaseSHA.String()
+		pr.MergeSHA = nil
+		pr.MarkAsMergeUnchecked()
+
+		return nil
+	})
+	if errors.Is(err, errPRNotOpen) {
+		return nil
+	}
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		// there's already an open pull request from the same source branch to the new target branch.
+		log.Ctx(ctx).Info().
+			Int64("pullreq_number", dependent.Number).
+			Msgf("skipping retargeting: a pull request from %q to %q already exists",
+				dependent.SourceBranch, newTargetBranch)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update target branch of the pull request: %w", err)
+	}
+
+	dependent = updated
+
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+
+	payload := &types.PullRequestActivityPayloadTargetChange{
+		Old:              oldTargetBranch,
+		New:              newTargetBranch,
+		DependencyNumber: merged.Number,
+	}
+	if _, err := s.activityStore.CreateWithPayload(ctx, dependent, systemPrincipal.ID, payload, nil); err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msg("failed to write pull request activity for target branch change")
+	}
+
+	if err = s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullRequestUpdated, dependent); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+
+	if dependent.SourceRepoID != dependent.TargetRepoID {
+		// the source branches of pull requests from forks can't be updated.
+		return nil
+	}
+
+	return s.rebase(ctx, repo, dependent, mergeBase.MergeBaseSHA)
+}
+
+// rebase rebases the source branch of the pull request onto its target branch.
+// Commits of the merged pull request that are already on the target branch are dropped by the rebase.
+func (s *DependencyService) rebase(
+	ctx context.Context,
+	repo *types.RepositoryGitInfo,
+	pr *types.PullReq,
+	mergeBaseSHA sha.SHA,
+) error {
+	targetBranch, err := s.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		BranchName: pr.TargetBranch,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get target branch: %w", err)
+	}
+
+	if targetBranch.Branch.SHA.Equal(mergeBaseSHA) {
+		// the source branch is already based on the latest commit of the target branch.
+		return nil
+	}
+
+	writeParams, err := createSystemRPCWriteParams(ctx, s.urlProvider, repo.ID, repo.GitUID)
+	if err != nil {
+		return fmt.Errorf("failed to generate rpc write params: %w", err)
+	}
+
+	mergeOutput, err := s.git.Merge(ctx, &git.MergeParams{
+		WriteParams:     writeParams,
+		BaseBranch:      pr.TargetBranch,
+		HeadRepoUID:     repo.GitUID,
+		HeadBranch:      pr.SourceBranch,
+		RefType:         gitenum.RefTypeBranch,
+		RefName:         pr.SourceBranch,
+		HeadExpectedSHA: sha.Must(pr.SourceSHA),
+		Method:          gitenum.MergeMethodRebase,
+	})
+	if gitnesserrors.IsInvalidArgument(err) {
+		// the source branch doesn't contain any commits that aren't on the target branch.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to rebase source branch onto the new target branch: %w", err)
+	}
+
+	if len(mergeOutput.ConflictFiles) > 0 {
+		log.Ctx(ctx).Info().
+			Int64("pullreq_number", pr.Number).
+			Strs("conflict_files", mergeOutput.ConflictFiles).
+			Msg("source branch can't be rebased onto the new target branch due to conflicts")
+	}
+
+	return nil
+}
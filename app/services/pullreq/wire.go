@@ -18,11 +18,17 @@ import (
 	"context"
 
 	"github.com/harness/gitness/app/auth/authz"
+	checkevents "github.com/harness/gitness/app/events/check"
 	gitevents "github.com/harness/gitness/app/events/git"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/codecomments"
+	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -38,6 +44,10 @@ import (
 var WireSet = wire.NewSet(
 	ProvideService,
 	ProvideListService,
+	ProvideMerger,
+	ProvideAutoMergeService,
+	ProvideDefaultReviewersService,
+	ProvideDependencyService,
 )
 
 func ProvideService(ctx context.Context,
@@ -103,3 +113,123 @@ func ProvideListService(
 		protectionManager,
 	)
 }
+
+func ProvideMerger(
+	git git.Interface,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	pullreqEvReporter *pullreqevents.Reporter,
+	sseStreamer sse.Streamer,
+) *Merger {
+	return NewMerger(
+		git,
+		pullreqStore,
+		activityStore,
+		pullreqEvReporter,
+		sseStreamer,
+	)
+}
+
+func ProvideAutoMergeService(ctx context.Context,
+	config *types.Config,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	checkEvReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	pipelineEvReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
+	urlProvider url.Provider,
+	authorizer authz.Authorizer,
+	git git.Interface,
+	locker *locker.Locker,
+	merger *Merger,
+	autoMergeStore store.PullReqAutoMergeStore,
+	pullreqStore store.PullReqStore,
+	reviewerStore store.PullReqReviewerStore,
+	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
+	checkStore store.CheckStore,
+	protectionManager *protection.Manager,
+	codeOwners *codeowners.Service,
+	userGroupService usergroup.SearchService,
+	signatureVerifier *publickey.SignatureVerifier,
+	dependencyService *DependencyService,
+) (*AutoMergeService, error) {
+	return NewAutoMergeService(ctx,
+		config,
+		gitReaderFactory,
+		pullreqEvReaderFactory,
+		checkEvReaderFactory,
+		pipelineEvReaderFactory,
+		urlProvider,
+		authorizer,
+		git,
+		locker,
+		merger,
+		autoMergeStore,
+		pullreqStore,
+		reviewerStore,
+		repoStore,
+		principalStore,
+		checkStore,
+		protectionManager,
+		codeOwners,
+		userGroupService,
+		signatureVerifier,
+		dependencyService,
+	)
+}
+
+func ProvideDefaultReviewersService(ctx context.Context,
+	config *types.Config,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	pullreqEvReporter *pullreqevents.Reporter,
+	authorizer authz.Authorizer,
+	repoStore store.RepoStore,
+	pullreqStore store.PullReqStore,
+	reviewerStore store.PullReqReviewerStore,
+	activityStore store.PullReqActivityStore,
+	principalStore store.PrincipalStore,
+	protectionManager *protection.Manager,
+	codeOwners *codeowners.Service,
+	userGroupService usergroup.SearchService,
+	sseStreamer sse.Streamer,
+) (*DefaultReviewersService, error) {
+	return NewDefaultReviewersService(ctx,
+		config,
+		pullreqEvReaderFactory,
+		pullreqEvReporter,
+		authorizer,
+		repoStore,
+		pullreqStore,
+		reviewerStore,
+		activityStore,
+		principalStore,
+		protectionManager,
+		codeOwners,
+		userGroupService,
+		sseStreamer,
+	)
+}
+
+func ProvideDependencyService(ctx context.Context,
+	config *types.Config,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	git git.Interface,
+	urlProvider url.Provider,
+	repoGitInfoCache store.RepoGitInfoCache,
+	pullreqStore store.PullReqStore,
+	dependencyStore store.PullReqDependencyStore,
+	activityStore store.PullReqActivityStore,
+	sseStreamer sse.Streamer,
+) (*DependencyService, error) {
+	return NewDependencyService(ctx,
+		config,
+		pullreqEvReaderFactory,
+		git,
+		urlProvider,
+		repoGitInfoCache,
+		pullreqStore,
+		dependencyStore,
+		activityStore,
+		sseStreamer,
+	)
+}
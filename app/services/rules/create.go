@@ -70,6 +70,10 @@ func (in *CreateInput) sanitize() error {
 		in.Type = protection.TypeBranch
 	}
 
+	if in.Type == protection.TypeTag && in.Pattern.Default {
+		return usererror.BadRequest("tag rules can't target the default branch")
+	}
+
 	if len(in.Definition) == 0 {
 		return usererror.BadRequest("rule definition missing")
 	}
@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+)
+
+// awsSecretsManager reads secrets from AWS Secrets Manager.
+// Paths are the secret name or ARN, optionally followed by "#key" to select a field of a JSON secret.
+type awsSecretsManager struct {
+	client *secretsmanager.SecretsManager
+}
+
+func newAWSSecretsManager(region, endpoint string) (*awsSecretsManager, error) {
+	cfg := &aws.Config{
+		Region: aws.String(region),
+	}
+	if endpoint != "" {
+		cfg.Endpoint = aws.String(endpoint)
+	}
+
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create aws session: %w", err)
+	}
+
+	return &awsSecretsManager{
+		client: secretsmanager.New(sess),
+	}, nil
+}
+
+func (p *awsSecretsManager) Validate(path string) error {
+	secretID, _ := splitKey(path)
+	if secretID == "" {
+		return fmt.Errorf("%w: aws secret path must be in the form 'secret-name#key'", ErrInvalidPath)
+	}
+
+	return nil
+}
+
+func (p *awsSecretsManager) Fetch(ctx context.Context, path string) (string, error) {
+	if err := p.Validate(path); err != nil {
+		return "", err
+	}
+
+	secretID, key := splitKey(path)
+
+	out, err := p.client.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(secretID),
+	})
+
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) && awsErr.Code() == secretsmanager.ErrCodeResourceNotFoundException {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get secret value: %w", err)
+	}
+
+	if out.SecretString == nil {
+		return "", fmt.Errorf("%w: only secrets with a string value are supported", ErrInvalidPath)
+	}
+
+	if key == "" {
+		return *out.SecretString, nil
+	}
+
+	var values map[string]any
+	if err := json.Unmarshal([]byte(*out.SecretString), &values); err != nil {
+		return "", fmt.Errorf("%w: the secret value isn't a JSON object", ErrInvalidPath)
+	}
+
+	return pickKey(values, key)
+}
@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var envNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// env reads secrets from environment variables of the server.
+// Only variables starting with the configured prefix can be referenced,
+// which keeps the server's own configuration out of reach.
+type env struct {
+	prefix string
+}
+
+func newEnv(prefix string) *env {
+	return &env{prefix: prefix}
+}
+
+func (p *env) Validate(path string) error {
+	if !envNameRegex.MatchString(path) {
+		return fmt.Errorf("%w: %q isn't a valid environment variable name", ErrInvalidPath, path)
+	}
+
+	if !strings.HasPrefix(path, p.prefix) {
+		return fmt.Errorf("%w: environment variable name must start with %q", ErrInvalidPath, p.prefix)
+	}
+
+	return nil
+}
+
+func (p *env) Fetch(_ context.Context, path string) (string, error) {
+	if err := p.Validate(path); err != nil {
+		return "", err
+	}
+
+	value, ok := os.LookupEnv(path)
+	if !ok {
+		return "", ErrNotFound
+	}
+
+	return value, nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+var (
+	// ErrProviderNotConfigured is returned for secrets that reference a provider that isn't configured.
+	ErrProviderNotConfigured = errors.New("secret provider is not configured")
+	// ErrInvalidPath is returned if a path isn't valid for the provider.
+	ErrInvalidPath = errors.New("invalid secret path")
+	// ErrNotFound is returned if the provider doesn't have a value for the path.
+	ErrNotFound = errors.New("secret not found in provider")
+)
+
+// Provider reads secret values from a store outside of the Gitness database.
+type Provider interface {
+	// Validate checks that the path is well-formed for the provider, without accessing the store.
+	Validate(path string) error
+
+	// Fetch returns the value referenced by the path.
+	Fetch(ctx context.Context, path string) (string, error)
+}
+
+// Providers holds the configured external secret providers and caches the values they return.
+type Providers struct {
+	providers map[enum.SecretProvider]Provider
+	ttl       time.Duration
+	now       func() time.Time
+
+	mx    sync.Mutex
+	cache map[cacheKey]cacheEntry
+}
+
+type cacheKey struct {
+	provider enum.SecretProvider
+	path     string
+}
+
+type cacheEntry struct {
+	value   string
+	expires time.Time
+}
+
+func NewProviders(providers map[enum.SecretProvider]Provider, ttl time.Duration) *Providers {
+	return &Providers{
+		providers: providers,
+		ttl:       ttl,
+		now:       time.Now,
+		cache:     make(map[cacheKey]cacheEntry),
+	}
+}
+
+// Enabled returns true if the provider is configured.
+func (p *Providers) Enabled(provider enum.SecretProvider) bool {
+	_, ok := p.providers[provider]
+	return ok
+}
+
+// Validate checks that the provider is configured and that the path is valid for it.
+func (p *Providers) Validate(provider enum.SecretProvider, path string) error {
+	impl, ok := p.providers[provider]
+	if !ok {
+		return ErrProviderNotConfigured
+	}
+
+	return impl.Validate(path)
+}
+
+// Fetch returns the value referenced by the path from the provider.
+// Values are cached for the configured TTL, so rotated secrets are picked up once the TTL expires.
+func (p *Providers) Fetch(ctx context.Context, provider enum.SecretProvider, path string) (string, error) {
+	impl, ok := p.providers[provider]
+	if !ok {
+		return "", ErrProviderNotConfigured
+	}
+
+	key := cacheKey{provider: provider, path: path}
+	now := p.now()
+
+	p.mx.Lock()
+	entry, ok := p.cache[key]
+	p.mx.Unlock()
+
+	if ok && now.Before(entry.expires) {
+		return entry.value, nil
+	}
+
+	value, err := impl.Fetch(ctx, path)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch secret from %s provider: %w", provider, err)
+	}
+
+	if p.ttl > 0 {
+		p.mx.Lock()
+		p.cache[key] = cacheEntry{value: value, expires: now.Add(p.ttl)}
+		p.purge(now)
+		p.mx.Unlock()
+	}
+
+	return value, nil
+}
+
+// purge removes expired cache entries. Must be called with the mutex held.
+func (p *Providers) purge(now time.Time) {
+	for key, entry := range p.cache {
+		if !now.Before(entry.expires) {
+			delete(p.cache, key)
+		}
+	}
+}
+
+// splitKey splits a path in the form "location#key" into its parts.
+// The key selects a single field of a secret that holds multiple key-value pairs.
+func splitKey(path string) (string, string) {
+	location, key, _ := strings.Cut(path, "#")
+	return location, key
+}
+
+// pickKey returns the value of the field of a multi-field secret.
+// The key can be omitted if the secret has only one field.
+func pickKey(values map[string]any, key string) (string, error) {
+	if key == "" {
+		if len(values) != 1 {
+			return "", fmt.Errorf("%w: the secret has %d fields, select one with 'path#key'",
+				ErrInvalidPath, len(values))
+		}
+		for k := range values {
+			key = k
+		}
+	}
+
+	value, ok := values[key]
+	if !ok {
+		return "", fmt.Errorf("%w: field %q doesn't exist", ErrNotFound, key)
+	}
+
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal secret field %q: %w", key, err)
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+type countingProvider struct {
+	value string
+	calls int
+}
+
+func (p *countingProvider) Validate(string) error { return nil }
+
+func (p *countingProvider) Fetch(context.Context, string) (string, error) {
+	p.calls++
+	return p.value, nil
+}
+
+func TestProvidersFetchCache(t *testing.T) {
+	impl := &countingProvider{value: "v1"}
+	providers := NewProviders(map[enum.SecretProvider]Provider{enum.SecretProviderVault: impl}, time.Minute)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	providers.now = func() time.Time { return now }
+
+	fetch := func() string {
+		value, err := providers.Fetch(context.Background(), enum.SecretProviderVault, "app/db#password")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return value
+	}
+
+	if value := fetch(); value != "v1" || impl.calls != 1 {
+		t.Fatalf("got value=%q calls=%d", value, impl.calls)
+	}
+
+	impl.value = "v2"
+	if value := fetch(); value != "v1" || impl.calls != 1 {
+		t.Fatalf("expected cached value, got value=%q calls=%d", value, impl.calls)
+	}
+
+	now = now.Add(time.Minute)
+	if value := fetch(); value != "v2" || impl.calls != 2 {
+		t.Fatalf("expected refreshed value, got value=%q calls=%d", value, impl.calls)
+	}
+
+	_, err := providers.Fetch(context.Background(), enum.SecretProviderAWS, "app/db")
+	if !errors.Is(err, ErrProviderNotConfigured) {
+		t.Fatalf("expected ErrProviderNotConfigured, got %v", err)
+	}
+}
+
+func TestPickKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  map[string]any
+		key     string
+		want    string
+		wantErr error
+	}{
+		{name: "key", values: map[string]any{"user": "u", "pass": "p"}, key: "pass", want: "p"},
+		{name: "single field without key", values: map[string]any{"pass": "p"}, want: "p"},
+		{name: "non-string field", values: map[string]any{"port": 5432.0}, key: "port", want: "5432"},
+		{name: "multiple fields without key", values: map[string]any{"a": "1", "b": "2"}, wantErr: ErrInvalidPath},
+		{name: "missing key", values: map[string]any{"a": "1"}, key: "b", wantErr: ErrNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := pickKey(test.values, test.key)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("expected error %v, got %v", test.wantErr, err)
+			}
+			if got != test.want {
+				t.Errorf("expected %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestVaultFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" || r.Header.Get("X-Vault-Namespace") != "team" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path != "/v1/kv/data/app/db" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"data":{"user":"admin","password":"s3cr3t"},"metadata":{"version":3}}}`))
+	}))
+	defer server.Close()
+
+	provider := newVault(server.URL+"/", "token", "team", "/kv/")
+
+	value, err := provider.Fetch(context.Background(), "app/db#password")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value != "s3cr3t" {
+		t.Errorf("expected s3cr3t, got %q", value)
+	}
+
+	if _, err = provider.Fetch(context.Background(), "app/other#password"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	if _, err = provider.Fetch(context.Background(), "app/../sys#password"); !errors.Is(err, ErrInvalidPath) {
+		t.Errorf("expected ErrInvalidPath, got %v", err)
+	}
+}
+
+func TestEnvFetch(t *testing.T) {
+	t.Setenv("CI_SECRET_TOKEN", "abc")
+	t.Setenv("GITNESS_DATABASE_DATASOURCE", "postgres://")
+
+	provider := newEnv("CI_SECRET_")
+
+	value, err := provider.Fetch(context.Background(), "CI_SECRET_TOKEN")
+	if err != nil || value != "abc" {
+		t.Fatalf("got value=%q err=%v", value, err)
+	}
+
+	if _, err = provider.Fetch(context.Background(), "GITNESS_DATABASE_DATASOURCE"); !errors.Is(err, ErrInvalidPath) {
+		t.Errorf("expected ErrInvalidPath for variable without prefix, got %v", err)
+	}
+
+	if _, err = provider.Fetch(context.Background(), "CI_SECRET_MISSING"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestFileFetch(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "db"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "db", "password"), []byte("s3cr3t\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := newFile(dir)
+
+	value, err := provider.Fetch(context.Background(), "db/password")
+	if err != nil || value != "s3cr3t" {
+		t.Fatalf("got value=%q err=%v", value, err)
+	}
+
+	for _, path := range []string{"../etc/passwd", "/etc/passwd", "db/../../etc/passwd"} {
+		if _, err = provider.Fetch(context.Background(), path); !errors.Is(err, ErrInvalidPath) {
+			t.Errorf("expected ErrInvalidPath for %q, got %v", path, err)
+		}
+	}
+
+	if _, err = provider.Fetch(context.Background(), "db/missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
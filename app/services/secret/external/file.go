@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// file reads secrets from files located in the configured directory,
+// for example secrets mounted into the container by an orchestrator.
+type file struct {
+	dir string
+}
+
+func newFile(dir string) *file {
+	return &file{dir: dir}
+}
+
+func (p *file) Validate(path string) error {
+	if !filepath.IsLocal(path) {
+		return fmt.Errorf("%w: file path must be relative to the secrets directory", ErrInvalidPath)
+	}
+
+	return nil
+}
+
+func (p *file) Fetch(_ context.Context, path string) (string, error) {
+	if err := p.Validate(path); err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(filepath.Join(p.dir, path))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const vaultMaxResponseSize = 1 << 20
+
+// vault reads secrets from a HashiCorp Vault KV v2 secrets engine.
+// Paths are in the form "path/to/secret#key".
+type vault struct {
+	client    *http.Client
+	address   string
+	token     string
+	namespace string
+	mount     string
+}
+
+func newVault(address, token, namespace, mount string) *vault {
+	return &vault{
+		client:    &http.Client{Timeout: 30 * time.Second},
+		address:   strings.TrimSuffix(address, "/"),
+		token:     token,
+		namespace: namespace,
+		mount:     strings.Trim(mount, "/"),
+	}
+}
+
+func (v *vault) Validate(path string) error {
+	location, _ := splitKey(path)
+	if location == "" || strings.HasPrefix(location, "/") {
+		return fmt.Errorf("%w: vault path must be in the form 'path/to/secret#key'", ErrInvalidPath)
+	}
+
+	for _, segment := range strings.Split(location, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("%w: vault path contains an invalid segment", ErrInvalidPath)
+		}
+	}
+
+	return nil
+}
+
+func (v *vault) Fetch(ctx context.Context, path string) (string, error) {
+	if err := v.Validate(path); err != nil {
+		return "", err
+	}
+
+	location, key := splitKey(path)
+
+	segments := strings.Split(location, "/")
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+
+	secretURL := v.address + "/v1/" + v.mount + "/data/" + strings.Join(segments, "/")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, secretURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create vault request: %w", err)
+	}
+
+	req.Header.Set("X-Vault-Token", v.token)
+	if v.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.namespace)
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault responded with status code %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Data struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+
+	err = json.NewDecoder(io.LimitReader(resp.Body, vaultMaxResponseSize)).Decode(&body)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode vault response: %w", err)
+	}
+
+	// A deleted secret version is returned without data.
+	if body.Data.Data == nil {
+		return "", ErrNotFound
+	}
+
+	return pickKey(body.Data.Data, key)
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideProviders,
+)
+
+func ProvideProviders(config *types.Config) (*Providers, error) {
+	providers := make(map[enum.SecretProvider]Provider)
+
+	if config.Secrets.Vault.Address != "" {
+		providers[enum.SecretProviderVault] = newVault(
+			config.Secrets.Vault.Address,
+			config.Secrets.Vault.Token,
+			config.Secrets.Vault.Namespace,
+			config.Secrets.Vault.Mount,
+		)
+	}
+
+	if config.Secrets.AWS.Region != "" {
+		awsProvider, err := newAWSSecretsManager(config.Secrets.AWS.Region, config.Secrets.AWS.Endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create aws secrets manager provider: %w", err)
+		}
+		providers[enum.SecretProviderAWS] = awsProvider
+	}
+
+	if config.Secrets.Env.Prefix != "" {
+		providers[enum.SecretProviderEnv] = newEnv(config.Secrets.Env.Prefix)
+	}
+
+	if config.Secrets.File.Dir != "" {
+		providers[enum.SecretProviderFile] = newFile(config.Secrets.File.Dir)
+	}
+
+	return NewProviders(providers, config.Secrets.CacheTTL), nil
+}
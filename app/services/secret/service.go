@@ -18,27 +18,39 @@ import (
 	"context"
 
 	secretCtrl "github.com/harness/gitness/app/api/controller/secret"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/secret/external"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/secret"
+	"github.com/harness/gitness/types"
 
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 )
 
 type service struct {
-	secretStore    store.SecretStore
-	encrypter      encrypt.Encrypter
-	spacePathStore store.SpacePathStore
+	secretStore       store.SecretStore
+	encrypter         encrypt.Encrypter
+	spacePathStore    store.SpacePathStore
+	externalProviders *external.Providers
+	auditService      audit.Service
 }
 
 func NewService(
-	secretStore store.SecretStore, encrypter encrypt.Encrypter, spacePathStore store.SpacePathStore,
+	secretStore store.SecretStore,
+	encrypter encrypt.Encrypter,
+	spacePathStore store.SpacePathStore,
+	externalProviders *external.Providers,
+	auditService audit.Service,
 ) secret.Service {
 	return &service{
-		secretStore:    secretStore,
-		encrypter:      encrypter,
-		spacePathStore: spacePathStore,
+		secretStore:       secretStore,
+		encrypter:         encrypter,
+		spacePathStore:    spacePathStore,
+		externalProviders: externalProviders,
+		auditService:      auditService,
 	}
 }
 
@@ -53,10 +65,54 @@ func (s *service) DecryptSecret(ctx context.Context, spacePath string, secretIde
 		log.Error().Msgf("failed to find secret: %v", err)
 		return "", errors.Wrap(err, "failed to find secret")
 	}
-	sec, err = secretCtrl.Dec(s.encrypter, sec)
+	return s.ResolveSecret(ctx, bootstrap.NewSystemServiceSession().Principal, sec)
+}
+
+func (s *service) ResolveSecret(
+	ctx context.Context,
+	principal types.Principal,
+	sec *types.Secret,
+) (string, error) {
+	if !sec.Provider.IsExternal() {
+		decrypted, err := secretCtrl.Dec(s.encrypter, sec)
+		if err != nil {
+			log.Error().Msgf("could not decrypt secret: %v", err)
+			return "", errors.Wrap(err, "failed to decrypt secret")
+		}
+		return decrypted.Data, nil
+	}
+
+	value, err := s.externalProviders.Fetch(ctx, sec.Provider, sec.ExternalPath)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Str("secret", sec.Identifier).
+			Str("provider", string(sec.Provider)).
+			Msg("could not resolve secret from external provider")
+		return "", errors.Wrap(err, "failed to resolve secret from external provider")
+	}
+
+	s.auditAccess(ctx, principal, sec)
+
+	return value, nil
+}
+
+func (s *service) auditAccess(ctx context.Context, principal types.Principal, sec *types.Secret) {
+	spacePath, err := s.spacePathStore.FindPrimaryBySpaceID(ctx, sec.SpaceID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find space path for secret access audit log")
+		return
+	}
+
+	err = s.auditService.Log(ctx,
+		principal,
+		audit.NewResource(audit.ResourceTypeSecret, sec.Identifier,
+			audit.SecretProvider, string(sec.Provider),
+			audit.SecretExternalPath, sec.ExternalPath,
+		),
+		audit.ActionAccessed,
+		spacePath.Value,
+	)
 	if err != nil {
-		log.Error().Msgf("could not decrypt secret: %v", err)
-		return "", errors.Wrap(err, "failed to decrypt secret")
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for secret access operation: %s", err)
 	}
-	return sec.Data, nil
 }
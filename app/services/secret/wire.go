@@ -15,7 +15,9 @@
 package secret
 
 import (
+	"github.com/harness/gitness/app/services/secret/external"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/secret"
 
@@ -27,7 +29,11 @@ var WireSet = wire.NewSet(
 )
 
 func ProvideSecretService(
-	secretStore store.SecretStore, encrypter encrypt.Encrypter, spacePathStore store.SpacePathStore,
+	secretStore store.SecretStore,
+	encrypter encrypt.Encrypter,
+	spacePathStore store.SpacePathStore,
+	externalProviders *external.Providers,
+	auditService audit.Service,
 ) secret.Service {
-	return NewService(secretStore, encrypter, spacePathStore)
+	return NewService(secretStore, encrypter, spacePathStore, externalProviders, auditService)
 }
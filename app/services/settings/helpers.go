@@ -60,3 +60,24 @@ func RepoGetRequired[T any](
 
 	return out, nil
 }
+
+// SpaceGet is a helper method for getting a setting of a specific type for a space.
+func SpaceGet[T any](
+	ctx context.Context,
+	s *Service,
+	spaceID int64,
+	key Key,
+	dflt T,
+) (T, error) {
+	var out T
+	ok, err := s.SpaceGet(ctx, spaceID, key, &out)
+	if err != nil {
+		return out, err
+	}
+
+	if !ok {
+		return dflt, nil
+	}
+
+	return out, nil
+}
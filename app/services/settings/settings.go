@@ -24,4 +24,10 @@ var (
 	DefaultFileSizeLimit             = int64(1e+8) // 100 MB
 	KeyInstallID                 Key = "install_id"
 	DefaultInstallID                 = string("")
+	// KeyNotificationSlackWebhookURL [string] is the Slack incoming webhook URL
+	// to which space notifications are delivered.
+	KeyNotificationSlackWebhookURL Key = "notification_slack_webhook_url"
+	// KeyNotificationTeamsWebhookURL [string] is the Microsoft Teams incoming webhook URL
+	// to which space notifications are delivered.
+	KeyNotificationTeamsWebhookURL Key = "notification_teams_webhook_url"
 )
@@ -0,0 +1,260 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/pipeline/commit"
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/go-scm/scm"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeCronTrigger   = "gitness:trigger:cron"
+	jobGroupCronTrigger  = "gitness:trigger:cron"
+	jobUIDPrefixCron     = "gitness:trigger:cron:"
+	jobMaxDurCronTrigger = time.Minute
+
+	// jobTypeCronSync periodically reconciles the recurring jobs with the cron triggers
+	// to clean up after triggers that were removed along with their pipeline or repository.
+	jobTypeCronSync   = "gitness:trigger:cron-sync"
+	jobCronCronSync   = "30 * * * *" // every hour at 30 minutes
+	jobMaxDurCronSync = 5 * time.Minute
+)
+
+// CronScheduler schedules pipeline executions of cron triggers.
+// Every enabled cron trigger has its own recurring job, so only one replica fires each run.
+type CronScheduler struct {
+	scheduler     *job.Scheduler
+	triggerStore  store.TriggerStore
+	pipelineStore store.PipelineStore
+	repoStore     store.RepoStore
+	commitSvc     commit.Service
+	triggerSvc    triggerer.Triggerer
+}
+
+func NewCronScheduler(
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	triggerStore store.TriggerStore,
+	pipelineStore store.PipelineStore,
+	repoStore store.RepoStore,
+	commitSvc commit.Service,
+	triggerSvc triggerer.Triggerer,
+) (*CronScheduler, error) {
+	s := &CronScheduler{
+		scheduler:     scheduler,
+		triggerStore:  triggerStore,
+		pipelineStore: pipelineStore,
+		repoStore:     repoStore,
+		commitSvc:     commitSvc,
+		triggerSvc:    triggerSvc,
+	}
+
+	if err := executor.Register(jobTypeCronTrigger, s); err != nil {
+		return nil, fmt.Errorf("failed to register cron trigger job handler: %w", err)
+	}
+
+	if err := executor.Register(jobTypeCronSync, cronSyncHandler{s}); err != nil {
+		return nil, fmt.Errorf("failed to register cron trigger sync job handler: %w", err)
+	}
+
+	return s, nil
+}
+
+// Register registers the recurring job that keeps the cron trigger jobs in sync with the triggers.
+func (s *CronScheduler) Register(ctx context.Context) error {
+	err := s.scheduler.AddRecurring(ctx, jobTypeCronSync, jobTypeCronSync, jobCronCronSync, jobMaxDurCronSync)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for cron trigger sync: %w", err)
+	}
+
+	return nil
+}
+
+// Schedule adds, updates or removes the recurring job of the trigger,
+// depending on whether it's an enabled cron trigger.
+func (s *CronScheduler) Schedule(ctx context.Context, trigger *types.Trigger) error {
+	if trigger.Type != enum.TriggerCron || trigger.Disabled {
+		return s.Unschedule(ctx, trigger.ID)
+	}
+
+	timezone := trigger.CronTimezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+
+	err := s.scheduler.AddRecurringInGroup(ctx,
+		jobGroupCronTrigger,
+		cronJobUID(trigger.ID),
+		jobTypeCronTrigger,
+		job.CronInTimezone(trigger.Cron, timezone),
+		strconv.FormatInt(trigger.ID, 10),
+		jobMaxDurCronTrigger,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule cron trigger: %w", err)
+	}
+
+	return nil
+}
+
+// Unschedule removes the recurring job of the trigger.
+func (s *CronScheduler) Unschedule(ctx context.Context, triggerID int64) error {
+	err := s.scheduler.PurgeJobByUID(ctx, cronJobUID(triggerID))
+	if err != nil {
+		return fmt.Errorf("failed to unschedule cron trigger: %w", err)
+	}
+
+	return nil
+}
+
+// Handle fires the cron trigger whose ID is provided as the job input.
+func (s *CronScheduler) Handle(ctx context.Context, input string, _ job.ProgressReporter) (string, error) {
+	triggerID, err := strconv.ParseInt(input, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid cron trigger job input %q: %w", input, err)
+	}
+
+	trigger, err := s.triggerStore.Find(ctx, triggerID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		// the trigger has been removed, the job is cleaned up by the sync job.
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find cron trigger: %w", err)
+	}
+
+	if trigger.Type != enum.TriggerCron || trigger.Disabled {
+		return "", nil
+	}
+
+	pipeline, err := s.pipelineStore.Find(ctx, trigger.PipelineID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find pipeline of cron trigger: %w", err)
+	}
+
+	if pipeline.Disabled {
+		return "", nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, pipeline.RepoID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find repo of cron trigger: %w", err)
+	}
+
+	branch := trigger.CronBranch
+	if branch == "" {
+		branch = repo.DefaultBranch
+	}
+	ref := scm.ExpandRef(branch, "refs/heads")
+
+	commit, err := s.commitSvc.FindRef(ctx, repo, ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to find commit of branch %q: %w", branch, err)
+	}
+
+	hook := &triggerer.Hook{
+		Trigger:     enum.TriggerCron,
+		TriggeredBy: bootstrap.NewSystemServiceSession().Principal.ID,
+		Cron:        trigger.Identifier,
+		Ref:         ref,
+		Source:      branch,
+		Target:      branch,
+		Before:      commit.SHA,
+		After:       commit.SHA,
+		Title:       commit.Title,
+		Message:     commit.Message,
+		AuthorLogin: commit.Author.Identity.Name,
+		AuthorName:  commit.Author.Identity.Name,
+		AuthorEmail: commit.Author.Identity.Email,
+		Timestamp:   commit.Author.When.UnixMilli(),
+		Params:      map[string]string{},
+	}
+
+	execution, err := s.triggerSvc.Trigger(ctx, pipeline, hook)
+	if err != nil {
+		return "", fmt.Errorf("failed to trigger pipeline execution: %w", err)
+	}
+
+	if execution == nil {
+		return "skipped", nil
+	}
+
+	return fmt.Sprintf("execution %d", execution.Number), nil
+}
+
+// sync schedules all enabled cron triggers and removes the jobs of the triggers that no longer exist.
+// Jobs of disabled triggers are removed by Schedule.
+func (s *CronScheduler) sync(ctx context.Context) error {
+	triggers, err := s.triggerStore.ListAllCron(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list cron triggers: %w", err)
+	}
+
+	jobUIDs, err := s.scheduler.ListJobUIDsByGroupID(ctx, jobGroupCronTrigger)
+	if err != nil {
+		return fmt.Errorf("failed to list cron trigger jobs: %w", err)
+	}
+
+	stale := make(map[string]struct{}, len(jobUIDs))
+	for _, jobUID := range jobUIDs {
+		stale[jobUID] = struct{}{}
+	}
+
+	for _, trigger := range triggers {
+		delete(stale, cronJobUID(trigger.ID))
+
+		if err := s.Schedule(ctx, trigger); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("trigger_id", trigger.ID).
+				Msg("failed to schedule cron trigger")
+		}
+	}
+
+	for jobUID := range stale {
+		if err := s.scheduler.PurgeJobByUID(ctx, jobUID); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Str("job_uid", jobUID).
+				Msg("failed to remove job of deleted cron trigger")
+		}
+	}
+
+	return nil
+}
+
+func cronJobUID(triggerID int64) string {
+	return jobUIDPrefixCron + strconv.FormatInt(triggerID, 10)
+}
+
+// cronSyncHandler is the job handler that reconciles cron trigger jobs with the cron triggers.
+type cronSyncHandler struct {
+	s *CronScheduler
+}
+
+func (h cronSyncHandler) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	return "", h.s.sync(ctx)
+}
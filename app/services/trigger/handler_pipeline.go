@@ -0,0 +1,205 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/bootstrap"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/go-scm/scm"
+	"github.com/hashicorp/go-multierror"
+	"github.com/rs/zerolog/log"
+)
+
+// maxPipelineTriggerDepth is the maximum length of a chain of executions fired by pipeline triggers.
+const maxPipelineTriggerDepth = 10
+
+// handleEventPipelineExecuted fires the pipeline triggers of the pipeline of the completed execution.
+func (s *Service) handleEventPipelineExecuted(ctx context.Context,
+	event *events.Event[*pipelineevents.ExecutedPayload]) error {
+	triggers, err := s.triggerStore.ListAllEnabledByUpstream(ctx, event.Payload.PipelineID)
+	if err != nil {
+		return fmt.Errorf("failed to list pipeline triggers: %w", err)
+	}
+
+	if len(triggers) == 0 {
+		return nil
+	}
+
+	upstream, err := s.executionStore.FindByNumber(ctx, event.Payload.PipelineID, event.Payload.ExecutionNum)
+	if err != nil {
+		return fmt.Errorf("failed to find upstream execution: %w", err)
+	}
+
+	chain, err := s.upstreamPipelines(ctx, upstream)
+	if err != nil {
+		return fmt.Errorf("failed to find upstream executions: %w", err)
+	}
+
+	var errs error
+	for _, t := range triggers {
+		if !matchesUpstreamStatus(t, upstream.Status) {
+			continue
+		}
+
+		log := log.Ctx(ctx).With().
+			Int64("trigger_id", t.ID).
+			Int64("upstream_execution_id", upstream.ID).
+			Logger()
+
+		// loop protection: pipelines are fired at most once per chain of executions.
+		if _, ok := chain[t.PipelineID]; ok {
+			log.Warn().Msg("skipping pipeline trigger, the pipeline is already in the chain of upstream executions")
+			continue
+		}
+		if len(chain) >= maxPipelineTriggerDepth {
+			log.Warn().Msgf("skipping pipeline trigger, the chain of upstream executions exceeds %d executions",
+				maxPipelineTriggerDepth)
+			continue
+		}
+
+		if err := s.triggerDownstream(ctx, t, upstream); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// upstreamPipelines returns the IDs of the pipelines of the execution and of the executions that fired it.
+func (s *Service) upstreamPipelines(
+	ctx context.Context,
+	execution *types.Execution,
+) (map[int64]struct{}, error) {
+	pipelines := map[int64]struct{}{execution.PipelineID: {}}
+	for execution.Trigger == enum.TriggerPipeline && execution.Parent != 0 &&
+		len(pipelines) <= maxPipelineTriggerDepth {
+		var err error
+		execution, err = s.executionStore.Find(ctx, execution.Parent)
+		if err != nil {
+			return nil, err
+		}
+		pipelines[execution.PipelineID] = struct{}{}
+	}
+
+	return pipelines, nil
+}
+
+// triggerDownstream fires the pipeline of the trigger for the upstream execution.
+// Pipelines in the same repo run on the commit of the upstream execution,
+// pipelines in other repos run on the latest commit of their default branch.
+func (s *Service) triggerDownstream(
+	ctx context.Context,
+	trigger *types.Trigger,
+	upstream *types.Execution,
+) error {
+	pipeline, err := s.pipelineStore.Find(ctx, trigger.PipelineID)
+	if err != nil {
+		return fmt.Errorf("failed to find pipeline of pipeline trigger: %w", err)
+	}
+
+	// Don't fire triggers for disabled pipelines
+	if pipeline.Disabled {
+		return nil
+	}
+
+	hook := &triggerer.Hook{
+		Parent:      upstream.ID,
+		Trigger:     enum.TriggerPipeline,
+		TriggeredBy: bootstrap.NewSystemServiceSession().Principal.ID,
+		Params:      downstreamParams(trigger, upstream),
+	}
+
+	if pipeline.RepoID == upstream.RepoID {
+		hook.Ref = upstream.Ref
+		hook.Source = upstream.Source
+		hook.Target = upstream.Target
+		hook.Before = upstream.Before
+		hook.After = upstream.After
+		hook.Title = upstream.Title
+		hook.Message = upstream.Message
+		hook.AuthorLogin = upstream.Author
+		hook.AuthorName = upstream.AuthorName
+		hook.AuthorEmail = upstream.AuthorEmail
+		hook.AuthorAvatar = upstream.AuthorAvatar
+		hook.Timestamp = upstream.Timestamp
+	} else {
+		repo, err := s.repoStore.Find(ctx, pipeline.RepoID)
+		if err != nil {
+			return fmt.Errorf("failed to find repo of pipeline trigger: %w", err)
+		}
+
+		hook.Ref = scm.ExpandRef(repo.DefaultBranch, "refs/heads")
+		hook.Source = repo.DefaultBranch
+		hook.Target = repo.DefaultBranch
+
+		commit, err := s.commitSvc.FindRef(ctx, repo, hook.Ref)
+		if err != nil {
+			return fmt.Errorf("failed to find commit of branch %q: %w", repo.DefaultBranch, err)
+		}
+
+		hook.Before = commit.SHA
+		hook.After = commit.SHA
+		hook.Title = commit.Title
+		hook.Message = commit.Message
+		hook.AuthorLogin = commit.Author.Identity.Name
+		hook.AuthorName = commit.Author.Identity.Name
+		hook.AuthorEmail = commit.Author.Identity.Email
+		hook.Timestamp = commit.Author.When.UnixMilli()
+	}
+
+	_, err = s.triggerSvc.Trigger(ctx, pipeline, hook)
+	if err != nil {
+		return fmt.Errorf("failed to trigger pipeline execution: %w", err)
+	}
+
+	return nil
+}
+
+// downstreamParams returns the params of the downstream execution.
+// The params of the upstream execution are passed on, overridden by the params of the trigger.
+func downstreamParams(trigger *types.Trigger, upstream *types.Execution) map[string]string {
+	params := make(map[string]string, len(upstream.Params)+len(trigger.Params))
+	for k, v := range upstream.Params {
+		params[k] = v
+	}
+	for k, v := range trigger.Params {
+		params[k] = v
+	}
+
+	return params
+}
+
+// matchesUpstreamStatus returns true if the pipeline trigger fires on the status of the upstream execution.
+func matchesUpstreamStatus(trigger *types.Trigger, status enum.CIStatus) bool {
+	if len(trigger.UpstreamStatuses) == 0 {
+		return status == enum.CIStatusSuccess
+	}
+
+	for _, s := range trigger.UpstreamStatuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,326 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeTriggerStore struct {
+	store.TriggerStore
+	triggers []*types.Trigger
+}
+
+func (s *fakeTriggerStore) ListAllEnabledByUpstream(_ context.Context, id int64) ([]*types.Trigger, error) {
+	var triggers []*types.Trigger
+	for _, t := range s.triggers {
+		if t.UpstreamPipelineID == id {
+			triggers = append(triggers, t)
+		}
+	}
+	return triggers, nil
+}
+
+type fakeExecutionStore struct {
+	store.ExecutionStore
+	executions map[int64]*types.Execution
+}
+
+func (s *fakeExecutionStore) Find(_ context.Context, id int64) (*types.Execution, error) {
+	execution, ok := s.executions[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return execution, nil
+}
+
+func (s *fakeExecutionStore) FindByNumber(_ context.Context, pipelineID int64, num int64) (*types.Execution, error) {
+	execution, ok := s.executions[num]
+	if !ok || execution.PipelineID != pipelineID {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return execution, nil
+}
+
+// fakePipelineStore records the downstream pipelines the handler tries to fire.
+// The pipelines are disabled, so the handler stops before creating the executions.
+type fakePipelineStore struct {
+	store.PipelineStore
+	fired []int64
+}
+
+func (s *fakePipelineStore) Find(_ context.Context, id int64) (*types.Pipeline, error) {
+	s.fired = append(s.fired, id)
+	return &types.Pipeline{ID: id, Disabled: true}, nil
+}
+
+// executionChain returns n executions, each fired by a pipeline trigger of the previous one.
+// The execution i has ID and number i and belongs to the pipeline 100+i.
+func executionChain(n int) map[int64]*types.Execution {
+	executions := make(map[int64]*types.Execution, n)
+	for i := int64(1); i <= int64(n); i++ {
+		execution := &types.Execution{
+			ID:         i,
+			Number:     i,
+			PipelineID: 100 + i,
+			Trigger:    enum.TriggerPipeline,
+			Parent:     i - 1,
+			Status:     enum.CIStatusSuccess,
+		}
+		if i == 1 {
+			execution.Trigger = enum.TriggerHook
+		}
+		executions[i] = execution
+	}
+	return executions
+}
+
+func TestService_HandleEventPipelineExecuted(t *testing.T) {
+	tests := []struct {
+		name     string
+		chainLen int
+		status   enum.CIStatus
+		triggers []*types.Trigger
+		want     []int64
+	}{
+		{
+			name:     "fires on success by default",
+			chainLen: 1,
+			status:   enum.CIStatusSuccess,
+			triggers: []*types.Trigger{{PipelineID: 200, UpstreamPipelineID: 101}},
+			want:     []int64{200},
+		},
+		{
+			name:     "doesn't fire on failure by default",
+			chainLen: 1,
+			status:   enum.CIStatusFailure,
+			triggers: []*types.Trigger{{PipelineID: 200, UpstreamPipelineID: 101}},
+			want:     nil,
+		},
+		{
+			name:     "fires on configured statuses",
+			chainLen: 1,
+			status:   enum.CIStatusFailure,
+			triggers: []*types.Trigger{
+				{PipelineID: 200, UpstreamPipelineID: 101, UpstreamStatuses: []enum.CIStatus{enum.CIStatusFailure}},
+				{PipelineID: 201, UpstreamPipelineID: 101, UpstreamStatuses: []enum.CIStatus{enum.CIStatusSuccess}},
+			},
+			want: []int64{200},
+		},
+		{
+			name:     "skips pipelines already in the chain",
+			chainLen: 3,
+			status:   enum.CIStatusSuccess,
+			triggers: []*types.Trigger{
+				{PipelineID: 101, UpstreamPipelineID: 103},
+				{PipelineID: 102, UpstreamPipelineID: 103},
+				{PipelineID: 200, UpstreamPipelineID: 103},
+			},
+			want: []int64{200},
+		},
+		{
+			name:     "fires below the depth limit",
+			chainLen: maxPipelineTriggerDepth - 1,
+			status:   enum.CIStatusSuccess,
+			triggers: []*types.Trigger{{PipelineID: 200, UpstreamPipelineID: 100 + maxPipelineTriggerDepth - 1}},
+			want:     []int64{200},
+		},
+		{
+			name:     "skips at the depth limit",
+			chainLen: maxPipelineTriggerDepth,
+			status:   enum.CIStatusSuccess,
+			triggers: []*types.Trigger{{PipelineID: 200, UpstreamPipelineID: 100 + maxPipelineTriggerDepth}},
+			want:     nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			executions := executionChain(test.chainLen)
+			upstream := executions[int64(test.chainLen)]
+			upstream.Status = test.status
+
+			pipelineStore := &fakePipelineStore{}
+			s := &Service{
+				triggerStore:   &fakeTriggerStore{triggers: test.triggers},
+				executionStore: &fakeExecutionStore{executions: executions},
+				pipelineStore:  pipelineStore,
+			}
+
+			err := s.handleEventPipelineExecuted(context.Background(), &events.Event[*pipelineevents.ExecutedPayload]{
+				Payload: &pipelineevents.ExecutedPayload{
+					PipelineID:   upstream.PipelineID,
+					ExecutionNum: upstream.Number,
+					Status:       test.status,
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !slices.Equal(pipelineStore.fired, test.want) {
+				t.Errorf("expected fired pipelines %v, got %v", test.want, pipelineStore.fired)
+			}
+		})
+	}
+}
+
+func TestService_UpstreamPipelines(t *testing.T) {
+	tests := []struct {
+		name       string
+		executions map[int64]*types.Execution
+		upstream   int64
+		want       []int64
+	}{
+		{
+			name:       "single execution",
+			executions: executionChain(1),
+			upstream:   1,
+			want:       []int64{101},
+		},
+		{
+			name:       "chain of executions",
+			executions: executionChain(3),
+			upstream:   3,
+			want:       []int64{101, 102, 103},
+		},
+		{
+			name: "stops at executions not fired by a pipeline trigger",
+			executions: func() map[int64]*types.Execution {
+				executions := executionChain(3)
+				executions[2].Trigger = enum.TriggerHook
+				return executions
+			}(),
+			upstream: 3,
+			want:     []int64{102, 103},
+		},
+		{
+			name:       "stops above the depth limit",
+			executions: executionChain(2 * maxPipelineTriggerDepth),
+			upstream:   2 * maxPipelineTriggerDepth,
+			want: func() []int64 {
+				var want []int64
+				for i := int64(maxPipelineTriggerDepth); i <= 2*maxPipelineTriggerDepth; i++ {
+					want = append(want, 100+i)
+				}
+				return want
+			}(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Service{executionStore: &fakeExecutionStore{executions: test.executions}}
+
+			chain, err := s.upstreamPipelines(context.Background(), test.executions[test.upstream])
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := make([]int64, 0, len(chain))
+			for id := range chain {
+				got = append(got, id)
+			}
+			slices.Sort(got)
+
+			if !slices.Equal(got, test.want) {
+				t.Errorf("expected pipelines %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestMatchesUpstreamStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []enum.CIStatus
+		status   enum.CIStatus
+		want     bool
+	}{
+		{name: "default success", statuses: nil, status: enum.CIStatusSuccess, want: true},
+		{name: "default failure", statuses: nil, status: enum.CIStatusFailure, want: false},
+		{name: "default killed", statuses: nil, status: enum.CIStatusKilled, want: false},
+		{
+			name:     "configured match",
+			statuses: []enum.CIStatus{enum.CIStatusFailure, enum.CIStatusKilled},
+			status:   enum.CIStatusKilled,
+			want:     true,
+		},
+		{
+			name:     "configured mismatch",
+			statuses: []enum.CIStatus{enum.CIStatusFailure},
+			status:   enum.CIStatusSuccess,
+			want:     false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := matchesUpstreamStatus(&types.Trigger{UpstreamStatuses: test.statuses}, test.status)
+			if got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestDownstreamParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		upstream map[string]string
+		trigger  map[string]string
+		want     map[string]string
+	}{
+		{
+			name: "no params",
+			want: map[string]string{},
+		},
+		{
+			name:     "upstream params are passed on",
+			upstream: map[string]string{"a": "1"},
+			want:     map[string]string{"a": "1"},
+		},
+		{
+			name:    "trigger params are added",
+			trigger: map[string]string{"b": "2"},
+			want:    map[string]string{"b": "2"},
+		},
+		{
+			name:     "trigger params override upstream params",
+			upstream: map[string]string{"a": "1", "b": "upstream"},
+			trigger:  map[string]string{"b": "trigger", "c": "3"},
+			want:     map[string]string{"a": "1", "b": "trigger", "c": "3"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := downstreamParams(&types.Trigger{Params: test.trigger}, &types.Execution{Params: test.upstream})
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected params %v, got %v", test.want, got)
+			}
+		})
+	}
+}
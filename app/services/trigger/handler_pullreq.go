@@ -102,6 +102,26 @@ func (s *Service) handleEventPullReqMerged(
 	return s.trigger(ctx, event.Payload.SourceRepoID, enum.TriggerActionPullReqMerged, hook)
 }
 
+func (s *Service) handleEventPullReqMergeQueueCommitUpdated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergeQueueCommitUpdatedPayload],
+) error {
+	hook := &triggerer.Hook{
+		Trigger:     enum.TriggerHook,
+		Action:      enum.TriggerActionPullReqMergeQueueUpdated,
+		TriggeredBy: bootstrap.NewSystemServiceSession().Principal.ID,
+		After:       event.Payload.MergeSHA,
+	}
+	err := s.augmentPullReqInfo(ctx, hook, event.Payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("could not augment pull request info: %w", err)
+	}
+	// the speculative merge commit lives in the target repository on top of the target branch.
+	hook.Before = event.Payload.BaseSHA
+	hook.Ref = fmt.Sprintf("refs/pullreq/%d/queue", event.Payload.Number)
+	return s.trigger(ctx, event.Payload.TargetRepoID, enum.TriggerActionPullReqMergeQueueUpdated, hook)
+}
+
 // augmentPullReqInfo adds in information into the hook pertaining to the pull request
 // by querying the database.
 func (s *Service) augmentPullReqInfo(
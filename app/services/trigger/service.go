@@ -21,6 +21,7 @@ import (
 	"time"
 
 	gitevents "github.com/harness/gitness/app/events/git"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/triggerer"
@@ -61,12 +62,13 @@ func (c *Config) Prepare() error {
 }
 
 type Service struct {
-	triggerStore  store.TriggerStore
-	pullReqStore  store.PullReqStore
-	repoStore     store.RepoStore
-	pipelineStore store.PipelineStore
-	triggerSvc    triggerer.Triggerer
-	commitSvc     commit.Service
+	triggerStore   store.TriggerStore
+	pullReqStore   store.PullReqStore
+	repoStore      store.RepoStore
+	pipelineStore  store.PipelineStore
+	executionStore store.ExecutionStore
+	triggerSvc     triggerer.Triggerer
+	commitSvc      commit.Service
 }
 
 func New(
@@ -76,22 +78,25 @@ func New(
 	pullReqStore store.PullReqStore,
 	repoStore store.RepoStore,
 	pipelineStore store.PipelineStore,
+	executionStore store.ExecutionStore,
 	triggerSvc triggerer.Triggerer,
 	commitSvc commit.Service,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	pipelineEvReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided trigger service config is invalid: %w", err)
 	}
 
 	service := &Service{
-		triggerStore:  triggerStore,
-		pullReqStore:  pullReqStore,
-		repoStore:     repoStore,
-		commitSvc:     commitSvc,
-		pipelineStore: pipelineStore,
-		triggerSvc:    triggerSvc,
+		triggerStore:   triggerStore,
+		pullReqStore:   pullReqStore,
+		repoStore:      repoStore,
+		commitSvc:      commitSvc,
+		pipelineStore:  pipelineStore,
+		executionStore: executionStore,
+		triggerSvc:     triggerSvc,
 	}
 
 	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
@@ -132,6 +137,7 @@ func New(
 			_ = r.RegisterReopened(service.handleEventPullReqReopened)
 			_ = r.RegisterClosed(service.handleEventPullReqClosed)
 			_ = r.RegisterMerged(service.handleEventPullReqMerged)
+			_ = r.RegisterMergeQueueCommitUpdated(service.handleEventPullReqMergeQueueCommitUpdated)
 
 			return nil
 		})
@@ -139,6 +145,25 @@ func New(
 		return nil, fmt.Errorf("failed to launch pr events reader: %w", err)
 	}
 
+	_, err = pipelineEvReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pipelineevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					// retries could fire the downstream pipelines more than once
+					stream.WithMaxRetries(0),
+				))
+
+			_ = r.RegisterExecuted(service.handleEventPipelineExecuted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pipeline events reader: %w", err)
+	}
+
 	return service, nil
 }
 
@@ -18,17 +18,20 @@ import (
 	"context"
 
 	gitevents "github.com/harness/gitness/app/events/git"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/triggerer"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
 
 	"github.com/google/wire"
 )
 
 var WireSet = wire.NewSet(
 	ProvideService,
+	ProvideCronScheduler,
 )
 
 func ProvideService(
@@ -39,10 +42,24 @@ func ProvideService(
 	pullReqStore store.PullReqStore,
 	repoStore store.RepoStore,
 	pipelineStore store.PipelineStore,
+	executionStore store.ExecutionStore,
 	triggerSvc triggerer.Triggerer,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	pullReqEvFactory *events.ReaderFactory[*pullreqevents.Reader],
+	pipelineEvFactory *events.ReaderFactory[*pipelineevents.Reader],
 ) (*Service, error) {
-	return New(ctx, config, triggerStore, pullReqStore, repoStore, pipelineStore, triggerSvc,
-		commitSvc, gitReaderFactory, pullReqEvFactory)
+	return New(ctx, config, triggerStore, pullReqStore, repoStore, pipelineStore, executionStore, triggerSvc,
+		commitSvc, gitReaderFactory, pullReqEvFactory, pipelineEvFactory)
+}
+
+func ProvideCronScheduler(
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	triggerStore store.TriggerStore,
+	pipelineStore store.PipelineStore,
+	repoStore store.RepoStore,
+	commitSvc commit.Service,
+	triggerSvc triggerer.Triggerer,
+) (*CronScheduler, error) {
+	return NewCronScheduler(scheduler, executor, triggerStore, pipelineStore, repoStore, commitSvc, triggerSvc)
 }
@@ -22,6 +22,7 @@ import (
 	"github.com/harness/gitness/app/services/infraprovider"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/mergequeue"
 	"github.com/harness/gitness/app/services/metric"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/pullreq"
@@ -40,7 +41,10 @@ var WireSet = wire.NewSet(
 type Services struct {
 	Webhook               *webhook.Service
 	PullReq               *pullreq.Service
+	PullReqAutoMerge      *pullreq.AutoMergeService
+	PullReqReviewers      *pullreq.DefaultReviewersService
 	Trigger               *trigger.Service
+	CronTrigger           *trigger.CronScheduler
 	JobScheduler          *job.Scheduler
 	MetricCollector       *metric.Collector
 	RepoSizeCalculator    *repo.SizeCalculator
@@ -48,6 +52,7 @@ type Services struct {
 	Cleanup               *cleanup.Service
 	Notification          *notification.Service
 	Keywordsearch         *keywordsearch.Service
+	MergeQueue            *mergequeue.Service
 	GitspaceService       *GitspaceServices
 	Instrumentation       instrument.Service
 	instrumentConsumer    instrument.Consumer
@@ -78,7 +83,10 @@ func ProvideGitspaceServices(
 func ProvideServices(
 	webhooksSvc *webhook.Service,
 	pullReqSvc *pullreq.Service,
+	pullReqAutoMergeSvc *pullreq.AutoMergeService,
+	pullReqReviewersSvc *pullreq.DefaultReviewersService,
 	triggerSvc *trigger.Service,
+	cronTriggerSvc *trigger.CronScheduler,
 	jobScheduler *job.Scheduler,
 	metricCollector *metric.Collector,
 	repoSizeCalculator *repo.SizeCalculator,
@@ -86,6 +94,7 @@ func ProvideServices(
 	cleanupSvc *cleanup.Service,
 	notificationSvc *notification.Service,
 	keywordsearchSvc *keywordsearch.Service,
+	mergeQueueSvc *mergequeue.Service,
 	gitspaceSvc *GitspaceServices,
 	instrumentation instrument.Service,
 	instrumentConsumer instrument.Consumer,
@@ -94,7 +103,10 @@ func ProvideServices(
 	return Services{
 		Webhook:               webhooksSvc,
 		PullReq:               pullReqSvc,
+		PullReqAutoMerge:      pullReqAutoMergeSvc,
+		PullReqReviewers:      pullReqReviewersSvc,
 		Trigger:               triggerSvc,
+		CronTrigger:           cronTriggerSvc,
 		JobScheduler:          jobScheduler,
 		MetricCollector:       metricCollector,
 		RepoSizeCalculator:    repoSizeCalculator,
@@ -102,6 +114,7 @@ func ProvideServices(
 		Cleanup:               cleanupSvc,
 		Notification:          notificationSvc,
 		Keywordsearch:         keywordsearchSvc,
+		MergeQueue:            mergeQueueSvc,
 		GitspaceService:       gitspaceSvc,
 		Instrumentation:       instrumentation,
 		instrumentConsumer:    instrumentConsumer,
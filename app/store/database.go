@@ -284,6 +284,12 @@ type (
 
 		// ListSizeInfos returns a list of all active repo sizes.
 		ListSizeInfos(ctx context.Context) ([]*types.RepositorySizeInfo, error)
+
+		// ListForks returns all forks of the repository, including the deleted ones.
+		ListForks(ctx context.Context, upstreamID int64) ([]*types.Repository, error)
+
+		// ResetForkID removes the link between the fork and its upstream repository.
+		ResetForkID(ctx context.Context, id int64) error
 	}
 
 	// SettingsStore defines the settings storage.
@@ -497,6 +503,10 @@ type (
 
 		// List returns all pull request reviewers for the pull request.
 		List(ctx context.Context, prID int64) ([]*types.PullReqReviewer, error)
+
+		// ListWorkload returns the review statistics of the provided principals.
+		// Principals that were never added as reviewers are not included in the result.
+		ListWorkload(ctx context.Context, principalIDs []int64) ([]types.PullReqReviewerWorkload, error)
 	}
 
 	// UserGroupReviewersStore defines the pull request usergroup reviewer storage.
@@ -674,6 +684,14 @@ type (
 		) (map[sha.SHA]types.CheckCountSummary, error)
 	}
 
+	CheckAnnotationStore interface {
+		// ReplaceForCheck replaces all annotations of a status check with the provided ones.
+		ReplaceForCheck(ctx context.Context, checkID int64, annotations []*types.CheckAnnotation) error
+
+		// List returns the annotations of all status checks reported for a specific commit in a repo.
+		List(ctx context.Context, repoID int64, commitSHA string) ([]*types.CheckAnnotation, error)
+	}
+
 	GitspaceConfigStore interface {
 		// Find returns a gitspace config given a ID from the datastore.
 		Find(ctx context.Context, id int64, includeDeleted bool) (*types.GitspaceConfig, error)
@@ -914,6 +932,9 @@ type (
 	}
 
 	StepStore interface {
+		// Find returns a step from the datastore by ID.
+		Find(ctx context.Context, id int64) (*types.Step, error)
+
 		// FindByNumber returns a step from the datastore by number.
 		FindByNumber(ctx context.Context, stageID int64, stepNum int) (*types.Step, error)
 
@@ -998,6 +1019,9 @@ type (
 	}
 
 	TriggerStore interface {
+		// Find returns a trigger given its ID.
+		Find(ctx context.Context, id int64) (*types.Trigger, error)
+
 		// FindByIdentifier returns a trigger given a pipeline and a trigger identifier.
 		FindByIdentifier(ctx context.Context, pipelineID int64, identifier string) (*types.Trigger, error)
 
@@ -1025,6 +1049,14 @@ type (
 		// ListAllEnabled lists all enabled triggers for a given repo without pagination.
 		// It's used only internally to trigger builds.
 		ListAllEnabled(ctx context.Context, repoID int64) ([]*types.Trigger, error)
+
+		// ListAllCron lists all cron triggers across all repos without pagination.
+		// It's used only internally to schedule builds.
+		ListAllCron(ctx context.Context) ([]*types.Trigger, error)
+
+		// ListAllEnabledByUpstream lists all enabled pipeline triggers fired by the given pipeline
+		// without pagination. It's used only internally to trigger builds.
+		ListAllEnabledByUpstream(ctx context.Context, upstreamPipelineID int64) ([]*types.Trigger, error)
 	}
 
 	PluginStore interface {
@@ -1102,6 +1134,10 @@ type (
 
 		// ListByFingerprint returns public keys given a fingerprint and key usage.
 		ListByFingerprint(ctx context.Context, fingerprint string) ([]types.PublicKey, error)
+
+		// ListBySubKeyID returns PGP public keys that have the provided key ID
+		// as the ID of the primary key or one of the sub keys.
+		ListBySubKeyID(ctx context.Context, subKeyID string) ([]types.PublicKey, error)
 	}
 
 	GitspaceEventStore interface {
@@ -1273,4 +1309,244 @@ type (
 		Delete(ctx context.Context, id int64) error
 		Update(ctx context.Context, infraProvisioned *types.InfraProvisioned) error
 	}
+
+	LFSObjectStore interface {
+		// Find finds an LFS object with a specified oid and repo-id.
+		Find(ctx context.Context, repoID int64, oid string) (*types.LFSObject, error)
+
+		// FindMany finds LFS objects for a specified repo.
+		FindMany(ctx context.Context, repoID int64, oids []string) ([]*types.LFSObject, error)
+
+		// Create creates an LFS object.
+		Create(ctx context.Context, lfsObject *types.LFSObject) error
+
+		// GetSizeInKBByRepoID returns the total size of LFS objects in KiB for a specified repo.
+		GetSizeInKBByRepoID(ctx context.Context, repoID int64) (int64, error)
+
+		// CopyToRepo copies LFS objects of a repo to another repo.
+		// The objects the destination repo already has are skipped.
+		CopyToRepo(ctx context.Context, srcRepoID, dstRepoID int64) error
+	}
+
+	LFSLockStore interface {
+		// Find finds an LFS lock by its id.
+		Find(ctx context.Context, id int64) (*types.LFSLock, error)
+
+		// FindByPath finds an LFS lock of a file in a specified repo.
+		FindByPath(ctx context.Context, repoID int64, path string) (*types.LFSLock, error)
+
+		// Create creates an LFS lock.
+		Create(ctx context.Context, lock *types.LFSLock) error
+
+		// Delete deletes an LFS lock.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns LFS locks of a specified repo that match the provided filter.
+		List(ctx context.Context, repoID int64, filter *types.LFSLockFilter) ([]*types.LFSLock, error)
+	}
+
+	MergeQueueStore interface {
+		// Find finds a merge queue entry by its id.
+		Find(ctx context.Context, id int64) (*types.MergeQueueEntry, error)
+
+		// FindByPullReqID finds the merge queue entry of a pull request.
+		FindByPullReqID(ctx context.Context, pullreqID int64) (*types.MergeQueueEntry, error)
+
+		// Create adds a pull request to the merge queue.
+		Create(ctx context.Context, entry *types.MergeQueueEntry) error
+
+		// Update updates the state of a merge queue entry.
+		Update(ctx context.Context, entry *types.MergeQueueEntry) error
+
+		// Delete removes an entry from the merge queue.
+		Delete(ctx context.Context, id int64) error
+
+		// ListByBranch returns all entries of the merge queue of a branch
+		// in the order they were added to the queue.
+		ListByBranch(ctx context.Context, repoID int64, branch string) ([]*types.MergeQueueEntry, error)
+
+		// ListBranches returns all branches that have at least one entry waiting in the merge queue.
+		ListBranches(ctx context.Context) ([]types.MergeQueueBranch, error)
+	}
+
+	PullReqAutoMergeStore interface {
+		// Find finds the auto merge settings of a pull request.
+		Find(ctx context.Context, pullreqID int64) (*types.PullReqAutoMerge, error)
+
+		// Upsert enables auto merge of a pull request or overrides its existing auto merge settings.
+		Upsert(ctx context.Context, autoMerge *types.PullReqAutoMerge) error
+
+		// Delete disables auto merge of a pull request.
+		Delete(ctx context.Context, pullreqID int64) error
+
+		// ListBySourceSHA returns auto merge settings of all pull requests of a repository
+		// that have auto merge enabled for the provided source branch commit.
+		ListBySourceSHA(ctx context.Context, repoID int64, sourceSHA string) ([]*types.PullReqAutoMerge, error)
+
+		// ListByTargetBranch returns auto merge settings of all pull requests of a repository
+		// that have auto merge enabled and target the provided branch.
+		ListByTargetBranch(ctx context.Context, repoID int64, branch string) ([]*types.PullReqAutoMerge, error)
+	}
+
+	PullReqDependencyStore interface {
+		// Create declares that a pull request depends on another pull request.
+		Create(ctx context.Context, dependency *types.PullReqDependency) error
+
+		// Delete removes the dependency of a pull request on another pull request.
+		Delete(ctx context.Context, pullreqID, dependsOnID int64) error
+
+		// List returns the dependencies of a pull request: the pull requests it depends on.
+		List(ctx context.Context, pullreqID int64) ([]*types.PullReqDependency, error)
+
+		// ListDependents returns the dependencies on a pull request: the pull requests that depend on it.
+		ListDependents(ctx context.Context, dependsOnID int64) ([]*types.PullReqDependency, error)
+	}
+
+	AuditEventStore interface {
+		// Create stores a new audit event.
+		Create(ctx context.Context, event *types.AuditEvent) error
+
+		// List returns a list of audit events matching the filter, newest first.
+		List(ctx context.Context, filter *types.AuditEventFilter) ([]*types.AuditEvent, error)
+
+		// Count returns the number of audit events matching the filter.
+		Count(ctx context.Context, filter *types.AuditEventFilter) (int64, error)
+	}
+
+	ChatIdentityStore interface {
+		// Upsert links a principal to a chat application user or updates the existing link.
+		Upsert(ctx context.Context, identity *types.ChatIdentity) error
+
+		// Delete removes the link between a principal and a chat application user.
+		Delete(ctx context.Context, principalID int64, provider enum.ChatProvider) error
+
+		// List returns all chat identities of a principal.
+		List(ctx context.Context, principalID int64) ([]types.ChatIdentity, error)
+
+		// Map returns identifiers of the chat application users linked to the provided principals.
+		Map(ctx context.Context, provider enum.ChatProvider, principalIDs []int64) (map[int64]string, error)
+	}
+
+	RunnerStore interface {
+		// Find finds the runner by id.
+		Find(ctx context.Context, id int64) (*types.Runner, error)
+
+		// FindByIdentifier finds the runner by its identifier.
+		FindByIdentifier(ctx context.Context, identifier string) (*types.Runner, error)
+
+		// FindByTokenHash finds the runner by the hash of its registration token.
+		FindByTokenHash(ctx context.Context, tokenHash string) (*types.Runner, error)
+
+		// Create creates a new runner.
+		Create(ctx context.Context, runner *types.Runner) error
+
+		// UpdateLastSeen updates the time the runner last contacted the server.
+		UpdateLastSeen(ctx context.Context, id int64, lastSeen int64) error
+
+		// Delete deletes the runner.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns a list of runners.
+		List(ctx context.Context, filter types.ListQueryFilter) ([]*types.Runner, error)
+
+		// Count returns the number of runners matching the filter.
+		Count(ctx context.Context, filter types.ListQueryFilter) (int64, error)
+	}
+
+	PipelineCacheStore interface {
+		// Find finds the pipeline cache of a repository by its key.
+		Find(ctx context.Context, repoID int64, key string) (*types.PipelineCache, error)
+
+		// Upsert creates a new pipeline cache or updates the size of an existing one.
+		Upsert(ctx context.Context, cache *types.PipelineCache) error
+
+		// UpdateLastUsed updates the time the pipeline cache was last used.
+		UpdateLastUsed(ctx context.Context, repoID int64, key string, lastUsed int64) error
+
+		// Delete deletes the pipeline cache of a repository.
+		Delete(ctx context.Context, repoID int64, key string) error
+
+		// List returns a list of pipeline caches of a repository.
+		List(ctx context.Context, repoID int64, filter types.ListQueryFilter) ([]*types.PipelineCache, error)
+
+		// Count returns the number of pipeline caches of a repository matching the filter.
+		Count(ctx context.Context, repoID int64, filter types.ListQueryFilter) (int64, error)
+
+		// ListUnused returns up to limit pipeline caches, of all repositories,
+		// that weren't used since the provided time.
+		ListUnused(ctx context.Context, lastUsedBefore int64, limit int) ([]*types.PipelineCache, error)
+	}
+
+	TestCaseStore interface {
+		// CreateMany stores the test cases of a test report.
+		CreateMany(ctx context.Context, testCases []*types.TestCase) error
+
+		// Summary returns the summary of all test cases reported by a pipeline execution.
+		Summary(ctx context.Context, executionID int64) (types.TestSummary, error)
+
+		// Count returns the number of test cases reported by a pipeline execution.
+		Count(ctx context.Context, executionID int64, filter types.TestCaseFilter) (int64, error)
+
+		// List returns the test cases reported by a pipeline execution.
+		List(ctx context.Context, executionID int64, filter types.TestCaseFilter) ([]*types.TestCase, error)
+
+		// ListHistory returns the most recent results of a test case in the executions of a pipeline,
+		// the newest first.
+		ListHistory(
+			ctx context.Context,
+			pipelineID int64,
+			suite string,
+			name string,
+			limit int,
+		) ([]*types.TestCaseRun, error)
+	}
+
+	PipelineArtifactStore interface {
+		// Find finds the artifact of a pipeline execution by its name.
+		Find(ctx context.Context, executionID int64, name string) (*types.PipelineArtifact, error)
+
+		// Upsert creates a new pipeline artifact or replaces the existing one with the same name.
+		Upsert(ctx context.Context, artifact *types.PipelineArtifact) error
+
+		// Delete deletes the pipeline artifact.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns all artifacts of a pipeline execution.
+		List(ctx context.Context, executionID int64) ([]*types.PipelineArtifact, error)
+
+		// ListCreatedBefore returns up to limit pipeline artifacts, of all repositories,
+		// that were created before the provided time.
+		ListCreatedBefore(ctx context.Context, createdBefore int64, limit int) ([]*types.PipelineArtifact, error)
+	}
+
+	EnvironmentStore interface {
+		// Find finds the environment by id.
+		Find(ctx context.Context, id int64) (*types.Environment, error)
+
+		// FindByIdentifier finds the environment of a space by its identifier.
+		FindByIdentifier(ctx context.Context, spaceID int64, identifier string) (*types.Environment, error)
+
+		// Create creates a new environment.
+		Create(ctx context.Context, environment *types.Environment) error
+
+		// Update updates the environment.
+		Update(ctx context.Context, environment *types.Environment) error
+
+		// Delete deletes the environment.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns a list of environments of a space.
+		List(ctx context.Context, spaceID int64, filter types.ListQueryFilter) ([]*types.Environment, error)
+
+		// Count returns the number of environments of a space matching the filter.
+		Count(ctx context.Context, spaceID int64, filter types.ListQueryFilter) (int64, error)
+	}
+
+	StageApprovalStore interface {
+		// Create records a decision on a stage waiting for approval.
+		Create(ctx context.Context, approval *types.StageApproval) error
+
+		// List returns all approval decisions made on the stages of a pipeline execution.
+		List(ctx context.Context, executionID int64) ([]*types.StageApproval, error)
+	}
 )
@@ -0,0 +1,330 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+var _ store.AuditEventStore = (*AuditEventStore)(nil)
+
+// NewAuditEventStore returns a new AuditEventStore.
+func NewAuditEventStore(db *sqlx.DB) *AuditEventStore {
+	return &AuditEventStore{
+		db: db,
+	}
+}
+
+// AuditEventStore implements store.AuditEventStore backed by a relational database.
+type AuditEventStore struct {
+	db *sqlx.DB
+}
+
+type auditEvent struct {
+	ID                   int64              `db:"audit_event_id"`
+	Created              int64              `db:"audit_event_created"`
+	Action               string             `db:"audit_event_action"`
+	ResourceType         string             `db:"audit_event_resource_type"`
+	ResourceIdentifier   string             `db:"audit_event_resource_identifier"`
+	ResourceData         sqlxtypes.JSONText `db:"audit_event_resource_data"`
+	SpacePath            string             `db:"audit_event_space_path"`
+	RepoPath             string             `db:"audit_event_repo_path"`
+	PrincipalID          int64              `db:"audit_event_principal_id"`
+	PrincipalUID         string             `db:"audit_event_principal_uid"`
+	PrincipalEmail       string             `db:"audit_event_principal_email"`
+	PrincipalDisplayName string             `db:"audit_event_principal_display_name"`
+	PrincipalType        string             `db:"audit_event_principal_type"`
+	OldObject            sqlxtypes.JSONText `db:"audit_event_old_object"`
+	NewObject            sqlxtypes.JSONText `db:"audit_event_new_object"`
+	ClientIP             string             `db:"audit_event_client_ip"`
+	RequestMethod        string             `db:"audit_event_request_method"`
+	RequestID            string             `db:"audit_event_request_id"`
+	Data                 sqlxtypes.JSONText `db:"audit_event_data"`
+}
+
+const (
+	auditEventColumns = `
+		 audit_event_id
+		,audit_event_created
+		,audit_event_action
+		,audit_event_resource_type
+		,audit_event_resource_identifier
+		,audit_event_resource_data
+		,audit_event_space_path
+		,audit_event_repo_path
+		,audit_event_principal_id
+		,audit_event_principal_uid
+		,audit_event_principal_email
+		,audit_event_principal_display_name
+		,audit_event_principal_type
+		,audit_event_old_object
+		,audit_event_new_object
+		,audit_event_client_ip
+		,audit_event_request_method
+		,audit_event_request_id
+		,audit_event_data`
+)
+
+// Create stores a new audit event.
+func (s *AuditEventStore) Create(ctx context.Context, event *types.AuditEvent) error {
+	const sqlQuery = `
+	INSERT INTO audit_events (
+		 audit_event_created
+		,audit_event_action
+		,audit_event_resource_type
+		,audit_event_resource_identifier
+		,audit_event_resource_data
+		,audit_event_space_path
+		,audit_event_repo_path
+		,audit_event_principal_id
+		,audit_event_principal_uid
+		,audit_event_principal_email
+		,audit_event_principal_display_name
+		,audit_event_principal_type
+		,audit_event_old_object
+		,audit_event_new_object
+		,audit_event_client_ip
+		,audit_event_request_method
+		,audit_event_request_id
+		,audit_event_data
+	) values (
+		 :audit_event_created
+		,:audit_event_action
+		,:audit_event_resource_type
+		,:audit_event_resource_identifier
+		,:audit_event_resource_data
+		,:audit_event_space_path
+		,:audit_event_repo_path
+		,:audit_event_principal_id
+		,:audit_event_principal_uid
+		,:audit_event_principal_email
+		,:audit_event_principal_display_name
+		,:audit_event_principal_type
+		,:audit_event_old_object
+		,:audit_event_new_object
+		,:audit_event_client_ip
+		,:audit_event_request_method
+		,:audit_event_request_id
+		,:audit_event_data
+	) RETURNING audit_event_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalAuditEvent(event))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind audit event object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&event.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// List returns a list of audit events matching the filter, newest first.
+func (s *AuditEventStore) List(
+	ctx context.Context,
+	filter *types.AuditEventFilter,
+) ([]*types.AuditEvent, error) {
+	stmt := database.Builder.
+		Select(auditEventColumns).
+		From("audit_events")
+
+	stmt = applyAuditEventFilter(stmt, filter)
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	stmt = stmt.OrderBy("audit_event_created DESC", "audit_event_id DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*auditEvent{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Select query failed")
+	}
+
+	return mapToAuditEvents(dst)
+}
+
+// Count returns the number of audit events matching the filter.
+func (s *AuditEventStore) Count(
+	ctx context.Context,
+	filter *types.AuditEventFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("COUNT(*)").
+		From("audit_events")
+
+	stmt = applyAuditEventFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.GetContext(ctx, &count, sql, args...); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Count query failed")
+	}
+
+	return count, nil
+}
+
+func applyAuditEventFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.AuditEventFilter,
+) squirrel.SelectBuilder {
+	if filter.SpacePath != "" {
+		stmt = stmt.Where(squirrel.Or{
+			squirrel.Expr("LOWER(audit_event_space_path) = LOWER(?)", filter.SpacePath),
+			squirrel.Expr(PrefixMatch("audit_event_space_path", filter.SpacePath+"/")),
+		})
+	}
+
+	if filter.RepoPath != "" {
+		stmt = stmt.Where("LOWER(audit_event_repo_path) = LOWER(?)", filter.RepoPath)
+	}
+
+	if len(filter.ResourceTypes) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"audit_event_resource_type": filter.ResourceTypes})
+	}
+
+	if len(filter.Actions) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"audit_event_action": filter.Actions})
+	}
+
+	if len(filter.PrincipalIDs) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"audit_event_principal_id": filter.PrincipalIDs})
+	}
+
+	if filter.CreatedLt > 0 {
+		stmt = stmt.Where("audit_event_created < ?", filter.CreatedLt)
+	}
+
+	if filter.CreatedGt > 0 {
+		stmt = stmt.Where("audit_event_created > ?", filter.CreatedGt)
+	}
+
+	return stmt
+}
+
+func mapToAuditEvent(in *auditEvent) (*types.AuditEvent, error) {
+	var resourceData map[string]string
+	if err := json.Unmarshal(in.ResourceData, &resourceData); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal audit event resource data: %w", err)
+	}
+
+	var data map[string]string
+	if err := json.Unmarshal(in.Data, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal audit event data: %w", err)
+	}
+
+	return &types.AuditEvent{
+		ID:                 in.ID,
+		Created:            in.Created,
+		Action:             in.Action,
+		ResourceType:       in.ResourceType,
+		ResourceIdentifier: in.ResourceIdentifier,
+		ResourceData:       resourceData,
+		SpacePath:          in.SpacePath,
+		RepoPath:           in.RepoPath,
+		Actor: types.PrincipalInfo{
+			ID:          in.PrincipalID,
+			UID:         in.PrincipalUID,
+			DisplayName: in.PrincipalDisplayName,
+			Email:       in.PrincipalEmail,
+			Type:        enum.PrincipalType(in.PrincipalType),
+		},
+		OldObject:     nullJSONToRaw(in.OldObject),
+		NewObject:     nullJSONToRaw(in.NewObject),
+		ClientIP:      in.ClientIP,
+		RequestMethod: in.RequestMethod,
+		RequestID:     in.RequestID,
+		Data:          data,
+	}, nil
+}
+
+func mapToInternalAuditEvent(in *types.AuditEvent) *auditEvent {
+	return &auditEvent{
+		ID:                   in.ID,
+		Created:              in.Created,
+		Action:               in.Action,
+		ResourceType:         in.ResourceType,
+		ResourceIdentifier:   in.ResourceIdentifier,
+		ResourceData:         EncodeToSQLXJSON(in.ResourceData),
+		SpacePath:            in.SpacePath,
+		RepoPath:             in.RepoPath,
+		PrincipalID:          in.Actor.ID,
+		PrincipalUID:         in.Actor.UID,
+		PrincipalEmail:       in.Actor.Email,
+		PrincipalDisplayName: in.Actor.DisplayName,
+		PrincipalType:        string(in.Actor.Type),
+		OldObject:            rawToNullJSON(in.OldObject),
+		NewObject:            rawToNullJSON(in.NewObject),
+		ClientIP:             in.ClientIP,
+		RequestMethod:        in.RequestMethod,
+		RequestID:            in.RequestID,
+		Data:                 EncodeToSQLXJSON(in.Data),
+	}
+}
+
+func mapToAuditEvents(events []*auditEvent) ([]*types.AuditEvent, error) {
+	m := make([]*types.AuditEvent, len(events))
+	for i, event := range events {
+		var err error
+		if m[i], err = mapToAuditEvent(event); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// rawToNullJSON converts an empty audit object to a JSON null value.
+func rawToNullJSON(raw json.RawMessage) sqlxtypes.JSONText {
+	if len(raw) == 0 {
+		return sqlxtypes.JSONText("null")
+	}
+	return sqlxtypes.JSONText(raw)
+}
+
+// nullJSONToRaw converts a JSON null value to an empty audit object.
+func nullJSONToRaw(text sqlxtypes.JSONText) json.RawMessage {
+	if len(text) == 0 || string(text) == "null" {
+		return nil
+	}
+	return json.RawMessage(text)
+}
@@ -0,0 +1,123 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+)
+
+func TestAuditEventStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	auditEventStore := database.NewAuditEventStore(db)
+
+	ctx := context.Background()
+
+	events := []*types.AuditEvent{
+		{Created: 1, SpacePath: "acme", RepoPath: "acme/repo", ResourceType: "repository", Action: "created"},
+		{Created: 2, SpacePath: "acme/team_a", RepoPath: "acme/team_a/repo", ResourceType: "branch", Action: "bypassed"},
+		{Created: 3, SpacePath: "acme/teamXa", ResourceType: "branch_rule", Action: "updated"},
+		{Created: 4, SpacePath: "acme2", RepoPath: "acme2/repo", ResourceType: "branch", Action: "bypassed"},
+	}
+	for i, event := range events {
+		event.ResourceIdentifier = "resource"
+		event.Actor = types.PrincipalInfo{ID: int64(i%2 + 1), UID: "user"}
+		event.ResourceData = map[string]string{"repoName": "repo"}
+		if i == 0 {
+			event.NewObject = []byte(`{"identifier":"repo"}`)
+		}
+		if err := auditEventStore.Create(ctx, event); err != nil {
+			t.Fatalf("failed to create audit event: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter types.AuditEventFilter
+		expect []int64
+	}{
+		{
+			name:   "space-includes-subspaces",
+			filter: types.AuditEventFilter{SpacePath: "ACME"},
+			expect: []int64{events[2].ID, events[1].ID, events[0].ID},
+		},
+		{
+			name:   "space-escapes-wildcards",
+			filter: types.AuditEventFilter{SpacePath: "acme/team_a"},
+			expect: []int64{events[1].ID},
+		},
+		{
+			name:   "repo",
+			filter: types.AuditEventFilter{RepoPath: "acme/repo"},
+			expect: []int64{events[0].ID},
+		},
+		{
+			name: "action-and-principal",
+			filter: types.AuditEventFilter{
+				Actions:      []string{"bypassed"},
+				PrincipalIDs: []int64{2},
+			},
+			expect: []int64{events[1].ID},
+		},
+		{
+			name: "resource-type-and-created",
+			filter: types.AuditEventFilter{
+				ResourceTypes: []string{"branch", "branch_rule"},
+				CreatedFilter: types.CreatedFilter{CreatedGt: 2, CreatedLt: 4},
+			},
+			expect: []int64{events[2].ID},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			list, err := auditEventStore.List(ctx, &test.filter)
+			if err != nil {
+				t.Fatalf("failed to list audit events: %v", err)
+			}
+
+			count, err := auditEventStore.Count(ctx, &test.filter)
+			if err != nil {
+				t.Fatalf("failed to count audit events: %v", err)
+			}
+
+			if int(count) != len(test.expect) || len(list) != len(test.expect) {
+				t.Fatalf("expected %d audit events, got list=%d count=%d", len(test.expect), len(list), count)
+			}
+
+			for i, event := range list {
+				if event.ID != test.expect[i] {
+					t.Errorf("expected audit event %d at position %d, got %d", test.expect[i], i, event.ID)
+				}
+			}
+		})
+	}
+
+	list, err := auditEventStore.List(ctx, &types.AuditEventFilter{RepoPath: "acme/repo"})
+	if err != nil {
+		t.Fatalf("failed to list audit events: %v", err)
+	}
+	if got := string(list[0].NewObject); got != `{"identifier":"repo"}` || list[0].OldObject != nil {
+		t.Errorf("unexpected audit event objects: old=%s new=%s", list[0].OldObject, got)
+	}
+	if list[0].ResourceData["repoName"] != "repo" || list[0].Actor.ID != 1 {
+		t.Errorf("unexpected audit event: %+v", list[0])
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.ChatIdentityStore = (*ChatIdentityStore)(nil)
+
+// NewChatIdentityStore returns a new ChatIdentityStore.
+func NewChatIdentityStore(db *sqlx.DB) *ChatIdentityStore {
+	return &ChatIdentityStore{
+		db: db,
+	}
+}
+
+// ChatIdentityStore implements a store.ChatIdentityStore backed by a relational database.
+type ChatIdentityStore struct {
+	db *sqlx.DB
+}
+
+type chatIdentity struct {
+	PrincipalID int64             `db:"chat_identity_principal_id"`
+	Provider    enum.ChatProvider `db:"chat_identity_provider"`
+	Identifier  string            `db:"chat_identity_identifier"`
+	Created     int64             `db:"chat_identity_created"`
+	Updated     int64             `db:"chat_identity_updated"`
+}
+
+const (
+	chatIdentityColumns = `
+		 chat_identity_principal_id
+		,chat_identity_provider
+		,chat_identity_identifier
+		,chat_identity_created
+		,chat_identity_updated`
+)
+
+// Upsert links a principal to a chat application user or updates the existing link.
+func (s *ChatIdentityStore) Upsert(ctx context.Context, identity *types.ChatIdentity) error {
+	const sqlQuery = `
+		INSERT INTO chat_identities (
+			 chat_identity_principal_id
+			,chat_identity_provider
+			,chat_identity_identifier
+			,chat_identity_created
+			,chat_identity_updated
+		) values (
+			 :chat_identity_principal_id
+			,:chat_identity_provider
+			,:chat_identity_identifier
+			,:chat_identity_created
+			,:chat_identity_updated
+		)
+		ON CONFLICT (chat_identity_principal_id, chat_identity_provider) DO
+		UPDATE SET
+			 chat_identity_identifier = :chat_identity_identifier
+			,chat_identity_updated = :chat_identity_updated
+		RETURNING chat_identity_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalChatIdentity(identity))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind chat identity")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&identity.Created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert chat identity query failed")
+	}
+
+	return nil
+}
+
+// Delete removes the link between a principal and a chat application user.
+func (s *ChatIdentityStore) Delete(ctx context.Context, principalID int64, provider enum.ChatProvider) error {
+	const sqlQuery = `
+		DELETE FROM chat_identities
+		WHERE chat_identity_principal_id = $1 AND chat_identity_provider = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, principalID, provider)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete chat identity query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted chat identities")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// List returns all chat identities of a principal.
+func (s *ChatIdentityStore) List(ctx context.Context, principalID int64) ([]types.ChatIdentity, error) {
+	const sqlQuery = `
+		SELECT` + chatIdentityColumns + `
+		FROM chat_identities
+		WHERE chat_identity_principal_id = $1
+		ORDER BY chat_identity_provider`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*chatIdentity{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Select chat identities query failed")
+	}
+
+	identities := make([]types.ChatIdentity, len(dst))
+	for i, identity := range dst {
+		identities[i] = mapChatIdentity(identity)
+	}
+
+	return identities, nil
+}
+
+// Map returns identifiers of the chat application users linked to the provided principals.
+func (s *ChatIdentityStore) Map(
+	ctx context.Context,
+	provider enum.ChatProvider,
+	principalIDs []int64,
+) (map[int64]string, error) {
+	if len(principalIDs) == 0 {
+		return map[int64]string{}, nil
+	}
+
+	stmt := database.Builder.
+		Select(chatIdentityColumns).
+		From("chat_identities").
+		Where("chat_identity_provider = ?", provider).
+		Where(squirrel.Eq{"chat_identity_principal_id": principalIDs})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*chatIdentity{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Select chat identities query failed")
+	}
+
+	identifiers := make(map[int64]string, len(dst))
+	for _, identity := range dst {
+		identifiers[identity.PrincipalID] = identity.Identifier
+	}
+
+	return identifiers, nil
+}
+
+func mapChatIdentity(in *chatIdentity) types.ChatIdentity {
+	return types.ChatIdentity{
+		PrincipalID: in.PrincipalID,
+		Provider:    in.Provider,
+		Identifier:  in.Identifier,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+}
+
+func mapInternalChatIdentity(in *types.ChatIdentity) *chatIdentity {
+	return &chatIdentity{
+		PrincipalID: in.PrincipalID,
+		Provider:    in.Provider,
+		Identifier:  in.Identifier,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.CheckAnnotationStore = (*CheckAnnotationStore)(nil)
+
+// NewCheckAnnotationStore returns a new CheckAnnotationStore.
+func NewCheckAnnotationStore(db *sqlx.DB) *CheckAnnotationStore {
+	return &CheckAnnotationStore{
+		db: db,
+	}
+}
+
+// CheckAnnotationStore implements a store.CheckAnnotationStore backed by a relational database.
+type CheckAnnotationStore struct {
+	db *sqlx.DB
+}
+
+type checkAnnotation struct {
+	ID              int64                        `db:"check_annotation_id"`
+	CheckID         int64                        `db:"check_annotation_check_id"`
+	CheckIdentifier string                       `db:"check_uid"`
+	Path            string                       `db:"check_annotation_path"`
+	LineStart       int                          `db:"check_annotation_line_start"`
+	LineEnd         int                          `db:"check_annotation_line_end"`
+	Severity        enum.CheckAnnotationSeverity `db:"check_annotation_severity"`
+	Title           string                       `db:"check_annotation_title"`
+	Message         string                       `db:"check_annotation_message"`
+}
+
+const (
+	checkAnnotationTable = "check_annotations"
+
+	checkAnnotationColumns = `
+		 check_annotation_id
+		,check_annotation_check_id
+		,check_uid
+		,check_annotation_path
+		,check_annotation_line_start
+		,check_annotation_line_end
+		,check_annotation_severity
+		,check_annotation_title
+		,check_annotation_message`
+)
+
+// ReplaceForCheck replaces all annotations of a status check with the provided ones.
+func (s *CheckAnnotationStore) ReplaceForCheck(
+	ctx context.Context,
+	checkID int64,
+	annotations []*types.CheckAnnotation,
+) error {
+	const sqlQueryDelete = `
+		DELETE FROM check_annotations
+		WHERE check_annotation_check_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQueryDelete, checkID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete check annotations")
+	}
+
+	if len(annotations) == 0 {
+		return nil
+	}
+
+	stmt := database.Builder.
+		Insert(checkAnnotationTable).
+		Columns(
+			"check_annotation_check_id",
+			"check_annotation_path",
+			"check_annotation_line_start",
+			"check_annotation_line_end",
+			"check_annotation_severity",
+			"check_annotation_title",
+			"check_annotation_message",
+		)
+
+	for _, a := range annotations {
+		a.CheckID = checkID
+		stmt = stmt.Values(a.CheckID, a.Path, a.LineStart, a.LineEnd, a.Severity, a.Title, a.Message)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert squirrel builder to sql: %w", err)
+	}
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert check annotations")
+	}
+
+	return nil
+}
+
+// List returns the annotations of all status checks reported for a specific commit in a repo.
+func (s *CheckAnnotationStore) List(
+	ctx context.Context,
+	repoID int64,
+	commitSHA string,
+) ([]*types.CheckAnnotation, error) {
+	const sqlQuery = `
+		SELECT` + checkAnnotationColumns + `
+		FROM check_annotations
+		INNER JOIN checks ON check_id = check_annotation_check_id
+		WHERE check_repo_id = $1 AND check_commit_sha = $2
+		ORDER BY check_uid ASC, check_annotation_path ASC, check_annotation_line_start ASC, check_annotation_id ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*checkAnnotation{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID, commitSHA); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing check annotation list query")
+	}
+
+	result := make([]*types.CheckAnnotation, len(dst))
+	for i, a := range dst {
+		result[i] = mapCheckAnnotation(a)
+	}
+
+	return result, nil
+}
+
+func mapCheckAnnotation(in *checkAnnotation) *types.CheckAnnotation {
+	return &types.CheckAnnotation{
+		ID:              in.ID,
+		CheckID:         in.CheckID,
+		CheckIdentifier: in.CheckIdentifier,
+		Path:            in.Path,
+		LineStart:       in.LineStart,
+		LineEnd:         in.LineEnd,
+		Severity:        in.Severity,
+		Title:           in.Title,
+		Message:         in.Message,
+	}
+}
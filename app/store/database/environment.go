@@ -0,0 +1,310 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+var _ store.EnvironmentStore = (*EnvironmentStore)(nil)
+
+// NewEnvironmentStore returns a new EnvironmentStore.
+func NewEnvironmentStore(db *sqlx.DB) *EnvironmentStore {
+	return &EnvironmentStore{
+		db: db,
+	}
+}
+
+// EnvironmentStore implements a store.EnvironmentStore backed by a relational database.
+type EnvironmentStore struct {
+	db *sqlx.DB
+}
+
+type environment struct {
+	ID                 int64              `db:"environment_id"`
+	SpaceID            int64              `db:"environment_space_id"`
+	Identifier         string             `db:"environment_identifier"`
+	Description        string             `db:"environment_description"`
+	ApproverIDs        sqlxtypes.JSONText `db:"environment_approver_ids"`
+	ApproverUserGroups sqlxtypes.JSONText `db:"environment_approver_user_groups"`
+	CreatedBy          int64              `db:"environment_created_by"`
+	Created            int64              `db:"environment_created"`
+	Updated            int64              `db:"environment_updated"`
+}
+
+const (
+	environmentColumns = `
+		 environment_id
+		,environment_space_id
+		,environment_identifier
+		,environment_description
+		,environment_approver_ids
+		,environment_approver_user_groups
+		,environment_created_by
+		,environment_created
+		,environment_updated`
+
+	environmentSelectBase = `
+		SELECT` + environmentColumns + `
+		FROM environments`
+)
+
+// Find finds the environment by id.
+func (s *EnvironmentStore) Find(ctx context.Context, id int64) (*types.Environment, error) {
+	const sqlQuery = environmentSelectBase + `
+		WHERE environment_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &environment{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find environment")
+	}
+
+	return mapEnvironment(dst)
+}
+
+// FindByIdentifier finds the environment of a space by its identifier.
+func (s *EnvironmentStore) FindByIdentifier(
+	ctx context.Context,
+	spaceID int64,
+	identifier string,
+) (*types.Environment, error) {
+	const sqlQuery = environmentSelectBase + `
+		WHERE environment_space_id = $1 AND LOWER(environment_identifier) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &environment{}
+	if err := db.GetContext(ctx, dst, sqlQuery, spaceID, strings.ToLower(identifier)); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find environment by identifier")
+	}
+
+	return mapEnvironment(dst)
+}
+
+// Create creates a new environment.
+func (s *EnvironmentStore) Create(ctx context.Context, env *types.Environment) error {
+	const sqlQuery = `
+		INSERT INTO environments (
+			 environment_space_id
+			,environment_identifier
+			,environment_description
+			,environment_approver_ids
+			,environment_approver_user_groups
+			,environment_created_by
+			,environment_created
+			,environment_updated
+		) values (
+			 :environment_space_id
+			,:environment_identifier
+			,:environment_description
+			,:environment_approver_ids
+			,:environment_approver_user_groups
+			,:environment_created_by
+			,:environment_created
+			,:environment_updated
+		) RETURNING environment_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalEnvironment(env))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind environment object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&env.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert environment query failed")
+	}
+
+	return nil
+}
+
+// Update updates the environment.
+func (s *EnvironmentStore) Update(ctx context.Context, env *types.Environment) error {
+	const sqlQuery = `
+		UPDATE environments
+		SET
+			 environment_description = :environment_description
+			,environment_approver_ids = :environment_approver_ids
+			,environment_approver_user_groups = :environment_approver_user_groups
+			,environment_updated = :environment_updated
+		WHERE environment_id = :environment_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalEnvironment(env))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind environment object")
+	}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update environment")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated environments")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes the environment.
+func (s *EnvironmentStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM environments
+		WHERE environment_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete environment query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted environments")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// List returns a list of environments of a space.
+func (s *EnvironmentStore) List(
+	ctx context.Context,
+	spaceID int64,
+	filter types.ListQueryFilter,
+) ([]*types.Environment, error) {
+	stmt := database.Builder.
+		Select(environmentColumns).
+		From("environments").
+		Where("environment_space_id = ?", spaceID)
+
+	if filter.Query != "" {
+		stmt = stmt.Where(PartialMatch("environment_identifier", filter.Query))
+	}
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	stmt = stmt.OrderBy("environment_identifier")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*environment{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing environment list query")
+	}
+
+	environments := make([]*types.Environment, len(dst))
+	for i, env := range dst {
+		if environments[i], err = mapEnvironment(env); err != nil {
+			return nil, err
+		}
+	}
+
+	return environments, nil
+}
+
+// Count returns the number of environments of a space matching the filter.
+func (s *EnvironmentStore) Count(ctx context.Context, spaceID int64, filter types.ListQueryFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("environments").
+		Where("environment_space_id = ?", spaceID)
+
+	if filter.Query != "" {
+		stmt = stmt.Where(PartialMatch("environment_identifier", filter.Query))
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing environment count query")
+	}
+
+	return count, nil
+}
+
+func mapEnvironment(in *environment) (*types.Environment, error) {
+	var approverIDs []int64
+	if err := json.Unmarshal(in.ApproverIDs, &approverIDs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal environment approver ids: %w", err)
+	}
+
+	var approverUserGroups []string
+	if err := json.Unmarshal(in.ApproverUserGroups, &approverUserGroups); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal environment approver user groups: %w", err)
+	}
+
+	return &types.Environment{
+		ID:                 in.ID,
+		SpaceID:            in.SpaceID,
+		Identifier:         in.Identifier,
+		Description:        in.Description,
+		ApproverIDs:        approverIDs,
+		ApproverUserGroups: approverUserGroups,
+		CreatedBy:          in.CreatedBy,
+		Created:            in.Created,
+		Updated:            in.Updated,
+	}, nil
+}
+
+func mapInternalEnvironment(in *types.Environment) *environment {
+	return &environment{
+		ID:                 in.ID,
+		SpaceID:            in.SpaceID,
+		Identifier:         in.Identifier,
+		Description:        in.Description,
+		ApproverIDs:        EncodeToSQLXJSON(in.ApproverIDs),
+		ApproverUserGroups: EncodeToSQLXJSON(in.ApproverUserGroups),
+		CreatedBy:          in.CreatedBy,
+		Created:            in.Created,
+		Updated:            in.Updated,
+	}
+}
@@ -78,6 +78,7 @@ type execution struct {
 	Cron         string             `db:"execution_cron"`
 	Deploy       string             `db:"execution_deploy"`
 	DeployID     int64              `db:"execution_deploy_id"`
+	RetryOf      int64              `db:"execution_retry_of"`
 	Debug        bool               `db:"execution_debug"`
 	Started      int64              `db:"execution_started"`
 	Finished     int64              `db:"execution_finished"`
@@ -124,6 +125,7 @@ const (
 		,execution_cron
 		,execution_deploy
 		,execution_deploy_id
+		,execution_retry_of
 		,execution_debug
 		,execution_started
 		,execution_finished
@@ -212,6 +214,7 @@ func (s *executionStore) Create(ctx context.Context, execution *types.Execution)
 		,execution_cron
 		,execution_deploy
 		,execution_deploy_id
+		,execution_retry_of
 		,execution_debug
 		,execution_started
 		,execution_finished
@@ -248,6 +251,7 @@ func (s *executionStore) Create(ctx context.Context, execution *types.Execution)
 		,:execution_cron
 		,:execution_deploy
 		,:execution_deploy_id
+		,:execution_retry_of
 		,:execution_debug
 		,:execution_started
 		,:execution_finished
@@ -55,6 +55,7 @@ func mapInternalToExecution(in *execution) (*types.Execution, error) {
 		Cron:         in.Cron,
 		Deploy:       in.Deploy,
 		DeployID:     in.DeployID,
+		RetryOf:      in.RetryOf,
 		Debug:        in.Debug,
 		Started:      in.Started,
 		Finished:     in.Finished,
@@ -96,6 +97,7 @@ func mapExecutionToInternal(in *types.Execution) *execution {
 		Cron:         in.Cron,
 		Deploy:       in.Deploy,
 		DeployID:     in.DeployID,
+		RetryOf:      in.RetryOf,
 		Debug:        in.Debug,
 		Started:      in.Started,
 		Finished:     in.Finished,
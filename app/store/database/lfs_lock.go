@@ -0,0 +1,221 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.LFSLockStore = (*LFSLockStore)(nil)
+
+// NewLFSLockStore returns a new LFSLockStore.
+func NewLFSLockStore(db *sqlx.DB) *LFSLockStore {
+	return &LFSLockStore{
+		db: db,
+	}
+}
+
+// LFSLockStore implements a store.LFSLockStore backed by a relational database.
+type LFSLockStore struct {
+	db *sqlx.DB
+}
+
+type lfsLock struct {
+	ID        int64  `db:"lfs_lock_id"`
+	RepoID    int64  `db:"lfs_lock_repo_id"`
+	Path      string `db:"lfs_lock_path"`
+	Ref       string `db:"lfs_lock_ref"`
+	Created   int64  `db:"lfs_lock_created"`
+	CreatedBy int64  `db:"lfs_lock_created_by"`
+}
+
+const (
+	lfsLockColumns = `
+		 lfs_lock_id
+		,lfs_lock_repo_id
+		,lfs_lock_path
+		,lfs_lock_ref
+		,lfs_lock_created
+		,lfs_lock_created_by`
+
+	lfsLockSelectBase = `
+		SELECT` + lfsLockColumns + `
+		FROM lfs_locks`
+)
+
+// Find finds an LFS lock by its id.
+func (s *LFSLockStore) Find(ctx context.Context, id int64) (*types.LFSLock, error) {
+	const sqlQuery = lfsLockSelectBase + `
+		WHERE lfs_lock_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &lfsLock{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find LFS lock")
+	}
+
+	return mapLFSLock(dst), nil
+}
+
+// FindByPath finds an LFS lock of a file in a specified repo.
+func (s *LFSLockStore) FindByPath(ctx context.Context, repoID int64, path string) (*types.LFSLock, error) {
+	const sqlQuery = lfsLockSelectBase + `
+		WHERE lfs_lock_repo_id = $1 AND lfs_lock_path = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &lfsLock{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, path); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find LFS lock by path")
+	}
+
+	return mapLFSLock(dst), nil
+}
+
+// Create creates an LFS lock.
+func (s *LFSLockStore) Create(ctx context.Context, lock *types.LFSLock) error {
+	const sqlQuery = `
+		INSERT INTO lfs_locks (
+			 lfs_lock_repo_id
+			,lfs_lock_path
+			,lfs_lock_ref
+			,lfs_lock_created
+			,lfs_lock_created_by
+		) values (
+			 :lfs_lock_repo_id
+			,:lfs_lock_path
+			,:lfs_lock_ref
+			,:lfs_lock_created
+			,:lfs_lock_created_by
+		) RETURNING lfs_lock_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalLFSLock(lock))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind LFS lock")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&lock.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert LFS lock query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes an LFS lock.
+func (s *LFSLockStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM lfs_locks
+		WHERE lfs_lock_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete LFS lock query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted LFS locks")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// List returns LFS locks of a specified repo that match the provided filter.
+// The locks are ordered by their id, the filter cursor is the id of the last lock of the previous page.
+func (s *LFSLockStore) List(
+	ctx context.Context,
+	repoID int64,
+	filter *types.LFSLockFilter,
+) ([]*types.LFSLock, error) {
+	stmt := database.Builder.
+		Select(lfsLockColumns).
+		From("lfs_locks").
+		Where("lfs_lock_repo_id = ?", repoID)
+
+	if filter.Path != "" {
+		stmt = stmt.Where("lfs_lock_path = ?", filter.Path)
+	}
+	if filter.ID > 0 {
+		stmt = stmt.Where("lfs_lock_id = ?", filter.ID)
+	}
+	if filter.Cursor > 0 {
+		stmt = stmt.Where("lfs_lock_id > ?", filter.Cursor)
+	}
+
+	stmt = stmt.OrderBy("lfs_lock_id ASC").
+		Limit(database.Limit(filter.Limit))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*lfsLock
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list LFS locks")
+	}
+
+	return mapLFSLocks(dst), nil
+}
+
+func mapInternalLFSLock(lock *types.LFSLock) *lfsLock {
+	return &lfsLock{
+		ID:        lock.ID,
+		RepoID:    lock.RepoID,
+		Path:      lock.Path,
+		Ref:       lock.Ref,
+		Created:   lock.Created,
+		CreatedBy: lock.CreatedBy,
+	}
+}
+
+func mapLFSLock(lock *lfsLock) *types.LFSLock {
+	return &types.LFSLock{
+		ID:        lock.ID,
+		RepoID:    lock.RepoID,
+		Path:      lock.Path,
+		Ref:       lock.Ref,
+		Created:   lock.Created,
+		CreatedBy: lock.CreatedBy,
+	}
+}
+
+func mapLFSLocks(locks []*lfsLock) []*types.LFSLock {
+	res := make([]*types.LFSLock, len(locks))
+	for i := range locks {
+		res[i] = mapLFSLock(locks[i])
+	}
+	return res
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+)
+
+func TestLFSObjectStore_PurgeForkedRepo(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	lfsObjectStore := database.NewLFSObjectStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	const upstreamID, forkID = int64(1), int64(2)
+
+	createRepo(ctx, t, repoStore, upstreamID, 1, 0)
+
+	fork := types.Repository{Identifier: "fork", ID: forkID, ParentID: 1, GitUID: "fork", ForkID: upstreamID}
+	if err := repoStore.Create(ctx, &fork); err != nil {
+		t.Fatalf("failed to create fork: %v", err)
+	}
+
+	for _, obj := range []types.LFSObject{
+		{OID: "oid-a", Size: 10, RepoID: upstreamID},
+		{OID: "oid-b", Size: 20, RepoID: upstreamID},
+		{OID: "oid-b", Size: 20, RepoID: forkID},
+	} {
+		obj.CreatedBy = userID
+		if err := lfsObjectStore.Create(ctx, &obj); err != nil {
+			t.Fatalf("failed to create LFS object: %v", err)
+		}
+	}
+
+	// the same steps as when the upstream repository is purged: the fork is detached and the upstream is removed.
+	if err := lfsObjectStore.CopyToRepo(ctx, upstreamID, forkID); err != nil {
+		t.Fatalf("failed to copy LFS objects: %v", err)
+	}
+	if err := repoStore.ResetForkID(ctx, forkID); err != nil {
+		t.Fatalf("failed to reset fork ID: %v", err)
+	}
+	if err := repoStore.Purge(ctx, upstreamID, nil); err != nil {
+		t.Fatalf("failed to purge upstream repo: %v", err)
+	}
+
+	oids := []string{"oid-a", "oid-b"}
+
+	objects, err := lfsObjectStore.FindMany(ctx, forkID, oids)
+	if err != nil {
+		t.Fatalf("failed to find LFS objects of the fork: %v", err)
+	}
+
+	got := make([]string, len(objects))
+	for i, obj := range objects {
+		got[i] = obj.OID
+	}
+	sort.Strings(got)
+
+	if len(got) != len(oids) || got[0] != oids[0] || got[1] != oids[1] {
+		t.Errorf("fork LFS objects = %v, want %v", got, oids)
+	}
+
+	objects, err = lfsObjectStore.FindMany(ctx, upstreamID, oids)
+	if err != nil {
+		t.Fatalf("failed to find LFS objects of the upstream: %v", err)
+	}
+	if len(objects) != 0 {
+		t.Errorf("expected no LFS objects of the purged upstream, got %d", len(objects))
+	}
+}
@@ -0,0 +1,332 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.MergeQueueStore = (*MergeQueueStore)(nil)
+
+// NewMergeQueueStore returns a new MergeQueueStore.
+func NewMergeQueueStore(db *sqlx.DB) *MergeQueueStore {
+	return &MergeQueueStore{
+		db: db,
+	}
+}
+
+// MergeQueueStore implements a store.MergeQueueStore backed by a relational database.
+type MergeQueueStore struct {
+	db *sqlx.DB
+}
+
+type mergeQueueEntry struct {
+	ID            int64                     `db:"merge_queue_entry_id"`
+	RepoID        int64                     `db:"merge_queue_entry_repo_id"`
+	Branch        string                    `db:"merge_queue_entry_branch"`
+	PullReqID     int64                     `db:"merge_queue_entry_pullreq_id"`
+	PullReqNumber int64                     `db:"merge_queue_entry_pullreq_number"`
+	State         enum.MergeQueueEntryState `db:"merge_queue_entry_state"`
+	Method        enum.MergeMethod          `db:"merge_queue_entry_method"`
+	Title         string                    `db:"merge_queue_entry_title"`
+	Message       string                    `db:"merge_queue_entry_message"`
+	BypassRules   bool                      `db:"merge_queue_entry_bypass_rules"`
+	HeadSHA       string                    `db:"merge_queue_entry_head_sha"`
+	BaseSHA       string                    `db:"merge_queue_entry_base_sha"`
+	MergeSHA      string                    `db:"merge_queue_entry_merge_sha"`
+	Error         string                    `db:"merge_queue_entry_error"`
+	CreatedBy     int64                     `db:"merge_queue_entry_created_by"`
+	Created       int64                     `db:"merge_queue_entry_created"`
+	Updated       int64                     `db:"merge_queue_entry_updated"`
+}
+
+const (
+	mergeQueueEntryColumns = `
+		 merge_queue_entry_id
+		,merge_queue_entry_repo_id
+		,merge_queue_entry_branch
+		,merge_queue_entry_pullreq_id
+		,merge_queue_entry_pullreq_number
+		,merge_queue_entry_state
+		,merge_queue_entry_method
+		,merge_queue_entry_title
+		,merge_queue_entry_message
+		,merge_queue_entry_bypass_rules
+		,merge_queue_entry_head_sha
+		,merge_queue_entry_base_sha
+		,merge_queue_entry_merge_sha
+		,merge_queue_entry_error
+		,merge_queue_entry_created_by
+		,merge_queue_entry_created
+		,merge_queue_entry_updated`
+
+	mergeQueueEntrySelectBase = `
+		SELECT` + mergeQueueEntryColumns + `
+		FROM merge_queue_entries`
+)
+
+// Find finds a merge queue entry by its id.
+func (s *MergeQueueStore) Find(ctx context.Context, id int64) (*types.MergeQueueEntry, error) {
+	const sqlQuery = mergeQueueEntrySelectBase + `
+		WHERE merge_queue_entry_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &mergeQueueEntry{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find merge queue entry")
+	}
+
+	return mapMergeQueueEntry(dst), nil
+}
+
+// FindByPullReqID finds the merge queue entry of a pull request.
+func (s *MergeQueueStore) FindByPullReqID(ctx context.Context, pullreqID int64) (*types.MergeQueueEntry, error) {
+	const sqlQuery = mergeQueueEntrySelectBase + `
+		WHERE merge_queue_entry_pullreq_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &mergeQueueEntry{}
+	if err := db.GetContext(ctx, dst, sqlQuery, pullreqID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find merge queue entry by pull request id")
+	}
+
+	return mapMergeQueueEntry(dst), nil
+}
+
+// Create adds a pull request to the merge queue.
+func (s *MergeQueueStore) Create(ctx context.Context, entry *types.MergeQueueEntry) error {
+	const sqlQuery = `
+		INSERT INTO merge_queue_entries (
+			 merge_queue_entry_repo_id
+			,merge_queue_entry_branch
+			,merge_queue_entry_pullreq_id
+			,merge_queue_entry_pullreq_number
+			,merge_queue_entry_state
+			,merge_queue_entry_method
+			,merge_queue_entry_title
+			,merge_queue_entry_message
+			,merge_queue_entry_bypass_rules
+			,merge_queue_entry_head_sha
+			,merge_queue_entry_base_sha
+			,merge_queue_entry_merge_sha
+			,merge_queue_entry_error
+			,merge_queue_entry_created_by
+			,merge_queue_entry_created
+			,merge_queue_entry_updated
+		) values (
+			 :merge_queue_entry_repo_id
+			,:merge_queue_entry_branch
+			,:merge_queue_entry_pullreq_id
+			,:merge_queue_entry_pullreq_number
+			,:merge_queue_entry_state
+			,:merge_queue_entry_method
+			,:merge_queue_entry_title
+			,:merge_queue_entry_message
+			,:merge_queue_entry_bypass_rules
+			,:merge_queue_entry_head_sha
+			,:merge_queue_entry_base_sha
+			,:merge_queue_entry_merge_sha
+			,:merge_queue_entry_error
+			,:merge_queue_entry_created_by
+			,:merge_queue_entry_created
+			,:merge_queue_entry_updated
+		) RETURNING merge_queue_entry_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalMergeQueueEntry(entry))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind merge queue entry")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&entry.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert merge queue entry query failed")
+	}
+
+	return nil
+}
+
+// Update updates the state of a merge queue entry.
+func (s *MergeQueueStore) Update(ctx context.Context, entry *types.MergeQueueEntry) error {
+	const sqlQuery = `
+		UPDATE merge_queue_entries
+		SET
+			 merge_queue_entry_state = :merge_queue_entry_state
+			,merge_queue_entry_base_sha = :merge_queue_entry_base_sha
+			,merge_queue_entry_merge_sha = :merge_queue_entry_merge_sha
+			,merge_queue_entry_error = :merge_queue_entry_error
+			,merge_queue_entry_updated = :merge_queue_entry_updated
+		WHERE merge_queue_entry_id = :merge_queue_entry_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalMergeQueueEntry(entry))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind merge queue entry")
+	}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update merge queue entry")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated merge queue entries")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete removes an entry from the merge queue.
+func (s *MergeQueueStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM merge_queue_entries
+		WHERE merge_queue_entry_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete merge queue entry query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted merge queue entries")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// ListByBranch returns all entries of the merge queue of a branch in the order they were added to the queue.
+func (s *MergeQueueStore) ListByBranch(
+	ctx context.Context,
+	repoID int64,
+	branch string,
+) ([]*types.MergeQueueEntry, error) {
+	const sqlQuery = mergeQueueEntrySelectBase + `
+		WHERE merge_queue_entry_repo_id = $1 AND merge_queue_entry_branch = $2
+		ORDER BY merge_queue_entry_id ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*mergeQueueEntry
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID, branch); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list merge queue entries")
+	}
+
+	return mapMergeQueueEntries(dst), nil
+}
+
+// ListBranches returns all branches that have at least one entry waiting in the merge queue.
+func (s *MergeQueueStore) ListBranches(ctx context.Context) ([]types.MergeQueueBranch, error) {
+	const sqlQuery = `
+		SELECT DISTINCT merge_queue_entry_repo_id, merge_queue_entry_branch
+		FROM merge_queue_entries
+		WHERE merge_queue_entry_state <> $1
+		ORDER BY merge_queue_entry_repo_id, merge_queue_entry_branch`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []struct {
+		RepoID int64  `db:"merge_queue_entry_repo_id"`
+		Branch string `db:"merge_queue_entry_branch"`
+	}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, enum.MergeQueueEntryStateFailed); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list merge queue branches")
+	}
+
+	branches := make([]types.MergeQueueBranch, len(dst))
+	for i := range dst {
+		branches[i] = types.MergeQueueBranch{
+			RepoID: dst[i].RepoID,
+			Branch: dst[i].Branch,
+		}
+	}
+
+	return branches, nil
+}
+
+func mapInternalMergeQueueEntry(entry *types.MergeQueueEntry) *mergeQueueEntry {
+	return &mergeQueueEntry{
+		ID:            entry.ID,
+		RepoID:        entry.RepoID,
+		Branch:        entry.Branch,
+		PullReqID:     entry.PullReqID,
+		PullReqNumber: entry.PullReqNumber,
+		State:         entry.State,
+		Method:        entry.Method,
+		Title:         entry.Title,
+		Message:       entry.Message,
+		BypassRules:   entry.BypassRules,
+		HeadSHA:       entry.HeadSHA,
+		BaseSHA:       entry.BaseSHA,
+		MergeSHA:      entry.MergeSHA,
+		Error:         entry.Error,
+		CreatedBy:     entry.CreatedBy,
+		Created:       entry.Created,
+		Updated:       entry.Updated,
+	}
+}
+
+func mapMergeQueueEntry(entry *mergeQueueEntry) *types.MergeQueueEntry {
+	return &types.MergeQueueEntry{
+		ID:            entry.ID,
+		RepoID:        entry.RepoID,
+		Branch:        entry.Branch,
+		PullReqID:     entry.PullReqID,
+		PullReqNumber: entry.PullReqNumber,
+		State:         entry.State,
+		Method:        entry.Method,
+		Title:         entry.Title,
+		Message:       entry.Message,
+		BypassRules:   entry.BypassRules,
+		HeadSHA:       entry.HeadSHA,
+		BaseSHA:       entry.BaseSHA,
+		MergeSHA:      entry.MergeSHA,
+		Error:         entry.Error,
+		CreatedBy:     entry.CreatedBy,
+		Created:       entry.Created,
+		Updated:       entry.Updated,
+	}
+}
+
+func mapMergeQueueEntries(entries []*mergeQueueEntry) []*types.MergeQueueEntry {
+	res := make([]*types.MergeQueueEntry, len(entries))
+	for i := range entries {
+		res[i] = mapMergeQueueEntry(entries[i])
+	}
+	return res
+}
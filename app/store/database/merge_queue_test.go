@@ -0,0 +1,168 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestMergeQueueStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	pullReqStore := database.NewPullReqStore(db, nil)
+	mergeQueueStore := database.NewMergeQueueStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	entries := make([]*types.MergeQueueEntry, 3)
+	for i := range entries {
+		pr := createPullReq(ctx, t, pullReqStore, 1, int64(i+1))
+
+		entries[i] = &types.MergeQueueEntry{
+			RepoID:        1,
+			Branch:        pr.TargetBranch,
+			PullReqID:     pr.ID,
+			PullReqNumber: pr.Number,
+			State:         enum.MergeQueueEntryStateQueued,
+			Method:        enum.MergeMethodSquash,
+			Title:         pr.Title,
+			BypassRules:   i == 0,
+			HeadSHA:       pr.SourceSHA,
+			CreatedBy:     userID,
+		}
+		if err := mergeQueueStore.Create(ctx, entries[i]); err != nil {
+			t.Fatalf("failed to create merge queue entry: %v", err)
+		}
+	}
+
+	found, err := mergeQueueStore.FindByPullReqID(ctx, entries[0].PullReqID)
+	if err != nil {
+		t.Fatalf("failed to find merge queue entry: %v", err)
+	}
+	if found.ID != entries[0].ID || !found.BypassRules || found.Method != enum.MergeMethodSquash {
+		t.Errorf("found unexpected merge queue entry: %+v", found)
+	}
+
+	entries[1].State = enum.MergeQueueEntryStateChecking
+	entries[1].BaseSHA = "base"
+	entries[1].MergeSHA = "merge"
+	if err = mergeQueueStore.Update(ctx, entries[1]); err != nil {
+		t.Fatalf("failed to update merge queue entry: %v", err)
+	}
+
+	found, err = mergeQueueStore.Find(ctx, entries[1].ID)
+	if err != nil {
+		t.Fatalf("failed to find merge queue entry: %v", err)
+	}
+	if found.State != enum.MergeQueueEntryStateChecking || found.BaseSHA != "base" || found.MergeSHA != "merge" {
+		t.Errorf("merge queue entry not updated: %+v", found)
+	}
+
+	list, err := mergeQueueStore.ListByBranch(ctx, 1, "main")
+	if err != nil {
+		t.Fatalf("failed to list merge queue entries: %v", err)
+	}
+	if len(list) != len(entries) {
+		t.Fatalf("want %d merge queue entries, got %d", len(entries), len(list))
+	}
+	for i := range list {
+		if list[i].ID != entries[i].ID {
+			t.Errorf("merge queue entry %d: want id %d, got %d", i, entries[i].ID, list[i].ID)
+		}
+	}
+
+	// failed entries remain in the table, but the branch is listed only while it has entries waiting.
+	for _, entry := range entries {
+		entry.State = enum.MergeQueueEntryStateFailed
+		if err = mergeQueueStore.Update(ctx, entry); err != nil {
+			t.Fatalf("failed to update merge queue entry: %v", err)
+		}
+	}
+
+	branches, err := mergeQueueStore.ListBranches(ctx)
+	if err != nil {
+		t.Fatalf("failed to list merge queue branches: %v", err)
+	}
+	if len(branches) != 0 {
+		t.Errorf("want no merge queue branches, got %v", branches)
+	}
+
+	entries[2].State = enum.MergeQueueEntryStateQueued
+	if err = mergeQueueStore.Update(ctx, entries[2]); err != nil {
+		t.Fatalf("failed to update merge queue entry: %v", err)
+	}
+
+	branches, err = mergeQueueStore.ListBranches(ctx)
+	if err != nil {
+		t.Fatalf("failed to list merge queue branches: %v", err)
+	}
+	if len(branches) != 1 || branches[0] != (types.MergeQueueBranch{RepoID: 1, Branch: "main"}) {
+		t.Errorf("unexpected merge queue branches: %v", branches)
+	}
+
+	if err = mergeQueueStore.Delete(ctx, entries[0].ID); err != nil {
+		t.Fatalf("failed to delete merge queue entry: %v", err)
+	}
+
+	if err = mergeQueueStore.Delete(ctx, entries[0].ID); !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("want %v when deleting a missing entry, got %v", gitness_store.ErrResourceNotFound, err)
+	}
+
+	_, err = mergeQueueStore.FindByPullReqID(ctx, entries[0].PullReqID)
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("want %v when finding a deleted entry, got %v", gitness_store.ErrResourceNotFound, err)
+	}
+}
+
+func createPullReq(
+	ctx context.Context,
+	t *testing.T,
+	pullReqStore *database.PullReqStore,
+	repoID int64,
+	number int64,
+) *types.PullReq {
+	t.Helper()
+
+	pr := &types.PullReq{
+		Number:       number,
+		CreatedBy:    userID,
+		State:        enum.PullReqStateOpen,
+		Title:        "pull request " + strconv.FormatInt(number, 10),
+		SourceRepoID: repoID,
+		SourceBranch: "feature-" + strconv.FormatInt(number, 10),
+		SourceSHA:    strconv.FormatInt(number, 10),
+		TargetRepoID: repoID,
+		TargetBranch: "main",
+	}
+	if err := pullReqStore.Create(ctx, pr); err != nil {
+		t.Fatalf("failed to create pull request: %v", err)
+	}
+
+	return pr
+}
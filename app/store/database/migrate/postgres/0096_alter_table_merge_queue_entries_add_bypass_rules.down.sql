@@ -0,0 +1 @@
+ALTER TABLE merge_queue_entries DROP COLUMN merge_queue_entry_bypass_rules;
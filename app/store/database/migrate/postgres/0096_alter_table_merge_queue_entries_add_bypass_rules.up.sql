@@ -0,0 +1 @@
+ALTER TABLE merge_queue_entries ADD COLUMN merge_queue_entry_bypass_rules BOOLEAN NOT NULL DEFAULT FALSE;
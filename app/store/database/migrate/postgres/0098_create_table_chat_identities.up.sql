@@ -0,0 +1,12 @@
+CREATE TABLE chat_identities (
+ chat_identity_principal_id INTEGER NOT NULL
+,chat_identity_provider TEXT NOT NULL
+,chat_identity_identifier TEXT NOT NULL
+,chat_identity_created BIGINT NOT NULL
+,chat_identity_updated BIGINT NOT NULL
+,CONSTRAINT pk_chat_identities PRIMARY KEY (chat_identity_principal_id, chat_identity_provider)
+,CONSTRAINT fk_chat_identity_principal_id FOREIGN KEY (chat_identity_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
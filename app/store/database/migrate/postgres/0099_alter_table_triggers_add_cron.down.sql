@@ -0,0 +1,3 @@
+ALTER TABLE triggers DROP COLUMN trigger_cron;
+ALTER TABLE triggers DROP COLUMN trigger_cron_branch;
+ALTER TABLE triggers DROP COLUMN trigger_cron_timezone;
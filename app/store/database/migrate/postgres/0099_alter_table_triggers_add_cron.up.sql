@@ -0,0 +1,3 @@
+ALTER TABLE triggers ADD COLUMN trigger_cron TEXT NOT NULL DEFAULT '';
+ALTER TABLE triggers ADD COLUMN trigger_cron_branch TEXT NOT NULL DEFAULT '';
+ALTER TABLE triggers ADD COLUMN trigger_cron_timezone TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+DROP TABLE pipeline_artifacts;
+DROP TABLE pipeline_caches;
@@ -0,0 +1,5 @@
+ALTER TABLE stages DROP COLUMN stage_environment_id;
+ALTER TABLE stages DROP COLUMN stage_environment;
+
+DROP TABLE stage_approvals;
+DROP TABLE environments;
@@ -0,0 +1,49 @@
+CREATE TABLE environments (
+ environment_id SERIAL PRIMARY KEY
+,environment_space_id INTEGER NOT NULL
+,environment_identifier TEXT NOT NULL
+,environment_description TEXT NOT NULL
+,environment_approver_ids TEXT NOT NULL
+,environment_approver_user_groups TEXT NOT NULL
+,environment_created_by INTEGER NOT NULL
+,environment_created BIGINT NOT NULL
+,environment_updated BIGINT NOT NULL
+,CONSTRAINT fk_environment_space_id FOREIGN KEY (environment_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_environment_created_by FOREIGN KEY (environment_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX environments_space_id_identifier
+    ON environments(environment_space_id, LOWER(environment_identifier));
+
+CREATE TABLE stage_approvals (
+ stage_approval_id SERIAL PRIMARY KEY
+,stage_approval_execution_id INTEGER NOT NULL
+,stage_approval_stage_id INTEGER NOT NULL
+,stage_approval_stage_number INTEGER NOT NULL
+,stage_approval_environment_id INTEGER NOT NULL
+,stage_approval_environment TEXT NOT NULL
+,stage_approval_principal_id INTEGER NOT NULL
+,stage_approval_decision TEXT NOT NULL
+,stage_approval_comment TEXT NOT NULL
+,stage_approval_created BIGINT NOT NULL
+,CONSTRAINT fk_stage_approval_execution_id FOREIGN KEY (stage_approval_execution_id)
+    REFERENCES executions (execution_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_stage_approval_principal_id FOREIGN KEY (stage_approval_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE INDEX stage_approvals_execution_id
+    ON stage_approvals(stage_approval_execution_id);
+
+ALTER TABLE stages ADD COLUMN stage_environment TEXT NOT NULL DEFAULT '';
+ALTER TABLE stages ADD COLUMN stage_environment_id INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+DROP INDEX triggers_upstream_pipeline_id;
+
+ALTER TABLE triggers DROP COLUMN trigger_params;
+ALTER TABLE triggers DROP COLUMN trigger_upstream_statuses;
+ALTER TABLE triggers DROP COLUMN trigger_upstream_pipeline_id;
@@ -0,0 +1,6 @@
+ALTER TABLE triggers ADD COLUMN trigger_upstream_pipeline_id INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE triggers ADD COLUMN trigger_upstream_statuses TEXT NOT NULL DEFAULT 'null';
+ALTER TABLE triggers ADD COLUMN trigger_params TEXT NOT NULL DEFAULT 'null';
+
+CREATE INDEX triggers_upstream_pipeline_id
+    ON triggers(trigger_upstream_pipeline_id);
@@ -0,0 +1,2 @@
+ALTER TABLE stages DROP COLUMN stage_reused_from;
+ALTER TABLE executions DROP COLUMN execution_retry_of;
@@ -0,0 +1,22 @@
+CREATE TABLE test_cases (
+ test_case_id SERIAL PRIMARY KEY
+,test_case_pipeline_id INTEGER NOT NULL
+,test_case_execution_id INTEGER NOT NULL
+,test_case_suite TEXT NOT NULL
+,test_case_name TEXT NOT NULL
+,test_case_status TEXT NOT NULL
+,test_case_duration BIGINT NOT NULL
+,test_case_message TEXT NOT NULL
+,test_case_flaky BOOLEAN NOT NULL
+,test_case_created BIGINT NOT NULL
+,CONSTRAINT fk_test_case_execution_id FOREIGN KEY (test_case_execution_id)
+    REFERENCES executions (execution_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX test_cases_execution_id_status
+    ON test_cases(test_case_execution_id, test_case_status);
+
+CREATE INDEX test_cases_pipeline_id_suite_name
+    ON test_cases(test_case_pipeline_id, test_case_suite, test_case_name);
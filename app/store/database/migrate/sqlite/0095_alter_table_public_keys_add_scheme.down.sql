@@ -0,0 +1,3 @@
+DROP TABLE public_key_sub_keys;
+
+ALTER TABLE public_keys DROP COLUMN public_key_scheme;
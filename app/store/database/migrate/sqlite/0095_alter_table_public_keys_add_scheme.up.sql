@@ -0,0 +1,13 @@
+ALTER TABLE public_keys ADD COLUMN public_key_scheme TEXT NOT NULL DEFAULT 'ssh';
+
+CREATE TABLE public_key_sub_keys (
+ public_key_sub_key_public_key_id INTEGER NOT NULL
+,public_key_sub_key_id TEXT NOT NULL
+,CONSTRAINT fk_public_key_sub_key_public_key_id FOREIGN KEY (public_key_sub_key_public_key_id)
+    REFERENCES public_keys (public_key_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX public_key_sub_keys_id
+    ON public_key_sub_keys(public_key_sub_key_id);
@@ -0,0 +1,30 @@
+CREATE TABLE audit_events (
+ audit_event_id INTEGER PRIMARY KEY AUTOINCREMENT
+,audit_event_created BIGINT NOT NULL
+,audit_event_action TEXT NOT NULL
+,audit_event_resource_type TEXT NOT NULL
+,audit_event_resource_identifier TEXT NOT NULL
+,audit_event_resource_data TEXT NOT NULL
+,audit_event_space_path TEXT NOT NULL
+,audit_event_repo_path TEXT NOT NULL
+,audit_event_principal_id INTEGER NOT NULL
+,audit_event_principal_uid TEXT NOT NULL
+,audit_event_principal_email TEXT NOT NULL
+,audit_event_principal_display_name TEXT NOT NULL
+,audit_event_principal_type TEXT NOT NULL
+,audit_event_old_object TEXT NOT NULL
+,audit_event_new_object TEXT NOT NULL
+,audit_event_client_ip TEXT NOT NULL
+,audit_event_request_method TEXT NOT NULL
+,audit_event_request_id TEXT NOT NULL
+,audit_event_data TEXT NOT NULL
+);
+
+CREATE INDEX audit_events_created
+    ON audit_events(audit_event_created);
+
+CREATE INDEX audit_events_space_path_created
+    ON audit_events(LOWER(audit_event_space_path), audit_event_created);
+
+CREATE INDEX audit_events_repo_path_created
+    ON audit_events(LOWER(audit_event_repo_path), audit_event_created);
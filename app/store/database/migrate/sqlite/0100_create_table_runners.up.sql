@@ -0,0 +1,25 @@
+CREATE TABLE runners (
+ runner_id INTEGER PRIMARY KEY AUTOINCREMENT
+,runner_identifier TEXT NOT NULL
+,runner_description TEXT NOT NULL
+,runner_os TEXT NOT NULL
+,runner_arch TEXT NOT NULL
+,runner_kernel TEXT NOT NULL
+,runner_variant TEXT NOT NULL
+,runner_labels TEXT NOT NULL
+,runner_token_hash TEXT NOT NULL
+,runner_last_seen BIGINT NOT NULL
+,runner_created_by INTEGER NOT NULL
+,runner_created BIGINT NOT NULL
+,runner_updated BIGINT NOT NULL
+,CONSTRAINT fk_runner_created_by FOREIGN KEY (runner_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX runners_identifier
+    ON runners(LOWER(runner_identifier));
+
+CREATE UNIQUE INDEX runners_token_hash
+    ON runners(runner_token_hash);
@@ -0,0 +1,27 @@
+CREATE TABLE pipeline_caches (
+ pipeline_cache_repo_id INTEGER NOT NULL
+,pipeline_cache_key TEXT NOT NULL
+,pipeline_cache_size BIGINT NOT NULL
+,pipeline_cache_created BIGINT NOT NULL
+,pipeline_cache_updated BIGINT NOT NULL
+,pipeline_cache_last_used BIGINT NOT NULL
+,CONSTRAINT pk_pipeline_caches PRIMARY KEY (pipeline_cache_repo_id, pipeline_cache_key)
+);
+
+CREATE INDEX pipeline_caches_last_used
+    ON pipeline_caches(pipeline_cache_last_used);
+
+CREATE TABLE pipeline_artifacts (
+ pipeline_artifact_id INTEGER PRIMARY KEY AUTOINCREMENT
+,pipeline_artifact_repo_id INTEGER NOT NULL
+,pipeline_artifact_execution_id INTEGER NOT NULL
+,pipeline_artifact_name TEXT NOT NULL
+,pipeline_artifact_size BIGINT NOT NULL
+,pipeline_artifact_created BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX pipeline_artifacts_execution_id_name
+    ON pipeline_artifacts(pipeline_artifact_execution_id, pipeline_artifact_name);
+
+CREATE INDEX pipeline_artifacts_created
+    ON pipeline_artifacts(pipeline_artifact_created);
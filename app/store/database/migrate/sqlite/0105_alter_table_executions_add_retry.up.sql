@@ -0,0 +1,2 @@
+ALTER TABLE executions ADD COLUMN execution_retry_of INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE stages ADD COLUMN stage_reused_from INTEGER NOT NULL DEFAULT 0;
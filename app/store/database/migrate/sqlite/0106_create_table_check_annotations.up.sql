@@ -0,0 +1,17 @@
+CREATE TABLE check_annotations (
+ check_annotation_id INTEGER PRIMARY KEY AUTOINCREMENT
+,check_annotation_check_id INTEGER NOT NULL
+,check_annotation_path TEXT NOT NULL
+,check_annotation_line_start INTEGER NOT NULL
+,check_annotation_line_end INTEGER NOT NULL
+,check_annotation_severity TEXT NOT NULL
+,check_annotation_title TEXT NOT NULL
+,check_annotation_message TEXT NOT NULL
+,CONSTRAINT fk_check_annotation_check_id FOREIGN KEY (check_annotation_check_id)
+    REFERENCES checks (check_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX check_annotations_check_id
+    ON check_annotations(check_annotation_check_id);
@@ -0,0 +1,2 @@
+ALTER TABLE secrets DROP COLUMN secret_external_path;
+ALTER TABLE secrets DROP COLUMN secret_provider;
@@ -0,0 +1,2 @@
+ALTER TABLE secrets ADD COLUMN secret_provider TEXT NOT NULL DEFAULT 'local';
+ALTER TABLE secrets ADD COLUMN secret_external_path TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,209 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PipelineArtifactStore = (*PipelineArtifactStore)(nil)
+
+// NewPipelineArtifactStore returns a new PipelineArtifactStore.
+func NewPipelineArtifactStore(db *sqlx.DB) *PipelineArtifactStore {
+	return &PipelineArtifactStore{
+		db: db,
+	}
+}
+
+// PipelineArtifactStore implements a store.PipelineArtifactStore backed by a relational database.
+type PipelineArtifactStore struct {
+	db *sqlx.DB
+}
+
+type pipelineArtifact struct {
+	ID          int64  `db:"pipeline_artifact_id"`
+	RepoID      int64  `db:"pipeline_artifact_repo_id"`
+	ExecutionID int64  `db:"pipeline_artifact_execution_id"`
+	Name        string `db:"pipeline_artifact_name"`
+	Size        int64  `db:"pipeline_artifact_size"`
+	Created     int64  `db:"pipeline_artifact_created"`
+}
+
+const (
+	pipelineArtifactColumns = `
+		 pipeline_artifact_id
+		,pipeline_artifact_repo_id
+		,pipeline_artifact_execution_id
+		,pipeline_artifact_name
+		,pipeline_artifact_size
+		,pipeline_artifact_created`
+
+	pipelineArtifactSelectBase = `
+		SELECT` + pipelineArtifactColumns + `
+		FROM pipeline_artifacts`
+)
+
+// Find finds the artifact of a pipeline execution by its name.
+func (s *PipelineArtifactStore) Find(
+	ctx context.Context,
+	executionID int64,
+	name string,
+) (*types.PipelineArtifact, error) {
+	const sqlQuery = pipelineArtifactSelectBase + `
+		WHERE pipeline_artifact_execution_id = $1 AND pipeline_artifact_name = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pipelineArtifact{}
+	if err := db.GetContext(ctx, dst, sqlQuery, executionID, name); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find pipeline artifact")
+	}
+
+	return mapPipelineArtifact(dst), nil
+}
+
+// Upsert creates a new pipeline artifact or replaces the existing one with the same name.
+func (s *PipelineArtifactStore) Upsert(ctx context.Context, artifact *types.PipelineArtifact) error {
+	const sqlQuery = `
+		INSERT INTO pipeline_artifacts (
+			 pipeline_artifact_repo_id
+			,pipeline_artifact_execution_id
+			,pipeline_artifact_name
+			,pipeline_artifact_size
+			,pipeline_artifact_created
+		) values (
+			 :pipeline_artifact_repo_id
+			,:pipeline_artifact_execution_id
+			,:pipeline_artifact_name
+			,:pipeline_artifact_size
+			,:pipeline_artifact_created
+		)
+		ON CONFLICT (pipeline_artifact_execution_id, pipeline_artifact_name) DO
+		UPDATE SET
+			 pipeline_artifact_size = :pipeline_artifact_size
+			,pipeline_artifact_created = :pipeline_artifact_created
+		RETURNING pipeline_artifact_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalPipelineArtifact(artifact))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pipeline artifact object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&artifact.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert pipeline artifact query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the pipeline artifact.
+func (s *PipelineArtifactStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM pipeline_artifacts
+		WHERE pipeline_artifact_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete pipeline artifact query failed")
+	}
+
+	return nil
+}
+
+// List returns all artifacts of a pipeline execution.
+func (s *PipelineArtifactStore) List(ctx context.Context, executionID int64) ([]*types.PipelineArtifact, error) {
+	stmt := database.Builder.
+		Select(pipelineArtifactColumns).
+		From("pipeline_artifacts").
+		Where("pipeline_artifact_execution_id = ?", executionID).
+		OrderBy("pipeline_artifact_name")
+
+	return s.list(ctx, stmt)
+}
+
+// ListCreatedBefore returns up to limit pipeline artifacts, of all repositories,
+// that were created before the provided time.
+func (s *PipelineArtifactStore) ListCreatedBefore(
+	ctx context.Context,
+	createdBefore int64,
+	limit int,
+) ([]*types.PipelineArtifact, error) {
+	stmt := database.Builder.
+		Select(pipelineArtifactColumns).
+		From("pipeline_artifacts").
+		Where("pipeline_artifact_created < ?", createdBefore).
+		OrderBy("pipeline_artifact_created").
+		Limit(uint64(limit))
+
+	return s.list(ctx, stmt)
+}
+
+func (s *PipelineArtifactStore) list(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+) ([]*types.PipelineArtifact, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*pipelineArtifact{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing pipeline artifact list query")
+	}
+
+	artifacts := make([]*types.PipelineArtifact, len(dst))
+	for i, a := range dst {
+		artifacts[i] = mapPipelineArtifact(a)
+	}
+
+	return artifacts, nil
+}
+
+func mapPipelineArtifact(in *pipelineArtifact) *types.PipelineArtifact {
+	return &types.PipelineArtifact{
+		ID:          in.ID,
+		RepoID:      in.RepoID,
+		ExecutionID: in.ExecutionID,
+		Name:        in.Name,
+		Size:        in.Size,
+		Created:     in.Created,
+	}
+}
+
+func mapInternalPipelineArtifact(in *types.PipelineArtifact) *pipelineArtifact {
+	return &pipelineArtifact{
+		ID:          in.ID,
+		RepoID:      in.RepoID,
+		ExecutionID: in.ExecutionID,
+		Name:        in.Name,
+		Size:        in.Size,
+		Created:     in.Created,
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PipelineCacheStore = (*PipelineCacheStore)(nil)
+
+// NewPipelineCacheStore returns a new PipelineCacheStore.
+func NewPipelineCacheStore(db *sqlx.DB) *PipelineCacheStore {
+	return &PipelineCacheStore{
+		db: db,
+	}
+}
+
+// PipelineCacheStore implements a store.PipelineCacheStore backed by a relational database.
+type PipelineCacheStore struct {
+	db *sqlx.DB
+}
+
+type pipelineCache struct {
+	RepoID   int64  `db:"pipeline_cache_repo_id"`
+	Key      string `db:"pipeline_cache_key"`
+	Size     int64  `db:"pipeline_cache_size"`
+	Created  int64  `db:"pipeline_cache_created"`
+	Updated  int64  `db:"pipeline_cache_updated"`
+	LastUsed int64  `db:"pipeline_cache_last_used"`
+}
+
+const (
+	pipelineCacheColumns = `
+		 pipeline_cache_repo_id
+		,pipeline_cache_key
+		,pipeline_cache_size
+		,pipeline_cache_created
+		,pipeline_cache_updated
+		,pipeline_cache_last_used`
+
+	pipelineCacheSelectBase = `
+		SELECT` + pipelineCacheColumns + `
+		FROM pipeline_caches`
+)
+
+// Find finds the pipeline cache of a repository by its key.
+func (s *PipelineCacheStore) Find(ctx context.Context, repoID int64, key string) (*types.PipelineCache, error) {
+	const sqlQuery = pipelineCacheSelectBase + `
+		WHERE pipeline_cache_repo_id = $1 AND pipeline_cache_key = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pipelineCache{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, key); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find pipeline cache")
+	}
+
+	return mapPipelineCache(dst), nil
+}
+
+// Upsert creates a new pipeline cache or updates the size of an existing one.
+func (s *PipelineCacheStore) Upsert(ctx context.Context, cache *types.PipelineCache) error {
+	const sqlQuery = `
+		INSERT INTO pipeline_caches (
+			 pipeline_cache_repo_id
+			,pipeline_cache_key
+			,pipeline_cache_size
+			,pipeline_cache_created
+			,pipeline_cache_updated
+			,pipeline_cache_last_used
+		) values (
+			 :pipeline_cache_repo_id
+			,:pipeline_cache_key
+			,:pipeline_cache_size
+			,:pipeline_cache_created
+			,:pipeline_cache_updated
+			,:pipeline_cache_last_used
+		)
+		ON CONFLICT (pipeline_cache_repo_id, pipeline_cache_key) DO
+		UPDATE SET
+			 pipeline_cache_size = :pipeline_cache_size
+			,pipeline_cache_updated = :pipeline_cache_updated
+			,pipeline_cache_last_used = :pipeline_cache_last_used
+		RETURNING pipeline_cache_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalPipelineCache(cache))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pipeline cache object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&cache.Created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert pipeline cache query failed")
+	}
+
+	return nil
+}
+
+// UpdateLastUsed updates the time the pipeline cache was last used.
+func (s *PipelineCacheStore) UpdateLastUsed(ctx context.Context, repoID int64, key string, lastUsed int64) error {
+	const sqlQuery = `
+		UPDATE pipeline_caches
+		SET pipeline_cache_last_used = $1
+		WHERE pipeline_cache_repo_id = $2 AND pipeline_cache_key = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, lastUsed, repoID, key); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update pipeline cache last used time")
+	}
+
+	return nil
+}
+
+// Delete deletes the pipeline cache of a repository.
+func (s *PipelineCacheStore) Delete(ctx context.Context, repoID int64, key string) error {
+	const sqlQuery = `
+		DELETE FROM pipeline_caches
+		WHERE pipeline_cache_repo_id = $1 AND pipeline_cache_key = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, repoID, key)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete pipeline cache query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted pipeline caches")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// List returns a list of pipeline caches of a repository.
+func (s *PipelineCacheStore) List(
+	ctx context.Context,
+	repoID int64,
+	filter types.ListQueryFilter,
+) ([]*types.PipelineCache, error) {
+	stmt := database.Builder.
+		Select(pipelineCacheColumns).
+		From("pipeline_caches").
+		Where("pipeline_cache_repo_id = ?", repoID)
+
+	if filter.Query != "" {
+		stmt = stmt.Where(PartialMatch("pipeline_cache_key", filter.Query))
+	}
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	stmt = stmt.OrderBy("pipeline_cache_key")
+
+	return s.list(ctx, stmt)
+}
+
+// Count returns the number of pipeline caches of a repository matching the filter.
+func (s *PipelineCacheStore) Count(ctx context.Context, repoID int64, filter types.ListQueryFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("pipeline_caches").
+		Where("pipeline_cache_repo_id = ?", repoID)
+
+	if filter.Query != "" {
+		stmt = stmt.Where(PartialMatch("pipeline_cache_key", filter.Query))
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing pipeline cache count query")
+	}
+
+	return count, nil
+}
+
+// ListUnused returns up to limit pipeline caches, of all repositories, that weren't used since the provided time.
+func (s *PipelineCacheStore) ListUnused(
+	ctx context.Context,
+	lastUsedBefore int64,
+	limit int,
+) ([]*types.PipelineCache, error) {
+	stmt := database.Builder.
+		Select(pipelineCacheColumns).
+		From("pipeline_caches").
+		Where("pipeline_cache_last_used < ?", lastUsedBefore).
+		OrderBy("pipeline_cache_last_used").
+		Limit(uint64(limit))
+
+	return s.list(ctx, stmt)
+}
+
+func (s *PipelineCacheStore) list(ctx context.Context, stmt squirrel.SelectBuilder) ([]*types.PipelineCache, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*pipelineCache{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing pipeline cache list query")
+	}
+
+	caches := make([]*types.PipelineCache, len(dst))
+	for i, c := range dst {
+		caches[i] = mapPipelineCache(c)
+	}
+
+	return caches, nil
+}
+
+func mapPipelineCache(in *pipelineCache) *types.PipelineCache {
+	return &types.PipelineCache{
+		RepoID:   in.RepoID,
+		Key:      in.Key,
+		Size:     in.Size,
+		Created:  in.Created,
+		Updated:  in.Updated,
+		LastUsed: in.LastUsed,
+	}
+}
+
+func mapInternalPipelineCache(in *types.PipelineCache) *pipelineCache {
+	return &pipelineCache{
+		RepoID:   in.RepoID,
+		Key:      in.Key,
+		Size:     in.Size,
+		Created:  in.Created,
+		Updated:  in.Updated,
+		LastUsed: in.LastUsed,
+	}
+}
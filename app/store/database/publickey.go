@@ -60,6 +60,7 @@ type publicKey struct {
 	Content     string `db:"public_key_content"`
 	Comment     string `db:"public_key_comment"`
 	Type        string `db:"public_key_type"`
+	Scheme      string `db:"public_key_scheme"`
 }
 
 const (
@@ -73,7 +74,8 @@ const (
 		,public_key_fingerprint
 		,public_key_content
 		,public_key_comment
-		,public_key_type`
+		,public_key_type
+		,public_key_scheme`
 
 	publicKeySelectBase = `
 		SELECT` + publicKeyColumns + `
@@ -131,6 +133,7 @@ func (s PublicKeyStore) Create(ctx context.Context, key *types.PublicKey) error
 			,public_key_content
 			,public_key_comment
 			,public_key_type
+			,public_key_scheme
 		) values (
 			 :public_key_principal_id
 			,:public_key_created
@@ -141,6 +144,7 @@ func (s PublicKeyStore) Create(ctx context.Context, key *types.PublicKey) error
 			,:public_key_content
 			,:public_key_comment
 			,:public_key_type
+			,:public_key_scheme
 		) RETURNING public_key_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -158,6 +162,18 @@ func (s PublicKeyStore) Create(ctx context.Context, key *types.PublicKey) error
 
 	key.ID = dbKey.ID
 
+	for _, subKeyID := range key.SubKeyIDs {
+		const sqlQuerySubKey = `
+			INSERT INTO public_key_sub_keys (
+				 public_key_sub_key_public_key_id
+				,public_key_sub_key_id
+			) values ($1, $2)`
+
+		if _, err = db.ExecContext(ctx, sqlQuerySubKey, key.ID, subKeyID); err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Insert public key sub key query failed")
+		}
+	}
+
 	return nil
 }
 
@@ -281,6 +297,34 @@ func (s PublicKeyStore) ListByFingerprint(
 	return mapToPublicKeys(keys), nil
 }
 
+// ListBySubKeyID returns PGP public keys that have the provided key ID
+// as the ID of the primary key or one of the sub keys.
+func (s PublicKeyStore) ListBySubKeyID(
+	ctx context.Context,
+	subKeyID string,
+) ([]types.PublicKey, error) {
+	stmt := database.Builder.
+		Select(publicKeyColumns).
+		From("public_keys").
+		Join("public_key_sub_keys ON public_key_sub_key_public_key_id = public_key_id").
+		Where("public_key_sub_key_id = ?", subKeyID).
+		OrderBy("public_key_created ASC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	keys := make([]publicKey, 0)
+	if err = db.SelectContext(ctx, &keys, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "failed to execute public keys by sub key ID query")
+	}
+
+	return mapToPublicKeys(keys), nil
+}
+
 func (PublicKeyStore) applyQueryFilter(
 	stmt squirrel.SelectBuilder,
 	filter *types.PublicKeyFilter,
@@ -326,6 +370,7 @@ func mapToInternalPublicKey(in *types.PublicKey) publicKey {
 		Content:     in.Content,
 		Comment:     in.Comment,
 		Type:        in.Type,
+		Scheme:      string(in.Scheme),
 	}
 }
 
@@ -341,6 +386,7 @@ func mapToPublicKey(in *publicKey) types.PublicKey {
 		Content:     in.Content,
 		Comment:     in.Comment,
 		Type:        in.Type,
+		Scheme:      enum.PublicKeyScheme(in.Scheme),
 	}
 }
 
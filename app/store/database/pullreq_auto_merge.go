@@ -0,0 +1,233 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PullReqAutoMergeStore = (*PullReqAutoMergeStore)(nil)
+
+// NewPullReqAutoMergeStore returns a new PullReqAutoMergeStore.
+func NewPullReqAutoMergeStore(db *sqlx.DB) *PullReqAutoMergeStore {
+	return &PullReqAutoMergeStore{
+		db: db,
+	}
+}
+
+// PullReqAutoMergeStore implements a store.PullReqAutoMergeStore backed by a relational database.
+type PullReqAutoMergeStore struct {
+	db *sqlx.DB
+}
+
+type pullReqAutoMerge struct {
+	PullReqID int64            `db:"auto_merge_pullreq_id"`
+	RepoID    int64            `db:"auto_merge_repo_id"`
+	SourceSHA string           `db:"auto_merge_source_sha"`
+	Method    enum.MergeMethod `db:"auto_merge_method"`
+	Title     string           `db:"auto_merge_title"`
+	Message   string           `db:"auto_merge_message"`
+	CreatedBy int64            `db:"auto_merge_created_by"`
+	Created   int64            `db:"auto_merge_created"`
+}
+
+const (
+	pullReqAutoMergeColumns = `
+		 auto_merge_pullreq_id
+		,auto_merge_repo_id
+		,auto_merge_source_sha
+		,auto_merge_method
+		,auto_merge_title
+		,auto_merge_message
+		,auto_merge_created_by
+		,auto_merge_created`
+
+	pullReqAutoMergeSelectBase = `
+		SELECT` + pullReqAutoMergeColumns + `
+		FROM pullreq_auto_merges`
+)
+
+// Find finds the auto merge settings of a pull request.
+func (s *PullReqAutoMergeStore) Find(ctx context.Context, pullreqID int64) (*types.PullReqAutoMerge, error) {
+	const sqlQuery = pullReqAutoMergeSelectBase + `
+		WHERE auto_merge_pullreq_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pullReqAutoMerge{}
+	if err := db.GetContext(ctx, dst, sqlQuery, pullreqID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find pull request auto merge")
+	}
+
+	return mapPullReqAutoMerge(dst), nil
+}
+
+// Upsert enables auto merge of a pull request or overrides its existing auto merge settings.
+func (s *PullReqAutoMergeStore) Upsert(ctx context.Context, autoMerge *types.PullReqAutoMerge) error {
+	const sqlQuery = `
+		INSERT INTO pullreq_auto_merges (
+			 auto_merge_pullreq_id
+			,auto_merge_repo_id
+			,auto_merge_source_sha
+			,auto_merge_method
+			,auto_merge_title
+			,auto_merge_message
+			,auto_merge_created_by
+			,auto_merge_created
+		) values (
+			 :auto_merge_pullreq_id
+			,:auto_merge_repo_id
+			,:auto_merge_source_sha
+			,:auto_merge_method
+			,:auto_merge_title
+			,:auto_merge_message
+			,:auto_merge_created_by
+			,:auto_merge_created
+		)
+		ON CONFLICT (auto_merge_pullreq_id) DO
+		UPDATE SET
+			 auto_merge_source_sha = :auto_merge_source_sha
+			,auto_merge_method = :auto_merge_method
+			,auto_merge_title = :auto_merge_title
+			,auto_merge_message = :auto_merge_message
+			,auto_merge_created_by = :auto_merge_created_by
+			,auto_merge_created = :auto_merge_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalPullReqAutoMerge(autoMerge))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pull request auto merge")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert pull request auto merge query failed")
+	}
+
+	return nil
+}
+
+// Delete disables auto merge of a pull request.
+func (s *PullReqAutoMergeStore) Delete(ctx context.Context, pullreqID int64) error {
+	const sqlQuery = `
+		DELETE FROM pullreq_auto_merges
+		WHERE auto_merge_pullreq_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, pullreqID)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete pull request auto merge query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted pull request auto merges")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// ListBySourceSHA returns auto merge settings of all pull requests of a repository
+// that have auto merge enabled for the provided source branch commit.
+func (s *PullReqAutoMergeStore) ListBySourceSHA(
+	ctx context.Context,
+	repoID int64,
+	sourceSHA string,
+) ([]*types.PullReqAutoMerge, error) {
+	const sqlQuery = pullReqAutoMergeSelectBase + `
+		WHERE auto_merge_repo_id = $1 AND auto_merge_source_sha = $2
+		ORDER BY auto_merge_created ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*pullReqAutoMerge
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID, sourceSHA); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pull request auto merges")
+	}
+
+	res := make([]*types.PullReqAutoMerge, len(dst))
+	for i := range dst {
+		res[i] = mapPullReqAutoMerge(dst[i])
+	}
+
+	return res, nil
+}
+
+// ListByTargetBranch returns auto merge settings of all pull requests of a repository
+// that have auto merge enabled and target the provided branch.
+func (s *PullReqAutoMergeStore) ListByTargetBranch(
+	ctx context.Context,
+	repoID int64,
+	branch string,
+) ([]*types.PullReqAutoMerge, error) {
+	const sqlQuery = pullReqAutoMergeSelectBase + `
+		INNER JOIN pullreqs ON pullreq_id = auto_merge_pullreq_id
+		WHERE auto_merge_repo_id = $1 AND pullreq_target_branch = $2
+		ORDER BY auto_merge_created ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*pullReqAutoMerge
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID, branch); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pull request auto merges by target branch")
+	}
+
+	res := make([]*types.PullReqAutoMerge, len(dst))
+	for i := range dst {
+		res[i] = mapPullReqAutoMerge(dst[i])
+	}
+
+	return res, nil
+}
+
+func mapInternalPullReqAutoMerge(autoMerge *types.PullReqAutoMerge) *pullReqAutoMerge {
+	return &pullReqAutoMerge{
+		PullReqID: autoMerge.PullReqID,
+		RepoID:    autoMerge.RepoID,
+		SourceSHA: autoMerge.SourceSHA,
+		Method:    autoMerge.Method,
+		Title:     autoMerge.Title,
+		Message:   autoMerge.Message,
+		CreatedBy: autoMerge.CreatedBy,
+		Created:   autoMerge.Created,
+	}
+}
+
+func mapPullReqAutoMerge(autoMerge *pullReqAutoMerge) *types.PullReqAutoMerge {
+	return &types.PullReqAutoMerge{
+		PullReqID: autoMerge.PullReqID,
+		RepoID:    autoMerge.RepoID,
+		SourceSHA: autoMerge.SourceSHA,
+		Method:    autoMerge.Method,
+		Title:     autoMerge.Title,
+		Message:   autoMerge.Message,
+		CreatedBy: autoMerge.CreatedBy,
+		Created:   autoMerge.Created,
+	}
+}
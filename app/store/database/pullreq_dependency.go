@@ -0,0 +1,171 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PullReqDependencyStore = (*PullReqDependencyStore)(nil)
+
+// NewPullReqDependencyStore returns a new PullReqDependencyStore.
+func NewPullReqDependencyStore(db *sqlx.DB) *PullReqDependencyStore {
+	return &PullReqDependencyStore{
+		db: db,
+	}
+}
+
+// PullReqDependencyStore implements a store.PullReqDependencyStore backed by a relational database.
+type PullReqDependencyStore struct {
+	db *sqlx.DB
+}
+
+type pullReqDependency struct {
+	PullReqID   int64 `db:"pullreq_dependency_pullreq_id"`
+	DependsOnID int64 `db:"pullreq_dependency_depends_on_id"`
+	CreatedBy   int64 `db:"pullreq_dependency_created_by"`
+	Created     int64 `db:"pullreq_dependency_created"`
+}
+
+const (
+	pullReqDependencyColumns = `
+		 pullreq_dependency_pullreq_id
+		,pullreq_dependency_depends_on_id
+		,pullreq_dependency_created_by
+		,pullreq_dependency_created`
+
+	pullReqDependencySelectBase = `
+		SELECT` + pullReqDependencyColumns + `
+		FROM pullreq_dependencies`
+)
+
+// Create declares that a pull request depends on another pull request.
+func (s *PullReqDependencyStore) Create(ctx context.Context, dependency *types.PullReqDependency) error {
+	const sqlQuery = `
+		INSERT INTO pullreq_dependencies (
+			 pullreq_dependency_pullreq_id
+			,pullreq_dependency_depends_on_id
+			,pullreq_dependency_created_by
+			,pullreq_dependency_created
+		) values (
+			 :pullreq_dependency_pullreq_id
+			,:pullreq_dependency_depends_on_id
+			,:pullreq_dependency_created_by
+			,:pullreq_dependency_created
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalPullReqDependency(dependency))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pull request dependency")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert pull request dependency query failed")
+	}
+
+	return nil
+}
+
+// Delete removes the dependency of a pull request on another pull request.
+func (s *PullReqDependencyStore) Delete(ctx context.Context, pullreqID, dependsOnID int64) error {
+	const sqlQuery = `
+		DELETE FROM pullreq_dependencies
+		WHERE pullreq_dependency_pullreq_id = $1 AND pullreq_dependency_depends_on_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, pullreqID, dependsOnID)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete pull request dependency query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted pull request dependencies")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// List returns the dependencies of a pull request: the pull requests it depends on.
+func (s *PullReqDependencyStore) List(ctx context.Context, pullreqID int64) ([]*types.PullReqDependency, error) {
+	const sqlQuery = pullReqDependencySelectBase + `
+		WHERE pullreq_dependency_pullreq_id = $1
+		ORDER BY pullreq_dependency_created ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*pullReqDependency
+	if err := db.SelectContext(ctx, &dst, sqlQuery, pullreqID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pull request dependencies")
+	}
+
+	return mapPullReqDependencies(dst), nil
+}
+
+// ListDependents returns the dependencies on a pull request: the pull requests that depend on it.
+func (s *PullReqDependencyStore) ListDependents(
+	ctx context.Context,
+	dependsOnID int64,
+) ([]*types.PullReqDependency, error) {
+	const sqlQuery = pullReqDependencySelectBase + `
+		WHERE pullreq_dependency_depends_on_id = $1
+		ORDER BY pullreq_dependency_created ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*pullReqDependency
+	if err := db.SelectContext(ctx, &dst, sqlQuery, dependsOnID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pull request dependents")
+	}
+
+	return mapPullReqDependencies(dst), nil
+}
+
+func mapInternalPullReqDependency(dependency *types.PullReqDependency) *pullReqDependency {
+	return &pullReqDependency{
+		PullReqID:   dependency.PullReqID,
+		DependsOnID: dependency.DependsOnID,
+		CreatedBy:   dependency.CreatedBy,
+		Created:     dependency.Created,
+	}
+}
+
+func mapPullReqDependencies(dependencies []*pullReqDependency) []*types.PullReqDependency {
+	res := make([]*types.PullReqDependency, len(dependencies))
+	for i, dependency := range dependencies {
+		res[i] = &types.PullReqDependency{
+			PullReqID:   dependency.PullReqID,
+			DependsOnID: dependency.DependsOnID,
+			CreatedBy:   dependency.CreatedBy,
+			Created:     dependency.Created,
+		}
+	}
+	return res
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/guregu/null"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
@@ -219,6 +220,57 @@ func (s *PullReqReviewerStore) List(ctx context.Context, prID int64) ([]*types.P
 	return result, nil
 }
 
+// ListWorkload returns the review statistics of the provided principals.
+func (s *PullReqReviewerStore) ListWorkload(
+	ctx context.Context,
+	principalIDs []int64,
+) ([]types.PullReqReviewerWorkload, error) {
+	if len(principalIDs) == 0 {
+		return []types.PullReqReviewerWorkload{}, nil
+	}
+
+	stmt := database.Builder.
+		Select(`
+			 pullreq_reviewer_principal_id
+			,COUNT(CASE WHEN pullreq_state = 'open' AND pullreq_reviewer_review_decision = 'pending' THEN 1 END)
+			,MAX(pullreq_reviewer_created)`).
+		From("pullreq_reviewers").
+		InnerJoin("pullreqs ON pullreq_id = pullreq_reviewer_pullreq_id").
+		Where(squirrel.Eq{"pullreq_reviewer_principal_id": principalIDs}).
+		GroupBy("pullreq_reviewer_principal_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert pull request reviewer workload query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing pull request reviewer workload query")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	result := make([]types.PullReqReviewerWorkload, 0, len(principalIDs))
+	for rows.Next() {
+		var workload types.PullReqReviewerWorkload
+		if err = rows.Scan(&workload.PrincipalID, &workload.PendingReviews, &workload.LastRequested); err != nil {
+			return nil, database.ProcessSQLErrorf(ctx, err, "Failed to scan pull request reviewer workload")
+		}
+
+		result = append(result, workload)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to read pull request reviewer workload")
+	}
+
+	return result, nil
+}
+
 func mapPullReqReviewer(v *pullReqReviewer) *types.PullReqReviewer {
 	m := &types.PullReqReviewer{
 		PullReqID:      v.PullReqID,
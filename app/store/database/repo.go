@@ -721,6 +721,63 @@ func (s *RepoStore) listAll(
 	return s.mapToRepos(ctx, repos)
 }
 
+// ListForks returns all forks of the repository, including the deleted ones.
+func (s *RepoStore) ListForks(ctx context.Context, upstreamID int64) ([]*types.Repository, error) {
+	stmt := database.Builder.
+		Select(repoColumnsForJoin).
+		From("repositories").
+		Where("repo_fork_id = ?", upstreamID).
+		OrderBy("repo_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*repository{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list forks query")
+	}
+
+	return s.mapToRepos(ctx, dst)
+}
+
+// ResetForkID removes the link between the fork and its upstream repository.
+// It's applied to deleted repositories as well, hence it doesn't use optimistic locking.
+func (s *RepoStore) ResetForkID(ctx context.Context, id int64) error {
+	stmt := database.Builder.
+		Update("repositories").
+		Set("repo_fork_id", 0).
+		Set("repo_version", squirrel.Expr("repo_version + 1")).
+		Set("repo_updated", time.Now().UnixMilli()).
+		Where("repo_id = ?", id)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to reset fork id of repository")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
 type repoSize struct {
 	ID          int64  `db:"repo_id"`
 	GitUID      string `db:"repo_git_uid"`
@@ -0,0 +1,305 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+var _ store.RunnerStore = (*RunnerStore)(nil)
+
+// NewRunnerStore returns a new RunnerStore.
+func NewRunnerStore(db *sqlx.DB) *RunnerStore {
+	return &RunnerStore{
+		db: db,
+	}
+}
+
+// RunnerStore implements a store.RunnerStore backed by a relational database.
+type RunnerStore struct {
+	db *sqlx.DB
+}
+
+type runner struct {
+	ID          int64              `db:"runner_id"`
+	Identifier  string             `db:"runner_identifier"`
+	Description string             `db:"runner_description"`
+	OS          string             `db:"runner_os"`
+	Arch        string             `db:"runner_arch"`
+	Kernel      string             `db:"runner_kernel"`
+	Variant     string             `db:"runner_variant"`
+	Labels      sqlxtypes.JSONText `db:"runner_labels"`
+	TokenHash   string             `db:"runner_token_hash"`
+	LastSeen    int64              `db:"runner_last_seen"`
+	CreatedBy   int64              `db:"runner_created_by"`
+	Created     int64              `db:"runner_created"`
+	Updated     int64              `db:"runner_updated"`
+}
+
+const (
+	runnerColumns = `
+		 runner_id
+		,runner_identifier
+		,runner_description
+		,runner_os
+		,runner_arch
+		,runner_kernel
+		,runner_variant
+		,runner_labels
+		,runner_token_hash
+		,runner_last_seen
+		,runner_created_by
+		,runner_created
+		,runner_updated`
+
+	runnerSelectBase = `
+		SELECT` + runnerColumns + `
+		FROM runners`
+)
+
+// Find finds the runner by id.
+func (s *RunnerStore) Find(ctx context.Context, id int64) (*types.Runner, error) {
+	const sqlQuery = runnerSelectBase + `
+		WHERE runner_id = $1`
+
+	return s.find(ctx, sqlQuery, id)
+}
+
+// FindByIdentifier finds the runner by its identifier.
+func (s *RunnerStore) FindByIdentifier(ctx context.Context, identifier string) (*types.Runner, error) {
+	const sqlQuery = runnerSelectBase + `
+		WHERE LOWER(runner_identifier) = $1`
+
+	return s.find(ctx, sqlQuery, strings.ToLower(identifier))
+}
+
+// FindByTokenHash finds the runner by the hash of its registration token.
+func (s *RunnerStore) FindByTokenHash(ctx context.Context, tokenHash string) (*types.Runner, error) {
+	const sqlQuery = runnerSelectBase + `
+		WHERE runner_token_hash = $1`
+
+	return s.find(ctx, sqlQuery, tokenHash)
+}
+
+func (s *RunnerStore) find(ctx context.Context, sqlQuery string, arg any) (*types.Runner, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &runner{}
+	if err := db.GetContext(ctx, dst, sqlQuery, arg); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find runner")
+	}
+
+	return mapRunner(dst)
+}
+
+// Create creates a new runner.
+func (s *RunnerStore) Create(ctx context.Context, r *types.Runner) error {
+	const sqlQuery = `
+		INSERT INTO runners (
+			 runner_identifier
+			,runner_description
+			,runner_os
+			,runner_arch
+			,runner_kernel
+			,runner_variant
+			,runner_labels
+			,runner_token_hash
+			,runner_last_seen
+			,runner_created_by
+			,runner_created
+			,runner_updated
+		) values (
+			 :runner_identifier
+			,:runner_description
+			,:runner_os
+			,:runner_arch
+			,:runner_kernel
+			,:runner_variant
+			,:runner_labels
+			,:runner_token_hash
+			,:runner_last_seen
+			,:runner_created_by
+			,:runner_created
+			,:runner_updated
+		) RETURNING runner_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalRunner(r))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind runner object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&r.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert runner query failed")
+	}
+
+	return nil
+}
+
+// UpdateLastSeen updates the time the runner last contacted the server.
+func (s *RunnerStore) UpdateLastSeen(ctx context.Context, id int64, lastSeen int64) error {
+	const sqlQuery = `
+		UPDATE runners
+		SET runner_last_seen = $1
+		WHERE runner_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, lastSeen, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update runner last seen time")
+	}
+
+	return nil
+}
+
+// Delete deletes the runner.
+func (s *RunnerStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM runners
+		WHERE runner_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, id)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete runner query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted runners")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// List returns a list of runners.
+func (s *RunnerStore) List(ctx context.Context, filter types.ListQueryFilter) ([]*types.Runner, error) {
+	stmt := database.Builder.
+		Select(runnerColumns).
+		From("runners")
+
+	if filter.Query != "" {
+		stmt = stmt.Where(PartialMatch("runner_identifier", filter.Query))
+	}
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	stmt = stmt.OrderBy("runner_identifier")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*runner{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing runner list query")
+	}
+
+	runners := make([]*types.Runner, len(dst))
+	for i, r := range dst {
+		if runners[i], err = mapRunner(r); err != nil {
+			return nil, err
+		}
+	}
+
+	return runners, nil
+}
+
+// Count returns the number of runners matching the filter.
+func (s *RunnerStore) Count(ctx context.Context, filter types.ListQueryFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("runners")
+
+	if filter.Query != "" {
+		stmt = stmt.Where(PartialMatch("runner_identifier", filter.Query))
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing runner count query")
+	}
+
+	return count, nil
+}
+
+func mapRunner(in *runner) (*types.Runner, error) {
+	var labels map[string]string
+	if err := json.Unmarshal(in.Labels, &labels); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal runner labels: %w", err)
+	}
+
+	return &types.Runner{
+		ID:          in.ID,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		OS:          in.OS,
+		Arch:        in.Arch,
+		Kernel:      in.Kernel,
+		Variant:     in.Variant,
+		Labels:      labels,
+		TokenHash:   in.TokenHash,
+		LastSeen:    in.LastSeen,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}, nil
+}
+
+func mapInternalRunner(in *types.Runner) *runner {
+	return &runner{
+		ID:          in.ID,
+		Identifier:  in.Identifier,
+		Description: in.Description,
+		OS:          in.OS,
+		Arch:        in.Arch,
+		Kernel:      in.Kernel,
+		Variant:     in.Variant,
+		Labels:      EncodeToSQLXJSON(in.Labels),
+		TokenHash:   in.TokenHash,
+		LastSeen:    in.LastSeen,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+}
@@ -46,7 +46,9 @@ const (
 	secret_data,
 	secret_created,
 	secret_updated,
-	secret_version
+	secret_version,
+	secret_provider,
+	secret_external_path
 	`
 )
 
@@ -99,7 +101,9 @@ func (s *secretStore) Create(ctx context.Context, secret *types.Secret) error {
 		secret_data,
 		secret_created,
 		secret_updated,
-		secret_version
+		secret_version,
+		secret_provider,
+		secret_external_path
 	) VALUES (
 		:secret_description,
 		:secret_space_id,
@@ -108,7 +112,9 @@ func (s *secretStore) Create(ctx context.Context, secret *types.Secret) error {
 		:secret_data,
 		:secret_created,
 		:secret_updated,
-		:secret_version
+		:secret_version,
+		:secret_provider,
+		:secret_external_path
 	) RETURNING secret_id`
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -132,7 +138,9 @@ func (s *secretStore) Update(ctx context.Context, p *types.Secret) error {
 		secret_uid = :secret_uid,
 		secret_data = :secret_data,
 		secret_updated = :secret_updated,
-		secret_version = :secret_version
+		secret_version = :secret_version,
+		secret_provider = :secret_provider,
+		secret_external_path = :secret_external_path
 	WHERE secret_id = :secret_id AND secret_version = :secret_version - 1`
 	updatedAt := time.Now()
 	secret := *p
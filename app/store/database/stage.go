@@ -61,6 +61,10 @@ const (
 	,stage_on_failure
 	,stage_depends_on
 	,stage_labels
+	,stage_environment
+	,stage_environment_id
+	,stage_matrix
+	,stage_reused_from
 	`
 )
 
@@ -93,6 +97,10 @@ type stage struct {
 	OnFailure     bool               `db:"stage_on_failure"`
 	DependsOn     sqlxtypes.JSONText `db:"stage_depends_on"`
 	Labels        sqlxtypes.JSONText `db:"stage_labels"`
+	Environment   string             `db:"stage_environment"`
+	EnvironmentID int64              `db:"stage_environment_id"`
+	Matrix        sqlxtypes.JSONText `db:"stage_matrix"`
+	ReusedFrom    int64              `db:"stage_reused_from"`
 }
 
 // NewStageStore returns a new StageStore.
@@ -152,6 +160,10 @@ func (s *stageStore) Create(ctx context.Context, st *types.Stage) error {
 			,stage_on_failure
 			,stage_depends_on
 			,stage_labels
+			,stage_environment
+			,stage_environment_id
+			,stage_matrix
+			,stage_reused_from
 		) VALUES (
 			:stage_execution_id
 			,:stage_repo_id
@@ -180,7 +192,10 @@ func (s *stageStore) Create(ctx context.Context, st *types.Stage) error {
 			,:stage_on_failure
 			,:stage_depends_on
 			,:stage_labels
-
+			,:stage_environment
+			,:stage_environment_id
+			,:stage_matrix
+			,:stage_reused_from
 		) RETURNING stage_id`
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -192,6 +207,7 @@ func (s *stageStore) Create(ctx context.Context, st *types.Stage) error {
 	if err = db.QueryRowContext(ctx, query, arg...).Scan(&stage.ID); err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "Stage query failed")
 	}
+	st.ID = stage.ID
 	return nil
 }
 
@@ -0,0 +1,181 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.StageApprovalStore = (*StageApprovalStore)(nil)
+
+// NewStageApprovalStore returns a new StageApprovalStore.
+func NewStageApprovalStore(db *sqlx.DB, pCache store.PrincipalInfoCache) *StageApprovalStore {
+	return &StageApprovalStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// StageApprovalStore implements a store.StageApprovalStore backed by a relational database.
+type StageApprovalStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type stageApproval struct {
+	ID            int64                      `db:"stage_approval_id"`
+	ExecutionID   int64                      `db:"stage_approval_execution_id"`
+	StageID       int64                      `db:"stage_approval_stage_id"`
+	StageNumber   int64                      `db:"stage_approval_stage_number"`
+	EnvironmentID int64                      `db:"stage_approval_environment_id"`
+	Environment   string                     `db:"stage_approval_environment"`
+	PrincipalID   int64                      `db:"stage_approval_principal_id"`
+	Decision      enum.StageApprovalDecision `db:"stage_approval_decision"`
+	Comment       string                     `db:"stage_approval_comment"`
+	Created       int64                      `db:"stage_approval_created"`
+}
+
+const (
+	stageApprovalColumns = `
+		 stage_approval_id
+		,stage_approval_execution_id
+		,stage_approval_stage_id
+		,stage_approval_stage_number
+		,stage_approval_environment_id
+		,stage_approval_environment
+		,stage_approval_principal_id
+		,stage_approval_decision
+		,stage_approval_comment
+		,stage_approval_created`
+)
+
+// Create records a decision on a stage waiting for approval.
+func (s *StageApprovalStore) Create(ctx context.Context, approval *types.StageApproval) error {
+	const sqlQuery = `
+		INSERT INTO stage_approvals (
+			 stage_approval_execution_id
+			,stage_approval_stage_id
+			,stage_approval_stage_number
+			,stage_approval_environment_id
+			,stage_approval_environment
+			,stage_approval_principal_id
+			,stage_approval_decision
+			,stage_approval_comment
+			,stage_approval_created
+		) values (
+			 :stage_approval_execution_id
+			,:stage_approval_stage_id
+			,:stage_approval_stage_number
+			,:stage_approval_environment_id
+			,:stage_approval_environment
+			,:stage_approval_principal_id
+			,:stage_approval_decision
+			,:stage_approval_comment
+			,:stage_approval_created
+		) RETURNING stage_approval_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalStageApproval(approval))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind stage approval object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&approval.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert stage approval query failed")
+	}
+
+	return nil
+}
+
+// List returns all approval decisions made on the stages of a pipeline execution.
+func (s *StageApprovalStore) List(ctx context.Context, executionID int64) ([]*types.StageApproval, error) {
+	const sqlQuery = `
+		SELECT` + stageApprovalColumns + `
+		FROM stage_approvals
+		WHERE stage_approval_execution_id = $1
+		ORDER BY stage_approval_created ASC, stage_approval_id ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*stageApproval{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, executionID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing stage approval list query")
+	}
+
+	return s.mapSliceStageApproval(ctx, dst)
+}
+
+func (s *StageApprovalStore) mapSliceStageApproval(
+	ctx context.Context,
+	approvals []*stageApproval,
+) ([]*types.StageApproval, error) {
+	ids := make([]int64, len(approvals))
+	for i, approval := range approvals {
+		ids[i] = approval.PrincipalID
+	}
+
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load stage approval principal infos: %w", err)
+	}
+
+	m := make([]*types.StageApproval, len(approvals))
+	for i, approval := range approvals {
+		m[i] = mapStageApproval(approval)
+		m[i].Approver = infoMap[approval.PrincipalID]
+	}
+
+	return m, nil
+}
+
+func mapStageApproval(in *stageApproval) *types.StageApproval {
+	return &types.StageApproval{
+		ID:            in.ID,
+		ExecutionID:   in.ExecutionID,
+		StageID:       in.StageID,
+		StageNumber:   in.StageNumber,
+		EnvironmentID: in.EnvironmentID,
+		Environment:   in.Environment,
+		PrincipalID:   in.PrincipalID,
+		Decision:      in.Decision,
+		Comment:       in.Comment,
+		Created:       in.Created,
+	}
+}
+
+func mapInternalStageApproval(in *types.StageApproval) *stageApproval {
+	return &stageApproval{
+		ID:            in.ID,
+		ExecutionID:   in.ExecutionID,
+		StageID:       in.StageID,
+		StageNumber:   in.StageNumber,
+		EnvironmentID: in.EnvironmentID,
+		Environment:   in.Environment,
+		PrincipalID:   in.PrincipalID,
+		Decision:      in.Decision,
+		Comment:       in.Comment,
+		Created:       in.Created,
+	}
+}
@@ -82,6 +82,11 @@ func mapInternalToStage(in *stage) (*types.Stage, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "could not unmarshal stage.labels")
 	}
+	var matrix *types.StageMatrix
+	err = json.Unmarshal(in.Matrix, &matrix)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal stage.matrix")
+	}
 	return &types.Stage{
 		ID:          in.ID,
 		ExecutionID: in.ExecutionID,
@@ -110,6 +115,11 @@ func mapInternalToStage(in *stage) (*types.Stage, error) {
 		OnFailure:   in.OnFailure,
 		DependsOn:   dependsOn,
 		Labels:      labels,
+
+		Environment:   in.Environment,
+		EnvironmentID: in.EnvironmentID,
+		Matrix:        matrix,
+		ReusedFrom:    in.ReusedFrom,
 	}, nil
 }
 
@@ -142,6 +152,11 @@ func mapStageToInternal(in *types.Stage) *stage {
 		OnFailure:   in.OnFailure,
 		DependsOn:   EncodeToSQLXJSON(in.DependsOn),
 		Labels:      EncodeToSQLXJSON(in.Labels),
+
+		Environment:   in.Environment,
+		EnvironmentID: in.EnvironmentID,
+		Matrix:        EncodeToSQLXJSON(in.Matrix),
+		ReusedFrom:    in.ReusedFrom,
 	}
 }
 
@@ -191,6 +206,7 @@ func scanRowsWithSteps(rows *sql.Rows) ([]*types.Stage, error) {
 func scanRowStep(rows *sql.Rows, stage *types.Stage, step *nullstep) error {
 	depJSON := sqlxtypes.JSONText{}
 	labJSON := sqlxtypes.JSONText{}
+	matrixJSON := sqlxtypes.JSONText{}
 	stepDepJSON := sqlxtypes.JSONText{}
 	err := rows.Scan(
 		&stage.ID,
@@ -220,6 +236,10 @@ func scanRowStep(rows *sql.Rows, stage *types.Stage, step *nullstep) error {
 		&stage.OnFailure,
 		&depJSON,
 		&labJSON,
+		&stage.Environment,
+		&stage.EnvironmentID,
+		&matrixJSON,
+		&stage.ReusedFrom,
 		&step.ID,
 		&step.StageID,
 		&step.Number,
@@ -247,6 +267,10 @@ func scanRowStep(rows *sql.Rows, stage *types.Stage, step *nullstep) error {
 	if err != nil {
 		return fmt.Errorf("failed to unmarshal labJSON: %w", err)
 	}
+	err = json.Unmarshal(matrixJSON, &stage.Matrix)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal matrixJSON: %w", err)
+	}
 	if step.ID.Valid {
 		// try to unmarshal step dependencies if step exists
 		err = json.Unmarshal(stepDepJSON, &step.DependsOn)
@@ -0,0 +1,315 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.TestCaseStore = (*TestCaseStore)(nil)
+
+// NewTestCaseStore returns a new TestCaseStore.
+func NewTestCaseStore(db *sqlx.DB) *TestCaseStore {
+	return &TestCaseStore{
+		db: db,
+	}
+}
+
+// TestCaseStore implements a store.TestCaseStore backed by a relational database.
+type TestCaseStore struct {
+	db *sqlx.DB
+}
+
+type testCase struct {
+	ID          int64           `db:"test_case_id"`
+	PipelineID  int64           `db:"test_case_pipeline_id"`
+	ExecutionID int64           `db:"test_case_execution_id"`
+	Suite       string          `db:"test_case_suite"`
+	Name        string          `db:"test_case_name"`
+	Status      enum.TestStatus `db:"test_case_status"`
+	Duration    int64           `db:"test_case_duration"`
+	Message     string          `db:"test_case_message"`
+	Flaky       bool            `db:"test_case_flaky"`
+	Created     int64           `db:"test_case_created"`
+}
+
+type testCaseRun struct {
+	ExecutionNumber int64           `db:"execution_number"`
+	CommitSHA       string          `db:"execution_after"`
+	Status          enum.TestStatus `db:"test_case_status"`
+	Duration        int64           `db:"test_case_duration"`
+	Message         string          `db:"test_case_message"`
+	Flaky           bool            `db:"test_case_flaky"`
+	Created         int64           `db:"test_case_created"`
+}
+
+const (
+	testCaseTable = "test_cases"
+
+	testCaseColumns = `
+		 test_case_id
+		,test_case_pipeline_id
+		,test_case_execution_id
+		,test_case_suite
+		,test_case_name
+		,test_case_status
+		,test_case_duration
+		,test_case_message
+		,test_case_flaky
+		,test_case_created`
+
+	// testCaseInsertBatchSize limits the number of rows inserted by a single query.
+	testCaseInsertBatchSize = 500
+)
+
+// CreateMany stores the test cases of a test report.
+func (s *TestCaseStore) CreateMany(ctx context.Context, testCases []*types.TestCase) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	for start := 0; start < len(testCases); start += testCaseInsertBatchSize {
+		end := min(start+testCaseInsertBatchSize, len(testCases))
+
+		stmt := database.Builder.
+			Insert(testCaseTable).
+			Columns(
+				"test_case_pipeline_id",
+				"test_case_execution_id",
+				"test_case_suite",
+				"test_case_name",
+				"test_case_status",
+				"test_case_duration",
+				"test_case_message",
+				"test_case_flaky",
+				"test_case_created",
+			)
+
+		for _, tc := range testCases[start:end] {
+			stmt = stmt.Values(tc.PipelineID, tc.ExecutionID, tc.Suite, tc.Name, tc.Status,
+				tc.Duration, tc.Message, tc.Flaky, tc.Created)
+		}
+
+		sql, args, err := stmt.ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to convert squirrel builder to sql: %w", err)
+		}
+
+		if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to insert test cases")
+		}
+	}
+
+	return nil
+}
+
+// Summary returns the summary of all test cases reported by a pipeline execution.
+func (s *TestCaseStore) Summary(ctx context.Context, executionID int64) (types.TestSummary, error) {
+	const sqlQuery = `
+		SELECT
+			 test_case_status
+			,COUNT(*)
+			,COALESCE(SUM(test_case_duration), 0)
+			,COALESCE(SUM(CASE WHEN test_case_flaky THEN 1 ELSE 0 END), 0)
+		FROM test_cases
+		WHERE test_case_execution_id = $1
+		GROUP BY test_case_status`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	rows, err := db.QueryContext(ctx, sqlQuery, executionID)
+	if err != nil {
+		return types.TestSummary{}, database.ProcessSQLErrorf(ctx, err, "Failed to query test summary")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	summary := types.TestSummary{}
+	for rows.Next() {
+		var status enum.TestStatus
+		var count, flaky int
+		var duration int64
+
+		if err = rows.Scan(&status, &count, &duration, &flaky); err != nil {
+			return types.TestSummary{}, database.ProcessSQLErrorf(ctx, err, "Failed to scan test summary")
+		}
+
+		summary.Total += count
+		summary.Flaky += flaky
+		summary.Duration += duration
+
+		switch status {
+		case enum.TestStatusPassed:
+			summary.Passed = count
+		case enum.TestStatusFailed:
+			summary.Failed = count
+		case enum.TestStatusSkipped:
+			summary.Skipped = count
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return types.TestSummary{}, database.ProcessSQLErrorf(ctx, err, "Failed to read test summary")
+	}
+
+	return summary, nil
+}
+
+// Count returns the number of test cases reported by a pipeline execution.
+func (s *TestCaseStore) Count(ctx context.Context, executionID int64, filter types.TestCaseFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From(testCaseTable).
+		Where("test_case_execution_id = ?", executionID)
+
+	stmt = applyTestCaseFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing test case count query")
+	}
+
+	return count, nil
+}
+
+// List returns the test cases reported by a pipeline execution.
+func (s *TestCaseStore) List(
+	ctx context.Context,
+	executionID int64,
+	filter types.TestCaseFilter,
+) ([]*types.TestCase, error) {
+	stmt := database.Builder.
+		Select(testCaseColumns).
+		From(testCaseTable).
+		Where("test_case_execution_id = ?", executionID)
+
+	stmt = applyTestCaseFilter(stmt, filter)
+	stmt = stmt.
+		OrderBy("test_case_suite", "test_case_name", "test_case_id").
+		Limit(database.Limit(filter.Size)).
+		Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*testCase{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing test case list query")
+	}
+
+	testCases := make([]*types.TestCase, len(dst))
+	for i, tc := range dst {
+		testCases[i] = mapTestCase(tc)
+	}
+
+	return testCases, nil
+}
+
+// ListHistory returns the most recent results of a test case in the executions of a pipeline,
+// the newest first.
+func (s *TestCaseStore) ListHistory(
+	ctx context.Context,
+	pipelineID int64,
+	suite string,
+	name string,
+	limit int,
+) ([]*types.TestCaseRun, error) {
+	stmt := database.Builder.
+		Select(`
+			 execution_number
+			,execution_after
+			,test_case_status
+			,test_case_duration
+			,test_case_message
+			,test_case_flaky
+			,test_case_created`).
+		From(testCaseTable).
+		InnerJoin("executions ON execution_id = test_case_execution_id").
+		Where("test_case_pipeline_id = ?", pipelineID).
+		Where("test_case_suite = ?", suite).
+		Where("test_case_name = ?", name).
+		OrderBy("execution_number DESC", "test_case_id DESC").
+		Limit(uint64(limit))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*testCaseRun{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing test case history query")
+	}
+
+	runs := make([]*types.TestCaseRun, len(dst))
+	for i, r := range dst {
+		runs[i] = &types.TestCaseRun{
+			ExecutionNumber: r.ExecutionNumber,
+			CommitSHA:       r.CommitSHA,
+			Status:          r.Status,
+			Duration:        r.Duration,
+			Message:         r.Message,
+			Flaky:           r.Flaky,
+			Created:         r.Created,
+		}
+	}
+
+	return runs, nil
+}
+
+func applyTestCaseFilter(stmt squirrel.SelectBuilder, filter types.TestCaseFilter) squirrel.SelectBuilder {
+	if filter.Status != "" {
+		stmt = stmt.Where("test_case_status = ?", filter.Status)
+	}
+
+	return stmt
+}
+
+func mapTestCase(in *testCase) *types.TestCase {
+	return &types.TestCase{
+		ID:          in.ID,
+		PipelineID:  in.PipelineID,
+		ExecutionID: in.ExecutionID,
+		Suite:       in.Suite,
+		Name:        in.Name,
+		Status:      in.Status,
+		Duration:    in.Duration,
+		Message:     in.Message,
+		Flaky:       in.Flaky,
+		Created:     in.Created,
+	}
+}
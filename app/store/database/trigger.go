@@ -48,6 +48,14 @@ type trigger struct {
 	Created     int64              `db:"trigger_created"`
 	Updated     int64              `db:"trigger_updated"`
 	Version     int64              `db:"trigger_version"`
+
+	Cron         string `db:"trigger_cron"`
+	CronBranch   string `db:"trigger_cron_branch"`
+	CronTimezone string `db:"trigger_cron_timezone"`
+
+	UpstreamPipelineID int64              `db:"trigger_upstream_pipeline_id"`
+	UpstreamStatuses   sqlxtypes.JSONText `db:"trigger_upstream_statuses"`
+	Params             sqlxtypes.JSONText `db:"trigger_params"`
 }
 
 func mapInternalToTrigger(trigger *trigger) (*types.Trigger, error) {
@@ -56,6 +64,16 @@ func mapInternalToTrigger(trigger *trigger) (*types.Trigger, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "could not unmarshal trigger.actions")
 	}
+	var upstreamStatuses []enum.CIStatus
+	err = json.Unmarshal(trigger.UpstreamStatuses, &upstreamStatuses)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal trigger.upstream_statuses")
+	}
+	var params map[string]string
+	err = json.Unmarshal(trigger.Params, &params)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal trigger.params")
+	}
 
 	return &types.Trigger{
 		ID:          trigger.ID,
@@ -71,6 +89,14 @@ func mapInternalToTrigger(trigger *trigger) (*types.Trigger, error) {
 		Created:     trigger.Created,
 		Updated:     trigger.Updated,
 		Version:     trigger.Version,
+
+		Cron:         trigger.Cron,
+		CronBranch:   trigger.CronBranch,
+		CronTimezone: trigger.CronTimezone,
+
+		UpstreamPipelineID: trigger.UpstreamPipelineID,
+		UpstreamStatuses:   upstreamStatuses,
+		Params:             params,
 	}, nil
 }
 
@@ -101,6 +127,14 @@ func mapTriggerToInternal(t *types.Trigger) *trigger {
 		Created:     t.Created,
 		Updated:     t.Updated,
 		Version:     t.Version,
+
+		Cron:         t.Cron,
+		CronBranch:   t.CronBranch,
+		CronTimezone: t.CronTimezone,
+
+		UpstreamPipelineID: t.UpstreamPipelineID,
+		UpstreamStatuses:   EncodeToSQLXJSON(t.UpstreamStatuses),
+		Params:             EncodeToSQLXJSON(t.Params),
 	}
 }
 
@@ -126,9 +160,31 @@ const (
 		,trigger_created
 		,trigger_updated
 		,trigger_version
+		,trigger_type
+		,trigger_cron
+		,trigger_cron_branch
+		,trigger_cron_timezone
+		,trigger_upstream_pipeline_id
+		,trigger_upstream_statuses
+		,trigger_params
 	`
 )
 
+// Find returns a trigger given its ID.
+func (s *triggerStore) Find(ctx context.Context, id int64) (*types.Trigger, error) {
+	const findQueryStmt = `
+	SELECT` + triggerColumns + `
+	FROM triggers
+	WHERE trigger_id = $1`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(trigger)
+	if err := db.GetContext(ctx, dst, findQueryStmt, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find trigger")
+	}
+	return mapInternalToTrigger(dst)
+}
+
 // Find returns an trigger given a pipeline ID and a trigger identifier.
 func (s *triggerStore) FindByIdentifier(
 	ctx context.Context,
@@ -164,6 +220,12 @@ func (s *triggerStore) Create(ctx context.Context, t *types.Trigger) error {
 		,trigger_created
 		,trigger_updated
 		,trigger_version
+		,trigger_cron
+		,trigger_cron_branch
+		,trigger_cron_timezone
+		,trigger_upstream_pipeline_id
+		,trigger_upstream_statuses
+		,trigger_params
 	) VALUES (
 		:trigger_uid
 		,:trigger_description
@@ -177,6 +239,12 @@ func (s *triggerStore) Create(ctx context.Context, t *types.Trigger) error {
 		,:trigger_created
 		,:trigger_updated
 		,:trigger_version
+		,:trigger_cron
+		,:trigger_cron_branch
+		,:trigger_cron_timezone
+		,:trigger_upstream_pipeline_id
+		,:trigger_upstream_statuses
+		,:trigger_params
 	) RETURNING trigger_id`
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -190,6 +258,8 @@ func (s *triggerStore) Create(ctx context.Context, t *types.Trigger) error {
 		return database.ProcessSQLErrorf(ctx, err, "Trigger query failed")
 	}
 
+	t.ID = trigger.ID
+
 	return nil
 }
 
@@ -204,6 +274,11 @@ func (s *triggerStore) Update(ctx context.Context, t *types.Trigger) error {
 		,trigger_updated = :trigger_updated
 		,trigger_actions = :trigger_actions
 		,trigger_version = :trigger_version
+		,trigger_cron = :trigger_cron
+		,trigger_cron_branch = :trigger_cron_branch
+		,trigger_cron_timezone = :trigger_cron_timezone
+		,trigger_upstream_statuses = :trigger_upstream_statuses
+		,trigger_params = :trigger_params
 	WHERE trigger_id = :trigger_id AND trigger_version = :trigger_version - 1`
 	updatedAt := time.Now()
 	trigger := mapTriggerToInternal(t)
@@ -322,6 +397,57 @@ func (s *triggerStore) ListAllEnabled(
 	return mapInternalToTriggerList(dst)
 }
 
+// ListAllCron lists all cron triggers across all repos without pagination.
+// It's used only internally to schedule builds.
+func (s *triggerStore) ListAllCron(ctx context.Context) ([]*types.Trigger, error) {
+	stmt := database.Builder.
+		Select(triggerColumns).
+		From("triggers").
+		Where("trigger_type = ?", enum.TriggerCron)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*trigger{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom list query")
+	}
+
+	return mapInternalToTriggerList(dst)
+}
+
+// ListAllEnabledByUpstream lists all enabled pipeline triggers fired by the given pipeline without pagination.
+// It's used only internally to trigger builds.
+func (s *triggerStore) ListAllEnabledByUpstream(
+	ctx context.Context,
+	upstreamPipelineID int64,
+) ([]*types.Trigger, error) {
+	stmt := database.Builder.
+		Select(triggerColumns).
+		From("triggers").
+		Where("trigger_type = ?", enum.TriggerPipeline).
+		Where("trigger_upstream_pipeline_id = ?", upstreamPipelineID).
+		Where("trigger_disabled = false")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*trigger{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom list query")
+	}
+
+	return mapInternalToTriggerList(dst)
+}
+
 // Count of triggers under a given pipeline.
 func (s *triggerStore) Count(ctx context.Context, pipelineID int64, filter types.ListQueryFilter) (int64, error) {
 	stmt := database.Builder.
@@ -73,6 +73,20 @@ var WireSet = wire.NewSet(
 	ProvidePullReqLabelStore,
 	ProvideInfraProviderTemplateStore,
 	ProvideInfraProvisionedStore,
+	ProvideLFSObjectStore,
+	ProvideLFSLockStore,
+	ProvideMergeQueueStore,
+	ProvidePullReqAutoMergeStore,
+	ProvidePullReqDependencyStore,
+	ProvideAuditEventStore,
+	ProvideChatIdentityStore,
+	ProvideRunnerStore,
+	ProvidePipelineCacheStore,
+	ProvidePipelineArtifactStore,
+	ProvideEnvironmentStore,
+	ProvideStageApprovalStore,
+	ProvideCheckAnnotationStore,
+	ProvideTestCaseStore,
 )
 
 // migrator is helper function to set up the database by performing automated
@@ -349,3 +363,73 @@ func ProvideInfraProviderTemplateStore(db *sqlx.DB) store.InfraProviderTemplateS
 func ProvideInfraProvisionedStore(db *sqlx.DB) store.InfraProvisionedStore {
 	return NewInfraProvisionedStore(db)
 }
+
+// ProvideLFSObjectStore provides an LFS object store.
+func ProvideLFSObjectStore(db *sqlx.DB) store.LFSObjectStore {
+	return NewLFSObjectStore(db)
+}
+
+// ProvideLFSLockStore provides an LFS lock store.
+func ProvideLFSLockStore(db *sqlx.DB) store.LFSLockStore {
+	return NewLFSLockStore(db)
+}
+
+// ProvideMergeQueueStore provides a merge queue store.
+func ProvideMergeQueueStore(db *sqlx.DB) store.MergeQueueStore {
+	return NewMergeQueueStore(db)
+}
+
+// ProvidePullReqAutoMergeStore provides a pull request auto merge store.
+func ProvidePullReqAutoMergeStore(db *sqlx.DB) store.PullReqAutoMergeStore {
+	return NewPullReqAutoMergeStore(db)
+}
+
+// ProvidePullReqDependencyStore provides a pull request dependency store.
+func ProvidePullReqDependencyStore(db *sqlx.DB) store.PullReqDependencyStore {
+	return NewPullReqDependencyStore(db)
+}
+
+// ProvideAuditEventStore provides an audit event store.
+func ProvideAuditEventStore(db *sqlx.DB) store.AuditEventStore {
+	return NewAuditEventStore(db)
+}
+
+// ProvideChatIdentityStore provides a chat identity store.
+func ProvideChatIdentityStore(db *sqlx.DB) store.ChatIdentityStore {
+	return NewChatIdentityStore(db)
+}
+
+// ProvideRunnerStore provides a runner store.
+func ProvideRunnerStore(db *sqlx.DB) store.RunnerStore {
+	return NewRunnerStore(db)
+}
+
+// ProvidePipelineCacheStore provides a pipeline cache store.
+func ProvidePipelineCacheStore(db *sqlx.DB) store.PipelineCacheStore {
+	return NewPipelineCacheStore(db)
+}
+
+// ProvidePipelineArtifactStore provides a pipeline artifact store.
+func ProvidePipelineArtifactStore(db *sqlx.DB) store.PipelineArtifactStore {
+	return NewPipelineArtifactStore(db)
+}
+
+// ProvideEnvironmentStore provides an environment store.
+func ProvideEnvironmentStore(db *sqlx.DB) store.EnvironmentStore {
+	return NewEnvironmentStore(db)
+}
+
+// ProvideStageApprovalStore provides a stage approval store.
+func ProvideStageApprovalStore(db *sqlx.DB, pCache store.PrincipalInfoCache) store.StageApprovalStore {
+	return NewStageApprovalStore(db, pCache)
+}
+
+// ProvideCheckAnnotationStore provides a check annotation store.
+func ProvideCheckAnnotationStore(db *sqlx.DB) store.CheckAnnotationStore {
+	return NewCheckAnnotationStore(db)
+}
+
+// ProvideTestCaseStore provides a test case store.
+func ProvideTestCaseStore(db *sqlx.DB) store.TestCaseStore {
+	return NewTestCaseStore(db)
+}
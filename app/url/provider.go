@@ -44,6 +44,14 @@ type Provider interface {
 	// NOTE: url is guaranteed to not have any trailing '/'.
 	GetInternalAPIURL(ctx context.Context) string
 
+	// GetAPIURL returns the publicly reachable base url of the api.
+	// NOTE: url is guaranteed to not have any trailing '/'.
+	GetAPIURL(ctx context.Context) string
+
+	// GetContainerAPIURL returns the base url of the api that can be used by CI container builds.
+	// NOTE: url is guaranteed to not have any trailing '/'.
+	GetContainerAPIURL(ctx context.Context) string
+
 	// GenerateContainerGITCloneURL generates a URL that can be used by CI container builds to
 	// interact with Harness and clone a repo.
 	GenerateContainerGITCloneURL(ctx context.Context, repoPath string) string
@@ -182,6 +190,14 @@ func (p *provider) GetInternalAPIURL(context.Context) string {
 	return p.internalURL.JoinPath(APIMount).String()
 }
 
+func (p *provider) GetAPIURL(context.Context) string {
+	return p.apiURL.String()
+}
+
+func (p *provider) GetContainerAPIURL(context.Context) string {
+	return p.containerURL.JoinPath(APIMount).String()
+}
+
 func (p *provider) GenerateContainerGITCloneURL(_ context.Context, repoPath string) string {
 	repoPath = path.Clean(repoPath)
 	if !strings.HasSuffix(repoPath, GITSuffix) {
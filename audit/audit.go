@@ -40,6 +40,7 @@ const (
 	BypassedResourceTypePullRequest = "pull_request"
 	BypassedResourceTypeBranch      = "branch"
 	BypassedResourceTypeCommit      = "commit"
+	BypassedResourceTypeTag         = "tag"
 	BypassAction                    = "bypass_action"
 	BypassActionDeleted             = "deleted"
 	BypassActionCreated             = "created"
@@ -47,6 +48,8 @@ const (
 	BypassActionMerged              = "merged"
 	BypassSHALabelFormat            = "%s @%s"
 	BypassPullReqLabelFormat        = "%s #%s"
+	SecretProvider                  = "secretProvider"
+	SecretExternalPath              = "secretExternalPath"
 )
 
 type Action string
@@ -56,11 +59,12 @@ const (
 	ActionUpdated  Action = "updated" // update default branch, switching default branch, updating description
 	ActionDeleted  Action = "deleted"
 	ActionBypassed Action = "bypassed"
+	ActionAccessed Action = "accessed" // reading the value of a secret from an external provider
 )
 
 func (a Action) Validate() error {
 	switch a {
-	case ActionCreated, ActionUpdated, ActionDeleted, ActionBypassed:
+	case ActionCreated, ActionUpdated, ActionDeleted, ActionBypassed, ActionAccessed:
 		return nil
 	default:
 		return ErrActionUndefined
@@ -78,6 +82,7 @@ const (
 	ResourceTypeRegistry              ResourceType = "registry"
 	ResourceTypeRegistryUpstreamProxy ResourceType = "registry_upstream_proxy"
 	ResourceTypeRegistryArtifact      ResourceType = "registry_artifact"
+	ResourceTypeSecret                ResourceType = "secret"
 )
 
 func (a ResourceType) Validate() error {
@@ -89,7 +94,8 @@ func (a ResourceType) Validate() error {
 		ResourceTypeRepositorySettings,
 		ResourceTypeRegistry,
 		ResourceTypeRegistryUpstreamProxy,
-		ResourceTypeRegistryArtifact:
+		ResourceTypeRegistryArtifact,
+		ResourceTypeSecret:
 		return nil
 
 	default:
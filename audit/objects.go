@@ -25,7 +25,7 @@ import (
 // TODO: ensure audit only takes audit related objects?
 type RepositoryObject struct {
 	types.Repository
-	IsPublic bool `yaml:"is_public"`
+	IsPublic bool `json:"is_public" yaml:"is_public"`
 }
 
 type RegistryObject struct {
@@ -33,31 +33,37 @@ type RegistryObject struct {
 }
 
 type PullRequestObject struct {
-	PullReq        types.PullReq
-	RepoPath       string                 `yaml:"repo_path"`
-	RuleViolations []types.RuleViolations `yaml:"rule_violations"`
+	PullReq        types.PullReq          `json:"pullreq" yaml:"pullreq"`
+	RepoPath       string                 `json:"repo_path" yaml:"repo_path"`
+	RuleViolations []types.RuleViolations `json:"rule_violations" yaml:"rule_violations"`
 }
 
 type CommitObject struct {
-	CommitSHA      string                 `yaml:"commit_sha"`
-	RepoPath       string                 `yaml:"repo_path"`
-	RuleViolations []types.RuleViolations `yaml:"rule_violations"`
+	CommitSHA      string                 `json:"commit_sha" yaml:"commit_sha"`
+	RepoPath       string                 `json:"repo_path" yaml:"repo_path"`
+	RuleViolations []types.RuleViolations `json:"rule_violations" yaml:"rule_violations"`
 }
 
 type BranchObject struct {
-	BranchName     string                 `yaml:"branch_name"`
-	RepoPath       string                 `yaml:"repo_path"`
-	RuleViolations []types.RuleViolations `yaml:"rule_violations"`
+	BranchName     string                 `json:"branch_name" yaml:"branch_name"`
+	RepoPath       string                 `json:"repo_path" yaml:"repo_path"`
+	RuleViolations []types.RuleViolations `json:"rule_violations" yaml:"rule_violations"`
+}
+
+type TagObject struct {
+	TagName        string                 `json:"tag_name" yaml:"tag_name"`
+	RepoPath       string                 `json:"repo_path" yaml:"repo_path"`
+	RuleViolations []types.RuleViolations `json:"rule_violations" yaml:"rule_violations"`
 }
 
 type RegistryUpstreamProxyConfigObject struct {
-	ID         int64
-	RegistryID int64
-	Source     string
-	URL        string
-	AuthType   string
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
-	CreatedBy  int64
-	UpdatedBy  int64
+	ID         int64     `json:"id"`
+	RegistryID int64     `json:"registry_id"`
+	Source     string    `json:"source"`
+	URL        string    `json:"url"`
+	AuthType   string    `json:"auth_type"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	CreatedBy  int64     `json:"created_by"`
+	UpdatedBy  int64     `json:"updated_by"`
 }
@@ -37,6 +37,7 @@ import (
 	gittypes "github.com/harness/gitness/git/types"
 	"github.com/harness/gitness/infraprovider"
 	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/livelog"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
 	"github.com/harness/gitness/store/database"
@@ -54,6 +55,8 @@ const (
 	schemeSSH      = "ssh"
 	gitnessHomeDir = ".gitness"
 	blobDir        = "blob"
+
+	keywordSearchDir = "keywordsearch"
 )
 
 // LoadConfig returns the system configuration from the
@@ -300,10 +303,11 @@ func ProvideBlobStoreConfig(config *types.Config) (blob.Config, error) {
 // ProvideGitConfig loads the git config from the main config.
 func ProvideGitConfig(config *types.Config) gittypes.Config {
 	return gittypes.Config{
-		Trace:    config.Git.Trace,
-		Root:     config.Git.Root,
-		TmpDir:   config.Git.TmpDir,
-		HookPath: config.Git.HookPath,
+		Trace:          config.Git.Trace,
+		Root:           config.Git.Root,
+		TmpDir:         config.Git.TmpDir,
+		HookPath:       config.Git.HookPath,
+		SigningKeyPath: config.Git.SigningKeyPath,
 		LastCommitCache: gittypes.LastCommitCacheConfig{
 			Mode:     config.Git.LastCommitCache.Mode,
 			Duration: config.Git.LastCommitCache.Duration,
@@ -340,6 +344,9 @@ func ProvideNotificationConfig(config *types.Config) notification.Config {
 		EventReaderName: config.InstanceID,
 		Concurrency:     config.Notification.Concurrency,
 		MaxRetries:      config.Notification.MaxRetries,
+		SlackEnabled:    config.Notification.Slack.Enabled,
+		SlackBotToken:   config.Notification.Slack.BotToken,
+		TeamsEnabled:    config.Notification.Teams.Enabled,
 	}
 }
 
@@ -378,11 +385,23 @@ func ProvidePubsubConfig(config *types.Config) pubsub.Config {
 	}
 }
 
+// ProvideLogStreamConfig loads the livelog config from the main config.
+func ProvideLogStreamConfig(config *types.Config) livelog.Config {
+	return livelog.Config{
+		Provider:  config.LogStream.Provider,
+		Namespace: config.LogStream.Namespace,
+		MaxLength: config.LogStream.MaxLength,
+		Retention: config.LogStream.Retention,
+	}
+}
+
 // ProvideCleanupConfig loads the cleanup service config from the main config.
 func ProvideCleanupConfig(config *types.Config) cleanup.Config {
 	return cleanup.Config{
 		WebhookExecutionsRetentionTime:   config.Webhook.RetentionTime,
 		DeletedRepositoriesRetentionTime: config.Repos.DeletedRetentionTime,
+		PipelineCacheRetentionTime:       config.CI.CacheRetentionTime,
+		PipelineArtifactRetentionTime:    config.CI.ArtifactRetentionTime,
 	}
 }
 
@@ -395,10 +414,20 @@ func ProvideCodeOwnerConfig(config *types.Config) codeowners.Config {
 
 // ProvideKeywordSearchConfig loads the keyword search service config from the main config.
 func ProvideKeywordSearchConfig(config *types.Config) keywordsearch.Config {
+	indexDir := config.KeywordSearch.IndexDir
+	if indexDir == "" {
+		indexDir = filepath.Join(config.Git.Root, keywordSearchDir)
+	}
+
 	return keywordsearch.Config{
 		EventReaderName: config.InstanceID,
 		Concurrency:     config.KeywordSearch.Concurrency,
 		MaxRetries:      config.KeywordSearch.MaxRetries,
+		IndexDir:        indexDir,
+		MaxFileSize:     config.KeywordSearch.MaxFileSize,
+
+		BackfillCRON:        config.KeywordSearch.BackfillCRON,
+		BackfillMaxDuration: config.KeywordSearch.BackfillMaxDuration,
 	}
 }
 
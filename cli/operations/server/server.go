@@ -100,6 +100,23 @@ func (c *command) run(*kingpin.ParseContext) error {
 			}
 		}
 
+		if system.services.MergeQueue != nil {
+			if err := system.services.MergeQueue.Register(gCtx); err != nil {
+				log.Error().Err(err).Msg("failed to register merge queue service")
+				return err
+			}
+		}
+
+		if err := system.services.CronTrigger.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register cron trigger sync job")
+			return err
+		}
+
+		if err := system.services.Keywordsearch.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register keyword search backfill job")
+			return err
+		}
+
 		if err := system.services.Cleanup.Register(gCtx); err != nil {
 			log.Error().Err(err).Msg("failed to register cleanup service")
 			return err
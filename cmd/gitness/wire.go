@@ -21,23 +21,28 @@ import (
 	"context"
 
 	"github.com/harness/gitness/app/api/controller/aiagent"
+	"github.com/harness/gitness/app/api/controller/auditlog"
 	"github.com/harness/gitness/app/api/controller/capabilities"
 	checkcontroller "github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
+	"github.com/harness/gitness/app/api/controller/environment"
 	"github.com/harness/gitness/app/api/controller/execution"
 	githookCtrl "github.com/harness/gitness/app/api/controller/githook"
 	gitspaceCtrl "github.com/harness/gitness/app/api/controller/gitspace"
 	infraproviderCtrl "github.com/harness/gitness/app/api/controller/infraprovider"
 	controllerkeywordsearch "github.com/harness/gitness/app/api/controller/keywordsearch"
+	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	controllerlogs "github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/migrate"
 	"github.com/harness/gitness/app/api/controller/pipeline"
+	"github.com/harness/gitness/app/api/controller/pipelinecache"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/reposettings"
+	controllerrunner "github.com/harness/gitness/app/api/controller/runner"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/service"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
@@ -54,6 +59,7 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/bootstrap"
 	connectorservice "github.com/harness/gitness/app/connector"
+	checkevents "github.com/harness/gitness/app/events/check"
 	gitevents "github.com/harness/gitness/app/events/git"
 	gitspaceevents "github.com/harness/gitness/app/events/gitspace"
 	gitspaceinfraevents "github.com/harness/gitness/app/events/gitspaceinfra"
@@ -84,6 +90,7 @@ import (
 	"github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
 	aiagentservice "github.com/harness/gitness/app/services/aiagent"
+	auditservice "github.com/harness/gitness/app/services/audit"
 	capabilitiesservice "github.com/harness/gitness/app/services/capabilities"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
@@ -96,6 +103,7 @@ import (
 	"github.com/harness/gitness/app/services/keywordsearch"
 	svclabel "github.com/harness/gitness/app/services/label"
 	locker "github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/mergequeue"
 	messagingservice "github.com/harness/gitness/app/services/messaging"
 	"github.com/harness/gitness/app/services/metric"
 	migrateservice "github.com/harness/gitness/app/services/migrate"
@@ -108,6 +116,7 @@ import (
 	reposervice "github.com/harness/gitness/app/services/repo"
 	"github.com/harness/gitness/app/services/rules"
 	secretservice "github.com/harness/gitness/app/services/secret"
+	"github.com/harness/gitness/app/services/secret/external"
 	"github.com/harness/gitness/app/services/settings"
 	systemsvc "github.com/harness/gitness/app/services/system"
 	"github.com/harness/gitness/app/services/trigger"
@@ -119,7 +128,6 @@ import (
 	"github.com/harness/gitness/app/store/database"
 	"github.com/harness/gitness/app/store/logs"
 	"github.com/harness/gitness/app/url"
-	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/blob"
 	cliserver "github.com/harness/gitness/cli/operations/server"
 	"github.com/harness/gitness/encrypt"
@@ -172,6 +180,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		serviceaccount.WireSet,
 		user.WireSet,
 		upload.WireSet,
+		lfs.WireSet,
 		service.WireSet,
 		principal.WireSet,
 		usergroupservice.WireSet,
@@ -186,6 +195,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		gitspaceCtrl.WireSet,
 		gitevents.WireSet,
 		pullreqevents.WireSet,
+		checkevents.WireSet,
 		repoevents.WireSet,
 		storage.WireSet,
 		api.WireSet,
@@ -207,6 +217,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		lock.WireSet,
 		locker.WireSet,
 		cliserver.ProvidePubsubConfig,
+		cliserver.ProvideLogStreamConfig,
 		pubsub.WireSet,
 		cliserver.ProvideJobsConfig,
 		job.WireSet,
@@ -215,6 +226,10 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		codecomments.WireSet,
 		protection.WireSet,
 		checkcontroller.WireSet,
+		auditlog.WireSet,
+		controllerrunner.WireSet,
+		pipelinecache.WireSet,
+		environment.WireSet,
 		execution.WireSet,
 		pipeline.WireSet,
 		logs.WireSet,
@@ -246,6 +261,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		gitspaceevent.WireSet,
 		cliserver.ProvideKeywordSearchConfig,
 		keywordsearch.WireSet,
+		mergequeue.WireSet,
 		rules.WireSet,
 		controllerkeywordsearch.WireSet,
 		settings.WireSet,
@@ -253,7 +269,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		usergroup.WireSet,
 		openapi.WireSet,
 		repo.ProvideRepoCheck,
-		audit.WireSet,
+		auditservice.WireSet,
 		ssh.WireSet,
 		publickey.WireSet,
 		migrate.WireSet,
@@ -279,6 +295,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		capabilitiesservice.WireSet,
 		docker.ProvideReporter,
 		secretservice.WireSet,
+		external.WireSet,
 		containerGit.WireSet,
 		containerUser.WireSet,
 		messagingservice.WireSet,
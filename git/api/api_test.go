@@ -16,6 +16,7 @@ package api
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -99,3 +100,23 @@ func testParseTagDataFromCatFileFor(t *testing.T, object string, typ GitObjectTy
 	require.Equal(t, tagger.Identity.Email, res.Tagger.Identity.Email, data)
 	require.Equal(t, tagger.When, res.Tagger.When, data)
 }
+
+func TestParseTagDataFromCatFile_TrailingSignature(t *testing.T) {
+	data := "object " + sha.EmptyTree.String() + "\n" +
+		"type commit\n" +
+		"tag v1.0.0\n" +
+		"tagger max <max@mail.com> 1666401234 -0700\n" +
+		"\n" +
+		"release v1.0.0\n" +
+		"-----BEGIN SSH SIGNATURE-----\n" +
+		"U1NIU0lH\n" +
+		"-----END SSH SIGNATURE-----\n"
+
+	res, err := parseTagDataFromCatFile([]byte(data))
+	require.NoError(t, err)
+
+	require.Equal(t, "release v1.0.0", res.Message)
+	require.NotNil(t, res.Signature)
+	require.Equal(t, "-----BEGIN SSH SIGNATURE-----\nU1NIU0lH\n-----END SSH SIGNATURE-----\n", res.Signature.Signature)
+	require.Equal(t, data[:strings.Index(data, "-----BEGIN")], res.Signature.Payload)
+}
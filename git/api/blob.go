@@ -78,6 +78,79 @@ func GetBlob(
 	}, nil
 }
 
+// BlobContent holds the (partial) content of a blob.
+type BlobContent struct {
+	SHA sha.SHA
+	// Size is the actual size of the blob.
+	Size int64
+	// Content contains the content of the blob, truncated to the size limit.
+	Content []byte
+}
+
+// ReadBlobs returns the content of all provided blobs using a single cat-file process.
+// Content of blobs larger than the sizeLimit is truncated to sizeLimit bytes.
+func ReadBlobs(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	shas []sha.SHA,
+	sizeLimit int64,
+) ([]BlobContent, error) {
+	if len(shas) == 0 {
+		return nil, nil
+	}
+
+	stdIn, stdOut, cancel := CatFileBatch(ctx, repoPath, alternateObjectDirs)
+	defer cancel()
+
+	blobs := make([]BlobContent, 0, len(shas))
+	for _, blobSHA := range shas {
+		_, err := stdIn.Write([]byte(blobSHA.String() + "\n"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to write blob sha to git stdin: %w", err)
+		}
+
+		output, err := ReadBatchHeaderLine(stdOut)
+		if err != nil {
+			return nil, processGitErrorf(err, "failed to read cat-file batch line")
+		}
+
+		if !output.SHA.Equal(blobSHA) {
+			return nil, fmt.Errorf("cat-file returned object sha '%s' but expected '%s'", output.SHA, blobSHA)
+		}
+
+		contentSize := output.Size
+		if sizeLimit > 0 && sizeLimit < contentSize {
+			contentSize = sizeLimit
+		}
+
+		content := make([]byte, contentSize)
+		if _, err = io.ReadFull(stdOut, content); err != nil {
+			return nil, fmt.Errorf("failed to read content of blob '%s': %w", blobSHA, err)
+		}
+
+		// discard the rest of the object including the trailing LF
+		if _, err = stdOut.Discard(int(output.Size-contentSize) + 1); err != nil {
+			return nil, fmt.Errorf("failed to discard remaining content of blob '%s': %w", blobSHA, err)
+		}
+
+		if output.Type != string(GitObjectTypeBlob) {
+			return nil, errors.InvalidArgument(
+				"cat-file returned object type '%s' but expected '%s'", output.Type, GitObjectTypeBlob)
+		}
+
+		blobs = append(blobs, BlobContent{
+			SHA:     output.SHA,
+			Size:    output.Size,
+			Content: content,
+		})
+	}
+
+	_ = stdIn.Close()
+
+	return blobs, nil
+}
+
 func newLimitReaderCloser(reader io.Reader, limit int64, stop func()) limitReaderCloser {
 	return limitReaderCloser{
 		reader: io.LimitReader(reader, limit),
@@ -73,6 +73,8 @@ type CommitFilter struct {
 	Committer string
 	Author    string
 	Regex     bool
+	// ExcludeAllRefs excludes the commits reachable from any of the existing references of the repository.
+	ExcludeAllRefs bool
 }
 
 // CommitDivergenceRequest contains the refs for which the converging commits should be counted.
@@ -152,6 +154,11 @@ func (g *Git) listCommitSHAs(
 	// add refCommitSHA as starting point
 	cmd.Add(command.WithArg(ref))
 
+	if filter.ExcludeAllRefs {
+		// --not --all tells the rev-list command to return only commits that aren't reachable by any reference
+		cmd.Add(command.WithArg("--not", "--all"))
+	}
+
 	cmd.Add(command.WithAlternateObjectDirs(alternateObjectDirs...))
 
 	if len(filter.Path) != 0 {
@@ -865,6 +872,7 @@ func CommitFromReader(commitSHA sha.SHA, reader io.Reader) (*Commit, error) {
 	messageSB := new(strings.Builder)
 	message := false
 	pgpsig := false
+	othersig := false
 
 	bufReader, ok := reader.(*bufio.Reader)
 	if !ok {
@@ -891,8 +899,20 @@ readLoop:
 			}
 			pgpsig = false
 		}
+		if othersig {
+			if len(line) > 0 && line[0] == ' ' {
+				continue
+			}
+			othersig = false
+		}
 
 		if !message {
+			// continuation lines of multi-line headers (e.g. mergetag) are part of the signed payload.
+			if len(line) > 0 && line[0] == ' ' {
+				_, _ = payloadSB.Write(line)
+				continue
+			}
+
 			// This is probably not correct but is copied from go-gits interpretation...
 			trimmed := bytes.TrimSpace(line)
 			if len(trimmed) == 0 {
@@ -929,6 +949,11 @@ readLoop:
 				_, _ = signatureSB.Write(data)
 				_ = signatureSB.WriteByte('\n')
 				pgpsig = true
+			case "gpgsig-sha256":
+				// signature for the other object format, it's never part of the signed payload.
+				othersig = true
+			default:
+				_, _ = payloadSB.Write(line)
 			}
 		} else {
 			_, _ = messageSB.Write(line)
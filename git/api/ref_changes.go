@@ -0,0 +1,190 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/sha"
+)
+
+// RefChanges are the changes introduced by an update of a reference.
+type RefChanges struct {
+	Commits []*Commit
+	// ChangedPaths are the paths of the files that differ between the old and the new commit of the reference.
+	ChangedPaths []string
+}
+
+// GetRefChanges reads the provided commits and lists the paths of the files changed by an update of a reference,
+// including objects available only in the alternate object directories.
+// If the reference is new (the old SHA is empty), the paths are compared to the merge base of the new commit
+// and the base reference, or to an empty tree if there's no base reference or no common history.
+func (g *Git) GetRefChanges(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	oldSHA string,
+	newSHA string,
+	baseRef string,
+	commitSHAs []string,
+) (RefChanges, error) {
+	if repoPath == "" {
+		return RefChanges{}, ErrRepositoryPathEmpty
+	}
+
+	commits, err := readCommits(ctx, repoPath, alternateObjectDirs, commitSHAs)
+	if err != nil {
+		return RefChanges{}, err
+	}
+
+	if oldSHA == "" {
+		oldSHA, err = changesBase(ctx, repoPath, alternateObjectDirs, baseRef, newSHA)
+		if err != nil {
+			return RefChanges{}, err
+		}
+	}
+
+	changedPaths, err := diffNames(ctx, repoPath, alternateObjectDirs, oldSHA, newSHA)
+	if err != nil {
+		return RefChanges{}, err
+	}
+
+	return RefChanges{
+		Commits:      commits,
+		ChangedPaths: changedPaths,
+	}, nil
+}
+
+func readCommits(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	commitSHAs []string,
+) ([]*Commit, error) {
+	if len(commitSHAs) == 0 {
+		return nil, nil
+	}
+
+	writer, reader, cancel := CatFileBatch(ctx, repoPath, alternateObjectDirs)
+	defer func() {
+		cancel()
+		_ = writer.Close()
+	}()
+
+	commits := make([]*Commit, len(commitSHAs))
+
+	for i, commitSHA := range commitSHAs {
+		if _, err := writer.Write([]byte(commitSHA + "\n")); err != nil {
+			return nil, err
+		}
+
+		output, err := ReadBatchHeaderLine(reader)
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.IsNotFound(err) {
+				return nil, errors.NotFound("commit '%s' not found", commitSHA)
+			}
+			return nil, err
+		}
+		if output.Type != string(GitObjectTypeCommit) {
+			return nil, fmt.Errorf("git object is of type '%s', expected commit", output.Type)
+		}
+
+		commits[i], err = CommitFromReader(output.SHA, io.LimitReader(reader, output.Size))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read commit '%s': %w", commitSHA, err)
+		}
+		if _, err = reader.Discard(1); err != nil {
+			return nil, fmt.Errorf("commit reader Discard failed: %w", err)
+		}
+	}
+
+	return commits, nil
+}
+
+// changesBase returns the commit the changes of a new reference are compared to.
+func changesBase(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	baseRef string,
+	newSHA string,
+) (string, error) {
+	if baseRef == "" {
+		return sha.EmptyTree.String(), nil
+	}
+
+	cmd := command.New("merge-base",
+		command.WithArg(baseRef, newSHA),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+	)
+
+	stdout := &bytes.Buffer{}
+	err := cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdout(stdout),
+	)
+	// merge-base exits with code 1 if there's no common history,
+	// and it fails if the base reference doesn't exist, for example in an empty repository.
+	if cErr := command.AsError(err); cErr != nil &&
+		(cErr.IsExitCode(1) || strings.Contains(err.Error(), "fatal: Not a valid object name")) {
+		return sha.EmptyTree.String(), nil
+	}
+	if err != nil {
+		return "", processGitErrorf(err, "failed to find merge base of %s and %s", baseRef, newSHA)
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// diffNames returns the paths of the files that differ between the two commits.
+func diffNames(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	fromSHA string,
+	toSHA string,
+) ([]string, error) {
+	cmd := command.New("diff",
+		command.WithFlag("--name-only"),
+		command.WithFlag("--no-renames"),
+		command.WithFlag("-z"),
+		command.WithArg(fromSHA, toSHA),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+	)
+
+	stdout := &bytes.Buffer{}
+	err := cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdout(stdout),
+	)
+	if err != nil {
+		return nil, processGitErrorf(err, "failed to list paths changed between %s and %s", fromSHA, toSHA)
+	}
+
+	var paths []string
+	for _, path := range bytes.Split(stdout.Bytes(), []byte{0}) {
+		if len(path) > 0 {
+			paths = append(paths, string(path))
+		}
+	}
+
+	return paths, nil
+}
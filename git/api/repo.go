@@ -26,6 +26,7 @@ import (
 
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/sha"
 
 	"github.com/rs/zerolog/log"
 )
@@ -285,6 +286,72 @@ func (g *Git) Sync(
 	return nil
 }
 
+// FetchObjects fetches the provided commits (including all objects reachable from them) from the source repository.
+// No references are updated, it's up to the caller to reference the fetched commits.
+func (g *Git) FetchObjects(
+	ctx context.Context,
+	repoPath string,
+	source string,
+	objectSHAs []sha.SHA,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+	if len(objectSHAs) == 0 {
+		return nil
+	}
+
+	args := make([]string, len(objectSHAs))
+	for i, objectSHA := range objectSHAs {
+		args[i] = objectSHA.String()
+	}
+
+	cmd := command.New("fetch",
+		command.WithConfig("credential.helper", ""),
+		command.WithConfig("uploadpack.allowAnySHA1InWant", "true"),
+		command.WithFlag(
+			"--quiet",
+			"--no-tags",
+			"--no-write-fetch-head",
+		),
+		command.WithArg(source),
+		command.WithArg(args...),
+	)
+
+	err := cmd.Run(ctx, command.WithDir(repoPath))
+	if err != nil {
+		return processGitErrorf(err, "failed to fetch objects")
+	}
+
+	return nil
+}
+
+// RepackObjects repacks all objects of the repository into a single pack.
+// Objects borrowed from alternates are copied as well, so the repository no longer depends on them.
+func (g *Git) RepackObjects(
+	ctx context.Context,
+	repoPath string,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("repack",
+		command.WithFlag(
+			"-a",
+			"-d",
+			"--quiet",
+		),
+	)
+
+	err := cmd.Run(ctx, command.WithDir(repoPath))
+	if err != nil {
+		return processGitErrorf(err, "failed to repack objects")
+	}
+
+	return nil
+}
+
 func (g *Git) AddFiles(
 	ctx context.Context,
 	repoPath string,
@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"context"
+)
+
+var signatureBeginTokens = [][]byte{
+	[]byte("\n-----BEGIN PGP SIGNATURE-----\n"), //#nosec G101
+	[]byte("\n-----BEGIN SSH SIGNATURE-----\n"), //#nosec G101
+}
+
+// splitTrailingSignature splits the raw data of a signed tag into the signed payload and the signature.
+// Signatures of tags are appended to the tag message. It returns nil signature if the data isn't signed.
+func splitTrailingSignature(data []byte) ([]byte, []byte) {
+	for _, token := range signatureBeginTokens {
+		idx := bytes.LastIndex(data, token)
+		if idx < 0 {
+			continue
+		}
+
+		return data[:idx+1], data[idx+1:]
+	}
+
+	return data, nil
+}
+
+// CommitSignature is the signature of a commit along with the committer of the commit.
+type CommitSignature struct {
+	Committer Signature
+	// Signature is nil for unsigned commits.
+	Signature *CommitGPGSignature
+}
+
+// GetCommitSignatures returns the signatures of the provided commits
+// in the same order as the commits were provided.
+func (g *Git) GetCommitSignatures(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	commitSHAs []string,
+) ([]CommitSignature, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	commits, err := readCommits(ctx, repoPath, alternateObjectDirs, commitSHAs)
+	if err != nil {
+		return nil, err
+	}
+
+	signatures := make([]CommitSignature, len(commits))
+	for i, commit := range commits {
+		signatures[i] = CommitSignature{
+			Committer: commit.Committer,
+			Signature: commit.Signature,
+		}
+	}
+
+	return signatures, nil
+}
@@ -211,8 +211,18 @@ func parseTagDataFromCatFile(data []byte) (tag Tag, err error) {
 		return tag, err
 	}
 
+	// signature of signed tags is appended to the message
+	body := data[p:]
+	if payload, signature := splitTrailingSignature(data); signature != nil && len(payload) > p {
+		tag.Signature = &CommitGPGSignature{
+			Signature: string(signature),
+			Payload:   string(payload),
+		}
+		body = payload[p:]
+	}
+
 	// remainder is message and gpg (remove leading and tailing new lines)
-	message := string(bytes.Trim(data[p:], "\n"))
+	message := string(bytes.Trim(body, "\n"))
 
 	// handle gpg signature
 	pgpEnd := strings.Index(message, pgpSignatureEndToken)
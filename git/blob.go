@@ -16,8 +16,10 @@ package git
 
 import (
 	"context"
+	"fmt"
 	"io"
 
+	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/api"
 	"github.com/harness/gitness/git/sha"
 )
@@ -64,3 +66,58 @@ func (s *Service) GetBlob(ctx context.Context, params *GetBlobParams) (*GetBlobO
 		Content:     reader.Content,
 	}, nil
 }
+
+type ReadBlobsParams struct {
+	ReadParams
+	SHAs []string
+	// SizeLimit limits the returned content of each blob (0 means no limit).
+	SizeLimit int64
+}
+
+type ReadBlobsOutput struct {
+	Blobs []BlobContent
+}
+
+type BlobContent struct {
+	SHA sha.SHA
+	// Size is the actual size of the blob.
+	Size int64
+	// Content contains the (partial) content of the blob.
+	Content []byte
+}
+
+// ReadBlobs returns the content of the requested blobs in the order they were requested.
+func (s *Service) ReadBlobs(ctx context.Context, params *ReadBlobsParams) (*ReadBlobsOutput, error) {
+	if params == nil {
+		return nil, ErrNoParamsProvided
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	shas := make([]sha.SHA, len(params.SHAs))
+	for i, blobSHA := range params.SHAs {
+		var err error
+		shas[i], err = sha.New(blobSHA)
+		if err != nil {
+			return nil, errors.InvalidArgument("invalid blob sha '%s'", blobSHA)
+		}
+	}
+
+	blobs, err := api.ReadBlobs(ctx, repoPath, params.AlternateObjectDirs, shas, params.SizeLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blobs: %w", err)
+	}
+
+	out := make([]BlobContent, len(blobs))
+	for i := range blobs {
+		out[i] = BlobContent{
+			SHA:     blobs[i].SHA,
+			Size:    blobs[i].Size,
+			Content: blobs[i].Content,
+		}
+	}
+
+	return &ReadBlobsOutput{
+		Blobs: out,
+	}, nil
+}
@@ -210,6 +210,156 @@ func (s *Service) ListCommits(ctx context.Context, params *ListCommitsParams) (*
 	}, nil
 }
 
+type ListCommitSHAsParams struct {
+	ReadParams
+	// GitREF is a git reference (branch / tag / commit SHA)
+	GitREF string
+	// After is a git reference (branch / tag / commit SHA)
+	// If provided, commits only up to that reference will be returned (exclusive)
+	After string
+	// ExcludeExistingRefs excludes the commits reachable from any of the existing references of the repository.
+	// Used in git hooks to list only the commits that are introduced by a push.
+	ExcludeExistingRefs bool
+}
+
+func (p *ListCommitSHAsParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.GitREF == "" {
+		return errors.InvalidArgument("git reference must be provided")
+	}
+
+	return nil
+}
+
+type ListCommitSHAsOutput struct {
+	SHAs []string
+}
+
+func (s *Service) ListCommitSHAs(
+	ctx context.Context,
+	params *ListCommitSHAsParams,
+) (*ListCommitSHAsOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	commitSHAs, err := s.git.ListCommitSHAs(
+		ctx,
+		repoPath,
+		params.AlternateObjectDirs,
+		params.GitREF,
+		0,
+		0,
+		api.CommitFilter{
+			AfterRef:       params.After,
+			ExcludeAllRefs: params.ExcludeExistingRefs,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commit SHAs: %w", err)
+	}
+
+	return &ListCommitSHAsOutput{
+		SHAs: commitSHAs,
+	}, nil
+}
+
+type GetRefChangesParams struct {
+	ReadParams
+	// OldSHA is the commit the reference pointed to before the update. It's empty for new references.
+	OldSHA string
+	// NewSHA is the commit the reference points to after the update.
+	NewSHA string
+	// BaseRef is the reference the changes of new references are compared to, usually the default branch.
+	BaseRef string
+}
+
+func (p *GetRefChangesParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.NewSHA == "" {
+		return errors.InvalidArgument("new commit SHA must be provided")
+	}
+
+	return nil
+}
+
+type GetRefChangesOutput struct {
+	// Commits are the commits reachable from the new commit that aren't reachable from any existing reference.
+	Commits []Commit
+	// ChangedPaths are the paths of the files that differ between the old and the new commit of the reference.
+	// The changes of a new reference are listed since its merge base with the base reference.
+	ChangedPaths []string
+}
+
+// GetRefChanges returns the new commits and the changed paths introduced by an update of a reference.
+// It's used in git hooks, where the new objects are available only in the alternate object directories.
+func (s *Service) GetRefChanges(
+	ctx context.Context,
+	params *GetRefChangesParams,
+) (*GetRefChangesOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	commitSHAs, err := s.git.ListCommitSHAs(
+		ctx,
+		repoPath,
+		params.AlternateObjectDirs,
+		params.NewSHA,
+		0,
+		0,
+		api.CommitFilter{ExcludeAllRefs: true},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list new commit SHAs: %w", err)
+	}
+
+	result, err := s.git.GetRefChanges(ctx,
+		repoPath,
+		params.AlternateObjectDirs,
+		params.OldSHA,
+		params.NewSHA,
+		params.BaseRef,
+		commitSHAs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reference changes: %w", err)
+	}
+
+	commits := make([]Commit, len(result.Commits))
+	for i := range result.Commits {
+		commit, err := mapCommit(result.Commits[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to map commit: %w", err)
+		}
+
+		commits[i] = *commit
+	}
+
+	return &GetRefChangesOutput{
+		Commits:      commits,
+		ChangedPaths: result.ChangedPaths,
+	}, nil
+}
+
 type GetCommitDivergencesParams struct {
 	ReadParams
 	MaxCount int32
@@ -23,6 +23,7 @@ const (
 	RefTypeTag
 	RefTypePullReqHead
 	RefTypePullReqMerge
+	RefTypePullReqQueue
 )
 
 func (t RefType) String() string {
@@ -37,6 +38,8 @@ func (t RefType) String() string {
 		return "head"
 	case RefTypePullReqMerge:
 		return "merge"
+	case RefTypePullReqQueue:
+		return "queue"
 	case RefTypeUndefined:
 		fallthrough
 	default:
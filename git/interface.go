@@ -27,8 +27,10 @@ type Interface interface {
 	GetTreeNode(ctx context.Context, params *GetTreeNodeParams) (*GetTreeNodeOutput, error)
 	ListTreeNodes(ctx context.Context, params *ListTreeNodeParams) (*ListTreeNodeOutput, error)
 	ListPaths(ctx context.Context, params *ListPathsParams) (*ListPathsOutput, error)
+	ListFiles(ctx context.Context, params *ListFilesParams) (*ListFilesOutput, error)
 	GetSubmodule(ctx context.Context, params *GetSubmoduleParams) (*GetSubmoduleOutput, error)
 	GetBlob(ctx context.Context, params *GetBlobParams) (*GetBlobOutput, error)
+	ReadBlobs(ctx context.Context, params *ReadBlobsParams) (*ReadBlobsOutput, error)
 	CreateBranch(ctx context.Context, params *CreateBranchParams) (*CreateBranchOutput, error)
 	CreateCommitTag(ctx context.Context, params *CreateCommitTagParams) (*CreateCommitTagOutput, error)
 	DeleteTag(ctx context.Context, params *DeleteTagParams) error
@@ -48,6 +50,11 @@ type Interface interface {
 	UpdateRef(ctx context.Context, params UpdateRefParams) error
 
 	SyncRepository(ctx context.Context, params *SyncRepositoryParams) (*SyncRepositoryOutput, error)
+	ForkRepository(ctx context.Context, params *ForkRepositoryParams) (*ForkRepositoryOutput, error)
+	// FetchObjects copies commits from another repository without updating any references.
+	FetchObjects(ctx context.Context, params *FetchObjectsParams) error
+	// DetachFork makes the fork independent of the objects of its upstream repository.
+	DetachFork(ctx context.Context, params *DetachForkParams) error
 
 	MatchFiles(ctx context.Context, params *MatchFilesParams) (*MatchFilesOutput, error)
 
@@ -56,7 +63,10 @@ type Interface interface {
 	 */
 	GetCommit(ctx context.Context, params *GetCommitParams) (*GetCommitOutput, error)
 	ListCommits(ctx context.Context, params *ListCommitsParams) (*ListCommitsOutput, error)
+	ListCommitSHAs(ctx context.Context, params *ListCommitSHAsParams) (*ListCommitSHAsOutput, error)
 	ListCommitTags(ctx context.Context, params *ListCommitTagsParams) (*ListCommitTagsOutput, error)
+	GetCommitSignatures(ctx context.Context, params *GetCommitSignaturesParams) (*GetCommitSignaturesOutput, error)
+	GetRefChanges(ctx context.Context, params *GetRefChangesParams) (*GetRefChangesOutput, error)
 	GetCommitDivergences(ctx context.Context, params *GetCommitDivergencesParams) (*GetCommitDivergencesOutput, error)
 	CommitFiles(ctx context.Context, params *CommitFilesParams) (CommitFilesResponse, error)
 	MergeBase(ctx context.Context, params MergeBaseParams) (MergeBaseOutput, error)
@@ -36,7 +36,7 @@ var (
 type Func func(
 	ctx context.Context,
 	refUpdater *hook.RefUpdater,
-	repoPath, tmpDir string,
+	repoPath, tmpDir, signingKeyPath string,
 	author, committer *api.Signature,
 	message string,
 	mergeBaseSHA, targetSHA, sourceSHA sha.SHA,
@@ -46,14 +46,14 @@ type Func func(
 func Merge(
 	ctx context.Context,
 	refUpdater *hook.RefUpdater,
-	repoPath, tmpDir string,
+	repoPath, tmpDir, signingKeyPath string,
 	author, committer *api.Signature,
 	message string,
 	mergeBaseSHA, targetSHA, sourceSHA sha.SHA,
 ) (mergeSHA sha.SHA, conflicts []string, err error) {
 	return mergeInternal(ctx,
 		refUpdater,
-		repoPath, tmpDir,
+		repoPath, tmpDir, signingKeyPath,
 		author, committer,
 		message,
 		mergeBaseSHA, targetSHA, sourceSHA,
@@ -64,14 +64,14 @@ func Merge(
 func Squash(
 	ctx context.Context,
 	refUpdater *hook.RefUpdater,
-	repoPath, tmpDir string,
+	repoPath, tmpDir, signingKeyPath string,
 	author, committer *api.Signature,
 	message string,
 	mergeBaseSHA, targetSHA, sourceSHA sha.SHA,
 ) (mergeSHA sha.SHA, conflicts []string, err error) {
 	return mergeInternal(ctx,
 		refUpdater,
-		repoPath, tmpDir,
+		repoPath, tmpDir, signingKeyPath,
 		author, committer,
 		message,
 		mergeBaseSHA, targetSHA, sourceSHA,
@@ -82,7 +82,7 @@ func Squash(
 func mergeInternal(
 	ctx context.Context,
 	refUpdater *hook.RefUpdater,
-	repoPath, tmpDir string,
+	repoPath, tmpDir, signingKeyPath string,
 	author, committer *api.Signature,
 	message string,
 	mergeBaseSHA, targetSHA, sourceSHA sha.SHA,
@@ -91,6 +91,8 @@ func mergeInternal(
 	err = sharedrepo.Run(ctx, refUpdater, tmpDir, repoPath, func(s *sharedrepo.SharedRepo) error {
 		var err error
 
+		s.SetSigningKeyPath(signingKeyPath)
+
 		var treeSHA sha.SHA
 
 		treeSHA, conflicts, err = s.MergeTree(ctx, mergeBaseSHA, targetSHA, sourceSHA)
@@ -132,12 +134,14 @@ func mergeInternal(
 func Rebase(
 	ctx context.Context,
 	refUpdater *hook.RefUpdater,
-	repoPath, tmpDir string,
+	repoPath, tmpDir, signingKeyPath string,
 	_, committer *api.Signature, // commit author isn't used here - it's copied from every commit
 	_ string, // commit message isn't used here
 	mergeBaseSHA, targetSHA, sourceSHA sha.SHA,
 ) (mergeSHA sha.SHA, conflicts []string, err error) {
 	err = sharedrepo.Run(ctx, refUpdater, tmpDir, repoPath, func(s *sharedrepo.SharedRepo) error {
+		s.SetSigningKeyPath(signingKeyPath)
+
 		sourceSHAs, err := s.CommitSHAsForRebase(ctx, mergeBaseSHA, sourceSHA)
 		if err != nil {
 			return fmt.Errorf("failed to find commit list in rebase merge: %w", err)
@@ -220,7 +224,7 @@ func Rebase(
 func FastForward(
 	ctx context.Context,
 	refUpdater *hook.RefUpdater,
-	repoPath, tmpDir string,
+	repoPath, tmpDir, _ string, // signing key isn't used here - no commits are created
 	_, _ *api.Signature, // commit author and committer aren't used here
 	_ string, // commit message isn't used here
 	mergeBaseSHA, targetSHA, sourceSHA sha.SHA,
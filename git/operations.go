@@ -152,6 +152,8 @@ func (s *Service) CommitFiles(ctx context.Context, params *CommitFilesParams) (C
 	// run the actions in a shared repo
 
 	err = sharedrepo.Run(ctx, refUpdater, s.tmpDir, repoPath, func(r *sharedrepo.SharedRepo) error {
+		r.SetSigningKeyPath(s.signingKeyPath)
+
 		var parentCommits []sha.SHA
 		var oldTreeSHA sha.SHA
 
@@ -28,6 +28,7 @@ import (
 	"github.com/harness/gitness/git/api"
 	"github.com/harness/gitness/git/check"
 	"github.com/harness/gitness/git/hash"
+	"github.com/harness/gitness/git/sha"
 
 	gonanoid "github.com/matoous/go-nanoid/v2"
 	"github.com/rs/zerolog/log"
@@ -120,6 +121,53 @@ type SyncRepositoryOutput struct {
 	DefaultBranch string
 }
 
+type ForkRepositoryParams struct {
+	// Fork operation is different from all (from user side), as UID doesn't exist yet.
+	// Only take actor and envars as input and create WriteParams manually
+	RepoUID string
+	Actor   Identity
+	EnvVars map[string]string
+
+	// UpstreamRepoUID is the UID of the repository that is being forked.
+	UpstreamRepoUID string
+	DefaultBranch   string
+}
+
+func (p *ForkRepositoryParams) Validate() error {
+	if p.UpstreamRepoUID == "" {
+		return errors.InvalidArgument("upstream repository UID is mandatory")
+	}
+
+	return p.Actor.Validate()
+}
+
+type ForkRepositoryOutput struct {
+	UID string
+}
+
+type FetchObjectsParams struct {
+	WriteParams
+	// SourceRepoUID is the UID of the repository the objects are fetched from.
+	SourceRepoUID string
+	ObjectSHAs    []sha.SHA
+}
+
+func (p *FetchObjectsParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.SourceRepoUID == "" {
+		return errors.InvalidArgument("source repository UID is mandatory")
+	}
+
+	return nil
+}
+
+type DetachForkParams struct {
+	WriteParams
+}
+
 type HashRepositoryParams struct {
 	ReadParams
 	HashType        hash.Type
@@ -320,6 +368,134 @@ func (s *Service) SyncRepository(
 	}, nil
 }
 
+// ForkRepository creates a new repository that shares the objects of the upstream repository.
+// The fork uses the upstream repository as git alternate, so only objects that are added to the fork are stored in it.
+// NOTE: The upstream repository has to stay in place for as long as the fork exists.
+func (s *Service) ForkRepository(
+	ctx context.Context,
+	params *ForkRepositoryParams,
+) (*ForkRepositoryOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	if params.RepoUID == "" {
+		uid, err := NewRepositoryUID()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create new uid: %w", err)
+		}
+		params.RepoUID = uid
+	}
+
+	log.Ctx(ctx).Info().
+		Msgf("Fork git repository with uid '%s' into new repository with uid '%s'",
+			params.UpstreamRepoUID, params.RepoUID)
+
+	writeParams := WriteParams{
+		RepoUID: params.RepoUID,
+		Actor:   params.Actor,
+		EnvVars: params.EnvVars,
+	}
+
+	upstreamRepoPath := getFullPathForRepo(s.reposRoot, params.UpstreamRepoUID)
+	if _, err := os.Stat(upstreamRepoPath); errors.Is(err, fs.ErrNotExist) {
+		return nil, errors.NotFound("upstream repository not found")
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to check the status of the upstream repository: %w", err)
+	}
+
+	err := s.createRepositoryInternal(
+		ctx,
+		&writeParams,
+		params.DefaultBranch,
+		nil,
+		nil,
+		time.Time{},
+		nil,
+		time.Time{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// delete repo dir on error
+	defer func() {
+		if err != nil {
+			if cleanupErr := s.DeleteRepositoryBestEffort(ctx, params.RepoUID); cleanupErr != nil {
+				log.Ctx(ctx).Warn().Err(cleanupErr).Msg("failed to cleanup repo dir")
+			}
+		}
+	}()
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	alternatesFilePath := path.Join(repoPath, "objects", "info", "alternates")
+	err = os.WriteFile(alternatesFilePath, []byte(path.Join(upstreamRepoPath, "objects")+"\n"), 0o600)
+	if err != nil {
+		return nil, errors.Internal(err, "failed to write alternates file of the fork")
+	}
+
+	// all objects are available via the alternates, only the branches and tags are copied.
+	err = s.git.Sync(ctx, repoPath, upstreamRepoPath, []string{
+		"+" + gitReferenceNamePrefixBranch + "*:" + gitReferenceNamePrefixBranch + "*",
+		"+" + gitReferenceNamePrefixTag + "*:" + gitReferenceNamePrefixTag + "*",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync from upstream repo: %w", err)
+	}
+
+	return &ForkRepositoryOutput{
+		UID: params.RepoUID,
+	}, nil
+}
+
+// FetchObjects copies commits (with all objects reachable from them) from the source repository.
+// It's used to make commits of a fork available in its upstream repository (e.g. for pull requests).
+func (s *Service) FetchObjects(
+	ctx context.Context,
+	params *FetchObjectsParams,
+) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	sourceRepoPath := getFullPathForRepo(s.reposRoot, params.SourceRepoUID)
+
+	err := s.git.FetchObjects(ctx, repoPath, sourceRepoPath, params.ObjectSHAs)
+	if err != nil {
+		return fmt.Errorf("failed to fetch objects from source repo: %w", err)
+	}
+
+	return nil
+}
+
+// DetachFork copies all objects the fork borrows from its upstream repository and removes the alternates file.
+// Afterwards the fork is a standalone repository and the upstream repository can be deleted.
+func (s *Service) DetachFork(
+	ctx context.Context,
+	params *DetachForkParams,
+) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	err := s.git.RepackObjects(ctx, repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to repack objects of the fork: %w", err)
+	}
+
+	alternatesFilePath := path.Join(repoPath, "objects", "info", "alternates")
+	err = os.Remove(alternatesFilePath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return errors.Internal(err, "failed to remove alternates file of the fork")
+	}
+
+	return nil
+}
+
 func (s *Service) HashRepository(ctx context.Context, params *HashRepositoryParams) (*HashRepositoryOutput, error) {
 	if err := params.Validate(); err != nil {
 		return nil, err
@@ -39,6 +39,7 @@ type Service struct {
 	store             storage.Store
 	gitHookPath       string
 	reposGraveyard    string
+	signingKeyPath    string
 }
 
 func New(
@@ -71,5 +72,6 @@ func New(
 		hookClientFactory: hookClientFactory,
 		store:             storage,
 		gitHookPath:       config.HookPath,
+		signingKeyPath:    config.SigningKeyPath,
 	}, nil
 }
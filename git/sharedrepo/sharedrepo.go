@@ -44,6 +44,7 @@ import (
 type SharedRepo struct {
 	repoPath       string
 	sourceRepoPath string
+	signingKeyPath string
 }
 
 // NewSharedRepo creates a new temporary bare repository.
@@ -72,6 +73,12 @@ func NewSharedRepo(
 	return t, nil
 }
 
+// SetSigningKeyPath sets the private SSH key used to sign the commits created in the shared repository.
+// Commits aren't signed if the path is empty.
+func (r *SharedRepo) SetSigningKeyPath(signingKeyPath string) {
+	r.signingKeyPath = signingKeyPath
+}
+
 func (r *SharedRepo) Close(ctx context.Context) {
 	if err := tempdir.RemoveTemporaryPath(r.repoPath); err != nil {
 		log.Ctx(ctx).Err(err).
@@ -386,8 +393,15 @@ func (r *SharedRepo) CommitTree(
 		cmd.Add(command.WithFlag("-p", parentCommit.String()))
 	}
 
-	// temporary no signing
-	cmd.Add(command.WithFlag("--no-gpg-sign"))
+	if r.signingKeyPath != "" {
+		cmd.Add(
+			command.WithConfig("gpg.format", "ssh"),
+			command.WithConfig("user.signingKey", r.signingKeyPath),
+			command.WithFlag("-S"),
+		)
+	} else {
+		cmd.Add(command.WithFlag("--no-gpg-sign"))
+	}
 
 	messageBytes := new(bytes.Buffer)
 	_, _ = messageBytes.WriteString(message)
@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+)
+
+// ObjectSignature is the signature of a signed git object (commit or annotated tag).
+type ObjectSignature struct {
+	// Signature is the armored PGP or SSH signature.
+	Signature []byte
+	// SignedData is the content of the git object that has been signed.
+	SignedData []byte
+}
+
+type GetCommitSignaturesParams struct {
+	ReadParams
+	CommitSHAs []string
+}
+
+func (p *GetCommitSignaturesParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if len(p.CommitSHAs) == 0 {
+		return errors.InvalidArgument("commit SHAs must be provided")
+	}
+
+	return nil
+}
+
+type GetCommitSignaturesOutput struct {
+	// Signatures are in the same order as the requested commit SHAs. Unsigned commits have nil signature.
+	Signatures []*ObjectSignature
+	// CommitterEmails are the emails of the committers of the requested commits, in the same order.
+	CommitterEmails []string
+}
+
+func (s *Service) GetCommitSignatures(
+	ctx context.Context,
+	params *GetCommitSignaturesParams,
+) (*GetCommitSignaturesOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	result, err := s.git.GetCommitSignatures(ctx, repoPath, params.AlternateObjectDirs, params.CommitSHAs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit signatures: %w", err)
+	}
+
+	signatures := make([]*ObjectSignature, len(result))
+	committerEmails := make([]string, len(result))
+	for i := range result {
+		signatures[i] = mapObjectSignature(result[i].Signature)
+		committerEmails[i] = result[i].Committer.Identity.Email
+	}
+
+	return &GetCommitSignaturesOutput{
+		Signatures:      signatures,
+		CommitterEmails: committerEmails,
+	}, nil
+}
+
+func mapObjectSignature(s *api.CommitGPGSignature) *ObjectSignature {
+	if s == nil {
+		return nil
+	}
+
+	return &ObjectSignature{
+		Signature:  []byte(s.Signature),
+		SignedData: []byte(s.Payload),
+	}
+}
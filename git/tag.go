@@ -69,6 +69,7 @@ type CommitTag struct {
 	Title       string
 	Message     string
 	Tagger      *Signature
+	Signature   *ObjectSignature
 	Commit      *Commit
 }
 
@@ -194,6 +195,7 @@ func (s *Service) ListCommitTags(
 				return nil, fmt.Errorf("signature mapping error: %w", err)
 			}
 			tags[wi].Tagger = tagger
+			tags[wi].Signature = mapObjectSignature(aTags[ai].Signature)
 
 			ai++
 			wi++
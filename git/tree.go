@@ -17,6 +17,8 @@ package git
 import (
 	"context"
 	"fmt"
+
+	"github.com/harness/gitness/git/sha"
 )
 
 // TreeNodeType specifies the different types of nodes in a git tree.
@@ -182,6 +184,50 @@ func (s *Service) ListPaths(ctx context.Context, params *ListPathsParams) (*List
 		nil
 }
 
+type ListFilesParams struct {
+	ReadParams
+	// GitREF is a git reference (branch / tag / commit SHA)
+	GitREF string
+}
+
+type ListFilesOutput struct {
+	Files []FileEntry
+}
+
+// FileEntry describes a single file of a tree.
+type FileEntry struct {
+	Path string
+	SHA  sha.SHA
+	Size int64
+}
+
+// ListFiles lists all files of a repo recursively, together with their blob SHA and size.
+func (s *Service) ListFiles(ctx context.Context, params *ListFilesParams) (*ListFilesOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	nodes, err := s.git.ListFiles(ctx, repoPath, params.GitREF)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	files := make([]FileEntry, len(nodes))
+	for i := range nodes {
+		files[i] = FileEntry{
+			Path: nodes[i].Path,
+			SHA:  nodes[i].SHA,
+			Size: nodes[i].Size,
+		}
+	}
+
+	return &ListFilesOutput{
+		Files: files,
+	}, nil
+}
+
 type PathsDetailsParams struct {
 	ReadParams
 	GitREF string
@@ -31,6 +31,8 @@ type Config struct {
 	TmpDir string
 	// HookPath points to the binary used as git server hook.
 	HookPath string
+	// SigningKeyPath (optional) points to the private SSH key used to sign the commits created by the server.
+	SigningKeyPath string
 
 	// LastCommitCache holds configuration options for the last commit cache.
 	LastCommitCache LastCommitCacheConfig
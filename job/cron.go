@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gorhill/cronexpr"
+)
+
+// cronTimezonePrefix is the prefix of a cron definition that specifies
+// the time zone in which the cron expression is evaluated, e.g. "CRON_TZ=Europe/Paris 0 2 * * *".
+// Without it the cron expression is evaluated in the local time zone of the server.
+const cronTimezonePrefix = "CRON_TZ="
+
+// CronInTimezone returns the cron definition that is evaluated in the provided time zone.
+func CronInTimezone(cronDef, timezone string) string {
+	if timezone == "" {
+		return cronDef
+	}
+
+	return cronTimezonePrefix + timezone + " " + cronDef
+}
+
+type cronSchedule struct {
+	exp *cronexpr.Expression
+	loc *time.Location
+}
+
+// next returns the first time the cron schedule matches after the provided time.
+func (c cronSchedule) next(t time.Time) time.Time {
+	return c.exp.Next(t.In(c.loc))
+}
+
+func parseCron(cronDef string) (cronSchedule, error) {
+	loc := time.Local
+
+	if strings.HasPrefix(cronDef, cronTimezonePrefix) {
+		timezone, exp, ok := strings.Cut(strings.TrimPrefix(cronDef, cronTimezonePrefix), " ")
+		if !ok {
+			return cronSchedule{}, fmt.Errorf("missing cron expression after time zone %q", timezone)
+		}
+
+		var err error
+		loc, err = time.LoadLocation(timezone)
+		if err != nil {
+			return cronSchedule{}, fmt.Errorf("invalid time zone %q: %w", timezone, err)
+		}
+
+		cronDef = exp
+	}
+
+	exp, err := cronexpr.Parse(cronDef)
+	if err != nil {
+		return cronSchedule{}, err
+	}
+
+	return cronSchedule{exp: exp, loc: loc}, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		cronDef string
+		exp     time.Time
+		expErr  bool
+	}{
+		{
+			name:    "utc",
+			cronDef: CronInTimezone("0 2 * * *", "UTC"),
+			exp:     time.Date(2024, 3, 2, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "timezone",
+			cronDef: CronInTimezone("0 2 * * *", "Asia/Tokyo"),
+			exp:     time.Date(2024, 3, 1, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "invalid-timezone",
+			cronDef: CronInTimezone("0 2 * * *", "Mars/Olympus"),
+			expErr:  true,
+		},
+		{
+			name:    "missing-expression",
+			cronDef: cronTimezonePrefix + "UTC",
+			expErr:  true,
+		},
+		{
+			name:    "invalid-expression",
+			cronDef: "not a cron",
+			expErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schedule, err := parseCron(test.cronDef)
+			if test.expErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := schedule.next(now); !got.Equal(test.exp) {
+				t.Errorf("want: %s, got: %s", test.exp, got)
+			}
+		})
+	}
+}
@@ -26,7 +26,6 @@ import (
 	"github.com/harness/gitness/pubsub"
 	"github.com/harness/gitness/store"
 
-	"github.com/gorhill/cronexpr"
 	"github.com/rs/zerolog/log"
 )
 
@@ -596,7 +595,7 @@ func postExec(job *Job, resultData, resultErr string) {
 			job.ConsecutiveFailures = 0
 		}
 
-		exp, err := cronexpr.Parse(job.RecurringCron)
+		schedule, err := parseCron(job.RecurringCron)
 		if err != nil {
 			job.State = JobStateFailed
 
@@ -608,7 +607,7 @@ func postExec(job *Job, resultData, resultErr string) {
 			job.LastFailureError = messages
 		} else {
 			job.State = JobStateScheduled
-			job.Scheduled = exp.Next(now).UnixMilli()
+			job.Scheduled = schedule.next(now).UnixMilli()
 		}
 
 		return
@@ -683,15 +682,65 @@ func (s *Scheduler) AddRecurring(
 	cronDef string,
 	maxDur time.Duration,
 ) error {
-	cronExp, err := cronexpr.Parse(cronDef)
+	_, err := s.addRecurring(ctx, jobUID, jobType, "", cronDef, "", maxDur)
+	return err
+}
+
+// AddRecurringInGroup adds or updates a recurring job that belongs to the group.
+// The job Handler receives the data as input on every execution.
+// Unlike AddRecurring, it should be used only after the scheduler has been started.
+func (s *Scheduler) AddRecurringInGroup(
+	ctx context.Context,
+	groupID,
+	jobUID,
+	jobType,
+	cronDef,
+	data string,
+	maxDur time.Duration,
+) error {
+	nextExec, err := s.addRecurring(ctx, jobUID, jobType, groupID, cronDef, data, maxDur)
+	if err != nil {
+		return err
+	}
+
+	s.scheduleProcessing(nextExec)
+
+	return nil
+}
+
+// ListJobUIDsByGroupID returns unique identifiers of all jobs that belong to the group.
+func (s *Scheduler) ListJobUIDsByGroupID(ctx context.Context, groupID string) ([]string, error) {
+	jobs, err := s.store.ListByGroupID(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs by group id=%s: %w", groupID, err)
+	}
+
+	uids := make([]string, len(jobs))
+	for i, job := range jobs {
+		uids[i] = job.UID
+	}
+
+	return uids, nil
+}
+
+func (s *Scheduler) addRecurring(
+	ctx context.Context,
+	jobUID,
+	jobType,
+	groupID,
+	cronDef,
+	data string,
+	maxDur time.Duration,
+) (time.Time, error) {
+	schedule, err := parseCron(cronDef)
 	if err != nil {
-		return fmt.Errorf("invalid cron definition string for job type=%s: %w", jobType, err)
+		return time.Time{}, fmt.Errorf("invalid cron definition string for job type=%s: %w", jobType, err)
 	}
 
 	now := time.Now()
 	nowMilli := now.UnixMilli()
 
-	nextExec := cronExp.Next(now)
+	nextExec := schedule.next(now)
 
 	job := &Job{
 		UID:                 jobUID,
@@ -699,7 +748,7 @@ func (s *Scheduler) AddRecurring(
 		Updated:             nowMilli,
 		Type:                jobType,
 		Priority:            JobPriorityElevated,
-		Data:                "",
+		Data:                data,
 		Result:              "",
 		MaxDurationSeconds:  int(maxDur / time.Second),
 		MaxRetries:          0,
@@ -714,14 +763,15 @@ func (s *Scheduler) AddRecurring(
 		RecurringCron:       cronDef,
 		ConsecutiveFailures: 0,
 		LastFailureError:    "",
+		GroupID:             groupID,
 	}
 
 	err = s.store.Upsert(ctx, job)
 	if err != nil {
-		return fmt.Errorf("failed to upsert job id=%s type=%s: %w", jobUID, jobType, err)
+		return time.Time{}, fmt.Errorf("failed to upsert job id=%s type=%s: %w", jobUID, jobType, err)
 	}
 
-	return nil
+	return nextExec, nil
 }
 
 func (s *Scheduler) createNecessaryJobs(ctx context.Context) error {
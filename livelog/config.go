@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package livelog
+
+import "time"
+
+type Provider string
+
+const (
+	ProviderMemory Provider = "inmemory"
+	ProviderRedis  Provider = "redis"
+)
+
+type Config struct {
+	Provider Provider
+
+	// Namespace is the prefix of the redis keys of the log streams.
+	Namespace string
+
+	// MaxLength is the (approximate) maximum number of lines kept in a redis log stream.
+	MaxLength int64
+
+	// Retention is the duration after which an idle redis log stream is removed.
+	Retention time.Duration
+}
@@ -0,0 +1,270 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package livelog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	redisFieldLine = "line"
+	redisFieldEOF  = "eof"
+
+	// redisTailBlock is the duration a subscriber waits for new lines before retrying.
+	redisTailBlock = 5 * time.Second
+	// redisTailCount is the maximum number of lines a subscriber reads at once.
+	redisTailCount = 100
+	// redisScanCount is the number of keys scanned at once when the log streams are listed.
+	redisScanCount = 100
+	// redisDeleteRetention is the duration a deleted log stream is kept
+	// to let the subscribers on other instances read the end of the stream.
+	redisDeleteRetention = time.Minute
+)
+
+// redisIncrIfExists increments the subscriber count of a log stream only if the log stream is registered.
+// The expiration of the key is kept.
+var redisIncrIfExists = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 1 then
+	return redis.call("INCRBY", KEYS[1], ARGV[1])
+end
+return 0
+`)
+
+type redisStreamer struct {
+	client redis.UniversalClient
+	config Config
+}
+
+// NewRedis returns a new log streamer backed by redis streams.
+// The log lines are shared between all instances, so a log stream
+// can be tailed on a different instance than the one it's written on.
+// Every log stream is registered with a key holding its subscriber count. The key expires
+// after the configured retention without writes, so log streams of a crashed instance get removed.
+func NewRedis(client redis.UniversalClient, config Config) LogStream {
+	return &redisStreamer{
+		client: client,
+		config: config,
+	}
+}
+
+func (s *redisStreamer) Create(ctx context.Context, id int64) error {
+	key := s.streamKey(id)
+
+	pipe := s.client.TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.Set(ctx, s.registryKey(id), 0, s.config.Retention)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to create log stream %d: %w", id, err)
+	}
+
+	return nil
+}
+
+func (s *redisStreamer) Delete(ctx context.Context, id int64) error {
+	removed, err := s.client.Del(ctx, s.registryKey(id)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to unregister log stream %d: %w", id, err)
+	}
+	if removed == 0 {
+		return ErrStreamNotFound
+	}
+
+	key := s.streamKey(id)
+
+	// the end of the stream is marked to let the subscribers know there are no more lines.
+	pipe := s.client.TxPipeline()
+	pipe.XAdd(ctx, &redis.XAddArgs{
+		Stream: key,
+		MaxLen: s.config.MaxLength,
+		Approx: true,
+		ID:     "*",
+		Values: map[string]interface{}{redisFieldEOF: true},
+	})
+	pipe.Expire(ctx, key, redisDeleteRetention)
+	if _, err = pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to close log stream %d: %w", id, err)
+	}
+
+	return nil
+}
+
+func (s *redisStreamer) Write(ctx context.Context, id int64, line *Line) error {
+	// the expiration of the registration is extended with every write.
+	exists, err := s.client.Expire(ctx, s.registryKey(id), s.config.Retention).Result()
+	if err != nil {
+		return fmt.Errorf("failed to check log stream %d: %w", id, err)
+	}
+	if !exists {
+		return ErrStreamNotFound
+	}
+
+	data, err := json.Marshal(line)
+	if err != nil {
+		return fmt.Errorf("failed to marshal log line: %w", err)
+	}
+
+	key := s.streamKey(id)
+
+	pipe := s.client.Pipeline()
+	pipe.XAdd(ctx, &redis.XAddArgs{
+		Stream: key,
+		MaxLen: s.config.MaxLength,
+		Approx: true,
+		ID:     "*",
+		Values: map[string]interface{}{redisFieldLine: data},
+	})
+	pipe.Expire(ctx, key, s.config.Retention)
+	if _, err = pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to write to log stream %d: %w", id, err)
+	}
+
+	return nil
+}
+
+func (s *redisStreamer) Tail(ctx context.Context, id int64) (<-chan *Line, <-chan error) {
+	registryKey := s.registryKey(id)
+
+	count, err := redisIncrIfExists.Run(ctx, s.client, []string{registryKey}, 1).Int64()
+	if err != nil {
+		errc := make(chan error, 1)
+		errc <- fmt.Errorf("failed to subscribe to log stream %d: %w", id, err)
+		close(errc)
+		return nil, errc
+	}
+	if count == 0 {
+		return nil, nil
+	}
+
+	linec := make(chan *Line, redisTailCount)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(errc)
+		defer close(linec)
+		defer func() {
+			// the context might be already canceled, so the subscription is removed with a new context.
+			err := redisIncrIfExists.Run(context.Background(), s.client, []string{registryKey}, -1).Err()
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).Int64("id", id).Msg("failed to unsubscribe from log stream")
+			}
+		}()
+
+		if err := s.tail(ctx, id, linec); err != nil {
+			errc <- err
+		}
+	}()
+
+	return linec, errc
+}
+
+// tail reads the log stream from the beginning and sends the lines to the channel
+// until the end of the stream is reached or the context is done.
+func (s *redisStreamer) tail(ctx context.Context, id int64, linec chan<- *Line) error {
+	key := s.streamKey(id)
+	lastID := "0"
+
+	for {
+		result, err := s.client.XRead(ctx, &redis.XReadArgs{
+			Streams: []string{key, lastID},
+			Count:   redisTailCount,
+			Block:   redisTailBlock,
+		}).Result()
+		if ctx.Err() != nil {
+			return nil
+		}
+		if errors.Is(err, redis.Nil) {
+			continue // no new lines
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read log stream %d: %w", id, err)
+		}
+
+		for _, stream := range result {
+			for _, msg := range stream.Messages {
+				lastID = msg.ID
+
+				if _, ok := msg.Values[redisFieldEOF]; ok {
+					return nil
+				}
+
+				data, _ := msg.Values[redisFieldLine].(string)
+
+				line := &Line{}
+				if err := json.Unmarshal([]byte(data), line); err != nil {
+					log.Ctx(ctx).Warn().Err(err).Int64("id", id).Msg("failed to unmarshal log line")
+					continue
+				}
+
+				select {
+				case linec <- line:
+				case <-ctx.Done():
+					return nil
+				}
+			}
+		}
+	}
+}
+
+func (s *redisStreamer) Info(ctx context.Context) *LogStreamInfo {
+	info := &LogStreamInfo{
+		Streams: map[int64]int{},
+	}
+
+	prefix := s.registryKeyPrefix()
+
+	iter := s.client.Scan(ctx, 0, prefix+"*", redisScanCount).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+
+		id, err := strconv.ParseInt(strings.TrimPrefix(key, prefix), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		count, err := s.client.Get(ctx, key).Int()
+		if err != nil {
+			continue // the log stream has been deleted or expired in the meantime
+		}
+
+		info.Streams[id] = count
+	}
+	if err := iter.Err(); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to read log streams info")
+	}
+
+	return info
+}
+
+func (s *redisStreamer) streamKey(id int64) string {
+	return s.config.Namespace + ":livelog:" + strconv.FormatInt(id, 10)
+}
+
+// registryKey returns the key that registers the log stream and holds the number of its subscribers.
+func (s *redisStreamer) registryKey(id int64) string {
+	return s.registryKeyPrefix() + strconv.FormatInt(id, 10)
+}
+
+func (s *redisStreamer) registryKeyPrefix() string {
+	return s.config.Namespace + ":livelog:streams:"
+}
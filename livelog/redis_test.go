@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package livelog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+const testRetention = time.Hour
+
+func setupRedis(t *testing.T) (*miniredis.Miniredis, LogStream) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return mr, NewRedis(client, Config{
+		Provider:  ProviderRedis,
+		Namespace: "test",
+		MaxLength: 100,
+		Retention: testRetention,
+	})
+}
+
+func TestRedis_WriteTail(t *testing.T) {
+	_, s := setupRedis(t)
+	ctx := context.Background()
+
+	if err := s.Create(ctx, 1); err != nil {
+		t.Fatalf("failed to create log stream: %s", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := s.Write(ctx, 1, &Line{Number: i, Message: "line"}); err != nil {
+			t.Fatalf("failed to write to log stream: %s", err)
+		}
+	}
+
+	linec, errc := s.Tail(ctx, 1)
+	if linec == nil {
+		t.Fatal("expected to subscribe to the log stream")
+	}
+
+	if got := s.Info(ctx).Streams[1]; got != 1 {
+		t.Errorf("want 1 subscriber, got %d", got)
+	}
+
+	if err := s.Delete(ctx, 1); err != nil {
+		t.Fatalf("failed to delete log stream: %s", err)
+	}
+
+	var numbers []int
+	for line := range linec {
+		numbers = append(numbers, line.Number)
+	}
+
+	if err := <-errc; err != nil {
+		t.Fatalf("failed to tail log stream: %s", err)
+	}
+
+	if len(numbers) != 3 || numbers[0] != 0 || numbers[1] != 1 || numbers[2] != 2 {
+		t.Errorf("unexpected lines: %v", numbers)
+	}
+
+	if _, ok := s.Info(ctx).Streams[1]; ok {
+		t.Error("deleted log stream is still registered")
+	}
+}
+
+func TestRedis_NotFound(t *testing.T) {
+	_, s := setupRedis(t)
+	ctx := context.Background()
+
+	if err := s.Write(ctx, 1, &Line{}); !errors.Is(err, ErrStreamNotFound) {
+		t.Errorf("write: want %s, got %v", ErrStreamNotFound, err)
+	}
+
+	if err := s.Delete(ctx, 1); !errors.Is(err, ErrStreamNotFound) {
+		t.Errorf("delete: want %s, got %v", ErrStreamNotFound, err)
+	}
+
+	if linec, errc := s.Tail(ctx, 1); linec != nil || errc != nil {
+		t.Error("tail: expected no subscription")
+	}
+}
+
+func TestRedis_Expiration(t *testing.T) {
+	mr, s := setupRedis(t)
+	ctx := context.Background()
+
+	if err := s.Create(ctx, 1); err != nil {
+		t.Fatalf("failed to create log stream 1: %s", err)
+	}
+	if err := s.Create(ctx, 2); err != nil {
+		t.Fatalf("failed to create log stream 2: %s", err)
+	}
+
+	mr.FastForward(testRetention - time.Minute)
+
+	// the write extends the expiration of the log stream 1 only.
+	if err := s.Write(ctx, 1, &Line{}); err != nil {
+		t.Fatalf("failed to write to log stream: %s", err)
+	}
+
+	mr.FastForward(2 * time.Minute)
+
+	if err := s.Write(ctx, 1, &Line{}); err != nil {
+		t.Errorf("failed to write to active log stream: %s", err)
+	}
+
+	if err := s.Write(ctx, 2, &Line{}); !errors.Is(err, ErrStreamNotFound) {
+		t.Errorf("write to expired log stream: want %s, got %v", ErrStreamNotFound, err)
+	}
+
+	streams := s.Info(ctx).Streams
+	if _, ok := streams[1]; !ok || len(streams) != 1 {
+		t.Errorf("want only log stream 1 registered, got %v", streams)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"net/http"
 
 	middlewareauthn "github.com/harness/gitness/app/api/middleware/authn"
+	middlewareauthz "github.com/harness/gitness/app/api/middleware/authz"
 	"github.com/harness/gitness/app/api/middleware/encode"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
@@ -70,6 +71,7 @@ func NewAPIHandler(
 	r := chi.NewRouter()
 	r.Use(audit.Middleware())
 	r.Use(middlewareauthn.Attempt(authenticator))
+	r.Use(middlewareauthz.BlockLFSToken)
 	r.Use(middleware.CheckAuth())
 	apiController := metadata.NewAPIController(
 		repoDao,
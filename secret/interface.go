@@ -16,8 +16,14 @@ package secret
 
 import (
 	"context"
+
+	"github.com/harness/gitness/types"
 )
 
 type Service interface {
 	DecryptSecret(ctx context.Context, spacePath, secretIdentifier string) (string, error)
+
+	// ResolveSecret returns the value of the secret. Values of local secrets are decrypted,
+	// values of secrets from external providers are fetched and the access is audited on behalf of the principal.
+	ResolveSecret(ctx context.Context, principal types.Principal, secret *types.Secret) (string, error)
 }
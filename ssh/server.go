@@ -19,6 +19,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/json"
 	"encoding/pem"
 	"fmt"
 	"io"
@@ -30,6 +31,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/services/publickey"
@@ -48,10 +50,13 @@ type contextKey string
 
 const principalKey = contextKey("principalKey")
 
+const lfsAuthenticateCommand = "git-lfs-authenticate"
+
 var (
 	allowedCommands = []string{
 		"git-upload-pack",
 		"git-receive-pack",
+		lfsAuthenticateCommand,
 	}
 	defaultCiphers = []string{
 		"chacha20-poly1305@openssh.com",
@@ -97,6 +102,7 @@ type Server struct {
 
 	Verifier publickey.Service
 	RepoCtrl *repo.Controller
+	LFSCtrl  *lfs.Controller
 }
 
 func (s *Server) sanitize() error {
@@ -222,6 +228,23 @@ func (s *Server) sessionHandler(session ssh.Session) {
 		return
 	}
 
+	authSession := &auth.Session{
+		Principal: types.Principal{
+			ID:          principal.ID,
+			UID:         principal.UID,
+			Email:       principal.Email,
+			Type:        principal.Type,
+			DisplayName: principal.DisplayName,
+			Created:     principal.Created,
+			Updated:     principal.Updated,
+		},
+	}
+
+	if gitCommand == lfsAuthenticateCommand {
+		s.lfsAuthenticate(session, authSession, parts[1:])
+		return
+	}
+
 	gitServicePack := strings.TrimPrefix(gitCommand, "git-")
 	service, err := enum.ParseGitServiceType(gitServicePack)
 	if err != nil {
@@ -255,17 +278,7 @@ func (s *Server) sessionHandler(session ssh.Session) {
 
 	err = s.RepoCtrl.GitServicePack(
 		ctx,
-		&auth.Session{
-			Principal: types.Principal{
-				ID:          principal.ID,
-				UID:         principal.UID,
-				Email:       principal.Email,
-				Type:        principal.Type,
-				DisplayName: principal.DisplayName,
-				Created:     principal.Created,
-				Updated:     principal.Updated,
-			},
-		},
+		authSession,
 		repoRef,
 		api.ServicePackOptions{
 			Service:  service,
@@ -284,6 +297,31 @@ func (s *Server) sessionHandler(session ssh.Session) {
 	}
 }
 
+// lfsAuthenticate handles the git-lfs-authenticate command.
+// Its arguments are the repo path and the operation: git-lfs-authenticate 'space/repository.git' download.
+func (s *Server) lfsAuthenticate(session ssh.Session, authSession *auth.Session, args []string) {
+	if len(args) < 2 {
+		_, _ = fmt.Fprintf(session.Stderr(), "%s requires the repository and the operation\n", lfsAuthenticateCommand)
+		_ = session.Exit(1)
+		return
+	}
+
+	repoRef := strings.TrimSuffix(strings.Trim(args[0], "'"), ".git")
+	operation := lfs.BatchOperation(args[1])
+
+	out, err := s.LFSCtrl.Authenticate(session.Context(), authSession, repoRef, operation)
+	if err != nil {
+		log.Error().Err(err).Msg("git lfs authenticate failed")
+		_, _ = fmt.Fprintln(session.Stderr(), err.Error())
+		_ = session.Exit(1)
+		return
+	}
+
+	if err = json.NewEncoder(session).Encode(out); err != nil {
+		log.Error().Err(err).Msg("error writing lfs authentication to session")
+	}
+}
+
 func sendKeepAliveMsg(ctx context.Context, session ssh.Session, interval time.Duration) {
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
@@ -0,0 +1,187 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/lfs"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gliderlabs/ssh"
+)
+
+type fakeSSHContext struct {
+	ssh.Context
+	ctx context.Context
+}
+
+func (c fakeSSHContext) Deadline() (time.Time, bool) { return c.ctx.Deadline() }
+func (c fakeSSHContext) Value(key any) any           { return c.ctx.Value(key) }
+func (c fakeSSHContext) Done() <-chan struct{}       { return c.ctx.Done() }
+func (c fakeSSHContext) Err() error                  { return c.ctx.Err() }
+
+type fakeSession struct {
+	ssh.Session
+	ctx      fakeSSHContext
+	command  string
+	stdout   bytes.Buffer
+	stderr   bytes.Buffer
+	exitCode *int
+}
+
+func (s *fakeSession) RawCommand() string          { return s.command }
+func (s *fakeSession) Context() ssh.Context        { return s.ctx }
+func (s *fakeSession) Write(p []byte) (int, error) { return s.stdout.Write(p) }
+func (s *fakeSession) Stderr() io.ReadWriter       { return &s.stderr }
+func (s *fakeSession) Exit(code int) error {
+	s.exitCode = &code
+	return nil
+}
+
+type fakeAuthorizer struct{}
+
+func (fakeAuthorizer) Check(context.Context, *auth.Session, *types.Scope, *types.Resource, enum.Permission,
+) (bool, error) {
+	return true, nil
+}
+
+func (fakeAuthorizer) CheckAll(context.Context, *auth.Session, ...types.PermissionCheck) (bool, error) {
+	return true, nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+}
+
+func (fakeRepoStore) FindByRef(_ context.Context, repoRef string) (*types.Repository, error) {
+	if repoRef != "space/repo" {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return &types.Repository{ID: 1, ParentID: 10, Identifier: "repo", Path: "space/repo"}, nil
+}
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+}
+
+func (fakePrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	return &types.Principal{ID: id, Salt: "salt"}, nil
+}
+
+type fakeURLProvider struct {
+	url.Provider
+}
+
+func (fakeURLProvider) GenerateGITCloneURL(_ context.Context, repoPath string) string {
+	return "https://git.example.com/" + repoPath + ".git"
+}
+
+func TestSessionHandler_LFSAuthenticate(t *testing.T) {
+	server := &Server{
+		LFSCtrl: lfs.NewController(fakeAuthorizer{}, fakeRepoStore{}, fakePrincipalStore{},
+			nil, nil, nil, nil, fakeURLProvider{}),
+	}
+
+	ctx := context.WithValue(context.Background(), principalKey, &types.PrincipalInfo{ID: 1, UID: "user"})
+
+	tests := []struct {
+		name         string
+		command      string
+		wantExitCode *int
+		wantHref     string
+	}{
+		{
+			name:     "download",
+			command:  "git-lfs-authenticate 'space/repo.git' download",
+			wantHref: "https://git.example.com/space/repo.git/info/lfs",
+		},
+		{
+			name:     "upload",
+			command:  "git-lfs-authenticate space/repo upload",
+			wantHref: "https://git.example.com/space/repo.git/info/lfs",
+		},
+		{
+			name:         "missing-operation",
+			command:      "git-lfs-authenticate 'space/repo.git'",
+			wantExitCode: ptrInt(1),
+		},
+		{
+			name:         "invalid-operation",
+			command:      "git-lfs-authenticate 'space/repo.git' delete",
+			wantExitCode: ptrInt(1),
+		},
+		{
+			name:         "unknown-repo",
+			command:      "git-lfs-authenticate 'space/other.git' download",
+			wantExitCode: ptrInt(1),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			session := &fakeSession{
+				ctx:     fakeSSHContext{ctx: ctx},
+				command: test.command,
+			}
+
+			server.sessionHandler(session)
+
+			if test.wantExitCode != nil {
+				if session.exitCode == nil || *session.exitCode != *test.wantExitCode {
+					t.Fatalf("want exit code %d got %v", *test.wantExitCode, session.exitCode)
+				}
+				if session.stderr.Len() == 0 {
+					t.Errorf("expected an error message")
+				}
+				return
+			}
+
+			if session.exitCode != nil {
+				t.Fatalf("unexpected exit code %d: %s", *session.exitCode, session.stderr.String())
+			}
+
+			out := &lfs.AuthenticateOutput{}
+			if err := json.Unmarshal(session.stdout.Bytes(), out); err != nil {
+				t.Fatalf("failed to decode output: %s", err)
+			}
+
+			if out.Href != test.wantHref {
+				t.Errorf("want href %q got %q", test.wantHref, out.Href)
+			}
+			if !strings.HasPrefix(out.Header["Authorization"], "Bearer ") {
+				t.Errorf("expected a bearer token, got %q", out.Header["Authorization"])
+			}
+			if out.ExpiresIn <= 0 {
+				t.Errorf("expected a positive expiration, got %d", out.ExpiresIn)
+			}
+		})
+	}
+}
+
+func ptrInt(i int) *int {
+	return &i
+}
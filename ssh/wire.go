@@ -15,6 +15,7 @@
 package ssh
 
 import (
+	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/types"
@@ -30,6 +31,7 @@ func ProvideServer(
 	config *types.Config,
 	vierifier publickey.Service,
 	repoctrl *repo.Controller,
+	lfsCtrl *lfs.Controller,
 ) *Server {
 	return &Server{
 		Host:                    config.SSH.Host,
@@ -44,5 +46,6 @@ func ProvideServer(
 		KeepAliveInterval:       config.SSH.KeepAliveInterval,
 		Verifier:                vierifier,
 		RepoCtrl:                repoctrl,
+		LFSCtrl:                 lfsCtrl,
 	}
 }
@@ -70,6 +70,20 @@ func (s CheckResult) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// CheckAnnotation is a finding of a status check about a range of lines of a file,
+// for example a linter warning or the assertion of a failed test.
+type CheckAnnotation struct {
+	ID              int64                        `json:"-"`
+	CheckID         int64                        `json:"-"`
+	CheckIdentifier string                       `json:"check_identifier"`
+	Path            string                       `json:"path"`
+	LineStart       int                          `json:"line_start"`
+	LineEnd         int                          `json:"line_end"`
+	Severity        enum.CheckAnnotationSeverity `json:"severity"`
+	Title           string                       `json:"title,omitempty"`
+	Message         string                       `json:"message"`
+}
+
 type CheckPayload struct {
 	Version string                `json:"version"`
 	Kind    enum.CheckPayloadKind `json:"kind"`
@@ -108,6 +122,16 @@ type PullReqCheck struct {
 	Required   bool  `json:"required"`
 	Bypassable bool  `json:"bypassable"`
 	Check      Check `json:"check"`
+	// Tests holds the test results of pipeline status checks that reported tests.
+	Tests *PullReqCheckTests `json:"tests,omitempty"`
+}
+
+// PullReqCheckAnnotation is a status check annotation of the latest commit of a pull request.
+type PullReqCheckAnnotation struct {
+	CheckAnnotation
+	// Changed is true if the annotated lines are changed by the pull request,
+	// in which case the annotation can be shown inline in the pull request diff.
+	Changed bool `json:"changed"`
 }
 
 type CheckCountSummary struct {
@@ -20,6 +20,7 @@ import (
 	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/events"
 	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/livelog"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
 
@@ -108,6 +109,9 @@ type Config struct {
 		TmpDir string `envconfig:"GITNESS_GIT_TMP_DIR"`
 		// HookPath points to the binary used as git server hook.
 		HookPath string `envconfig:"GITNESS_GIT_HOOK_PATH"`
+		// SigningKeyPath (optional) points to the private SSH key used to sign the commits created by the server
+		// (e.g. merge commits). If not provided, the commits created by the server aren't signed.
+		SigningKeyPath string `envconfig:"GITNESS_GIT_SIGNING_KEY_PATH"`
 
 		// LastCommitCache holds configuration options for the last commit cache.
 		LastCommitCache struct {
@@ -173,6 +177,23 @@ type Config struct {
 		// In that case, GITNESS_URL_CONTAINER should also be changed
 		// (eg to http://<gitness_container_name>:<port>).
 		ContainerNetworks []string `envconfig:"GITNESS_CI_CONTAINER_NETWORKS"`
+
+		// RunnerHeartbeatTimeout is the time after which a remote runner that hasn't contacted
+		// the server is reported as offline.
+		RunnerHeartbeatTimeout time.Duration `envconfig:"GITNESS_CI_RUNNER_HEARTBEAT_TIMEOUT" default:"2m"`
+
+		// StorageStepImage is the image of the steps added to pipelines to restore and save caches
+		// and to upload artifacts. It has to provide sh, tar and wget.
+		StorageStepImage string `envconfig:"GITNESS_CI_STORAGE_STEP_IMAGE" default:"alpine:3.20"`
+
+		// StorageMaxFileSize is the maximum size of a pipeline cache archive or artifact.
+		StorageMaxFileSize int64 `envconfig:"GITNESS_CI_STORAGE_MAX_FILE_SIZE" default:"5368709120"` // 5GiB
+
+		// CacheRetentionTime is the time after which unused pipeline caches are deleted.
+		CacheRetentionTime time.Duration `envconfig:"GITNESS_CI_CACHE_RETENTION_TIME" default:"168h"` // 7 days
+
+		// ArtifactRetentionTime is the time after which pipeline artifacts are deleted.
+		ArtifactRetentionTime time.Duration `envconfig:"GITNESS_CI_ARTIFACT_RETENTION_TIME" default:"720h"` // 30 days
 	}
 
 	// Database defines the database configuration parameters.
@@ -214,6 +235,37 @@ type Config struct {
 		}
 	}
 
+	// Secrets defines the external providers secrets can reference instead of storing a value.
+	// A provider is only available if it's configured.
+	Secrets struct {
+		// CacheTTL is how long a value resolved from an external provider is kept in memory.
+		CacheTTL time.Duration `envconfig:"GITNESS_SECRETS_CACHE_TTL" default:"5m"`
+
+		// Vault configures a HashiCorp Vault KV v2 secrets engine.
+		Vault struct {
+			Address   string `envconfig:"GITNESS_SECRETS_VAULT_ADDRESS"`
+			Token     string `envconfig:"GITNESS_SECRETS_VAULT_TOKEN"`
+			Namespace string `envconfig:"GITNESS_SECRETS_VAULT_NAMESPACE"`
+			Mount     string `envconfig:"GITNESS_SECRETS_VAULT_MOUNT" default:"secret"`
+		}
+
+		// AWS configures AWS Secrets Manager. Credentials are taken from the default AWS credential chain.
+		AWS struct {
+			Region   string `envconfig:"GITNESS_SECRETS_AWS_REGION"`
+			Endpoint string `envconfig:"GITNESS_SECRETS_AWS_ENDPOINT"`
+		}
+
+		// Env allows secrets to reference environment variables of the server that start with the prefix.
+		Env struct {
+			Prefix string `envconfig:"GITNESS_SECRETS_ENV_PREFIX"`
+		}
+
+		// File allows secrets to reference files located in the directory.
+		File struct {
+			Dir string `envconfig:"GITNESS_SECRETS_FILE_DIR"`
+		}
+	}
+
 	// Cors defines http cors parameters
 	Cors struct {
 		AllowedOrigins   []string `envconfig:"GITNESS_CORS_ALLOWED_ORIGINS"   default:"*"`
@@ -317,6 +369,19 @@ type Config struct {
 		ChannelSize      int           `envconfig:"GITNESS_PUBSUB_CHANNEL_SIZE"      default:"100"`
 	}
 
+	LogStream struct {
+		// Provider is the name of the live log stream provider, either "inmemory" or "redis".
+		// Redis is required if multiple instances are running, otherwise logs can only be tailed on the instance
+		// the runner is writing to.
+		Provider livelog.Provider `envconfig:"GITNESS_LOGSTREAM_PROVIDER" default:"inmemory"`
+		// Namespace is the prefix of the redis keys of the log streams.
+		Namespace string `envconfig:"GITNESS_LOGSTREAM_NAMESPACE" default:"gitness"`
+		// MaxLength is the (approximate) maximum number of lines kept in a redis log stream.
+		MaxLength int64 `envconfig:"GITNESS_LOGSTREAM_MAX_LENGTH" default:"5000"`
+		// Retention is the duration after which an idle redis log stream is removed.
+		Retention time.Duration `envconfig:"GITNESS_LOGSTREAM_RETENTION" default:"24h"`
+	}
+
 	BackgroundJobs struct {
 		// MaxRunning is maximum number of jobs that can be running at once.
 		MaxRunning int `envconfig:"GITNESS_JOBS_MAX_RUNNING" default:"10"`
@@ -361,6 +426,12 @@ type Config struct {
 		NumWorkers  int           `envconfig:"GITNESS_REPO_SIZE_NUM_WORKERS" default:"5"`
 	}
 
+	MergeQueue struct {
+		Enabled     bool          `envconfig:"GITNESS_MERGE_QUEUE_ENABLED" default:"true"`
+		CRON        string        `envconfig:"GITNESS_MERGE_QUEUE_CRON" default:"* * * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_MERGE_QUEUE_MAX_DURATION" default:"10m"`
+	}
+
 	CodeOwners struct {
 		FilePaths []string `envconfig:"GITNESS_CODEOWNERS_FILEPATH" default:"CODEOWNERS,.harness/CODEOWNERS"`
 	}
@@ -377,11 +448,33 @@ type Config struct {
 	Notification struct {
 		MaxRetries  int `envconfig:"GITNESS_NOTIFICATION_MAX_RETRIES" default:"3"`
 		Concurrency int `envconfig:"GITNESS_NOTIFICATION_CONCURRENCY" default:"4"`
+
+		Slack struct {
+			Enabled bool `envconfig:"GITNESS_NOTIFICATION_SLACK_ENABLED" default:"false"`
+			// BotToken is used to send direct messages to users that linked their Slack account.
+			// Without it only the channels configured on spaces are notified.
+			BotToken string `envconfig:"GITNESS_NOTIFICATION_SLACK_BOT_TOKEN"`
+		}
+
+		Teams struct {
+			Enabled bool `envconfig:"GITNESS_NOTIFICATION_TEAMS_ENABLED" default:"false"`
+		}
 	}
 
 	KeywordSearch struct {
 		Concurrency int `envconfig:"GITNESS_KEYWORD_SEARCH_CONCURRENCY" default:"4"`
 		MaxRetries  int `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_RETRIES" default:"3"`
+
+		// IndexDir specifies the directory of the local keyword search index.
+		// Value is derived from Git.Root unless explicitly specified.
+		IndexDir string `envconfig:"GITNESS_KEYWORD_SEARCH_INDEX_DIR"`
+
+		// MaxFileSize is the max size of a file in bytes that is indexed for keyword search.
+		MaxFileSize int64 `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_FILE_SIZE" default:"1048576"` // 1MiB
+
+		// BackfillCRON defines how often all repositories are checked and the missing or outdated indexes rebuilt.
+		BackfillCRON        string        `envconfig:"GITNESS_KEYWORD_SEARCH_BACKFILL_CRON" default:"0 * * * *"`
+		BackfillMaxDuration time.Duration `envconfig:"GITNESS_KEYWORD_SEARCH_BACKFILL_MAX_DURATION" default:"30m"`
 	}
 
 	Repos struct {
@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// AuditExportFormat represents the file format of exported audit events.
+type AuditExportFormat string
+
+// AuditExportFormat enumeration.
+const (
+	// AuditExportFormatJSON exports audit events as newline delimited JSON objects.
+	AuditExportFormatJSON AuditExportFormat = "json"
+	// AuditExportFormatCSV exports audit events as comma separated values with a header row.
+	AuditExportFormatCSV AuditExportFormat = "csv"
+)
+
+var auditExportFormats = sortEnum([]AuditExportFormat{
+	AuditExportFormatJSON,
+	AuditExportFormatCSV,
+})
+
+func (AuditExportFormat) Enum() []interface{} { return toInterfaceSlice(auditExportFormats) }
+func (f AuditExportFormat) Sanitize() (AuditExportFormat, bool) {
+	return Sanitize(f, GetAllAuditExportFormats)
+}
+func GetAllAuditExportFormats() ([]AuditExportFormat, AuditExportFormat) {
+	return auditExportFormats, AuditExportFormatJSON
+}
@@ -71,3 +71,29 @@ var checkPayloadTypes = sortEnum([]CheckPayloadKind{
 func (s CheckStatus) IsCompleted() bool {
 	return slices.Contains(terminalCheckStatuses, s)
 }
+
+// CheckAnnotationSeverity defines the severity of a status check annotation.
+type CheckAnnotationSeverity string
+
+func (CheckAnnotationSeverity) Enum() []interface{} {
+	return toInterfaceSlice(checkAnnotationSeverities)
+}
+func (s CheckAnnotationSeverity) Sanitize() (CheckAnnotationSeverity, bool) {
+	return Sanitize(s, GetAllCheckAnnotationSeverities)
+}
+func GetAllCheckAnnotationSeverities() ([]CheckAnnotationSeverity, CheckAnnotationSeverity) {
+	return checkAnnotationSeverities, CheckAnnotationSeverityWarning
+}
+
+// CheckAnnotationSeverity enumeration.
+const (
+	CheckAnnotationSeverityNotice  CheckAnnotationSeverity = "notice"
+	CheckAnnotationSeverityWarning CheckAnnotationSeverity = "warning"
+	CheckAnnotationSeverityFailure CheckAnnotationSeverity = "failure"
+)
+
+var checkAnnotationSeverities = sortEnum([]CheckAnnotationSeverity{
+	CheckAnnotationSeverityNotice,
+	CheckAnnotationSeverityWarning,
+	CheckAnnotationSeverityFailure,
+})
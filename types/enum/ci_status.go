@@ -34,6 +34,10 @@ const (
 	CIStatusFailure       CIStatus = "failure"
 	CIStatusKilled        CIStatus = "killed"
 	CIStatusError         CIStatus = "error"
+
+	// CIStatusWaitingOnApproval is the status of a stage deploying to a protected
+	// environment until one of the approvers of the environment approves or rejects it.
+	CIStatusWaitingOnApproval CIStatus = "waiting_on_approval"
 )
 
 // Enum returns all possible CIStatus values.
@@ -52,7 +56,7 @@ func GetAllCIStatuses() ([]CIStatus, CIStatus) {
 }
 
 func (status CIStatus) ConvertToCheckStatus() CheckStatus {
-	if status == CIStatusPending || status == CIStatusWaitingOnDeps {
+	if status == CIStatusPending || status == CIStatusWaitingOnDeps || status == CIStatusWaitingOnApproval {
 		return CheckStatusPending
 	}
 	if status == CIStatusSuccess || status == CIStatusSkipped {
@@ -73,7 +77,7 @@ func (status CIStatus) ConvertToCheckStatus() CheckStatus {
 func ParseCIStatus(status string) CIStatus {
 	switch strings.ToLower(status) {
 	case "skipped", "blocked", "declined", "waiting_on_dependencies",
-		"pending", "running", "success", "failure", "killed", "error":
+		"pending", "running", "success", "failure", "killed", "error", "waiting_on_approval":
 		return CIStatus(strings.ToLower(status))
 	case "": // just in case status is not passed through
 		return CIStatusPending
@@ -87,6 +91,7 @@ func (status CIStatus) IsDone() bool {
 	//nolint:exhaustive
 	switch status {
 	case CIStatusWaitingOnDeps,
+		CIStatusWaitingOnApproval,
 		CIStatusPending,
 		CIStatusRunning,
 		CIStatusBlocked:
@@ -115,4 +120,5 @@ var ciStatuses = sortEnum([]CIStatus{
 	CIStatusFailure,
 	CIStatusKilled,
 	CIStatusError,
+	CIStatusWaitingOnApproval,
 })
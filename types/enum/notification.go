@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// ChatProvider represents a chat application notifications can be delivered to.
+type ChatProvider string
+
+// ChatProvider enumeration.
+const (
+	ChatProviderSlack ChatProvider = "slack"
+	ChatProviderTeams ChatProvider = "teams"
+)
+
+var chatProviders = sortEnum([]ChatProvider{
+	ChatProviderSlack,
+	ChatProviderTeams,
+})
+
+func (ChatProvider) Enum() []interface{} { return toInterfaceSlice(chatProviders) }
+func (p ChatProvider) Sanitize() (ChatProvider, bool) {
+	return Sanitize(p, GetAllChatProviders)
+}
+func GetAllChatProviders() ([]ChatProvider, ChatProvider) {
+	return chatProviders, ""
+}
@@ -25,6 +25,7 @@ const (
 
 var publicKeyTypes = sortEnum([]PublicKeyUsage{
 	PublicKeyUsageAuth,
+	PublicKeyUsageSign,
 })
 
 func (PublicKeyUsage) Enum() []interface{} { return toInterfaceSlice(publicKeyTypes) }
@@ -35,6 +36,56 @@ func GetAllPublicKeyUsages() ([]PublicKeyUsage, PublicKeyUsage) {
 	return publicKeyTypes, PublicKeyUsageAuth
 }
 
+// PublicKeyScheme represents the scheme of a public key.
+type PublicKeyScheme string
+
+// PublicKeyScheme enumeration.
+const (
+	PublicKeySchemeSSH PublicKeyScheme = "ssh"
+	PublicKeySchemePGP PublicKeyScheme = "pgp"
+)
+
+var publicKeySchemes = sortEnum([]PublicKeyScheme{
+	PublicKeySchemeSSH,
+	PublicKeySchemePGP,
+})
+
+func (PublicKeyScheme) Enum() []interface{} { return toInterfaceSlice(publicKeySchemes) }
+func (s PublicKeyScheme) Sanitize() (PublicKeyScheme, bool) {
+	return Sanitize(s, GetAllPublicKeySchemes)
+}
+func GetAllPublicKeySchemes() ([]PublicKeyScheme, PublicKeyScheme) {
+	return publicKeySchemes, PublicKeySchemeSSH
+}
+
+// SignatureVerificationStatus represents the result of verification of a commit or tag signature.
+type SignatureVerificationStatus string
+
+// SignatureVerificationStatus enumeration.
+const (
+	// SignatureVerificationStatusVerified means the signature is valid, it has been created
+	// with a key of a registered user and the committer (or tagger) email matches the user's email.
+	SignatureVerificationStatusVerified SignatureVerificationStatus = "verified"
+	// SignatureVerificationStatusUnverified means the signature is invalid or it can't be verified.
+	SignatureVerificationStatusUnverified SignatureVerificationStatus = "unverified"
+	// SignatureVerificationStatusUnknownKey means the signature has been created with an unregistered key.
+	SignatureVerificationStatusUnknownKey SignatureVerificationStatus = "unknown_key"
+	// SignatureVerificationStatusBadEmail means the signature is valid,
+	// but the committer (or tagger) email doesn't match the email of the key owner.
+	SignatureVerificationStatusBadEmail SignatureVerificationStatus = "bad_email"
+)
+
+var signatureVerificationStatuses = sortEnum([]SignatureVerificationStatus{
+	SignatureVerificationStatusVerified,
+	SignatureVerificationStatusUnverified,
+	SignatureVerificationStatusUnknownKey,
+	SignatureVerificationStatusBadEmail,
+})
+
+func (SignatureVerificationStatus) Enum() []interface{} {
+	return toInterfaceSlice(signatureVerificationStatuses)
+}
+
 // PublicKeySort is used to specify sorting of public keys.
 type PublicKeySort string
 
@@ -90,6 +90,7 @@ const (
 	PullReqActivityTypeBranchRestore  PullReqActivityType = "branch-restore"
 	PullReqActivityTypeMerge          PullReqActivityType = "merge"
 	PullReqActivityTypeLabelModify    PullReqActivityType = "label-modify"
+	PullReqActivityTypeTargetChange   PullReqActivityType = "target-branch-change"
 )
 
 var pullReqActivityTypes = sortEnum([]PullReqActivityType{
@@ -105,6 +106,7 @@ var pullReqActivityTypes = sortEnum([]PullReqActivityType{
 	PullReqActivityTypeBranchRestore,
 	PullReqActivityTypeMerge,
 	PullReqActivityTypeLabelModify,
+	PullReqActivityTypeTargetChange,
 })
 
 // PullReqActivityKind defines kind of pull request activity system message.
@@ -215,6 +217,35 @@ var pullReqReviewerTypes = sortEnum([]PullReqReviewerType{
 	PullReqReviewerTypeSelfAssigned,
 })
 
+// ReviewerSelection defines how the default reviewers are picked from the members of a user group.
+type ReviewerSelection string
+
+func (ReviewerSelection) Enum() []interface{} { return toInterfaceSlice(reviewerSelections) }
+
+func (s ReviewerSelection) Sanitize() (ReviewerSelection, bool) {
+	return Sanitize(s, GetAllReviewerSelections)
+}
+
+func GetAllReviewerSelections() ([]ReviewerSelection, ReviewerSelection) {
+	return reviewerSelections, ReviewerSelectionAll
+}
+
+// ReviewerSelection enumeration.
+const (
+	// ReviewerSelectionAll requests review from all members of the user group.
+	ReviewerSelectionAll ReviewerSelection = "all"
+	// ReviewerSelectionRoundRobin picks the members who were requested to review least recently.
+	ReviewerSelectionRoundRobin ReviewerSelection = "round_robin"
+	// ReviewerSelectionLoadBalanced picks the members with the fewest pending reviews of open pull requests.
+	ReviewerSelectionLoadBalanced ReviewerSelection = "load_balanced"
+)
+
+var reviewerSelections = sortEnum([]ReviewerSelection{
+	ReviewerSelectionAll,
+	ReviewerSelectionRoundRobin,
+	ReviewerSelectionLoadBalanced,
+})
+
 type MergeMethod gitenum.MergeMethod
 
 // MergeMethod enumeration.
@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// SecretProvider defines where the value of a secret is stored.
+type SecretProvider string
+
+// SecretProvider enumeration.
+const (
+	// SecretProviderLocal stores the value encrypted in the Gitness database.
+	SecretProviderLocal SecretProvider = "local"
+	// SecretProviderVault reads the value from a HashiCorp Vault KV v2 secrets engine.
+	SecretProviderVault SecretProvider = "vault"
+	// SecretProviderAWS reads the value from AWS Secrets Manager.
+	SecretProviderAWS SecretProvider = "aws"
+	// SecretProviderEnv reads the value from an environment variable of the server.
+	SecretProviderEnv SecretProvider = "env"
+	// SecretProviderFile reads the value from a file on the server.
+	SecretProviderFile SecretProvider = "file"
+)
+
+var secretProviders = sortEnum([]SecretProvider{
+	SecretProviderLocal,
+	SecretProviderVault,
+	SecretProviderAWS,
+	SecretProviderEnv,
+	SecretProviderFile,
+})
+
+func (SecretProvider) Enum() []interface{} { return toInterfaceSlice(secretProviders) }
+func (p SecretProvider) Sanitize() (SecretProvider, bool) {
+	return Sanitize(p, GetAllSecretProviders)
+}
+func GetAllSecretProviders() ([]SecretProvider, SecretProvider) {
+	return secretProviders, SecretProviderLocal
+}
+
+// IsExternal returns true if the value of the secret isn't stored in the Gitness database.
+func (p SecretProvider) IsExternal() bool {
+	return p != "" && p != SecretProviderLocal
+}
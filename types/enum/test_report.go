@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// TestStatus is the result of a test case run by a pipeline execution.
+type TestStatus string
+
+// TestStatus enumeration.
+const (
+	TestStatusPassed  TestStatus = "passed"
+	TestStatusFailed  TestStatus = "failed"
+	TestStatusSkipped TestStatus = "skipped"
+)
+
+var testStatuses = sortEnum([]TestStatus{
+	TestStatusPassed,
+	TestStatusFailed,
+	TestStatusSkipped,
+})
+
+func (TestStatus) Enum() []interface{} { return toInterfaceSlice(testStatuses) }
+func (s TestStatus) Sanitize() (TestStatus, bool) {
+	return Sanitize(s, GetAllTestStatuses)
+}
+func GetAllTestStatuses() ([]TestStatus, TestStatus) {
+	return testStatuses, ""
+}
+
+// TestReportFormat is the format of a test report uploaded by a pipeline step.
+type TestReportFormat string
+
+// TestReportFormat enumeration.
+const (
+	// TestReportFormatJUnit is the JUnit XML format.
+	TestReportFormatJUnit TestReportFormat = "junit"
+	// TestReportFormatGoTest is the output of `go test -json`.
+	TestReportFormatGoTest TestReportFormat = "gotest"
+)
+
+var testReportFormats = sortEnum([]TestReportFormat{
+	TestReportFormatJUnit,
+	TestReportFormatGoTest,
+})
+
+func (TestReportFormat) Enum() []interface{} { return toInterfaceSlice(testReportFormats) }
+func (f TestReportFormat) Sanitize() (TestReportFormat, bool) {
+	return Sanitize(f, GetAllTestReportFormats)
+}
+func GetAllTestReportFormats() ([]TestReportFormat, TestReportFormat) {
+	return testReportFormats, TestReportFormatJUnit
+}
@@ -40,6 +40,9 @@ const (
 	TriggerActionPullReqClosed TriggerAction = "pullreq_closed"
 	// TriggerActionPullReqMerged gets triggered when a pull request is merged.
 	TriggerActionPullReqMerged TriggerAction = "pullreq_merged"
+	// TriggerActionPullReqMergeQueueUpdated gets triggered when the merge queue creates
+	// a new speculative merge commit of a pull request.
+	TriggerActionPullReqMergeQueueUpdated TriggerAction = "pullreq_merge_queue_updated"
 )
 
 func (TriggerAction) Enum() []interface{}               { return toInterfaceSlice(triggerActions) }
@@ -49,7 +52,8 @@ func (t TriggerAction) GetTriggerEvent() TriggerEvent {
 		t == TriggerActionPullReqBranchUpdated ||
 		t == TriggerActionPullReqReopened ||
 		t == TriggerActionPullReqClosed ||
-		t == TriggerActionPullReqMerged {
+		t == TriggerActionPullReqMerged ||
+		t == TriggerActionPullReqMergeQueueUpdated {
 		return TriggerEventPullRequest
 	}
 	if t == TriggerActionTagCreated || t == TriggerActionTagUpdated {
@@ -75,10 +79,13 @@ var triggerActions = sortEnum([]TriggerAction{
 	TriggerActionPullReqBranchUpdated,
 	TriggerActionPullReqClosed,
 	TriggerActionPullReqMerged,
+	TriggerActionPullReqMergeQueueUpdated,
 })
 
 // Trigger types.
 const (
 	TriggerHook = "@hook"
 	TriggerCron = "@cron"
+	// TriggerPipeline fires on completed executions of another pipeline.
+	TriggerPipeline = "@pipeline"
 )
@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// Environment is a deployment target of pipeline stages, defined in a space
+// and available to the pipelines of all repositories in the space and its subspaces.
+type Environment struct {
+	ID          int64  `json:"-"`
+	SpaceID     int64  `json:"-"`
+	Identifier  string `json:"identifier"`
+	Description string `json:"description"`
+
+	// ApproverIDs and ApproverUserGroups list the principals allowed to approve deployments.
+	// An environment with approvers is protected: stages deploying to it wait for approval.
+	ApproverIDs        []int64  `json:"approver_ids"`
+	ApproverUserGroups []string `json:"approver_user_groups"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+}
+
+// IsProtected returns true if deployments to the environment require approval.
+func (e *Environment) IsProtected() bool {
+	return len(e.ApproverIDs) > 0 || len(e.ApproverUserGroups) > 0
+}
+
+// StageApproval records the decision of an approver on a stage deploying to a protected environment.
+type StageApproval struct {
+	ID            int64                      `json:"-"`
+	ExecutionID   int64                      `json:"-"`
+	StageID       int64                      `json:"-"`
+	StageNumber   int64                      `json:"stage_number"`
+	EnvironmentID int64                      `json:"-"`
+	Environment   string                     `json:"environment"`
+	PrincipalID   int64                      `json:"-"`
+	Approver      *PrincipalInfo             `json:"approver"`
+	Decision      enum.StageApprovalDecision `json:"decision"`
+	Comment       string                     `json:"comment"`
+	Created       int64                      `json:"created"`
+}
@@ -56,6 +56,7 @@ type Execution struct {
 	Cron         string             `json:"cron,omitempty"`
 	Deploy       string             `json:"deploy_to,omitempty"`
 	DeployID     int64              `json:"deploy_id,omitempty"`
+	RetryOf      int64              `json:"retry_of,omitempty"`
 	Debug        bool               `json:"debug,omitempty"`
 	Started      int64              `json:"started,omitempty"`
 	Finished     int64              `json:"finished,omitempty"`
@@ -96,6 +96,8 @@ type Commit struct {
 	Author     Signature    `json:"author"`
 	Committer  Signature    `json:"committer"`
 	Stats      *CommitStats `json:"stats,omitempty"`
+
+	Verification *SignatureVerification `json:"verification,omitempty"`
 }
 
 type Signature struct {
@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// MergeQueueEntry represents a pull request waiting in the merge queue of its target branch.
+type MergeQueueEntry struct {
+	ID            int64                     `json:"id"`
+	RepoID        int64                     `json:"repo_id"`
+	Branch        string                    `json:"branch"`
+	PullReqID     int64                     `json:"pullreq_id"`
+	PullReqNumber int64                     `json:"pullreq_number"`
+	State         enum.MergeQueueEntryState `json:"state"`
+	Method        enum.MergeMethod          `json:"method"`
+	Title         string                    `json:"title,omitempty"`
+	Message       string                    `json:"message,omitempty"`
+	// BypassRules is set if the principal who added the pull request to the queue requested bypassing the rules.
+	BypassRules bool `json:"bypass_rules"`
+
+	// HeadSHA is the source branch commit of the pull request at the time it was added to the queue.
+	HeadSHA string `json:"head_sha"`
+	// BaseSHA is the commit the speculative merge commit is created on top of.
+	BaseSHA string `json:"base_sha,omitempty"`
+	// MergeSHA is the speculative merge commit on which the required checks are run.
+	MergeSHA string `json:"merge_sha,omitempty"`
+	Error    string `json:"error,omitempty"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+}
+
+// MergeQueueBranch identifies a branch with a non-empty merge queue.
+type MergeQueueBranch struct {
+	RepoID int64  `json:"repo_id"`
+	Branch string `json:"branch"`
+}
@@ -0,0 +1,27 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// ChatIdentity links a principal to its user account in a chat application.
+type ChatIdentity struct {
+	PrincipalID int64             `json:"-"`
+	Provider    enum.ChatProvider `json:"provider"`
+	// Identifier is the Slack member ID or the Microsoft Teams user principal name.
+	Identifier string `json:"identifier"`
+	Created    int64  `json:"created"`
+	Updated    int64  `json:"updated"`
+}
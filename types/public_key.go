@@ -17,16 +17,28 @@ package types
 import "github.com/harness/gitness/types/enum"
 
 type PublicKey struct {
-	ID          int64               `json:"-"` // frontend doesn't need it
-	PrincipalID int64               `json:"-"` // API always returns keys for the same user
-	Created     int64               `json:"created"`
-	Verified    *int64              `json:"verified"`
-	Identifier  string              `json:"identifier"`
-	Usage       enum.PublicKeyUsage `json:"usage"`
-	Fingerprint string              `json:"fingerprint"`
-	Content     string              `json:"-"`
-	Comment     string              `json:"comment"`
-	Type        string              `json:"type"`
+	ID          int64                `json:"-"` // frontend doesn't need it
+	PrincipalID int64                `json:"-"` // API always returns keys for the same user
+	Created     int64                `json:"created"`
+	Verified    *int64               `json:"verified"`
+	Identifier  string               `json:"identifier"`
+	Usage       enum.PublicKeyUsage  `json:"usage"`
+	Fingerprint string               `json:"fingerprint"`
+	Content     string               `json:"-"`
+	Comment     string               `json:"comment"`
+	Type        string               `json:"type"`
+	Scheme      enum.PublicKeyScheme `json:"scheme"`
+
+	// SubKeyIDs are the key IDs of the PGP primary key and all its sub keys.
+	SubKeyIDs []string `json:"sub_key_ids,omitempty"`
+}
+
+// SignatureVerification is the result of verification of a commit or tag signature.
+type SignatureVerification struct {
+	Status         enum.SignatureVerificationStatus `json:"status"`
+	Scheme         enum.PublicKeyScheme             `json:"scheme,omitempty"`
+	KeyFingerprint string                           `json:"key_fingerprint,omitempty"`
+	Signer         *PrincipalInfo                   `json:"signer,omitempty"`
 }
 
 type PublicKeyFilter struct {
@@ -219,6 +219,23 @@ type PullReqReviewer struct {
 	AddedBy  PrincipalInfo `json:"added_by"`
 }
 
+// PullReqReviewerWorkload holds the review statistics of a principal,
+// used to pick reviewers from the members of a user group.
+type PullReqReviewerWorkload struct {
+	PrincipalID int64
+	// PendingReviews is the number of open pull requests the principal is a reviewer of, but hasn't reviewed yet.
+	PendingReviews int
+	// LastRequested is the time the principal was last added as a reviewer to a pull request, 0 if never.
+	LastRequested int64
+}
+
+// PullReqTemplate is a pull request description template stored in a repository file.
+type PullReqTemplate struct {
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
 type UserGroupReviewer struct {
 	PullReqID   int64 `json:"-"`
 	UserGroupID int64 `json:"-"`
@@ -273,6 +290,8 @@ type MergeResponse struct {
 	RequiresCodeOwnersApprovalLatest    bool               `json:"requires_code_owners_approval_latest,omitempty"`
 	RequiresCommentResolution           bool               `json:"requires_comment_resolution,omitempty"`
 	RequiresNoChangeRequests            bool               `json:"requires_no_change_requests,omitempty"`
+	RequiresMergeQueue                  bool               `json:"requires_merge_queue,omitempty"`
+	RequiresChecklistCompletion         bool               `json:"requires_checklist_completion,omitempty"`
 }
 
 type MergeViolations struct {
@@ -61,6 +61,7 @@ var allPullReqActivityPayloads = func(
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchUpdate{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchDelete{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchRestore{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadTargetChange{} },
 })
 
 // newPayloadForActivity returns a new payload instance for the requested activity type.
@@ -178,6 +179,20 @@ func (a *PullRequestActivityPayloadBranchRestore) ActivityType() enum.PullReqAct
 	return enum.PullReqActivityTypeBranchRestore
 }
 
+// PullRequestActivityPayloadTargetChange is written when the target branch of a pull request is changed,
+// e.g. when a pull request it depends on is merged and it gets retargeted to the branch the other one was merged into.
+type PullRequestActivityPayloadTargetChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+
+	// DependencyNumber is the number of the merged pull request that caused the change of the target branch.
+	DependencyNumber int64 `json:"dependency_number,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadTargetChange) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeTargetChange
+}
+
 type PullRequestActivityLabel struct {
 	Label         string                        `json:"label"`
 	LabelColor    enum.LabelColor               `json:"label_color"`
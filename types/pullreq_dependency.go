@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// PullReqDependency is an explicitly declared dependency of a pull request on another pull request
+// of the same repository. The pull request can't be merged before the one it depends on.
+type PullReqDependency struct {
+	PullReqID   int64 `json:"-"`
+	DependsOnID int64 `json:"-"`
+	CreatedBy   int64 `json:"-"`
+	Created     int64 `json:"created"`
+}
+
+// PullReqDependencyInfo holds the basic info of a pull request in a stack of dependent pull requests.
+type PullReqDependencyInfo struct {
+	Number       int64             `json:"number"`
+	Title        string            `json:"title"`
+	State        enum.PullReqState `json:"state"`
+	IsDraft      bool              `json:"is_draft"`
+	SourceBranch string            `json:"source_branch"`
+	TargetBranch string            `json:"target_branch"`
+
+	// Implicit is true if the dependency isn't declared, but comes from the pull request
+	// targeting the source branch of the other pull request.
+	Implicit bool `json:"implicit"`
+}
+
+// PullReqDependencies holds the pull requests a pull request depends on and the ones that depend on it.
+type PullReqDependencies struct {
+	DependsOn  []PullReqDependencyInfo `json:"depends_on"`
+	Dependents []PullReqDependencyInfo `json:"dependents"`
+}
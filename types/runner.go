@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// Runner represents a pipeline runner executing stages on a host other than the Gitness server.
+type Runner struct {
+	ID          int64  `json:"-"`
+	Identifier  string `json:"identifier"`
+	Description string `json:"description"`
+
+	// OS, Arch, Kernel, Variant and Labels restrict the stages handed out to the runner.
+	// Empty values are replaced by the values reported by the runner when it requests a stage.
+	OS      string            `json:"os"`
+	Arch    string            `json:"arch"`
+	Kernel  string            `json:"kernel"`
+	Variant string            `json:"variant"`
+	Labels  map[string]string `json:"labels"`
+
+	TokenHash string `json:"-"`
+
+	// Status is computed from LastSeen, it isn't stored in the database.
+	Status   enum.RunnerStatus `json:"status"`
+	LastSeen int64             `json:"last_seen"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+}
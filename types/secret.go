@@ -14,7 +14,11 @@
 
 package types
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/harness/gitness/types/enum"
+)
 
 type Secret struct {
 	ID          int64  `db:"secret_id"              json:"-"`
@@ -26,6 +30,11 @@ type Secret struct {
 	Created     int64  `db:"secret_created"         json:"created"`
 	Updated     int64  `db:"secret_updated"         json:"updated"`
 	Version     int64  `db:"secret_version"         json:"-"`
+
+	// Provider is where the value of the secret is stored.
+	// For external providers Data is empty and the value is resolved from ExternalPath when needed.
+	Provider     enum.SecretProvider `db:"secret_provider"      json:"provider"`
+	ExternalPath string              `db:"secret_external_path" json:"external_path,omitempty"`
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
@@ -44,12 +53,14 @@ func (s Secret) MarshalJSON() ([]byte, error) {
 // Copy makes a copy of the secret without the value.
 func (s *Secret) CopyWithoutData() *Secret {
 	return &Secret{
-		ID:          s.ID,
-		Description: s.Description,
-		Identifier:  s.Identifier,
-		SpaceID:     s.SpaceID,
-		Created:     s.Created,
-		Updated:     s.Updated,
-		Version:     s.Version,
+		ID:           s.ID,
+		Description:  s.Description,
+		Identifier:   s.Identifier,
+		SpaceID:      s.SpaceID,
+		Created:      s.Created,
+		Updated:      s.Updated,
+		Version:      s.Version,
+		Provider:     s.Provider,
+		ExternalPath: s.ExternalPath,
 	}
 }
@@ -45,4 +45,25 @@ type Stage struct {
 	DependsOn   []string          `json:"depends_on,omitempty"`
 	Labels      map[string]string `json:"labels,omitempty"`
 	Steps       []*Step           `json:"steps,omitempty"`
+
+	// Environment is the identifier of the environment the stage deploys to, if any.
+	Environment   string `json:"environment,omitempty"`
+	EnvironmentID int64  `json:"-"`
+
+	// Matrix holds the matrix parameters the stage was expanded with, if any.
+	Matrix *StageMatrix `json:"matrix,omitempty"`
+
+	// ReusedFrom is the number of the execution whose result of the stage was reused
+	// when retrying the failed stages of the execution, if any.
+	ReusedFrom int64 `json:"reused_from,omitempty"`
+}
+
+// StageMatrix holds the details of a stage expanded from a matrix pipeline.
+type StageMatrix struct {
+	// Name is the name of the matrix pipeline the stage was expanded from.
+	Name string `json:"name"`
+	// Params are the matrix parameter values of the stage.
+	Params map[string]string `json:"params"`
+	// FailFast indicates whether the failure of the stage skips its pending sibling stages.
+	FailFast bool `json:"fail_fast"`
 }
@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// TestCase is the result of a test case from a test report uploaded by a pipeline execution.
+type TestCase struct {
+	ID          int64           `json:"-"`
+	PipelineID  int64           `json:"-"`
+	ExecutionID int64           `json:"-"`
+	Suite       string          `json:"suite"`
+	Name        string          `json:"name"`
+	Status      enum.TestStatus `json:"status"`
+	// Duration of the test case in milliseconds.
+	Duration int64  `json:"duration"`
+	Message  string `json:"message,omitempty"`
+	// Flaky is true for a failed test case that recently both passed and failed.
+	Flaky   bool  `json:"flaky"`
+	Created int64 `json:"created"`
+}
+
+// TestSummary is the summary of all test cases reported by a pipeline execution.
+type TestSummary struct {
+	Total   int `json:"total"`
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+	Flaky   int `json:"flaky"`
+	// Duration of all test cases in milliseconds.
+	Duration int64 `json:"duration"`
+}
+
+// TestCaseRun is the result of a test case in one of the executions of a pipeline.
+type TestCaseRun struct {
+	ExecutionNumber int64           `json:"execution_number"`
+	CommitSHA       string          `json:"commit_sha"`
+	Status          enum.TestStatus `json:"status"`
+	Duration        int64           `json:"duration"`
+	Message         string          `json:"message,omitempty"`
+	Flaky           bool            `json:"flaky"`
+	Created         int64           `json:"created"`
+}
+
+// TestCaseFilter stores test case query parameters.
+type TestCaseFilter struct {
+	Pagination
+	Status enum.TestStatus `json:"status"`
+}
+
+// PullReqCheckTests holds the test results of a pipeline status check of a pull request.
+type PullReqCheckTests struct {
+	Summary TestSummary `json:"summary"`
+	// FailedTests are the first failed test cases of the execution.
+	FailedTests []*TestCase `json:"failed_tests"`
+}
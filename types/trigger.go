@@ -34,6 +34,22 @@ type Trigger struct {
 	Created     int64                `json:"created"`
 	Updated     int64                `json:"updated"`
 	Version     int64                `json:"-"`
+
+	// Cron is the cron expression on which a trigger of type enum.TriggerCron fires.
+	Cron string `json:"cron,omitempty"`
+	// CronBranch is the branch built by a cron trigger. The default branch is built if empty.
+	CronBranch string `json:"cron_branch,omitempty"`
+	// CronTimezone is the time zone in which the cron expression is evaluated, UTC if empty.
+	CronTimezone string `json:"cron_timezone,omitempty"`
+
+	// UpstreamPipelineID is the pipeline whose completed executions fire a trigger of type enum.TriggerPipeline.
+	UpstreamPipelineID int64 `json:"upstream_pipeline_id,omitempty"`
+	// UpstreamStatuses are the statuses of the upstream execution on which the trigger fires.
+	// The trigger fires only on success if empty.
+	UpstreamStatuses []enum.CIStatus `json:"upstream_statuses,omitempty"`
+	// Params are passed to the executions fired by a pipeline trigger,
+	// on top of the params of the upstream execution.
+	Params map[string]string `json:"params,omitempty"`
 }
 
 // TODO [CODE-1363]: remove after identifier migration.